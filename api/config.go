@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 type Config interface {
 	MaintenanceMode() bool
 	ShowTestModeWarning() bool
@@ -18,4 +20,23 @@ type Config interface {
 	FBWebHookEnabled() bool
 	FBWebHookListenPort() int
 	FirebaseAPIKey() string
+	PushProvider() string
+	PushPlatformProviders() map[string]string
+	FCMCredentialsFile() string
+	APNSKeyFile() string
+	APNSKeyID() string
+	APNSTeamID() string
+	APNSTopic() string
+	APNSSandbox() bool
+	WebSocketEnabled() bool
+	WebSocketListenPort() int
+	WebSocketEnableTLS() bool
+	WebSocketAllowedOrigins() []string
+	RestEnabled() bool
+	RestListenPort() int
+	RestEnableHTTPS() bool
+	CaptureDir() string
+	MinClientVersions() map[string]string
+	EventReminders() []time.Duration
+	EventNudges() []time.Duration
 }
@@ -28,6 +28,12 @@ type UserDAO interface {
 	SetFacebookCredential(userId int64, fbId string, fbToken string) error
 	SetFacebook(userId int64, fbId string, fbToken string) error
 	SetIIDToken(userId int64, iidToken *IIDTokenDTO) error
+	DeleteIIDToken(userID int64) error
+	LoadPosition(userID int64) (*UserPositionDTO, error)
+	SetPosition(userID int64, position *UserPositionDTO) error
+	SetPositionRange(userID int64, rangeInMeters float32) error
+	LoadLanguage(userID int64) (string, error)
+	SetLanguage(userID int64, language string) error
 	Delete(user *UserDTO) error
 	DeleteAll() error
 }
@@ -45,8 +51,19 @@ type EventTimeLineDAO interface {
 type EventHistoryDAO interface {
 	Insert(userID int64, event *TimeLineEntryDTO) error
 	InsertBatch(event *TimeLineEntryDTO, userIDs ...int64) error
+	Delete(userID int64, event *TimeLineEntryDTO) error
 	FindAllBackward(userID int64, fromDate time.Time) ([]int64, error)
 	FindAllForward(userID int64, fromDate time.Time) ([]int64, error)
+	InsertAuthored(authorID int64, event *TimeLineEntryDTO) error
+	FindAuthoredBackward(authorID int64, fromDate time.Time) ([]int64, error)
+	FindAuthoredForward(authorID int64, fromDate time.Time) ([]int64, error)
+	DeleteAll() error
+}
+
+type PublicEventDAO interface {
+	Insert(entry *PublicEventEntryDTO) error
+	Delete(entry *PublicEventEntryDTO) error
+	FindAllForward(geohashes []string, fromDate time.Time) ([]*PublicEventEntryDTO, error)
 	DeleteAll() error
 }
 
@@ -63,6 +80,61 @@ type EventDAO interface {
 	DeleteAll() error
 }
 
+type EventChangeLogDAO interface {
+	Insert(change *EventChangeDTO, userIDs ...int64) error
+	FindAllSince(userID int64, timestamp int64, eventID int64) ([]*EventChangeDTO, error)
+	DeleteAll() error
+}
+
+type PushTokenDAO interface {
+	Insert(token *PushTokenDTO) error
+	FindAll(userID int64) ([]*PushTokenDTO, error)
+	Delete(userID int64, deviceID string) error
+	DeleteAll() error
+}
+
+type NotificationSettingsDAO interface {
+	Load(userID int64) (*NotificationSettingsDTO, error)
+	Insert(settings *NotificationSettingsDTO) error
+	FindMutedEvents(userID int64) ([]int64, error)
+	InsertMutedEvent(userID int64, eventID int64, ttl time.Duration) error
+	DeleteMutedEvent(userID int64, eventID int64) error
+	DeleteAll() error
+}
+
+type EventSeriesDAO interface {
+	Load(seriesID int64) (*EventSeriesDTO, error)
+	LoadAllActive() ([]*EventSeriesDTO, error)
+	Insert(series *EventSeriesDTO) error
+	InsertOccurrence(seriesID int64, eventID int64) error
+	FindOccurrences(seriesID int64) ([]int64, error)
+	DeleteAll() error
+}
+
+type EventReminderDAO interface {
+	Insert(reminder *EventReminderDTO) error
+	FindDue(from int64, to int64) ([]*EventReminderDTO, error)
+	Delete(reminder *EventReminderDTO) error
+	DeleteAll() error
+}
+
+type EventCommentDAO interface {
+	Load(eventID int64, commentID int64) (*EventCommentDTO, error)
+	Insert(comment *EventCommentDTO) error
+	Delete(eventID int64, commentID int64) error
+	FindPage(eventID int64, beforeID int64, limit int) ([]*EventCommentDTO, error)
+	DeleteAll() error
+}
+
+type ChangeProposalDAO interface {
+	Load(eventID int64, changeID int32) (*ChangeProposalDTO, error)
+	LoadAll(eventID int64) ([]*ChangeProposalDTO, error)
+	LoadAllVoting() ([]*ChangeProposalDTO, error)
+	Insert(proposal *ChangeProposalDTO) error
+	InsertVote(eventID int64, changeID int32, userID int64, accept bool) error
+	SetStatus(eventID int64, changeID int32, status ChangeProposalStatus) error
+}
+
 type FriendDAO interface {
 	LoadFriends(userId int64, groupId int32) ([]*FriendDTO, error)
 	ContainsFriend(userId int64, otherUserId int64) (bool, error)
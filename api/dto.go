@@ -23,18 +23,25 @@ type UserDTO struct {
 }
 
 type EventDTO struct {
-	Id            int64
-	AuthorId      int64
-	AuthorName    string
-	Description   string
-	PictureDigest []byte
-	CreatedDate   int64
-	InboxPosition int64
-	StartDate     int64
-	EndDate       int64
-	Cancelled     bool
-	Timestamp     int64 // Microseconds
-	Participants  map[int64]*ParticipantDTO
+	Id             int64
+	AuthorId       int64
+	AuthorName     string
+	Description    string
+	PictureDigest  []byte
+	CreatedDate    int64
+	InboxPosition  int64
+	StartDate      int64
+	EndDate        int64
+	Cancelled      bool
+	IsPublic       bool
+	Location       *LocationDTO // nil if event has no location
+	Timestamp      int64        // Microseconds
+	SeriesID       int64        // Zero if event isn't recurring
+	Capacity       int32        // Max attendees, zero if unlimited
+	Quorum         int32        // Min attendees, zero if event has no quorum
+	QuorumDeadline int64        // Event is cancelled if quorum isn't reached by then
+	Confirmed      bool         // Quorum was reached
+	Participants   map[int64]*ParticipantDTO
 }
 
 func EqualEventDTO(a *EventDTO, b *EventDTO) bool {
@@ -42,11 +49,18 @@ func EqualEventDTO(a *EventDTO, b *EventDTO) bool {
 	if a.Timestamp != b.Timestamp || a.Id != b.Id ||
 		a.AuthorId != b.AuthorId || a.AuthorName != b.AuthorName || a.Description != b.Description ||
 		a.CreatedDate != b.CreatedDate || a.StartDate != b.StartDate || a.EndDate != b.EndDate ||
-		a.InboxPosition != b.InboxPosition || a.Cancelled != b.Cancelled ||
+		a.InboxPosition != b.InboxPosition || a.Cancelled != b.Cancelled || a.IsPublic != b.IsPublic ||
+		a.SeriesID != b.SeriesID || a.Capacity != b.Capacity || a.Quorum != b.Quorum ||
+		a.QuorumDeadline != b.QuorumDeadline || a.Confirmed != b.Confirmed ||
 		!bytes.Equal(a.PictureDigest, b.PictureDigest) || len(a.Participants) != len(b.Participants) {
 		return false
 	}
 
+	if (a.Location == nil) != (b.Location == nil) ||
+		(a.Location != nil && *a.Location != *b.Location) {
+		return false
+	}
+
 	for pID, aParticipant := range a.Participants {
 
 		bParticipant, ok := b.Participants[pID]
@@ -64,7 +78,8 @@ func EqualEventDTO(a *EventDTO, b *EventDTO) bool {
 }
 
 func (d EventDTO) String() string {
-	return fmt.Sprintf("%v", d)
+	type eventDTO EventDTO // Avoid recursive String() calls
+	return fmt.Sprintf("%v", eventDTO(d))
 }
 
 func (d *EventDTO) Clone() *EventDTO {
@@ -73,6 +88,11 @@ func (d *EventDTO) Clone() *EventDTO {
 	*copy = *d
 	copy.Participants = make(map[int64]*ParticipantDTO)
 
+	if d.Location != nil {
+		location := *d.Location
+		copy.Location = &location
+	}
+
 	for pID, p := range d.Participants {
 		copy.Participants[pID] = p.Clone()
 	}
@@ -85,6 +105,26 @@ type TimeLineEntryDTO struct {
 	Position time.Time
 }
 
+type LocationDTO struct {
+	Latitude  float32
+	Longitude float32
+}
+
+type PublicEventEntryDTO struct {
+	EventID   int64
+	Geohash   string
+	StartDate int64
+	EndDate   int64 // Entry expires when event ends
+	Location  LocationDTO
+}
+
+type UserPositionDTO struct {
+	Location        LocationDTO
+	EstimationError float32 // Meters
+	RangeInMeters   float32
+	LastUpdate      int64 // Zero if position has never been set
+}
+
 type TimeLineByEndDate []*TimeLineEntryDTO
 
 func (a TimeLineByEndDate) Len() int           { return len(a) }
@@ -118,6 +158,20 @@ func (d *ParticipantDTO) Clone() *ParticipantDTO {
 	return copy
 }
 
+type ChangeProposalDTO struct {
+	EventID     int64
+	ChangeID    int32
+	AuthorID    int64
+	CreatedDate int64
+	Deadline    int64
+	StartDate   int64
+	EndDate     int64
+	Description string
+	VotesTotal  int32
+	Status      ChangeProposalStatus
+	Votes       map[int64]bool // userID -> accepted
+}
+
 type AccessTokenDTO struct {
 	UserId      int64
 	Token       string
@@ -132,6 +186,14 @@ type GroupDTO struct {
 	Members []int64
 }
 
+// EventChangeDTO is an entry of a user change log. Timestamp is in microseconds
+type EventChangeDTO struct {
+	UserID    int64
+	Timestamp int64
+	EventID   int64
+	Type      EventChangeType
+}
+
 type FriendDTO struct {
 	UserId        int64
 	Name          string
@@ -152,6 +214,76 @@ type IIDTokenDTO struct {
 	Platform string
 }
 
+// PushTokenDTO is the push token of a device of a user. LastSeen is in
+// milliseconds
+type PushTokenDTO struct {
+	UserID   int64
+	DeviceID string
+	Token    string
+	Platform string
+	Version  int
+	LastSeen int64
+}
+
+// NotificationSettingsDTO holds notification preferences of a user. Quiet
+// hours are minutes since midnight in TimeZone
+type NotificationSettingsDTO struct {
+	UserID             int64
+	DisabledCategories []NotificationCategory
+	QuietHoursEnabled  bool
+	QuietHoursStart    int32
+	QuietHoursEnd      int32
+	TimeZone           string
+}
+
+// EventSeriesDTO is a recurring event. Description, IsPublic, Location and
+// Participants are the template of occurrences not generated yet. StartDate
+// is the start of the first occurrence, Until are millis and Duration is the
+// length of each occurrence in millis
+type EventSeriesDTO struct {
+	ID           int64
+	AuthorID     int64
+	Description  string
+	IsPublic     bool
+	Location     *LocationDTO
+	Participants []int64
+	Capacity     int32
+	StartDate    int64
+	Duration     int64
+	Frequency    RecurrenceFrequency
+	Interval     int32
+	Count        int32
+	Until        int64
+	TimeZone     string // IANA name, empty for UTC
+	Generated    int32  // Number of occurrences generated so far
+	Skipped      int32  // Generated occurrences that couldn't be created
+	Finished     bool   // No more occurrences are generated
+}
+
+// EventReminderDTO is a reminder of an event due at DueDate, Offset before
+// event start. StartDate is the start date of the event when the reminder was
+// scheduled. Dates and Offset are in milliseconds
+type EventReminderDTO struct {
+	EventID   int64
+	Type      ReminderType
+	Offset    int64
+	DueDate   int64
+	StartDate int64
+}
+
+// EventCommentDTO is a comment posted in an event. CommentID is a time ordered
+// ID. Dates are in milliseconds and EditedDate is zero if comment was never
+// edited
+type EventCommentDTO struct {
+	EventID     int64
+	CommentID   int64
+	AuthorID    int64
+	AuthorName  string
+	Message     string
+	CreatedDate int64
+	EditedDate  int64
+}
+
 type PictureDTO struct {
 	RawData []byte
 	Digest  []byte
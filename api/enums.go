@@ -31,3 +31,50 @@ const (
 	EventState_FINISHED    EventState = 2
 	EventState_CANCELLED   EventState = 3
 )
+
+type ChangeProposalStatus int8
+
+const (
+	ChangeProposalStatus_VOTING    ChangeProposalStatus = 0
+	ChangeProposalStatus_ACCEPTED  ChangeProposalStatus = 1
+	ChangeProposalStatus_DISCARDED ChangeProposalStatus = 2
+)
+
+type EventChangeType int8
+
+const (
+	EventChangeType_INVITED             EventChangeType = 0 // User was invited to the event
+	EventChangeType_INFO_CHANGED        EventChangeType = 1 // Dates, description, picture, etc.
+	EventChangeType_PARTICIPANT_CHANGED EventChangeType = 2 // Participants added, removed or modified
+	EventChangeType_CANCELLED           EventChangeType = 3
+	EventChangeType_REMOVED             EventChangeType = 4 // User invitation was cancelled
+)
+
+type NotificationCategory int8
+
+const (
+	NotificationCategory_EVENT_INVITATION NotificationCategory = 0
+	NotificationCategory_EVENT_CANCELLED  NotificationCategory = 1
+	NotificationCategory_EVENT_RESPONSE   NotificationCategory = 2
+	NotificationCategory_EVENT_CHANGE     NotificationCategory = 3 // Change proposals and voting results
+	NotificationCategory_FRIEND_REQUEST   NotificationCategory = 4
+	NotificationCategory_NEW_FRIEND       NotificationCategory = 5
+	NotificationCategory_EVENT_REMINDER   NotificationCategory = 6 // Reminders and nudges before start
+	NotificationCategory_EVENT_COMMENT    NotificationCategory = 7
+)
+
+type RecurrenceFrequency int8
+
+const (
+	RecurrenceFrequency_NONE    RecurrenceFrequency = 0
+	RecurrenceFrequency_DAILY   RecurrenceFrequency = 1
+	RecurrenceFrequency_WEEKLY  RecurrenceFrequency = 2
+	RecurrenceFrequency_MONTHLY RecurrenceFrequency = 3
+)
+
+type ReminderType int8
+
+const (
+	ReminderType_REMINDER ReminderType = 0 // Sent to participants who will or may attend
+	ReminderType_NUDGE    ReminderType = 1 // Sent to participants who haven't answered yet
+)
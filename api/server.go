@@ -1,6 +1,52 @@
 package api
 
+import "time"
+
+// AckStats summarizes writes that required an ACK from clients
+type AckStats struct {
+	Acked      uint64 // Packets acknowledged
+	Retries    uint64 // Packets written again because ACK wasn't received in time
+	Timeouts   uint64 // Packets not acknowledged after all retries
+	AvgLatency time.Duration
+	MaxLatency time.Duration
+}
+
+// PushStats summarizes push notifications sent to a user
+type PushStats struct {
+	Sent          uint64 // Messages accepted by push service
+	Failed        uint64 // Messages discarded after an error
+	Retries       uint64 // Messages sent again after a transient error
+	Dropped       uint64 // Messages discarded because the queue was full
+	InvalidTokens uint64 // Tokens removed because push service rejected them
+	LastSent      time.Time
+	LastError     string
+	LastErrorTime time.Time
+}
+
+// SessionInfo describes a connected device of a user
+type SessionInfo struct {
+	UserID        int64
+	DeviceID      string
+	Platform      string
+	ClientVersion string
+	RemoteAddr    string
+}
+
 type Server interface {
 	Version() string
 	BuildTime() string
+
+	// Connected devices
+	UserSessions(userID int64) []SessionInfo
+
+	// Packet capture
+	StartCapture(userID int64) (files []string, err error)
+	StopCapture(userID int64) error
+	CapturedUsers() []int64
+
+	// Delivery acknowledgements
+	AckStats() AckStats
+
+	// Push notifications
+	PushStats() map[int64]PushStats
 }
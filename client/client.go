@@ -0,0 +1,422 @@
+package client
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+)
+
+const (
+	defaultRequestTimeout     = 30 * time.Second
+	defaultNotificationBuffer = 100
+	responseFlag              = 1 << 15
+)
+
+var (
+	ErrClosed             = errors.New("client closed")
+	ErrTimeout            = errors.New("request timed out")
+	ErrUnexpectedResponse = errors.New("unexpected response")
+	ErrNoFreeToken        = errors.New("too many requests in flight")
+)
+
+// ServerError is returned when server replies to a request with an error
+// message. Code is one of the E_* constants of protocol package.
+type ServerError struct {
+	RequestType proto.PacketType
+	Code        int32
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("server error %v (request type: %v)", e.Code, e.RequestType)
+}
+
+// Options used when dialing a server. Zero values are replaced by defaults.
+type Options struct {
+	ProtocolVersion    uint8
+	ClientVersion      string
+	Platform           string
+	PlatformVersion    string
+	Language           string
+	DeviceID           string      // Lets a user keep sessions in several devices
+	TLSConfig          *tls.Config // If set, connection is upgraded to TLS with USE_TLS
+	RequestTimeout     time.Duration
+	NotificationBuffer int
+	DisableCompression bool // Do not ask server to compress payloads
+}
+
+// Notification is a packet pushed by server that is not a response to a
+// request, i.e. an event created, an attendance status change, etc.
+type Notification struct {
+	Type    proto.PacketType
+	Message proto.Message
+}
+
+// Client is a connection to an AreYouIN server. Requests can be performed
+// concurrently from several goroutines. Responses are matched with requests
+// through the header token, which in responses has the most significant bit set.
+type Client struct {
+	conn          net.Conn
+	opts          Options
+	writeMutex    sync.Mutex
+	mutex         sync.Mutex
+	nextToken     uint16
+	pending       map[uint16]chan *proto.AyiPacket
+	notifications chan *Notification
+	closed        bool
+	done          chan struct{}
+	err           error
+	UserID        int64
+	ResumeToken   string // Issued by server at authentication. See Resume.
+}
+
+// Dial connects to a server, upgrades the connection to TLS if requested and
+// sends HELLO. Returned client is not authenticated yet.
+func Dial(address string, opts *Options) (*Client, error) {
+
+	conn, err := net.DialTimeout("tcp", address, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClient(conn, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// NewClient creates a client over an already connected socket
+func NewClient(conn net.Conn, opts *Options) (*Client, error) {
+	return newClient(conn, opts)
+}
+
+func newClient(conn net.Conn, opts *Options) (*Client, error) {
+
+	c := &Client{
+		conn:    conn,
+		pending: make(map[uint16]chan *proto.AyiPacket),
+		done:    make(chan struct{}),
+	}
+
+	if opts != nil {
+		c.opts = *opts
+	}
+
+	if c.opts.ProtocolVersion == 0 {
+		c.opts.ProtocolVersion = proto.VERSION_3
+	}
+
+	if c.opts.RequestTimeout == 0 {
+		c.opts.RequestTimeout = defaultRequestTimeout
+	}
+
+	if c.opts.NotificationBuffer == 0 {
+		c.opts.NotificationBuffer = defaultNotificationBuffer
+	}
+
+	c.notifications = make(chan *Notification, c.opts.NotificationBuffer)
+
+	// Upgrade to TLS before anything else is sent
+	if c.opts.TLSConfig != nil {
+		if err := c.write(c.newPacket().Request(proto.M_USE_TLS, nil)); err != nil {
+			return nil, err
+		}
+		tlsConn := tls.Client(conn, c.opts.TLSConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, err
+		}
+		c.conn = tlsConn
+	}
+
+	hello := &proto.Hello{
+		ProtocolVersion: uint32(c.opts.ProtocolVersion),
+		ClientVersion:   c.opts.ClientVersion,
+		Platform:        c.opts.Platform,
+		PlatformVersion: c.opts.PlatformVersion,
+		Language:        c.opts.Language,
+		Capabilities:    []string{proto.CAPABILITY_ACK_RESPONSES},
+		DeviceId:        c.opts.DeviceID,
+	}
+
+	if !c.opts.DisableCompression {
+		hello.Compression = []string{proto.COMPRESSION_SNAPPY}
+	}
+
+	if err := c.write(c.newPacket().Request(proto.M_HELLO, hello)); err != nil {
+		return nil, err
+	}
+
+	go c.readLoop()
+
+	return c, nil
+}
+
+// Notifications returns the channel where server pushed messages are
+// delivered. It is closed when the client is closed. If the channel is full,
+// new notifications are discarded.
+func (c *Client) Notifications() <-chan *Notification {
+	return c.notifications
+}
+
+// Done returns a channel that is closed when the connection finishes
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the error that closed the connection, if any
+func (c *Client) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+func (c *Client) Close() error {
+	c.shutdown(ErrClosed)
+	return nil
+}
+
+func (c *Client) shutdown(err error) {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.closed = true
+	c.err = err
+	c.conn.Close()
+
+	for token, ch := range c.pending {
+		close(ch)
+		delete(c.pending, token)
+	}
+
+	close(c.done)
+}
+
+func (c *Client) newPacket() *proto.PacketBuilder {
+	return proto.NewPacket(c.opts.ProtocolVersion)
+}
+
+func (c *Client) write(packet *proto.AyiPacket) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	_, err := proto.WriteBytes(packet.Marshal(), c.conn)
+	return err
+}
+
+func (c *Client) readLoop() {
+
+	defer close(c.notifications)
+
+	for {
+		packet, err := proto.ReadPacket(c.conn)
+		if err != nil {
+			c.shutdown(err)
+			return
+		}
+
+		if packet.IsResponse() {
+			c.dispatchResponse(packet)
+			// Responses that deliver content (events, friends...) are
+			// acknowledged so that server can change their delivery state
+			if packet.Type() != proto.M_OK && packet.Type() != proto.M_ERROR {
+				if err := c.write(c.ack(packet)); err != nil {
+					c.shutdown(err)
+					return
+				}
+			}
+			continue
+		}
+
+		switch packet.Type() {
+
+		case proto.M_PING:
+			pong := c.newPacket().Pong()
+			pong.Header.SetToken(packet.Id() | responseFlag)
+			if err := c.write(pong); err != nil {
+				c.shutdown(err)
+				return
+			}
+
+		default:
+			// Acknowledge every notification. Server ignores ACKs for packets
+			// that do not require them.
+			if err := c.write(c.ack(packet)); err != nil {
+				c.shutdown(err)
+				return
+			}
+			c.dispatchNotification(packet)
+		}
+	}
+}
+
+// ACK for packet. It's sent with the same token of the packet.
+func (c *Client) ack(packet *proto.AyiPacket) *proto.AyiPacket {
+	ack := c.newPacket().Request(proto.M_OK, nil)
+	ack.Header.SetToken(packet.Id())
+	return ack
+}
+
+func (c *Client) dispatchResponse(packet *proto.AyiPacket) {
+
+	// HELLO is rejected if client version is no longer supported. Server
+	// closes the connection afterwards, so keep the reason.
+	if packet.Type() == proto.M_ERROR {
+		if err, ok := decodeError(packet).(*ServerError); ok && err.RequestType == proto.M_HELLO {
+			c.shutdown(err)
+			return
+		}
+	}
+
+	c.mutex.Lock()
+	ch, ok := c.pending[packet.ResponseId()]
+	delete(c.pending, packet.ResponseId())
+	c.mutex.Unlock()
+
+	if !ok {
+		log.Printf("client: discarded response %v for unknown request %v\n", packet.Type(), packet.ResponseId())
+		return
+	}
+
+	ch <- packet
+}
+
+func (c *Client) dispatchNotification(packet *proto.AyiPacket) {
+
+	message, err := packet.DecodeMessage()
+	if err != nil && err != proto.ErrNoPayload {
+		log.Printf("client: cannot decode notification %v: %v\n", packet.Type(), err)
+		return
+	}
+
+	select {
+	case c.notifications <- &Notification{Type: packet.Type(), Message: message}:
+	default:
+		log.Printf("client: notifications buffer full, discarded %v\n", packet.Type())
+	}
+}
+
+// Request sends a raw request and waits for its response. Error responses
+// are returned as *ServerError. A nil message sends a request without payload.
+// Prefer the typed methods in requests.go.
+func (c *Client) Request(packetType proto.PacketType, message proto.Message) (*proto.AyiPacket, error) {
+
+	packet := c.newPacket().Request(packetType, message)
+	waitResponse := make(chan *proto.AyiPacket, 1)
+
+	c.mutex.Lock()
+
+	if c.closed {
+		c.mutex.Unlock()
+		return nil, ErrClosed
+	}
+
+	token, ok := c.allocToken()
+	if !ok {
+		c.mutex.Unlock()
+		return nil, ErrNoFreeToken
+	}
+
+	c.pending[token] = waitResponse
+	c.mutex.Unlock()
+
+	packet.Header.SetToken(token)
+
+	if err := c.write(packet); err != nil {
+		c.releaseToken(token)
+		return nil, err
+	}
+
+	select {
+	case response, ok := <-waitResponse:
+		if !ok {
+			return nil, c.Err()
+		}
+		if response.Type() == proto.M_ERROR {
+			return nil, decodeError(response)
+		}
+		return response, nil
+	case <-time.After(c.opts.RequestTimeout):
+		c.releaseToken(token)
+		return nil, ErrTimeout
+	}
+}
+
+// Sends a request and decodes the response, which must be of the given type
+func (c *Client) requestMessage(packetType proto.PacketType, message proto.Message,
+	responseType proto.PacketType) (proto.Message, error) {
+
+	response, err := c.Request(packetType, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Type() != responseType {
+		return nil, ErrUnexpectedResponse
+	}
+
+	responseMessage, err := response.DecodeMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	return responseMessage, nil
+}
+
+// Sends a request whose response is an OK message
+func (c *Client) requestOk(packetType proto.PacketType, message proto.Message) error {
+
+	response, err := c.Request(packetType, message)
+	if err != nil {
+		return err
+	}
+
+	if response.Type() != proto.M_OK {
+		return ErrUnexpectedResponse
+	}
+
+	return nil
+}
+
+// Returns a token not in use by other request. Tokens use 15 bits because the
+// most significant one is reserved to mark responses. Mutex must be held by
+// the caller.
+func (c *Client) allocToken() (uint16, bool) {
+	for i := 0; i < responseFlag; i++ {
+		token := c.nextToken
+		c.nextToken = (c.nextToken + 1) % responseFlag
+		if _, inUse := c.pending[token]; !inUse {
+			return token, true
+		}
+	}
+	return 0, false
+}
+
+func (c *Client) releaseToken(token uint16) {
+	c.mutex.Lock()
+	delete(c.pending, token)
+	c.mutex.Unlock()
+}
+
+func decodeError(packet *proto.AyiPacket) error {
+	message, err := packet.DecodeMessage()
+	if err != nil {
+		return err
+	}
+	netErr := message.(*proto.Error)
+	return &ServerError{
+		RequestType: proto.PacketType(netErr.Type),
+		Code:        netErr.Error,
+	}
+}
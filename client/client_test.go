@@ -0,0 +1,218 @@
+package client
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/core"
+)
+
+func newTestClient(t *testing.T) (*Client, net.Conn) {
+
+	clientConn, serverConn := net.Pipe()
+
+	// Pipes are synchronous, so HELLO must be read while the client is created
+	helloChan := make(chan *proto.AyiPacket, 1)
+	go func() {
+		packet, err := proto.ReadPacket(serverConn)
+		if err != nil {
+			t.Error(err)
+		}
+		helloChan <- packet
+	}()
+
+	c, err := NewClient(clientConn, &Options{RequestTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hello := <-helloChan
+	if hello == nil || hello.Type() != proto.M_HELLO {
+		t.Fatalf("expected HELLO, got %v", hello)
+	}
+
+	return c, serverConn
+}
+
+func writePacket(t *testing.T, conn net.Conn, packet *proto.AyiPacket) {
+	if _, err := proto.WriteBytes(packet.Marshal(), conn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRequestResponseMatching(t *testing.T) {
+
+	c, serverConn := newTestClient(t)
+	defer c.Close()
+
+	acked := make(chan bool, 1)
+
+	go func() {
+		request, err := proto.ReadPacket(serverConn)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if request.Type() != proto.M_LIST_PRIVATE_EVENTS {
+			t.Errorf("unexpected request type %v", request.Type())
+		}
+		events := []*core.Event{{EventId: 1}, {EventId: 2}}
+		response := proto.NewPacket(proto.VERSION_3).EventsList(events)
+		response.Header.SetToken(request.Id() | responseFlag)
+		writePacket(t, serverConn, response)
+
+		ack, err := proto.ReadPacket(serverConn)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if ack.Type() != proto.M_OK || ack.HasPayload() || ack.Id() != response.Id() {
+			t.Errorf("unexpected ack %v", ack)
+		}
+		acked <- true
+	}()
+
+	events, err := c.ListPrivateEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 || events[0].EventId != 1 || events[1].EventId != 2 {
+		t.Fatalf("unexpected events %v", events)
+	}
+
+	select {
+	case <-acked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("response not acknowledged")
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+
+	c, serverConn := newTestClient(t)
+	defer c.Close()
+
+	go func() {
+		request, err := proto.ReadPacket(serverConn)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		response := proto.NewPacket(proto.VERSION_3).Error(request.Type(), proto.E_EVENT_NOT_WRITABLE)
+		response.Header.SetToken(request.Id() | responseFlag)
+		writePacket(t, serverConn, response)
+	}()
+
+	err := c.CancelEvent(1234)
+	serverErr, ok := err.(*ServerError)
+	if !ok {
+		t.Fatalf("expected server error, got %v", err)
+	}
+
+	if serverErr.Code != proto.E_EVENT_NOT_WRITABLE || serverErr.RequestType != proto.M_CANCEL_EVENT {
+		t.Fatalf("unexpected error %v", serverErr)
+	}
+}
+
+func TestPingAndNotifications(t *testing.T) {
+
+	c, serverConn := newTestClient(t)
+	defer c.Close()
+
+	// Ping
+	ping := proto.NewPacket(proto.VERSION_3).Ping()
+	ping.Header.SetToken(7)
+	writePacket(t, serverConn, ping)
+
+	pong, err := proto.ReadPacket(serverConn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pong.Type() != proto.M_PONG || !pong.IsResponse() || pong.ResponseId() != 7 {
+		t.Fatalf("unexpected pong %v", pong)
+	}
+
+	// Notification
+	notification := proto.NewPacket(proto.VERSION_3).InvitationCancelled(1234)
+	notification.Header.SetToken(8)
+	writePacket(t, serverConn, notification)
+
+	ack, err := proto.ReadPacket(serverConn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ack.Type() != proto.M_OK || ack.HasPayload() || ack.Id() != 8 {
+		t.Fatalf("unexpected ack %v", ack)
+	}
+
+	select {
+	case n := <-c.Notifications():
+		msg, ok := n.Message.(*proto.InvitationCancelled)
+		if n.Type != proto.M_INVITATION_CANCELLED || !ok || msg.EventId != 1234 {
+			t.Fatalf("unexpected notification %v", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+	}
+}
+
+func TestCloseFailsPendingRequests(t *testing.T) {
+
+	c, serverConn := newTestClient(t)
+
+	go func() {
+		// Read request and close without responding
+		proto.ReadPacket(serverConn)
+		serverConn.Close()
+	}()
+
+	if _, err := c.ListPrivateEvents(); err == nil {
+		t.Fatal("expected error")
+	}
+
+	select {
+	case <-c.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("client not closed")
+	}
+
+	if _, ok := <-c.Notifications(); ok {
+		t.Fatal("notifications channel not closed")
+	}
+}
+
+func TestResume(t *testing.T) {
+
+	c, serverConn := newTestClient(t)
+	defer c.Close()
+
+	go func() {
+		request, err := proto.ReadPacket(serverConn)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		msg, _ := request.DecodeMessage()
+		if token := msg.(*proto.AccessToken); token.ResumeToken != "old" {
+			t.Errorf("unexpected resume token %v", token.ResumeToken)
+		}
+		info := &proto.ResumeInfo{ResumeToken: "new", Resumed: true, MissedPackets: 2}
+		response := proto.NewPacket(proto.VERSION_3).OkWithPayload(proto.M_USER_AUTH, info)
+		response.Header.SetToken(request.Id() | responseFlag)
+		writePacket(t, serverConn, response)
+	}()
+
+	info, err := c.Resume(1234, "auth", "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !info.Resumed || info.MissedPackets != 2 || c.ResumeToken != "new" || c.UserID != 1234 {
+		t.Fatalf("unexpected resume info %v", info)
+	}
+}
@@ -0,0 +1,300 @@
+package client
+
+import (
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/core"
+	"github.com/d3ce1t/areyouin-server/utils"
+	pb "github.com/golang/protobuf/proto"
+)
+
+// Account
+
+// NewAuthToken requests new credentials for a native account. Returned token
+// is used to authenticate.
+func (c *Client) NewAuthToken(email string, password string) (*proto.AccessToken, error) {
+	msg := &proto.NewAuthToken{Pass1: email, Pass2: password, Type: proto.AuthType_A_NATIVE}
+	response, err := c.requestMessage(proto.M_USER_NEW_AUTH_TOKEN, msg, proto.M_ACCESS_GRANTED)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.AccessToken), nil
+}
+
+func (c *Client) Authenticate(userID int64, authToken string) error {
+	_, err := c.Resume(userID, authToken, "")
+	return err
+}
+
+// Resume authenticates presenting the ResumeToken of a previous connection.
+// If the session is resumed, packets missed while offline are received as
+// notifications right after. Otherwise a full sync is needed.
+func (c *Client) Resume(userID int64, authToken string, resumeToken string) (*proto.ResumeInfo, error) {
+
+	msg := &proto.AccessToken{UserId: userID, AuthToken: authToken, ResumeToken: resumeToken}
+	response, err := c.requestMessage(proto.M_USER_AUTH, msg, proto.M_OK)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &proto.ResumeInfo{}
+	if err := pb.Unmarshal(response.(*proto.Ok).Payload, info); err != nil {
+		return nil, err
+	}
+
+	c.UserID = userID
+	c.ResumeToken = info.ResumeToken
+	return info, nil
+}
+
+// Login gets new credentials and authenticates with them
+func (c *Client) Login(email string, password string) (*proto.AccessToken, error) {
+	accessToken, err := c.NewAuthToken(email, password)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Authenticate(accessToken.UserId, accessToken.AuthToken); err != nil {
+		return nil, err
+	}
+	return accessToken, nil
+}
+
+// ListDevices returns devices of the user that receive push notifications
+func (c *Client) ListDevices() ([]*proto.Device, error) {
+	response, err := c.requestMessage(proto.M_LIST_DEVICES, nil, proto.M_DEVICES_LIST)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.DevicesList).Devices, nil
+}
+
+func (c *Client) RevokeDevice(deviceID string) error {
+	return c.requestOk(proto.M_REVOKE_DEVICE, &proto.RevokeDevice{DeviceId: deviceID})
+}
+
+func (c *Client) GetNotificationSettings() (*proto.NotificationSettings, error) {
+	response, err := c.requestMessage(proto.M_GET_NOTIFICATION_SETTINGS, nil, proto.M_NOTIFICATION_SETTINGS)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.NotificationSettings), nil
+}
+
+// SetNotificationSettings replaces notification settings of the user. Muted
+// events are ignored, use MuteEvent instead.
+func (c *Client) SetNotificationSettings(settings *proto.NotificationSettings) error {
+	return c.requestOk(proto.M_SET_NOTIFICATION_SETTINGS, settings)
+}
+
+func (c *Client) MuteEvent(eventID int64, mute bool) error {
+	return c.requestOk(proto.M_MUTE_EVENT, &proto.MuteEvent{EventId: eventID, Mute: mute})
+}
+
+func (c *Client) PostComment(eventID int64, message string) (*proto.EventComment, error) {
+	return c.requestComment(proto.M_POST_COMMENT, &proto.EventComment{EventId: eventID, Message: message},
+		proto.M_COMMENT_POSTED)
+}
+
+func (c *Client) EditComment(eventID int64, commentID int64, message string) (*proto.EventComment, error) {
+	return c.requestComment(proto.M_EDIT_COMMENT,
+		&proto.EventComment{EventId: eventID, CommentId: commentID, Message: message}, proto.M_COMMENT_EDITED)
+}
+
+func (c *Client) DeleteComment(eventID int64, commentID int64) error {
+	return c.requestOk(proto.M_DELETE_COMMENT, &proto.EventComment{EventId: eventID, CommentId: commentID})
+}
+
+// GetComments returns comments of an event older than beforeID, newest first.
+// Zero beforeID and limit mean the newest comments and the server page size.
+func (c *Client) GetComments(eventID int64, beforeID int64, limit uint32) (*proto.CommentsList, error) {
+	msg := &proto.CommentListRequest{EventId: eventID, BeforeId: beforeID, Limit: limit}
+	response, err := c.requestMessage(proto.M_GET_COMMENTS, msg, proto.M_COMMENTS_LIST)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.CommentsList), nil
+}
+
+func (c *Client) requestComment(packetType proto.PacketType, msg *proto.EventComment,
+	responseType proto.PacketType) (*proto.EventComment, error) {
+	response, err := c.requestMessage(packetType, msg, responseType)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.EventComment), nil
+}
+
+func (c *Client) GetUserAccount() (*core.UserAccount, error) {
+	response, err := c.requestMessage(proto.M_GET_USER_ACCOUNT, nil, proto.M_USER_ACCOUNT)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*core.UserAccount), nil
+}
+
+// GetAccessToken returns the token used to download images
+func (c *Client) GetAccessToken() (*proto.AccessToken, error) {
+	response, err := c.requestMessage(proto.M_GET_ACCESS_TOKEN, nil, proto.M_ACCESS_TOKEN)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.AccessToken), nil
+}
+
+func (c *Client) Ping() error {
+	msg := &proto.TimeInfo{CurrentTime: utils.GetCurrentTimeMillis()}
+	_, err := c.requestMessage(proto.M_PING, msg, proto.M_PONG)
+	return err
+}
+
+func (c *Client) Clock() (time.Time, error) {
+	response, err := c.requestMessage(proto.M_CLOCK_REQUEST, nil, proto.M_CLOCK_RESPONSE)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return utils.MillisToTimeUTC(response.(*proto.TimeInfo).CurrentTime), nil
+}
+
+// Events
+
+func (c *Client) CreateEvent(msg *proto.CreateEvent) (*core.Event, error) {
+	response, err := c.requestMessage(proto.M_CREATE_EVENT, msg, proto.M_EVENT_CREATED)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*core.Event), nil
+}
+
+func (c *Client) ModifyEvent(msg *proto.ModifyEvent) error {
+	return c.requestOk(proto.M_MODIFY_EVENT, msg)
+}
+
+func (c *Client) CancelEvent(eventID int64) error {
+	return c.requestOk(proto.M_CANCEL_EVENT, &proto.CancelEvent{EventId: eventID})
+}
+
+// CancelEventSeries cancels eventID and every later occurrence of its series
+func (c *Client) CancelEventSeries(eventID int64) error {
+	return c.requestOk(proto.M_CANCEL_EVENT, &proto.CancelEvent{EventId: eventID, CancelSeries: true})
+}
+
+func (c *Client) InviteUsers(eventID int64, participants ...int64) error {
+	msg := &proto.InviteUsers{EventId: eventID, Participants: participants}
+	return c.requestOk(proto.M_INVITE_USERS, msg)
+}
+
+func (c *Client) CancelUsersInvitation(eventID int64, participants ...int64) error {
+	msg := &proto.CancelUsersInvitation{EventId: eventID, Participants: participants}
+	return c.requestOk(proto.M_CANCEL_USERS_INVITATION, msg)
+}
+
+func (c *Client) ConfirmAttendance(eventID int64, response core.AttendanceResponse) error {
+	msg := &proto.ConfirmAttendance{EventId: eventID, ActionCode: response}
+	return c.requestOk(proto.M_CONFIRM_ATTENDANCE, msg)
+}
+
+func (c *Client) ProposeEventChange(msg *proto.EventChangeProposed) error {
+	return c.requestOk(proto.M_PROPOSE_EVENT_CHANGE, msg)
+}
+
+func (c *Client) VoteChange(eventID int64, changeID int32, accept bool) error {
+	msg := &proto.VoteChange{EventId: eventID, ChangeId: changeID, AcceptChange: accept}
+	return c.requestOk(proto.M_VOTE_CHANGE, msg)
+}
+
+func (c *Client) ReadEvent(eventID int64) (*core.Event, error) {
+	response, err := c.requestMessage(proto.M_READ_EVENT, &proto.ReadEvent{EventId: eventID}, proto.M_EVENT)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*core.Event), nil
+}
+
+func (c *Client) ListPrivateEvents() ([]*core.Event, error) {
+	return c.listEvents(proto.M_LIST_PRIVATE_EVENTS, nil, proto.M_EVENTS_LIST)
+}
+
+func (c *Client) ListAuthoredEvents() ([]*core.Event, error) {
+	return c.listEvents(proto.M_LIST_AUTHORED_EVENTS, nil, proto.M_EVENTS_LIST)
+}
+
+// ListPublicEvents returns public events around location. If location is nil,
+// last known position of the user is used.
+func (c *Client) ListPublicEvents(location *core.Location, rangeInMeters uint32) ([]*core.Event, error) {
+	msg := &proto.EventListRequest{UserCoordinates: location, RangeInMeters: rangeInMeters}
+	return c.listEvents(proto.M_LIST_PUBLIC_EVENTS, msg, proto.M_EVENTS_LIST)
+}
+
+// EventsHistory returns a page of the events history between start and end.
+// Pages are read backward if end is before start.
+func (c *Client) EventsHistory(start time.Time, end time.Time) (*proto.EventsList, error) {
+	msg := &proto.EventListRequest{StartWindow: utils.TimeToMillis(start), EndWindow: utils.TimeToMillis(end)}
+	response, err := c.requestMessage(proto.M_HISTORY_PRIVATE_EVENTS, msg, proto.M_EVENTS_HISTORY_LIST)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.EventsList), nil
+}
+
+// SyncEvents returns changes after cursor and cursorEventID, as returned by the
+// previous sync. Zero values return the current cursor.
+func (c *Client) SyncEvents(cursor int64, cursorEventID int64) (*proto.EventChanges, error) {
+	msg := &proto.SyncEvents{Cursor: cursor, CursorEventId: cursorEventID}
+	response, err := c.requestMessage(proto.M_SYNC_EVENTS, msg, proto.M_EVENT_CHANGES)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.EventChanges), nil
+}
+
+func (c *Client) listEvents(packetType proto.PacketType, msg proto.Message, responseType proto.PacketType) ([]*core.Event, error) {
+	response, err := c.requestMessage(packetType, msg, responseType)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.EventsList).Event, nil
+}
+
+// Friends
+
+func (c *Client) GetFriends() ([]*core.Friend, error) {
+	response, err := c.requestMessage(proto.M_GET_USER_FRIENDS, nil, proto.M_FRIENDS_LIST)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.FriendsList).Friends, nil
+}
+
+func (c *Client) GetGroups() ([]*core.Group, error) {
+	response, err := c.requestMessage(proto.M_GET_GROUPS, nil, proto.M_GROUPS_LIST)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.GroupsList).Groups, nil
+}
+
+func (c *Client) SyncGroups(groups []*core.Group) error {
+	msg := &proto.SyncGroups{Owner: c.UserID, Groups: groups}
+	return c.requestOk(proto.M_SYNC_GROUPS, msg)
+}
+
+func (c *Client) GetFriendRequests() ([]*core.FriendRequest, error) {
+	response, err := c.requestMessage(proto.M_GET_FRIEND_REQUESTS, nil, proto.M_FRIEND_REQUESTS_LIST)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*proto.FriendRequestsList).FriendRequests, nil
+}
+
+func (c *Client) CreateFriendRequest(email string) error {
+	return c.requestOk(proto.M_CREATE_FRIEND_REQUEST, &proto.CreateFriendRequest{Email: email})
+}
+
+func (c *Client) ConfirmFriendRequest(friendID int64, accept bool) error {
+	msg := &proto.ConfirmFriendRequest{FriendId: friendID, Response: proto.ConfirmFriendRequest_CANCEL}
+	if accept {
+		msg.Response = proto.ConfirmFriendRequest_CONFIRM
+	}
+	return c.requestOk(proto.M_CONFIRM_FRIEND_REQUEST, msg)
+}
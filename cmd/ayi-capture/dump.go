@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/capture"
+	"github.com/golang/protobuf/jsonpb"
+	pb "github.com/golang/protobuf/proto"
+)
+
+const dumpTimeLayout = "2006-01-02 15:04:05.000"
+
+func dump(args []string) error {
+
+	var verbose bool
+	var jsonOutput bool
+
+	cmd := flag.NewFlagSet(args[0], flag.ExitOnError)
+	cmd.BoolVar(&verbose, "v", false, "Print messages on several lines")
+	cmd.BoolVar(&jsonOutput, "json", false, "Print one JSON object per packet")
+	cmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %v %v [options] <capture file>\n\nOptions:\n", os.Args[0], args[0])
+		cmd.PrintDefaults()
+	}
+
+	cmd.Parse(args[1:])
+	if cmd.NArg() != 1 {
+		cmd.Usage()
+		os.Exit(2)
+	}
+
+	r, err := capture.Open(cmd.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	d := &dumper{w: os.Stdout, verbose: verbose, json: jsonOutput}
+
+	for n := 1; ; n++ {
+		record, err := r.Next()
+		if err == io.EOF {
+			return nil
+		} else if err == io.ErrUnexpectedEOF {
+			fmt.Fprintln(os.Stderr, "Capture is truncated")
+			return nil
+		} else if err != nil {
+			return err
+		}
+		d.Record(n, record)
+	}
+}
+
+type dumper struct {
+	w       io.Writer
+	verbose bool
+	json    bool
+}
+
+func (d *dumper) Record(n int, record *capture.Record) {
+
+	packet := record.Packet
+	message, decodeErr := packet.DecodeMessage()
+	if decodeErr == proto.ErrNoPayload {
+		decodeErr = nil
+	}
+
+	if d.json {
+		d.recordJSON(n, record, message, decodeErr)
+		return
+	}
+
+	token := fmt.Sprintf("id=%v", packet.Id())
+	if packet.IsResponse() {
+		token = fmt.Sprintf("resp=%v", packet.ResponseId())
+	}
+
+	fmt.Fprintf(d.w, "#%v %v %-3v user=%v v%v %v %v (%v bytes)", n, record.Time.Format(dumpTimeLayout),
+		record.Direction, record.UserID, packet.Version(), token, packet.Type(), len(packet.Data))
+
+	switch {
+	case decodeErr != nil:
+		fmt.Fprintf(d.w, "\n    %v: %x\n", decodeErr, packet.Data)
+	case message == nil:
+		fmt.Fprintln(d.w)
+	case d.verbose:
+		text := strings.TrimRight(pb.MarshalTextString(message), "\n")
+		fmt.Fprintf(d.w, "\n    %v\n", strings.Replace(text, "\n", "\n    ", -1))
+	default:
+		fmt.Fprintf(d.w, " %v\n", describeMessage(message))
+	}
+}
+
+func (d *dumper) recordJSON(n int, record *capture.Record, message proto.Message, decodeErr error) {
+
+	packet := record.Packet
+
+	obj := map[string]interface{}{
+		"n":         n,
+		"time":      record.Time.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		"direction": record.Direction.String(),
+		"user_id":   record.UserID,
+		"version":   packet.Version(),
+		"token":     packet.Id(),
+		"response":  packet.IsResponse(),
+		"type":      packet.Type().String(),
+		"type_code": uint8(packet.Type()),
+		"size":      len(packet.Data),
+	}
+
+	if decodeErr != nil {
+		obj["error"] = decodeErr.Error()
+		obj["payload"] = fmt.Sprintf("%x", packet.Data)
+	} else if message != nil {
+		marshaler := &jsonpb.Marshaler{OrigName: true}
+		if str, err := marshaler.MarshalToString(message); err == nil {
+			obj["message"] = json.RawMessage(str)
+		} else {
+			obj["error"] = err.Error()
+		}
+	}
+
+	data, _ := json.Marshal(obj)
+	fmt.Fprintln(d.w, string(data))
+}
+
+// Returns a one line description of message. Ok and Error messages refer to
+// a packet type, so show its name instead of the number.
+func describeMessage(message proto.Message) string {
+	switch msg := message.(type) {
+	case *proto.Ok:
+		return fmt.Sprintf("{type: %v}", proto.PacketType(msg.Type))
+	case *proto.Error:
+		return fmt.Sprintf("{type: %v, error: %v}", proto.PacketType(msg.Type), msg.Error)
+	default:
+		return fmt.Sprintf("{%v}", pb.CompactTextString(message))
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// ayi-capture dump [-v] [-json] captures/1234-20160102-150405.000.ayicap
+// ayi-capture replay -s localhost:1822 -email test@example.com -password secret captures/1234-20160102-150405.000.ayicap
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %v <command> [options] <capture file>\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+	fmt.Fprintf(os.Stderr, "  dump     Print packets of a capture\n")
+	fmt.Fprintf(os.Stderr, "  replay   Send client packets of a capture to a server\n\n")
+	fmt.Fprintf(os.Stderr, "%v <command> -help for options of each command\n", os.Args[0])
+}
+
+func main() {
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+
+	switch os.Args[1] {
+	case "dump":
+		err = dump(os.Args[1:])
+	case "replay":
+		err = replay(os.Args[1:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %v\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/client"
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/capture"
+)
+
+const CLIENT_VERSION = "1.0.0"
+
+// Client packets that aren't replayed. Session set up and authentication are
+// done by the replayer with its own credentials, while ACKs and PONGs answer
+// packets sent by the original server.
+var sessionPackets = map[proto.PacketType]bool{
+	proto.M_HELLO:               true,
+	proto.M_USE_TLS:             true,
+	proto.M_USER_AUTH:           true,
+	proto.M_USER_NEW_AUTH_TOKEN: true,
+	proto.M_PONG:                true,
+}
+
+// Returns true if record is a client request that must be replayed
+func isReplayable(record *capture.Record) bool {
+
+	if record.Direction != capture.Inbound {
+		return false
+	}
+
+	packet := record.Packet
+
+	if packet.IsResponse() || sessionPackets[packet.Type()] {
+		return false
+	}
+
+	// ACK
+	if packet.Type() == proto.M_OK && !packet.HasPayload() {
+		return false
+	}
+
+	return true
+}
+
+func replay(args []string) error {
+
+	var address string
+	var useTLS bool
+	var insecure bool
+	var email string
+	var password string
+	var userID int64
+	var token string
+	var realTime bool
+	var verbose bool
+
+	cmd := flag.NewFlagSet(args[0], flag.ExitOnError)
+	cmd.StringVar(&address, "s", "localhost:1822", "Test server address (host:port)")
+	cmd.BoolVar(&useTLS, "tls", false, "Upgrade connection to TLS")
+	cmd.BoolVar(&insecure, "insecure", false, "Do not verify server certificate")
+	cmd.StringVar(&email, "email", "", "E-mail of the test account")
+	cmd.StringVar(&password, "password", "", "Password of the test account")
+	cmd.Int64Var(&userID, "user-id", 0, "User ID of the test account (use with -token)")
+	cmd.StringVar(&token, "token", "", "Auth token of the test account (use with -user-id)")
+	cmd.BoolVar(&realTime, "real-time", false, "Keep the original delay between requests")
+	cmd.BoolVar(&verbose, "v", false, "Print notifications received while replaying")
+	cmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %v %v [options] <capture file>\n\nOptions:\n", os.Args[0], args[0])
+		cmd.PrintDefaults()
+	}
+
+	cmd.Parse(args[1:])
+	if cmd.NArg() != 1 {
+		cmd.Usage()
+		os.Exit(2)
+	}
+
+	if (email == "" || password == "") && (userID == 0 || token == "") {
+		return fmt.Errorf("credentials aren't set. Use -email and -password, or -user-id and -token")
+	}
+
+	r, err := capture.Open(cmd.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	// Connect
+
+	opts := &client.Options{
+		ClientVersion: CLIENT_VERSION,
+		Platform:      "ayi-capture",
+	}
+
+	if useTLS {
+		host := address
+		if i := strings.LastIndex(address, ":"); i != -1 {
+			host = address[:i]
+		}
+		opts.TLSConfig = &tls.Config{ServerName: host, InsecureSkipVerify: insecure}
+	}
+
+	c, err := client.Dial(address, opts)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if email != "" && password != "" {
+		if _, err := c.Login(email, password); err != nil {
+			return fmt.Errorf("login failed: %v", err)
+		}
+	} else if err := c.Authenticate(userID, token); err != nil {
+		return fmt.Errorf("authentication failed: %v", err)
+	}
+
+	go func() {
+		for n := range c.Notifications() {
+			if verbose {
+				fmt.Printf("! %v\n", n.Type)
+			}
+		}
+	}()
+
+	// Replay
+
+	var replayed, failed, skipped int
+	var lastTime time.Time
+
+	for {
+		record, err := r.Next()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if !isReplayable(record) {
+			continue
+		}
+
+		message, err := record.Packet.DecodeMessage()
+		if err != nil && err != proto.ErrNoPayload {
+			fmt.Printf("- %v skipped: %v\n", record.Type, err)
+			skipped++
+			continue
+		}
+
+		if realTime && !lastTime.IsZero() {
+			time.Sleep(record.Time.Sub(lastTime))
+		}
+		lastTime = record.Time
+
+		if message != nil {
+			fmt.Printf("> %v %v\n", record.Type, describeMessage(message))
+		} else {
+			fmt.Printf("> %v\n", record.Type)
+		}
+
+		start := time.Now()
+		response, err := c.Request(record.Type, message)
+		elapsed := time.Since(start)
+		replayed++
+
+		if err != nil {
+			fmt.Printf("< %v (%v)\n", err, elapsed)
+			failed++
+			if c.Err() != nil {
+				return c.Err()
+			}
+			continue
+		}
+
+		fmt.Printf("< %v (%v)\n", response.Type(), elapsed)
+	}
+
+	fmt.Printf("Replayed %v requests (%v failed, %v skipped)\n", replayed, failed, skipped)
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/capture"
+)
+
+func newRecord(direction capture.Direction, packet *proto.AyiPacket) *capture.Record {
+	return &capture.Record{
+		Time:      time.Date(2016, 1, 2, 15, 4, 5, 0, time.UTC),
+		Direction: direction,
+		UserID:    100,
+		Type:      packet.Type(),
+		Packet:    packet,
+	}
+}
+
+func TestIsReplayable(t *testing.T) {
+
+	builder := func() *proto.PacketBuilder { return proto.NewPacket(proto.VERSION_3) }
+
+	ack := builder().Request(proto.M_OK, nil)
+	pong := builder().Request(proto.M_PONG, nil)
+	pong.Header.SetToken(1 << 15)
+
+	tests := []struct {
+		record *capture.Record
+		want   bool
+	}{
+		{newRecord(capture.Inbound, builder().Request(proto.M_LIST_PRIVATE_EVENTS, nil)), true},
+		{newRecord(capture.Inbound, builder().Request(proto.M_CANCEL_EVENT, &proto.CancelEvent{EventId: 1})), true},
+		{newRecord(capture.Outbound, builder().Ok(proto.M_CANCEL_EVENT)), false},
+		{newRecord(capture.Inbound, builder().Request(proto.M_HELLO, &proto.Hello{})), false},
+		{newRecord(capture.Inbound, builder().Request(proto.M_USER_AUTH, &proto.AccessToken{UserId: 1})), false},
+		{newRecord(capture.Inbound, ack), false},
+		{newRecord(capture.Inbound, pong), false},
+	}
+
+	for i, test := range tests {
+		if got := isReplayable(test.record); got != test.want {
+			t.Errorf("test %v (%v): got %v, want %v", i, test.record.Type, got, test.want)
+		}
+	}
+}
+
+func TestDumpRecord(t *testing.T) {
+
+	buf := &bytes.Buffer{}
+	d := &dumper{w: buf}
+
+	response := proto.NewPacket(proto.VERSION_3).Ok(proto.M_CANCEL_EVENT)
+	response.Header.SetToken(5 | 1<<15)
+	d.Record(1, newRecord(capture.Outbound, response))
+
+	line := buf.String()
+	if !strings.Contains(line, "OUT") || !strings.Contains(line, "resp=5") ||
+		!strings.Contains(line, "OK (2 bytes) {type: CANCEL_EVENT}") {
+		t.Fatalf("unexpected output %q", line)
+	}
+}
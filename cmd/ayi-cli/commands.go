@@ -0,0 +1,1024 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/client"
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/core"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+var (
+	ErrInvalidArgs     = errors.New("invalid arguments")
+	ErrInvalidDate     = errors.New("invalid date, use RFC3339 (2006-01-02T15:04:05Z07:00) or a duration from now (+2h)")
+	ErrInvalidResponse = errors.New("invalid response, use assist, no or cannot")
+	ErrInvalidCategory = errors.New("invalid category, use invitation, cancelled, response, change, friend_request, new_friend, reminder or comment")
+	ErrInvalidQuiet    = errors.New("invalid quiet hours, use HH:MM-HH:MM or off")
+	ErrInvalidRepeat   = errors.New("invalid repeat, use daily, weekly or monthly")
+)
+
+// Names of notification categories in command line
+var categoryNames = map[string]proto.NotificationCategory{
+	"invitation":     proto.NotificationCategory_N_EVENT_INVITATION,
+	"cancelled":      proto.NotificationCategory_N_EVENT_CANCELLED,
+	"response":       proto.NotificationCategory_N_EVENT_RESPONSE,
+	"change":         proto.NotificationCategory_N_EVENT_CHANGE,
+	"friend_request": proto.NotificationCategory_N_FRIEND_REQUEST,
+	"new_friend":     proto.NotificationCategory_N_NEW_FRIEND,
+	"reminder":       proto.NotificationCategory_N_EVENT_REMINDER,
+	"comment":        proto.NotificationCategory_N_EVENT_COMMENT,
+}
+
+func init() {
+
+	// Events
+
+	registerCommand("events", &command{
+		usage:       "[-authored]",
+		description: "List recent events",
+		run:         runEvents,
+	})
+
+	registerCommand("event", &command{
+		usage:       "<event_id>",
+		description: "Show an event with its participants",
+		run:         runEvent,
+	})
+
+	registerCommand("create", &command{
+		usage:       "-start d -end d [-message s] [-invite ids] [-capacity n] [-quorum n -deadline d] [-public -lat n -lon n] [-repeat f [-every n] [-tz zone] -count n|-until d]",
+		description: "Create an event",
+		run:         runCreate,
+	})
+
+	registerCommand("modify", &command{
+		usage:       "<event_id> [-message s] [-start d] [-end d] [-invite ids] [-capacity n] [-quorum n] [-deadline d] [-series]",
+		description: "Modify an event",
+		run:         runModify,
+	})
+
+	registerCommand("cancel", &command{
+		usage:       "<event_id> [-series]",
+		description: "Cancel an event",
+		run:         runCancel,
+	})
+
+	registerCommand("invite", &command{
+		usage:       "<event_id> <user_id>...",
+		description: "Invite users to an event",
+		run:         runInvite,
+	})
+
+	registerCommand("uninvite", &command{
+		usage:       "<event_id> <user_id>...",
+		description: "Cancel the invitation of users",
+		run:         runUninvite,
+	})
+
+	registerCommand("respond", &command{
+		usage:       "<event_id> assist|no|cannot",
+		description: "Answer an invitation",
+		run:         runRespond,
+	})
+
+	// Comments
+
+	registerCommand("comments", &command{
+		usage:       "<event_id> [-before comment_id] [-limit n]",
+		description: "List comments of an event, newest first",
+		run:         runComments,
+	})
+
+	registerCommand("comment", &command{
+		usage:       "<event_id> <message>",
+		description: "Post a comment in an event",
+		run:         runComment,
+	})
+
+	registerCommand("edit-comment", &command{
+		usage:       "<event_id> <comment_id> <message>",
+		description: "Change the message of a comment",
+		run:         runEditComment,
+	})
+
+	registerCommand("delete-comment", &command{
+		usage:       "<event_id> <comment_id>",
+		description: "Delete a comment",
+		run:         runDeleteComment,
+	})
+
+	// Friends and groups
+
+	registerCommand("friends", &command{
+		description: "List friends",
+		run:         runFriends,
+	})
+
+	registerCommand("friend-requests", &command{
+		description: "List received friend requests",
+		run:         runFriendRequests,
+	})
+
+	registerCommand("add-friend", &command{
+		usage:       "<email>",
+		description: "Send a friend request",
+		run:         runAddFriend,
+	})
+
+	registerCommand("accept-friend", &command{
+		usage:       "<user_id>",
+		description: "Accept a friend request",
+		run:         runConfirmFriend(true),
+	})
+
+	registerCommand("reject-friend", &command{
+		usage:       "<user_id>",
+		description: "Reject a friend request",
+		run:         runConfirmFriend(false),
+	})
+
+	registerCommand("groups", &command{
+		description: "List groups",
+		run:         runGroups,
+	})
+
+	registerCommand("set-group", &command{
+		usage:       "<group_id> <name> <user_id>...",
+		description: "Create a group or replace its members",
+		run:         runSetGroup,
+	})
+
+	registerCommand("rename-group", &command{
+		usage:       "<group_id> <name>",
+		description: "Rename a group",
+		run:         runRenameGroup,
+	})
+
+	registerCommand("delete-group", &command{
+		usage:       "<group_id>",
+		description: "Delete a group",
+		run:         runDeleteGroup,
+	})
+
+	// Devices
+
+	registerCommand("devices", &command{
+		description: "List devices that receive push notifications",
+		run:         runDevices,
+	})
+
+	registerCommand("revoke-device", &command{
+		usage:       "<device_id>",
+		description: "Stop sending push notifications to a device",
+		run:         runRevokeDevice,
+	})
+
+	// Notifications
+
+	registerCommand("notification-settings", &command{
+		description: "Show notification settings",
+		run:         runNotificationSettings,
+	})
+
+	registerCommand("set-notification-settings", &command{
+		usage:       "[-disable <categories>] [-quiet <HH:MM-HH:MM|off>] [-tz <time_zone>]",
+		description: "Change notification settings. Unset flags are kept",
+		run:         runSetNotificationSettings,
+	})
+
+	registerCommand("mute-event", &command{
+		usage:       "<event_id>",
+		description: "Stop notifications of an event until it ends",
+		run:         runMuteEvent(true),
+	})
+
+	registerCommand("unmute-event", &command{
+		usage:       "<event_id>",
+		description: "Resume notifications of an event",
+		run:         runMuteEvent(false),
+	})
+
+	registerCommand("watch", &command{
+		description: "Stream live notifications until interrupted",
+		run:         runWatch,
+	})
+}
+
+func runEvents(c *client.Client, out *printer, args []string) error {
+
+	fs := flag.NewFlagSet("events", flag.ContinueOnError)
+	authored := fs.Bool("authored", false, "Only events authored by me")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var events []*core.Event
+	var err error
+
+	if *authored {
+		events, err = c.ListAuthoredEvents()
+	} else {
+		events, err = c.ListPrivateEvents()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	out.Events(events)
+	return nil
+}
+
+func runEvent(c *client.Client, out *printer, args []string) error {
+
+	if len(args) != 1 {
+		return ErrInvalidArgs
+	}
+
+	eventID, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	event, err := c.ReadEvent(eventID)
+	if err != nil {
+		return err
+	}
+
+	out.Event(event)
+	return nil
+}
+
+func runCreate(c *client.Client, out *printer, args []string) error {
+
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	message := fs.String("message", "", "Description")
+	start := fs.String("start", "", "Start date")
+	end := fs.String("end", "", "End date")
+	invite := fs.String("invite", "", "Comma separated list of user IDs")
+	capacity := fs.Uint("capacity", 0, "Maximum number of attendees, 0 for unlimited")
+	quorum := fs.Uint("quorum", 0, "Minimum number of attendees, 0 for none")
+	deadline := fs.String("deadline", "", "Date the event is cancelled if quorum isn't reached")
+	public := fs.Bool("public", false, "Public event")
+	lat := fs.Float64("lat", 0, "Latitude")
+	lon := fs.Float64("lon", 0, "Longitude")
+	repeat := fs.String("repeat", "", "Repeat daily, weekly or monthly")
+	every := fs.Uint("every", 1, "Repeat every n days, weeks or months")
+	count := fs.Uint("count", 0, "Number of occurrences")
+	until := fs.String("until", "", "Date of the last occurrence")
+	timeZone := fs.String("tz", "", "Time zone of occurrences (IANA name, UTC if empty)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	startDate, err := parseDate(*start, now)
+	if err != nil {
+		return err
+	}
+
+	endDate, err := parseDate(*end, now)
+	if err != nil {
+		return err
+	}
+
+	participants, err := parseIDList(*invite)
+	if err != nil {
+		return err
+	}
+
+	msg := &proto.CreateEvent{
+		Message:      *message,
+		CreatedDate:  utils.TimeToMillis(now),
+		StartDate:    utils.TimeToMillis(startDate),
+		EndDate:      utils.TimeToMillis(endDate),
+		Participants: participants,
+		IsPublic:     *public,
+		Capacity:     int32(*capacity),
+		Quorum:       int32(*quorum),
+	}
+
+	if *deadline != "" {
+		deadlineDate, err := parseDate(*deadline, now)
+		if err != nil {
+			return err
+		}
+		msg.QuorumDeadline = utils.TimeToMillis(deadlineDate)
+	}
+
+	if isFlagSet(fs, "lat") || isFlagSet(fs, "lon") {
+		msg.Geolocation = &core.Location{Latitude: float32(*lat), Longitude: float32(*lon)}
+	}
+
+	if *repeat != "" {
+
+		frequency, err := parseFrequency(*repeat)
+		if err != nil {
+			return err
+		}
+
+		msg.Recurrence = &proto.Recurrence{
+			Frequency: frequency,
+			Interval:  uint32(*every),
+			Count:     uint32(*count),
+			TimeZone:  *timeZone,
+		}
+
+		if *until != "" {
+			untilDate, err := parseDate(*until, now)
+			if err != nil {
+				return err
+			}
+			msg.Recurrence.Until = utils.TimeToMillis(untilDate)
+		}
+	}
+
+	event, err := c.CreateEvent(msg)
+	if err != nil {
+		return err
+	}
+
+	out.Event(event)
+	return nil
+}
+
+func runModify(c *client.Client, out *printer, args []string) error {
+
+	if len(args) < 1 {
+		return ErrInvalidArgs
+	}
+
+	eventID, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("modify", flag.ContinueOnError)
+	message := fs.String("message", "", "Description")
+	start := fs.String("start", "", "Start date")
+	end := fs.String("end", "", "End date")
+	invite := fs.String("invite", "", "Comma separated list of user IDs")
+	capacity := fs.Uint("capacity", 0, "Maximum number of attendees, 0 for unlimited")
+	quorum := fs.Uint("quorum", 0, "Minimum number of attendees, 0 for none")
+	deadline := fs.String("deadline", "", "Date the event is cancelled if quorum isn't reached")
+	series := fs.Bool("series", false, "Apply to every later occurrence")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	msg := &proto.ModifyEvent{
+		EventId:       eventID,
+		Message:       *message,
+		ModifyDate:    utils.TimeToMillis(now),
+		ApplyToSeries: *series,
+	}
+
+	if *start != "" {
+		startDate, err := parseDate(*start, now)
+		if err != nil {
+			return err
+		}
+		msg.StartDate = utils.TimeToMillis(startDate)
+	}
+
+	if *end != "" {
+		endDate, err := parseDate(*end, now)
+		if err != nil {
+			return err
+		}
+		msg.EndDate = utils.TimeToMillis(endDate)
+	}
+
+	if isFlagSet(fs, "capacity") {
+		if *capacity == 0 {
+			msg.RemoveCapacity = true
+		} else {
+			msg.Capacity = int32(*capacity)
+		}
+	}
+
+	if isFlagSet(fs, "quorum") {
+		if *quorum == 0 {
+			msg.RemoveQuorum = true
+		} else {
+			msg.Quorum = int32(*quorum)
+		}
+	}
+
+	if *deadline != "" {
+		deadlineDate, err := parseDate(*deadline, now)
+		if err != nil {
+			return err
+		}
+		msg.QuorumDeadline = utils.TimeToMillis(deadlineDate)
+	}
+
+	if msg.Participants, err = parseIDList(*invite); err != nil {
+		return err
+	}
+
+	if err := c.ModifyEvent(msg); err != nil {
+		return err
+	}
+
+	out.Done("Event %v modified", eventID)
+	return nil
+}
+
+func runCancel(c *client.Client, out *printer, args []string) error {
+
+	if len(args) < 1 {
+		return ErrInvalidArgs
+	}
+
+	eventID, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("cancel", flag.ContinueOnError)
+	series := fs.Bool("series", false, "Cancel also every later occurrence")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *series {
+		if err := c.CancelEventSeries(eventID); err != nil {
+			return err
+		}
+		out.Done("Event %v and later occurrences cancelled", eventID)
+		return nil
+	}
+
+	if err := c.CancelEvent(eventID); err != nil {
+		return err
+	}
+
+	out.Done("Event %v cancelled", eventID)
+	return nil
+}
+
+func runInvite(c *client.Client, out *printer, args []string) error {
+
+	eventID, userIDs, err := parseEventAndUsers(args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.InviteUsers(eventID, userIDs...); err != nil {
+		return err
+	}
+
+	out.Done("Invited %v users to event %v", len(userIDs), eventID)
+	return nil
+}
+
+func runUninvite(c *client.Client, out *printer, args []string) error {
+
+	eventID, userIDs, err := parseEventAndUsers(args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.CancelUsersInvitation(eventID, userIDs...); err != nil {
+		return err
+	}
+
+	out.Done("Cancelled invitation of %v users to event %v", len(userIDs), eventID)
+	return nil
+}
+
+func runRespond(c *client.Client, out *printer, args []string) error {
+
+	if len(args) != 2 {
+		return ErrInvalidArgs
+	}
+
+	eventID, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	response, err := parseResponse(args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := c.ConfirmAttendance(eventID, response); err != nil {
+		return err
+	}
+
+	out.Done("Answered %v to event %v", response, eventID)
+	return nil
+}
+
+func runFriends(c *client.Client, out *printer, args []string) error {
+
+	friends, err := c.GetFriends()
+	if err != nil {
+		return err
+	}
+
+	out.Friends(friends)
+	return nil
+}
+
+func runFriendRequests(c *client.Client, out *printer, args []string) error {
+
+	requests, err := c.GetFriendRequests()
+	if err != nil {
+		return err
+	}
+
+	out.FriendRequests(requests)
+	return nil
+}
+
+func runAddFriend(c *client.Client, out *printer, args []string) error {
+
+	if len(args) != 1 {
+		return ErrInvalidArgs
+	}
+
+	if err := c.CreateFriendRequest(args[0]); err != nil {
+		return err
+	}
+
+	out.Done("Friend request sent to %v", args[0])
+	return nil
+}
+
+func runConfirmFriend(accept bool) func(c *client.Client, out *printer, args []string) error {
+	return func(c *client.Client, out *printer, args []string) error {
+
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+
+		friendID, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := c.ConfirmFriendRequest(friendID, accept); err != nil {
+			return err
+		}
+
+		out.Done("Friend request of %v answered (accepted: %v)", friendID, accept)
+		return nil
+	}
+}
+
+func runGroups(c *client.Client, out *printer, args []string) error {
+
+	groups, err := c.GetGroups()
+	if err != nil {
+		return err
+	}
+
+	out.Groups(groups)
+	return nil
+}
+
+func runSetGroup(c *client.Client, out *printer, args []string) error {
+
+	if len(args) < 3 {
+		return ErrInvalidArgs
+	}
+
+	groupID, err := parseGroupID(args[0])
+	if err != nil {
+		return err
+	}
+
+	members, err := parseIDs(args[2:])
+	if err != nil {
+		return err
+	}
+
+	group := &core.Group{Id: groupID, Name: args[1], Size: int32(len(members)), Members: members}
+	if err := c.SyncGroups([]*core.Group{group}); err != nil {
+		return err
+	}
+
+	out.Done("Group %v saved", groupID)
+	return nil
+}
+
+// Rename and delete use the special group format of SYNC GROUPS: size -1 and
+// no members. An empty name removes the group.
+func runRenameGroup(c *client.Client, out *printer, args []string) error {
+
+	if len(args) != 2 || args[1] == "" {
+		return ErrInvalidArgs
+	}
+
+	groupID, err := parseGroupID(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := c.SyncGroups([]*core.Group{{Id: groupID, Name: args[1], Size: -1}}); err != nil {
+		return err
+	}
+
+	out.Done("Group %v renamed", groupID)
+	return nil
+}
+
+func runDeleteGroup(c *client.Client, out *printer, args []string) error {
+
+	if len(args) != 1 {
+		return ErrInvalidArgs
+	}
+
+	groupID, err := parseGroupID(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := c.SyncGroups([]*core.Group{{Id: groupID, Size: -1}}); err != nil {
+		return err
+	}
+
+	out.Done("Group %v deleted", groupID)
+	return nil
+}
+
+func runComments(c *client.Client, out *printer, args []string) error {
+
+	if len(args) < 1 {
+		return ErrInvalidArgs
+	}
+
+	eventID, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("comments", flag.ContinueOnError)
+	before := fs.Int64("before", 0, "Only comments older than this one")
+	limit := fs.Uint("limit", 0, "Max number of comments")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	comments, err := c.GetComments(eventID, *before, uint32(*limit))
+	if err != nil {
+		return err
+	}
+
+	out.Comments(comments)
+	return nil
+}
+
+func runComment(c *client.Client, out *printer, args []string) error {
+
+	if len(args) < 2 {
+		return ErrInvalidArgs
+	}
+
+	eventID, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	comment, err := c.PostComment(eventID, strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	out.Done("Comment %v posted", comment.CommentId)
+	return nil
+}
+
+func runEditComment(c *client.Client, out *printer, args []string) error {
+
+	if len(args) < 3 {
+		return ErrInvalidArgs
+	}
+
+	ids, err := parseIDs(args[:2])
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.EditComment(ids[0], ids[1], strings.Join(args[2:], " ")); err != nil {
+		return err
+	}
+
+	out.Done("Comment %v edited", ids[1])
+	return nil
+}
+
+func runDeleteComment(c *client.Client, out *printer, args []string) error {
+
+	if len(args) != 2 {
+		return ErrInvalidArgs
+	}
+
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DeleteComment(ids[0], ids[1]); err != nil {
+		return err
+	}
+
+	out.Done("Comment %v deleted", ids[1])
+	return nil
+}
+
+func runDevices(c *client.Client, out *printer, args []string) error {
+
+	devices, err := c.ListDevices()
+	if err != nil {
+		return err
+	}
+
+	out.Devices(devices)
+	return nil
+}
+
+func runRevokeDevice(c *client.Client, out *printer, args []string) error {
+
+	if len(args) != 1 {
+		return ErrInvalidArgs
+	}
+
+	if err := c.RevokeDevice(args[0]); err != nil {
+		return err
+	}
+
+	out.Done("Device %v revoked", args[0])
+	return nil
+}
+
+func runNotificationSettings(c *client.Client, out *printer, args []string) error {
+
+	settings, err := c.GetNotificationSettings()
+	if err != nil {
+		return err
+	}
+
+	out.NotificationSettings(settings)
+	return nil
+}
+
+func runSetNotificationSettings(c *client.Client, out *printer, args []string) error {
+
+	fs := flag.NewFlagSet("set-notification-settings", flag.ContinueOnError)
+	disable := fs.String("disable", "", "Comma separated list of disabled categories")
+	quiet := fs.String("quiet", "", "Quiet hours window")
+	tz := fs.String("tz", "", "Time zone of quiet hours, i.e. Europe/Madrid")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	settings, err := c.GetNotificationSettings()
+	if err != nil {
+		return err
+	}
+
+	if isFlagSet(fs, "disable") {
+		if settings.DisabledCategories, err = parseCategories(*disable); err != nil {
+			return err
+		}
+	}
+
+	if isFlagSet(fs, "quiet") {
+		if *quiet == "off" {
+			settings.QuietHoursEnabled = false
+		} else {
+			start, end, err := parseQuietHours(*quiet)
+			if err != nil {
+				return err
+			}
+			settings.QuietHoursEnabled = true
+			settings.QuietHoursStart = start
+			settings.QuietHoursEnd = end
+		}
+	}
+
+	if isFlagSet(fs, "tz") {
+		settings.TimeZone = *tz
+	}
+
+	if err := c.SetNotificationSettings(settings); err != nil {
+		return err
+	}
+
+	out.Done("Notification settings changed")
+	return nil
+}
+
+func runMuteEvent(mute bool) func(*client.Client, *printer, []string) error {
+	return func(c *client.Client, out *printer, args []string) error {
+
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+
+		eventID, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := c.MuteEvent(eventID, mute); err != nil {
+			return err
+		}
+
+		if mute {
+			out.Done("Event %v muted", eventID)
+		} else {
+			out.Done("Event %v unmuted", eventID)
+		}
+		return nil
+	}
+}
+
+func runWatch(c *client.Client, out *printer, args []string) error {
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	out.Info("Watching notifications. Press Ctrl+C to exit")
+
+	for {
+		select {
+		case n, ok := <-c.Notifications():
+			if !ok {
+				return c.Err()
+			}
+			out.Notification(n)
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+// Parsing helpers
+
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid id %q", s)
+	}
+	return id, nil
+}
+
+func parseGroupID(s string) (int32, error) {
+	id, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid group id %q", s)
+	}
+	return int32(id), nil
+}
+
+func parseIDs(values []string) ([]int64, error) {
+	ids := make([]int64, 0, len(values))
+	for _, v := range values {
+		id, err := parseID(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Parses a comma separated list of IDs. An empty string is an empty list.
+func parseIDList(s string) ([]int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return parseIDs(strings.Split(s, ","))
+}
+
+func parseEventAndUsers(args []string) (int64, []int64, error) {
+
+	if len(args) < 2 {
+		return 0, nil, ErrInvalidArgs
+	}
+
+	eventID, err := parseID(args[0])
+	if err != nil {
+		return 0, nil, err
+	}
+
+	userIDs, err := parseIDs(args[1:])
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return eventID, userIDs, nil
+}
+
+// Parses a date either in RFC3339 format or as a duration relative to now,
+// i.e. +90m or +2h
+func parseDate(s string, now time.Time) (time.Time, error) {
+
+	if strings.HasPrefix(s, "+") {
+		d, err := time.ParseDuration(s[1:])
+		if err != nil {
+			return time.Time{}, ErrInvalidDate
+		}
+		return now.Add(d), nil
+	}
+
+	date, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+
+	return date, nil
+}
+
+func parseResponse(s string) (core.AttendanceResponse, error) {
+	switch strings.ToLower(s) {
+	case "assist", "yes":
+		return core.AttendanceResponse_ASSIST, nil
+	case "no", "no_assist":
+		return core.AttendanceResponse_NO_ASSIST, nil
+	case "cannot", "cannot_assist":
+		return core.AttendanceResponse_CANNOT_ASSIST, nil
+	}
+	return core.AttendanceResponse_NO_RESPONSE, ErrInvalidResponse
+}
+
+func parseFrequency(s string) (proto.RecurrenceFrequency, error) {
+	switch strings.ToLower(s) {
+	case "daily":
+		return proto.RecurrenceFrequency_R_DAILY, nil
+	case "weekly":
+		return proto.RecurrenceFrequency_R_WEEKLY, nil
+	case "monthly":
+		return proto.RecurrenceFrequency_R_MONTHLY, nil
+	}
+	return proto.RecurrenceFrequency_R_NONE, ErrInvalidRepeat
+}
+
+// Parses a comma separated list of notification categories. An empty string
+// is an empty list.
+func parseCategories(s string) ([]proto.NotificationCategory, error) {
+
+	if s == "" {
+		return nil, nil
+	}
+
+	var categories []proto.NotificationCategory
+	for _, name := range strings.Split(s, ",") {
+		category, ok := categoryNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, ErrInvalidCategory
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// Parses a quiet hours window like 23:00-07:30 into minutes since midnight
+func parseQuietHours(s string) (uint32, uint32, error) {
+
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return 0, 0, ErrInvalidQuiet
+	}
+
+	var minutes [2]uint32
+	for i, part := range parts {
+		t, err := time.Parse("15:04", strings.TrimSpace(part))
+		if err != nil {
+			return 0, 0, ErrInvalidQuiet
+		}
+		minutes[i] = uint32(t.Hour()*60 + t.Minute())
+	}
+
+	return minutes[0], minutes[1], nil
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	found := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			found = true
+		}
+	})
+	return found
+}
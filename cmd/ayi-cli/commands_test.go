@@ -0,0 +1,123 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/core"
+)
+
+func TestParseDate(t *testing.T) {
+
+	now := time.Date(2017, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	date, err := parseDate("+90m", now)
+	if err != nil || !date.Equal(now.Add(90*time.Minute)) {
+		t.Fatalf("relative date: got %v, %v", date, err)
+	}
+
+	date, err = parseDate("2017-01-02T12:30:00+01:00", now)
+	if err != nil || !date.Equal(time.Date(2017, 1, 2, 11, 30, 0, 0, time.UTC)) {
+		t.Fatalf("absolute date: got %v, %v", date, err)
+	}
+
+	for _, s := range []string{"", "+", "+2x", "tomorrow"} {
+		if _, err := parseDate(s, now); err != ErrInvalidDate {
+			t.Errorf("date %q: expected ErrInvalidDate, got %v", s, err)
+		}
+	}
+}
+
+func TestParseIDList(t *testing.T) {
+
+	ids, err := parseIDList("")
+	if err != nil || len(ids) != 0 {
+		t.Fatalf("empty list: got %v, %v", ids, err)
+	}
+
+	ids, err = parseIDList("1, 2,3")
+	if err != nil || len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Fatalf("got %v, %v", ids, err)
+	}
+
+	if _, err := parseIDList("1,,2"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err := parseIDList("-1"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+
+	tests := map[string]core.AttendanceResponse{
+		"assist": core.AttendanceResponse_ASSIST,
+		"YES":    core.AttendanceResponse_ASSIST,
+		"no":     core.AttendanceResponse_NO_ASSIST,
+		"cannot": core.AttendanceResponse_CANNOT_ASSIST,
+	}
+
+	for s, expected := range tests {
+		if response, err := parseResponse(s); err != nil || response != expected {
+			t.Errorf("%v: expected %v, got %v (%v)", s, expected, response, err)
+		}
+	}
+
+	if _, err := parseResponse("maybe"); err != ErrInvalidResponse {
+		t.Errorf("expected ErrInvalidResponse, got %v", err)
+	}
+}
+
+func TestParseFrequency(t *testing.T) {
+
+	tests := map[string]proto.RecurrenceFrequency{
+		"daily":   proto.RecurrenceFrequency_R_DAILY,
+		"Weekly":  proto.RecurrenceFrequency_R_WEEKLY,
+		"MONTHLY": proto.RecurrenceFrequency_R_MONTHLY,
+	}
+
+	for input, want := range tests {
+		if got, err := parseFrequency(input); err != nil || got != want {
+			t.Fatalf("%v: expected %v, got %v (%v)", input, want, got, err)
+		}
+	}
+
+	if _, err := parseFrequency("yearly"); err != ErrInvalidRepeat {
+		t.Fatalf("expected ErrInvalidRepeat, got %v", err)
+	}
+}
+
+func TestParseCategories(t *testing.T) {
+
+	categories, err := parseCategories("")
+	if err != nil || len(categories) != 0 {
+		t.Fatalf("empty list: got %v, %v", categories, err)
+	}
+
+	categories, err = parseCategories("response, Change")
+	if err != nil || len(categories) != 2 ||
+		categories[0] != proto.NotificationCategory_N_EVENT_RESPONSE ||
+		categories[1] != proto.NotificationCategory_N_EVENT_CHANGE {
+		t.Fatalf("got %v, %v", categories, err)
+	}
+
+	if _, err := parseCategories("response,likes"); err != ErrInvalidCategory {
+		t.Fatalf("expected ErrInvalidCategory, got %v", err)
+	}
+}
+
+func TestParseQuietHours(t *testing.T) {
+
+	start, end, err := parseQuietHours("23:00-07:30")
+	if err != nil || start != 1380 || end != 450 {
+		t.Fatalf("got %v, %v, %v", start, end, err)
+	}
+
+	for _, s := range []string{"", "23:00", "23:00-24:00", "11pm-7am"} {
+		if _, _, err := parseQuietHours(s); err != ErrInvalidQuiet {
+			t.Errorf("quiet hours %q: expected ErrInvalidQuiet, got %v", s, err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/d3ce1t/areyouin-server/client"
+)
+
+const CLIENT_VERSION = "1.0.0"
+
+// ayi-cli -s staging.example.com:1822 -email user@example.com -password secret events
+// ayi-cli -s staging.example.com:1822 -user-id 1234 -token abcd -json watch
+
+type command struct {
+	usage       string
+	description string
+	run         func(c *client.Client, out *printer, args []string) error
+}
+
+var commands = map[string]*command{}
+
+func registerCommand(name string, cmd *command) {
+	commands[name] = cmd
+}
+
+func showError(errStr string) {
+	fmt.Fprintf(os.Stderr, "\n\tError: %v\n\n", errStr)
+	fmt.Fprintf(os.Stderr, "\t%v --help for usage information\n\n", os.Args[0])
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %v [options] <command> [args]\n\nOptions:\n", os.Args[0])
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\nCommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cmd := commands[name]
+		fmt.Fprintf(os.Stderr, "  %-62v %v\n", strings.TrimSpace(name+" "+cmd.usage), cmd.description)
+	}
+}
+
+func main() {
+
+	// Init flags
+
+	var address string
+	var useTLS bool
+	var insecure bool
+	var email string
+	var password string
+	var userID int64
+	var token string
+	var jsonOutput bool
+	var deviceID string
+
+	flag.StringVar(&address, "s", "localhost:1822", "Server address (host:port)")
+	flag.BoolVar(&useTLS, "tls", false, "Upgrade connection to TLS")
+	flag.BoolVar(&insecure, "insecure", false, "Do not verify server certificate")
+	flag.StringVar(&email, "email", "", "E-mail of the account")
+	flag.StringVar(&password, "password", "", "Password of the account")
+	flag.Int64Var(&userID, "user-id", 0, "User ID (use with -token)")
+	flag.StringVar(&token, "token", "", "Existing auth token (use with -user-id)")
+	flag.BoolVar(&jsonOutput, "json", false, "Print output as JSON")
+	flag.StringVar(&deviceID, "device", "ayi-cli", "Device ID, so other sessions of the user are kept")
+	flag.Usage = usage
+
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		showError(fmt.Sprintf("Unknown command %v", flag.Arg(0)))
+		os.Exit(2)
+	}
+
+	if (email == "" || password == "") && (userID == 0 || token == "") {
+		showError("Credentials aren't set. Use -email and -password, or -user-id and -token")
+		os.Exit(2)
+	}
+
+	// Connect
+
+	opts := &client.Options{
+		ClientVersion: CLIENT_VERSION,
+		Platform:      "ayi-cli",
+		DeviceID:      deviceID,
+	}
+
+	if useTLS {
+		host := address
+		if i := strings.LastIndex(address, ":"); i != -1 {
+			host = address[:i]
+		}
+		opts.TLSConfig = &tls.Config{ServerName: host, InsecureSkipVerify: insecure}
+	}
+
+	c, err := client.Dial(address, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot connect to %v: %v\n", address, err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	out := newPrinter(os.Stdout, jsonOutput)
+
+	// Authenticate
+
+	if email != "" && password != "" {
+		accessToken, err := c.Login(email, password)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Login failed: %v\n", err)
+			os.Exit(1)
+		}
+		out.Info("Logged in as %v (token: %v)", accessToken.UserId, accessToken.AuthToken)
+	} else if err := c.Authenticate(userID, token); err != nil {
+		fmt.Fprintf(os.Stderr, "Authentication failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Execute command
+
+	if err := cmd.run(c, out, flag.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%v: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+}
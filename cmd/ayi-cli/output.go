@@ -0,0 +1,364 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/client"
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/core"
+	"github.com/d3ce1t/areyouin-server/utils"
+	"github.com/golang/protobuf/jsonpb"
+	pb "github.com/golang/protobuf/proto"
+)
+
+const timeLayout = "2006-01-02 15:04 MST"
+
+var notificationNames = map[proto.PacketType]string{
+	proto.M_EVENT_CREATED:           "EVENT CREATED",
+	proto.M_EVENT_CANCELLED:         "EVENT CANCELLED",
+	proto.M_EVENT_EXPIRED:           "EVENT EXPIRED",
+	proto.M_EVENT_MODIFIED:          "EVENT MODIFIED",
+	proto.M_INVITATION_RECEIVED:     "INVITATION RECEIVED",
+	proto.M_INVITATION_CANCELLED:    "INVITATION CANCELLED",
+	proto.M_ATTENDANCE_STATUS:       "ATTENDANCE STATUS",
+	proto.M_EVENT_CHANGE_PROPOSED:   "EVENT CHANGE PROPOSED",
+	proto.M_VOTING_STATUS:           "VOTING STATUS",
+	proto.M_VOTING_FINISHED:         "VOTING FINISHED",
+	proto.M_CHANGE_ACCEPTED:         "CHANGE ACCEPTED",
+	proto.M_CHANGE_DISCARDED:        "CHANGE DISCARDED",
+	proto.M_FRIEND_REQUEST_RECEIVED: "FRIEND REQUEST RECEIVED",
+	proto.M_COMMENT_POSTED:          "COMMENT POSTED",
+	proto.M_COMMENT_EDITED:          "COMMENT EDITED",
+	proto.M_COMMENT_DELETED:         "COMMENT DELETED",
+}
+
+// printer writes command results to stdout either as readable text or as one
+// JSON object per line. Informative messages always go to stderr so that JSON
+// output can be piped.
+type printer struct {
+	w          io.Writer
+	json       bool
+	marshaler  *jsonpb.Marshaler
+	timeLocale *time.Location
+}
+
+func newPrinter(w io.Writer, json bool) *printer {
+	return &printer{
+		w:          w,
+		json:       json,
+		marshaler:  &jsonpb.Marshaler{OrigName: true},
+		timeLocale: time.Local,
+	}
+}
+
+func (p *printer) Info(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func (p *printer) JSON(message pb.Message) {
+	str, err := p.marshaler.MarshalToString(message)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot marshal %T: %v\n", message, err)
+		return
+	}
+	fmt.Fprintln(p.w, str)
+}
+
+func (p *printer) Events(events []*core.Event) {
+
+	if p.json {
+		p.JSON(&proto.EventsList{Event: events})
+		return
+	}
+
+	if len(events) == 0 {
+		fmt.Fprintln(p.w, "No events")
+		return
+	}
+
+	for _, event := range events {
+		fmt.Fprintln(p.w, p.eventSummary(event))
+	}
+}
+
+func (p *printer) Event(event *core.Event) {
+
+	if p.json {
+		p.JSON(event)
+		return
+	}
+
+	fmt.Fprintln(p.w, p.eventSummary(event))
+
+	if event.Geolocation != nil {
+		fmt.Fprintf(p.w, "  Location: %v, %v\n", event.Geolocation.Latitude, event.Geolocation.Longitude)
+	}
+
+	if event.SeriesId != 0 {
+		fmt.Fprintf(p.w, "  Series: %v\n", event.SeriesId)
+	}
+
+	if event.Capacity != 0 {
+		fmt.Fprintf(p.w, "  Capacity: %v\n", event.Capacity)
+	}
+
+	if len(event.Waitlist) > 0 {
+		fmt.Fprintf(p.w, "  Waitlist: %v\n", event.Waitlist)
+	}
+
+	if event.Quorum != 0 {
+		fmt.Fprintf(p.w, "  Quorum: %v by %v\n", event.Quorum, p.formatTime(event.QuorumDeadline))
+	}
+
+	participants := make([]*core.EventParticipant, 0, len(event.Participants))
+	for _, participant := range event.Participants {
+		participants = append(participants, participant)
+	}
+
+	p.Participants(participants)
+}
+
+func (p *printer) Participants(participants []*core.EventParticipant) {
+
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].UserId < participants[j].UserId
+	})
+
+	for _, participant := range participants {
+		fmt.Fprintf(p.w, "  - %v %v: %v (%v)\n", participant.UserId, participant.Name,
+			participant.Response, participant.Delivered)
+	}
+}
+
+func (p *printer) Friends(friends []*core.Friend) {
+
+	if p.json {
+		p.JSON(&proto.FriendsList{Friends: friends})
+		return
+	}
+
+	if len(friends) == 0 {
+		fmt.Fprintln(p.w, "No friends")
+		return
+	}
+
+	for _, friend := range friends {
+		fmt.Fprintf(p.w, "%v %v\n", friend.UserId, friend.Name)
+	}
+}
+
+func (p *printer) Groups(groups []*core.Group) {
+
+	if p.json {
+		p.JSON(&proto.GroupsList{Groups: groups})
+		return
+	}
+
+	if len(groups) == 0 {
+		fmt.Fprintln(p.w, "No groups")
+		return
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(p.w, "%v %v (%v members): %v\n", group.Id, group.Name, group.Size, group.Members)
+	}
+}
+
+func (p *printer) FriendRequests(requests []*core.FriendRequest) {
+
+	if p.json {
+		p.JSON(&proto.FriendRequestsList{FriendRequests: requests})
+		return
+	}
+
+	if len(requests) == 0 {
+		fmt.Fprintln(p.w, "No friend requests")
+		return
+	}
+
+	for _, r := range requests {
+		fmt.Fprintf(p.w, "%v %v <%v> (%v)\n", r.FriendId, r.Name, r.Email, p.formatTime(r.CreatedDate))
+	}
+}
+
+func (p *printer) Devices(devices []*proto.Device) {
+
+	if p.json {
+		p.JSON(&proto.DevicesList{Devices: devices})
+		return
+	}
+
+	if len(devices) == 0 {
+		fmt.Fprintln(p.w, "No devices")
+		return
+	}
+
+	for _, d := range devices {
+		current := ""
+		if d.Current {
+			current = " *"
+		}
+		fmt.Fprintf(p.w, "%q %v (version: %v, last seen: %v)%v\n", d.DeviceId, d.Platform,
+			d.NetworkVersion, p.formatTime(d.LastSeen), current)
+	}
+}
+
+// Comments are printed oldest first, as a conversation
+func (p *printer) Comments(list *proto.CommentsList) {
+
+	if p.json {
+		p.JSON(list)
+		return
+	}
+
+	if len(list.Comments) == 0 {
+		fmt.Fprintln(p.w, "No comments")
+		return
+	}
+
+	for i := len(list.Comments) - 1; i >= 0; i-- {
+		fmt.Fprintln(p.w, p.commentSummary(list.Comments[i]))
+	}
+
+	if list.HasMore {
+		fmt.Fprintf(p.w, "Older comments: -before %v\n", list.Comments[len(list.Comments)-1].CommentId)
+	}
+}
+
+func (p *printer) NotificationSettings(settings *proto.NotificationSettings) {
+
+	if p.json {
+		p.JSON(settings)
+		return
+	}
+
+	var disabled []string
+	for name, category := range categoryNames {
+		for _, c := range settings.DisabledCategories {
+			if c == category {
+				disabled = append(disabled, name)
+			}
+		}
+	}
+	sort.Strings(disabled)
+
+	if len(disabled) == 0 {
+		fmt.Fprintln(p.w, "Disabled categories: none")
+	} else {
+		fmt.Fprintf(p.w, "Disabled categories: %v\n", strings.Join(disabled, ", "))
+	}
+
+	if settings.QuietHoursEnabled {
+		tz := settings.TimeZone
+		if tz == "" {
+			tz = "UTC"
+		}
+		fmt.Fprintf(p.w, "Quiet hours: %02d:%02d-%02d:%02d (%v)\n",
+			settings.QuietHoursStart/60, settings.QuietHoursStart%60,
+			settings.QuietHoursEnd/60, settings.QuietHoursEnd%60, tz)
+	} else {
+		fmt.Fprintln(p.w, "Quiet hours: off")
+	}
+
+	fmt.Fprintf(p.w, "Muted events: %v\n", len(settings.MutedEvents))
+	for _, eventID := range settings.MutedEvents {
+		fmt.Fprintf(p.w, "  %v\n", eventID)
+	}
+}
+
+func (p *printer) Done(format string, args ...interface{}) {
+	if !p.json {
+		fmt.Fprintf(p.w, format+"\n", args...)
+	}
+}
+
+func (p *printer) Notification(n *client.Notification) {
+
+	name, ok := notificationNames[n.Type]
+	if !ok {
+		name = fmt.Sprintf("PACKET 0x%X", uint8(n.Type))
+	}
+
+	if p.json {
+		msg := ""
+		if n.Message != nil {
+			var err error
+			if msg, err = p.marshaler.MarshalToString(n.Message); err != nil {
+				msg = ""
+			}
+		}
+		if msg == "" {
+			msg = "{}"
+		}
+		fmt.Fprintf(p.w, "{\"type\":%q,\"time\":%q,\"message\":%v}\n",
+			name, time.Now().UTC().Format(time.RFC3339), msg)
+		return
+	}
+
+	fmt.Fprintf(p.w, "[%v] %v", time.Now().In(p.timeLocale).Format("15:04:05"), name)
+
+	switch msg := n.Message.(type) {
+	case *core.Event:
+		fmt.Fprintf(p.w, ": %v\n", p.eventSummary(msg))
+	case *proto.EventCancelled:
+		fmt.Fprintf(p.w, ": event %v cancelled by %v\n", msg.EventId, msg.WhoId)
+	case *proto.AttendanceStatus:
+		fmt.Fprintf(p.w, ": event %v (%v guests)\n", msg.EventId, msg.NumGuests)
+		p.Participants(msg.AttendanceStatus)
+		if len(msg.RemovedParticipants) > 0 {
+			fmt.Fprintf(p.w, "  removed: %v\n", msg.RemovedParticipants)
+		}
+		if len(msg.Waitlist) > 0 {
+			fmt.Fprintf(p.w, "  waitlist: %v\n", msg.Waitlist)
+		}
+	case *proto.EventComment:
+		if n.Type == proto.M_COMMENT_DELETED {
+			fmt.Fprintf(p.w, ": event %v comment %v\n", msg.EventId, msg.CommentId)
+		} else {
+			fmt.Fprintf(p.w, ": event %v %v\n", msg.EventId, p.commentSummary(msg))
+		}
+	case nil:
+		fmt.Fprintln(p.w)
+	default:
+		fmt.Fprintf(p.w, ": %v\n", pb.CompactTextString(msg))
+	}
+}
+
+func (p *printer) eventSummary(event *core.Event) string {
+
+	var tags []string
+	tags = append(tags, event.State.String())
+	if event.IsPublic {
+		tags = append(tags, "PUBLIC")
+	}
+	if event.Confirmed {
+		tags = append(tags, "CONFIRMED")
+	}
+
+	return fmt.Sprintf("#%v %q | %v - %v | by %v | %v/%v attending | %v",
+		event.EventId, event.Message, p.formatTime(event.StartDate), p.formatTime(event.EndDate),
+		event.AuthorName, event.NumAttendees, event.NumGuests, strings.Join(tags, ","))
+}
+
+func (p *printer) commentSummary(comment *proto.EventComment) string {
+
+	edited := ""
+	if comment.EditedDate != 0 {
+		edited = " (edited)"
+	}
+
+	return fmt.Sprintf("#%v %v | %v: %v%v", comment.CommentId, p.formatTime(comment.CreatedDate),
+		comment.AuthorName, comment.Message, edited)
+}
+
+func (p *printer) formatTime(millis int64) string {
+	if millis == 0 {
+		return "-"
+	}
+	return utils.MillisToTimeUTC(millis).In(p.timeLocale).Format(timeLayout)
+}
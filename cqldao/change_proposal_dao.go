@@ -0,0 +1,167 @@
+package cqldao
+
+import (
+	"github.com/d3ce1t/areyouin-server/api"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	// All proposals in voting state are kept in a single partition. There are
+	// only a few of them at the same time because they are removed as soon as
+	// the voting finishes.
+	votingProposalsBucket = 0
+
+	proposalCols = `event_id, change_id, author_id, created_date, deadline,
+		start_date, end_date, message, votes_total, status, votes`
+)
+
+type ChangeProposalDAO struct {
+	session *GocqlSession
+}
+
+func NewChangeProposalDAO(session api.DbSession) api.ChangeProposalDAO {
+	reconnectIfNeeded(session)
+	return &ChangeProposalDAO{session: session.(*GocqlSession)}
+}
+
+func (d *ChangeProposalDAO) Load(eventID int64, changeID int32) (*api.ChangeProposalDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT ` + proposalCols + ` FROM event_change_proposals
+		WHERE event_id = ? AND change_id = ?`
+
+	proposals, err := d.loadAux(d.session.Query(stmt, eventID, changeID))
+	if err == api.ErrNoResults {
+		return nil, api.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return proposals[0], nil
+}
+
+func (d *ChangeProposalDAO) LoadAll(eventID int64) ([]*api.ChangeProposalDTO, error) {
+	checkSession(d.session)
+	stmt := `SELECT ` + proposalCols + ` FROM event_change_proposals WHERE event_id = ?`
+	return d.loadAux(d.session.Query(stmt, eventID))
+}
+
+func (d *ChangeProposalDAO) LoadAllVoting() ([]*api.ChangeProposalDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT event_id, change_id FROM voting_change_proposals WHERE bucket = ?`
+	iter := d.session.Query(stmt, votingProposalsBucket).Iter()
+
+	var eventID int64
+	var changeID int32
+	var results []*api.ChangeProposalDTO
+
+	for iter.Scan(&eventID, &changeID) {
+		proposal, err := d.Load(eventID, changeID)
+		if err != nil {
+			iter.Close()
+			return nil, err
+		}
+		results = append(results, proposal)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convErr(err)
+	}
+
+	return results, nil
+}
+
+// Insert a new change proposal. Proposal is also added to the list of proposals
+// that are in voting state if its status is ChangeProposalStatus_VOTING.
+func (d *ChangeProposalDAO) Insert(proposal *api.ChangeProposalDTO) error {
+
+	checkSession(d.session)
+
+	if proposal == nil || proposal.EventID == 0 || proposal.ChangeID == 0 {
+		return ErrIllegalArguments
+	}
+
+	stmtProposal := `INSERT INTO event_change_proposals (` + proposalCols + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	stmtVoting := `INSERT INTO voting_change_proposals (bucket, event_id, change_id)
+		VALUES (?, ?, ?)`
+
+	batch := d.session.NewBatch(gocql.LoggedBatch)
+
+	batch.Query(stmtProposal, proposal.EventID, proposal.ChangeID, proposal.AuthorID,
+		proposal.CreatedDate, proposal.Deadline, proposal.StartDate, proposal.EndDate,
+		proposal.Description, proposal.VotesTotal, proposal.Status, proposal.Votes)
+
+	if proposal.Status == api.ChangeProposalStatus_VOTING {
+		batch.Query(stmtVoting, votingProposalsBucket, proposal.EventID, proposal.ChangeID)
+	}
+
+	return convErr(d.session.ExecuteBatch(batch))
+}
+
+func (d *ChangeProposalDAO) InsertVote(eventID int64, changeID int32, userID int64, accept bool) error {
+	checkSession(d.session)
+	stmt := `UPDATE event_change_proposals SET votes[?] = ? WHERE event_id = ? AND change_id = ?`
+	q := d.session.Query(stmt, userID, accept, eventID, changeID)
+	return convErr(q.Exec())
+}
+
+// SetStatus changes the status of a proposal. When status is other than
+// ChangeProposalStatus_VOTING the proposal is no longer considered in voting state.
+func (d *ChangeProposalDAO) SetStatus(eventID int64, changeID int32, status api.ChangeProposalStatus) error {
+
+	checkSession(d.session)
+
+	stmtStatus := `UPDATE event_change_proposals SET status = ? WHERE event_id = ? AND change_id = ?`
+	stmtDelete := `DELETE FROM voting_change_proposals WHERE bucket = ? AND event_id = ? AND change_id = ?`
+
+	batch := d.session.NewBatch(gocql.LoggedBatch)
+	batch.Query(stmtStatus, status, eventID, changeID)
+
+	if status != api.ChangeProposalStatus_VOTING {
+		batch.Query(stmtDelete, votingProposalsBucket, eventID, changeID)
+	}
+
+	return convErr(d.session.ExecuteBatch(batch))
+}
+
+func (d *ChangeProposalDAO) loadAux(query *gocql.Query) ([]*api.ChangeProposalDTO, error) {
+
+	iter := query.Iter()
+	var results []*api.ChangeProposalDTO
+	var status int32
+
+	for {
+
+		dto := &api.ChangeProposalDTO{}
+
+		if !iter.Scan(&dto.EventID, &dto.ChangeID, &dto.AuthorID, &dto.CreatedDate,
+			&dto.Deadline, &dto.StartDate, &dto.EndDate, &dto.Description,
+			&dto.VotesTotal, &status, &dto.Votes) {
+			break
+		}
+
+		dto.Status = api.ChangeProposalStatus(status)
+
+		if dto.Votes == nil {
+			dto.Votes = make(map[int64]bool)
+		}
+
+		results = append(results, dto)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convErr(err)
+	}
+
+	if len(results) == 0 {
+		return nil, api.ErrNoResults
+	}
+
+	return results, nil
+}
@@ -0,0 +1,82 @@
+package cqldao
+
+import (
+	"github.com/d3ce1t/areyouin-server/api"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	MAX_CHANGES_IN_SYNC_LIST = 200
+)
+
+type EventChangeLogDAO struct {
+	session *GocqlSession
+}
+
+func NewEventChangeLogDAO(session api.DbSession) api.EventChangeLogDAO {
+	reconnectIfNeeded(session)
+	return &EventChangeLogDAO{session: session.(*GocqlSession)}
+}
+
+// Insert adds the same change to the change log of every given user. UserID
+// field of change is ignored.
+func (d *EventChangeLogDAO) Insert(change *api.EventChangeDTO, userIDs ...int64) error {
+
+	checkSession(d.session)
+
+	if change == nil || change.EventID == 0 || change.Timestamp == 0 {
+		return ErrIllegalArguments
+	}
+
+	stmt := `INSERT INTO event_changes_by_user (user_id, timestamp, event_id, change_type)
+		VALUES (?, ?, ?, ?)`
+
+	batch := d.session.NewBatch(gocql.UnloggedBatch)
+
+	for _, userID := range userIDs {
+		batch.Query(stmt, userID, change.Timestamp, change.EventID, change.Type)
+	}
+
+	return convErr(d.session.ExecuteBatch(batch))
+}
+
+// FindAllSince returns changes of the events of a user that come after the
+// given timestamp and event ID, ordered by timestamp and event ID ASC. At most
+// MAX_CHANGES_IN_SYNC_LIST entries are returned.
+func (d *EventChangeLogDAO) FindAllSince(userID int64, timestamp int64, eventID int64) ([]*api.EventChangeDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT user_id, timestamp, event_id, change_type FROM event_changes_by_user
+		WHERE user_id = ? AND (timestamp, event_id) > (?, ?) LIMIT ?`
+
+	iter := d.session.Query(stmt, userID, timestamp, eventID, MAX_CHANGES_IN_SYNC_LIST).Iter()
+
+	var changeType int32
+	var results []*api.EventChangeDTO
+
+	for {
+		change := new(api.EventChangeDTO)
+		if !iter.Scan(&change.UserID, &change.Timestamp, &change.EventID, &changeType) {
+			break
+		}
+		change.Type = api.EventChangeType(changeType)
+		results = append(results, change)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convErr(err)
+	}
+
+	if len(results) == 0 {
+		return nil, api.ErrNoResults
+	}
+
+	return results, nil
+}
+
+func (d *EventChangeLogDAO) DeleteAll() error {
+	checkSession(d.session)
+	return d.session.Query(`TRUNCATE event_changes_by_user`).Exec()
+}
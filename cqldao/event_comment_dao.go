@@ -0,0 +1,108 @@
+package cqldao
+
+import (
+	"github.com/d3ce1t/areyouin-server/api"
+
+	"github.com/gocql/gocql"
+)
+
+const commentCols = `event_id, comment_id, author_id, author_name, message, created_date, edited_date`
+
+type EventCommentDAO struct {
+	session *GocqlSession
+}
+
+func NewEventCommentDAO(session api.DbSession) api.EventCommentDAO {
+	reconnectIfNeeded(session)
+	return &EventCommentDAO{session: session.(*GocqlSession)}
+}
+
+func (d *EventCommentDAO) Load(eventID int64, commentID int64) (*api.EventCommentDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT ` + commentCols + ` FROM event_comments WHERE event_id = ? AND comment_id = ?`
+
+	comments, err := d.loadAux(d.session.Query(stmt, eventID, commentID))
+	if err == api.ErrNoResults {
+		return nil, api.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return comments[0], nil
+}
+
+// Insert stores a comment replacing any previous version of it
+func (d *EventCommentDAO) Insert(comment *api.EventCommentDTO) error {
+
+	checkSession(d.session)
+
+	if comment == nil || comment.EventID == 0 || comment.CommentID == 0 || comment.AuthorID == 0 {
+		return ErrIllegalArguments
+	}
+
+	stmt := `INSERT INTO event_comments (` + commentCols + `) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	err := d.session.Query(stmt, comment.EventID, comment.CommentID, comment.AuthorID,
+		comment.AuthorName, comment.Message, comment.CreatedDate, comment.EditedDate).Exec()
+
+	return convErr(err)
+}
+
+func (d *EventCommentDAO) Delete(eventID int64, commentID int64) error {
+	checkSession(d.session)
+	stmt := `DELETE FROM event_comments WHERE event_id = ? AND comment_id = ?`
+	return convErr(d.session.Query(stmt, eventID, commentID).Exec())
+}
+
+// FindPage returns up to limit comments of an event older than beforeID,
+// newest first. A zero beforeID returns the newest comments.
+func (d *EventCommentDAO) FindPage(eventID int64, beforeID int64, limit int) ([]*api.EventCommentDTO, error) {
+
+	checkSession(d.session)
+
+	if limit <= 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if beforeID == 0 {
+		stmt := `SELECT ` + commentCols + ` FROM event_comments WHERE event_id = ? LIMIT ?`
+		return d.loadAux(d.session.Query(stmt, eventID, limit))
+	}
+
+	stmt := `SELECT ` + commentCols + ` FROM event_comments
+		WHERE event_id = ? AND comment_id < ? LIMIT ?`
+
+	return d.loadAux(d.session.Query(stmt, eventID, beforeID, limit))
+}
+
+func (d *EventCommentDAO) DeleteAll() error {
+	checkSession(d.session)
+	return d.session.Query(`TRUNCATE event_comments`).Exec()
+}
+
+func (d *EventCommentDAO) loadAux(query *gocql.Query) ([]*api.EventCommentDTO, error) {
+
+	iter := query.Iter()
+	var results []*api.EventCommentDTO
+
+	for {
+		dto := new(api.EventCommentDTO)
+		if !iter.Scan(&dto.EventID, &dto.CommentID, &dto.AuthorID, &dto.AuthorName,
+			&dto.Message, &dto.CreatedDate, &dto.EditedDate) {
+			break
+		}
+		results = append(results, dto)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convErr(err)
+	}
+
+	if len(results) == 0 {
+		return nil, api.ErrNoResults
+	}
+
+	return results, nil
+}
@@ -0,0 +1,89 @@
+package cqldao
+
+import (
+	"testing"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+func TestEventCommentDAO_Paging(t *testing.T) {
+
+	d := NewEventCommentDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.FindPage(1, 0, 10); err != api.ErrNoResults {
+		t.Fatalf("Expected ErrNoResults, got %v", err)
+	}
+
+	for commentID := int64(1); commentID <= 5; commentID++ {
+		comment := &api.EventCommentDTO{
+			EventID:     1,
+			CommentID:   commentID,
+			AuthorID:    10,
+			AuthorName:  "Ana",
+			Message:     "See you there",
+			CreatedDate: 1700000000000 + commentID,
+		}
+		if err := d.Insert(comment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := d.FindPage(1, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page) != 2 || page[0].CommentID != 5 || page[1].CommentID != 4 {
+		t.Fatalf("Unexpected first page %v", page)
+	}
+
+	page, err = d.FindPage(1, 4, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page) != 3 || page[0].CommentID != 3 || page[2].CommentID != 1 {
+		t.Fatalf("Unexpected second page %v", page)
+	}
+}
+
+func TestEventCommentDAO_EditAndDelete(t *testing.T) {
+
+	d := NewEventCommentDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	comment := &api.EventCommentDTO{EventID: 1, CommentID: 2, AuthorID: 10, Message: "Hello"}
+	if err := d.Insert(comment); err != nil {
+		t.Fatal(err)
+	}
+
+	comment.Message = "Hello again"
+	comment.EditedDate = 1700000000000
+	if err := d.Insert(comment); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := d.Load(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Message != "Hello again" || result.EditedDate != comment.EditedDate {
+		t.Fatalf("Read back different comment than inserted: %+v", result)
+	}
+
+	if err := d.Delete(1, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.Load(1, 2); err != api.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -15,7 +15,7 @@ const (
 
 	queryCols = `event_id, author_id, author_name, message, picture_digest,
 		created_date, inbox_position, start_date, end_date, event_state, event_timestamp,
-		guest_id, guest_name, guest_response, guest_status, writetime(guest_name) as guest_name_ts, 
+		public, latitude, longitude, series_id, capacity, quorum, quorum_deadline, confirmed, guest_id, guest_name, guest_response, guest_status, writetime(guest_name) as guest_name_ts, 
 		writetime(guest_response) as guest_response_ts,	writetime(guest_status) as guest_status_ts`
 )
 
@@ -41,8 +41,9 @@ func (d *EventDAO) Insert(event *api.EventDTO) error {
 		VALUES (?, ?, ?) USING TIMESTAMP ?`
 
 	stmtEvent := `INSERT INTO event (event_id, author_id, author_name, message,
-		start_date, end_date, created_date, inbox_position, event_state, event_timestamp)
-	  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)  USING TIMESTAMP ?`
+		start_date, end_date, created_date, inbox_position, event_state, event_timestamp,
+		public, latitude, longitude, series_id, capacity, quorum, quorum_deadline, confirmed)
+	  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)  USING TIMESTAMP ?`
 
 	var status int32
 	if event.Cancelled {
@@ -56,9 +57,12 @@ func (d *EventDAO) Insert(event *api.EventDTO) error {
 	timeLineBucket := utils.MillisToTimeUTC(event.EndDate).Year()
 	batch.Query(stmtTimeline, timeLineBucket, event.Id, event.EndDate, event.Timestamp)
 
+	latitude, longitude := locationValues(event.Location)
+
 	batch.Query(stmtEvent, event.Id, event.AuthorId, event.AuthorName,
 		event.Description, event.StartDate, event.EndDate, event.CreatedDate,
-		event.InboxPosition, status, event.Timestamp, event.Timestamp)
+		event.InboxPosition, status, event.Timestamp, event.IsPublic, latitude, longitude,
+		event.SeriesID, event.Capacity, event.Quorum, event.QuorumDeadline, event.Confirmed, event.Timestamp)
 
 	if len(event.Participants) > 0 {
 		stmtParticipant := `INSERT INTO event (event_id, guest_id, guest_name, guest_response, guest_status)
@@ -75,11 +79,11 @@ func (d *EventDAO) Insert(event *api.EventDTO) error {
 }
 
 // Replace modifies information of an existing event. This implementation only
-// changes message, start_date, end_date, inbox_position and event state. Moreover,
+// changes message, start_date, end_date, inbox_position, capacity, quorum and event state. Moreover,
 // it doesn't modify information related to existing participants but only can add
 // new ones where version isn't set.
-// NOTE: This implementation takes into account the single use case where an event update
-// contains new participants only.
+// NOTE: This implementation takes into account the use case where an event update
+// contains new participants or where some of the existing ones were removed.
 func (d *EventDAO) Replace(oldEvent *api.EventDTO, newEvent *api.EventDTO) error {
 
 	// TODO: Optimise use case when only it has to write to event (with and without participants)
@@ -125,10 +129,15 @@ func (d *EventDAO) Replace(oldEvent *api.EventDTO, newEvent *api.EventDTO) error
 		status = 3
 	}
 
+	latitude, longitude := locationValues(newEvent.Location)
+
 	stmtEvent := `INSERT INTO event (event_id, message, start_date,	end_date,
-		inbox_position, event_state, event_timestamp) VALUES (?, ?, ?, ?, ?, ?, ?) USING TIMESTAMP ?`
+		inbox_position, event_state, event_timestamp, public, latitude, longitude, capacity,
+		quorum, quorum_deadline, confirmed)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) USING TIMESTAMP ?`
 	batch.Query(stmtEvent, newEvent.Id, newEvent.Description, newEvent.StartDate, newEvent.EndDate,
-		newEvent.InboxPosition, status, newEvent.Timestamp, newEvent.Timestamp)
+		newEvent.InboxPosition, status, newEvent.Timestamp, newEvent.IsPublic, latitude, longitude,
+		newEvent.Capacity, newEvent.Quorum, newEvent.QuorumDeadline, newEvent.Confirmed, newEvent.Timestamp)
 
 	// Only add new participants when updating/replacing
 	newParticipants := d.extractNewParticipants(newEvent, oldEvent)
@@ -140,12 +149,23 @@ func (d *EventDAO) Replace(oldEvent *api.EventDTO, newEvent *api.EventDTO) error
 		}
 	}
 
+	// Remove participants whose invitation was cancelled
+	removedParticipants := d.extractNewParticipants(oldEvent, newEvent)
+	if len(removedParticipants) > 0 {
+		stmtParticipant := `DELETE FROM event USING TIMESTAMP ? WHERE event_id = ? AND guest_id = ?`
+		for pID := range removedParticipants {
+			batch.Query(stmtParticipant, newEvent.Timestamp, newEvent.Id, pID)
+		}
+	}
+
 	if !oldEvent.Cancelled && newEvent.Cancelled {
 		// Event was just cancelled. Insert event into user events history
 		historyStmt := `INSERT INTO events_history_by_user (user_id, position, event_id) VALUES (?, ?, ?) USING TIMESTAMP ?`
 		for pID := range newEvent.Participants {
 			batch.Query(historyStmt, pID, utils.TimeToMillis(newPosition), newEvent.Id, newEvent.Timestamp)
 		}
+		authorHistoryStmt := `INSERT INTO events_history_by_author (author_id, position, event_id) VALUES (?, ?, ?) USING TIMESTAMP ?`
+		batch.Query(authorHistoryStmt, newEvent.AuthorId, utils.TimeToMillis(newPosition), newEvent.Id, newEvent.Timestamp)
 	}
 
 	// Execute
@@ -310,6 +330,10 @@ func (d *EventDAO) DeleteAll() error {
 		return err
 	}
 
+	if err := d.session.Query("TRUNCATE events_history_by_author").Exec(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -321,6 +345,10 @@ func (d *EventDAO) findAllAux(query *gocql.Query, handler func(*api.EventDTO) er
 
 	var dto api.EventDTO
 	var status int32
+	var latitude, longitude *float32
+	var seriesID *int64
+	var capacity, quorum *int32
+	var quorumDeadline *int64
 	var guestID int64
 	var guestName string
 	var guestResponse, guestStatus int32
@@ -332,7 +360,7 @@ func (d *EventDAO) findAllAux(query *gocql.Query, handler func(*api.EventDTO) er
 	// Except guest attributes, all of the attributes are STATIC in cassandra
 	for iter.Scan(&dto.Id, &dto.AuthorId, &dto.AuthorName, &dto.Description, &dto.PictureDigest,
 		&dto.CreatedDate, &dto.InboxPosition, &dto.StartDate, &dto.EndDate, &status, &dto.Timestamp,
-		&guestID, &guestName, &guestResponse, &guestStatus, &guestNameTS, &guestResponseTS, &guestStatusTS) {
+		&dto.IsPublic, &latitude, &longitude, &seriesID, &capacity, &quorum, &quorumDeadline, &dto.Confirmed, &guestID, &guestName, &guestResponse, &guestStatus, &guestNameTS, &guestResponseTS, &guestStatusTS) {
 
 		if currentEvent == nil || currentEvent.Id != dto.Id {
 
@@ -351,6 +379,21 @@ func (d *EventDAO) findAllAux(query *gocql.Query, handler func(*api.EventDTO) er
 			if status == 3 {
 				currentEvent.Cancelled = true
 			}
+			if latitude != nil && longitude != nil {
+				currentEvent.Location = &api.LocationDTO{Latitude: *latitude, Longitude: *longitude}
+			}
+			if seriesID != nil {
+				currentEvent.SeriesID = *seriesID
+			}
+			if capacity != nil {
+				currentEvent.Capacity = *capacity
+			}
+			if quorum != nil {
+				currentEvent.Quorum = *quorum
+			}
+			if quorumDeadline != nil {
+				currentEvent.QuorumDeadline = *quorumDeadline
+			}
 		}
 
 		if guestID != 0 {
@@ -394,3 +437,12 @@ func (d *EventDAO) extractNewParticipants(extractEvent *api.EventDTO, baseEvent
 
 	return newParticipants
 }
+
+// Returns latitude and longitude values to be written into event table. Null is
+// written if event has no location.
+func locationValues(location *api.LocationDTO) (latitude interface{}, longitude interface{}) {
+	if location == nil {
+		return nil, nil
+	}
+	return location.Latitude, location.Longitude
+}
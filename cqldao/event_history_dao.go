@@ -44,47 +44,67 @@ func (d *EventHistoryDAO) InsertBatch(entry *api.TimeLineEntryDTO, userIDs ...in
 	return convErr(d.session.ExecuteBatch(batch))
 }
 
-func (d *EventHistoryDAO) DeleteAll() error {
+func (d *EventHistoryDAO) Delete(userID int64, entry *api.TimeLineEntryDTO) error {
 	checkSession(d.session)
-	return d.session.Query(`TRUNCATE events_history_by_user`).Exec()
+	stmt := `DELETE FROM events_history_by_user WHERE user_id = ? AND position = ? AND event_id = ?`
+	q := d.session.Query(stmt, userID, entry.Position, entry.EventID)
+	return convErr(q.Exec())
 }
 
-func (d *EventHistoryDAO) FindAllBackward(userID int64, fromDate time.Time) ([]int64, error) {
+// InsertAuthored adds an entry to the history of events created by authorID
+func (d *EventHistoryDAO) InsertAuthored(authorID int64, entry *api.TimeLineEntryDTO) error {
+	checkSession(d.session)
+	stmt := `INSERT INTO events_history_by_author (author_id, position, event_id) VALUES (?, ?, ?)`
+	q := d.session.Query(stmt, authorID, entry.Position, entry.EventID)
+	return convErr(q.Exec())
+}
 
+func (d *EventHistoryDAO) DeleteAll() error {
 	checkSession(d.session)
+	if err := d.session.Query(`TRUNCATE events_history_by_user`).Exec(); err != nil {
+		return err
+	}
+	return d.session.Query(`TRUNCATE events_history_by_author`).Exec()
+}
 
-	fromDateMillis := utils.TimeToMillis(fromDate)
-	currentTimeMillis := utils.GetCurrentTimeMillis()
+func (d *EventHistoryDAO) FindAllBackward(userID int64, fromDate time.Time) ([]int64, error) {
 
-	if fromDateMillis > currentTimeMillis {
-		fromDateMillis = currentTimeMillis
-	}
+	checkSession(d.session)
 
 	stmt := `SELECT event_id FROM events_history_by_user
         WHERE user_id = ? and position < ? LIMIT ?`
 
-	query := d.session.Query(stmt, userID, fromDate, MAX_EVENTS_IN_HISTORY_LIST)
-
-	return d.findAllAux(query)
+	return d.findAllAux(d.session.Query(stmt, userID, limitToCurrentTime(fromDate), MAX_EVENTS_IN_HISTORY_LIST))
 }
 
 func (d *EventHistoryDAO) FindAllForward(userID int64, fromDate time.Time) ([]int64, error) {
 
 	checkSession(d.session)
 
-	fromDateMillis := utils.TimeToMillis(fromDate)
-	currentTimeMillis := utils.GetCurrentTimeMillis()
-
-	if fromDateMillis > currentTimeMillis {
-		fromDateMillis = currentTimeMillis
-	}
-
 	stmt := `SELECT event_id FROM events_history_by_user
         WHERE user_id = ? and position > ? ORDER BY position ASC LIMIT ?`
 
-	query := d.session.Query(stmt, userID, fromDate, MAX_EVENTS_IN_HISTORY_LIST)
+	return d.findAllAux(d.session.Query(stmt, userID, limitToCurrentTime(fromDate), MAX_EVENTS_IN_HISTORY_LIST))
+}
+
+func (d *EventHistoryDAO) FindAuthoredBackward(authorID int64, fromDate time.Time) ([]int64, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT event_id FROM events_history_by_author
+        WHERE author_id = ? and position < ? LIMIT ?`
 
-	return d.findAllAux(query)
+	return d.findAllAux(d.session.Query(stmt, authorID, limitToCurrentTime(fromDate), MAX_EVENTS_IN_HISTORY_LIST))
+}
+
+func (d *EventHistoryDAO) FindAuthoredForward(authorID int64, fromDate time.Time) ([]int64, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT event_id FROM events_history_by_author
+        WHERE author_id = ? and position > ? ORDER BY position ASC LIMIT ?`
+
+	return d.findAllAux(d.session.Query(stmt, authorID, limitToCurrentTime(fromDate), MAX_EVENTS_IN_HISTORY_LIST))
 }
 
 func (d *EventHistoryDAO) findAllAux(query *gocql.Query) ([]int64, error) {
@@ -108,3 +128,17 @@ func (d *EventHistoryDAO) findAllAux(query *gocql.Query) ([]int64, error) {
 
 	return events, nil
 }
+
+// History only contains finished events, so there is no point in looking for
+// entries after current time
+func limitToCurrentTime(fromDate time.Time) int64 {
+
+	fromDateMillis := utils.TimeToMillis(fromDate)
+	currentTimeMillis := utils.GetCurrentTimeMillis()
+
+	if fromDateMillis > currentTimeMillis {
+		fromDateMillis = currentTimeMillis
+	}
+
+	return fromDateMillis
+}
@@ -0,0 +1,116 @@
+package cqldao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+func TestEventHistoryDAO_LimitToCurrentTime(t *testing.T) {
+
+	past := time.Now().UTC().Add(-time.Hour)
+	if limitToCurrentTime(past) != utils.TimeToMillis(past) {
+		t.Fatal("past date changed")
+	}
+
+	before := utils.GetCurrentTimeMillis()
+	limited := limitToCurrentTime(time.Now().UTC().Add(24 * time.Hour))
+	if limited < before || limited > utils.GetCurrentTimeMillis() {
+		t.Fatalf("future date not limited to current time (%v)", limited)
+	}
+}
+
+func TestEventHistoryDAO_FindAllBackward(t *testing.T) {
+
+	d := NewEventHistoryDAO(session)
+
+	// Clear
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Insert finished events and an entry after current time, which history
+	// shouldn't have
+	now := time.Now().UTC()
+	for i := int64(1); i <= 3; i++ {
+		entry := &api.TimeLineEntryDTO{EventID: i, Position: now.Add(-time.Duration(i) * time.Hour)}
+		if err := d.Insert(1, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := d.Insert(1, &api.TimeLineEntryDTO{EventID: 4, Position: now.Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read from a future date
+	results, err := d.FindAllBackward(1, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 || results[0] != 1 || results[2] != 3 {
+		t.Fatalf("Expected events 1 to 3, got %v", results)
+	}
+
+	// Read from a past date
+	results, err = d.FindAllBackward(1, now.Add(-90*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || results[0] != 2 || results[1] != 3 {
+		t.Fatalf("Expected events 2 and 3, got %v", results)
+	}
+}
+
+func TestEventHistoryDAO_FindAuthored(t *testing.T) {
+
+	d := NewEventHistoryDAO(session)
+
+	// Clear
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Insert events one hour apart. Author 1 created even events and author 2
+	// odd ones
+	origin := time.Now().UTC().Add(-24 * time.Hour).Truncate(time.Second)
+	for i := int64(1); i <= 6; i++ {
+		entry := &api.TimeLineEntryDTO{EventID: i, Position: origin.Add(time.Duration(i) * time.Hour)}
+		if err := d.InsertAuthored(i%2+1, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Events of author 2 newer than event 1
+	results, err := d.FindAuthoredForward(2, origin.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || results[0] != 3 || results[1] != 5 {
+		t.Fatalf("Expected events 3 and 5, got %v", results)
+	}
+
+	// Events of author 1 older than event 6
+	results, err = d.FindAuthoredBackward(1, origin.Add(6*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || results[0] != 4 || results[1] != 2 {
+		t.Fatalf("Expected events 4 and 2, got %v", results)
+	}
+
+	// Author history isn't mixed with user history
+	if _, err := d.FindAllBackward(1, origin.Add(24*time.Hour)); err != api.ErrNoResults {
+		t.Fatalf("Expected ErrNoResults, got %v", err)
+	}
+
+	if _, err := d.FindAuthoredBackward(3, time.Now().UTC()); err != api.ErrNoResults {
+		t.Fatalf("Expected ErrNoResults, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package cqldao
+
+import (
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// Reminders that couldn't be sent are kept this long after they were due
+const reminderExpiration = 24 * time.Hour
+
+type EventReminderDAO struct {
+	session *GocqlSession
+}
+
+func NewEventReminderDAO(session api.DbSession) api.EventReminderDAO {
+	reconnectIfNeeded(session)
+	return &EventReminderDAO{session: session.(*GocqlSession)}
+}
+
+// Insert schedules a reminder. Reminders expire one day after they're due.
+func (d *EventReminderDAO) Insert(reminder *api.EventReminderDTO) error {
+
+	checkSession(d.session)
+
+	if reminder == nil || reminder.EventID == 0 || reminder.DueDate == 0 {
+		return ErrIllegalArguments
+	}
+
+	ttl := utils.MillisToTimeUTC(reminder.DueDate).Add(reminderExpiration).Sub(utils.GetCurrentTimeUTC())
+	if ttl <= 0 {
+		return ErrIllegalArguments
+	}
+
+	stmt := `INSERT INTO event_reminders_by_hour (bucket, due_date, event_id, reminder_type,
+		time_before, start_date) VALUES (?, ?, ?, ?, ?, ?) USING TTL ?`
+
+	err := d.session.Query(stmt, reminderBucket(reminder.DueDate), reminder.DueDate, reminder.EventID,
+		int(reminder.Type), reminder.Offset, reminder.StartDate, int(ttl.Seconds())).Exec()
+
+	return convErr(err)
+}
+
+// FindDue returns reminders due between from and to (both included) ordered
+// by due date
+func (d *EventReminderDAO) FindDue(from int64, to int64) ([]*api.EventReminderDTO, error) {
+
+	checkSession(d.session)
+
+	if from > to {
+		return nil, ErrIllegalArguments
+	}
+
+	stmt := `SELECT due_date, event_id, reminder_type, time_before, start_date
+		FROM event_reminders_by_hour WHERE bucket = ? AND due_date >= ? AND due_date <= ?`
+
+	var results []*api.EventReminderDTO
+	var reminderType int
+
+	for bucket := reminderBucket(from); bucket <= to; bucket += int64(time.Hour / time.Millisecond) {
+
+		iter := d.session.Query(stmt, bucket, from, to).Iter()
+
+		for {
+			reminder := new(api.EventReminderDTO)
+			if !iter.Scan(&reminder.DueDate, &reminder.EventID, &reminderType,
+				&reminder.Offset, &reminder.StartDate) {
+				break
+			}
+			reminder.Type = api.ReminderType(reminderType)
+			results = append(results, reminder)
+		}
+
+		if err := iter.Close(); err != nil {
+			return nil, convErr(err)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, api.ErrNoResults
+	}
+
+	return results, nil
+}
+
+func (d *EventReminderDAO) Delete(reminder *api.EventReminderDTO) error {
+
+	checkSession(d.session)
+
+	stmt := `DELETE FROM event_reminders_by_hour WHERE bucket = ? AND due_date = ?
+		AND event_id = ? AND reminder_type = ? AND time_before = ?`
+
+	return convErr(d.session.Query(stmt, reminderBucket(reminder.DueDate), reminder.DueDate,
+		reminder.EventID, int(reminder.Type), reminder.Offset).Exec())
+}
+
+func (d *EventReminderDAO) DeleteAll() error {
+	checkSession(d.session)
+	return d.session.Query(`TRUNCATE event_reminders_by_hour`).Exec()
+}
+
+// Returns due date truncated to the hour
+func reminderBucket(dueDate int64) int64 {
+	return utils.TimeToMillis(utils.MillisToTimeUTC(dueDate).Truncate(time.Hour))
+}
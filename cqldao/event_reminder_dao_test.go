@@ -0,0 +1,51 @@
+package cqldao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+func TestEventReminderDAO_FindDue(t *testing.T) {
+
+	d := NewEventReminderDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := utils.TimeToMillis(utils.GetCurrentTimeUTC())
+	hour := int64(time.Hour / time.Millisecond)
+
+	reminders := []*api.EventReminderDTO{
+		{EventID: 1, Type: api.ReminderType_REMINDER, Offset: hour, DueDate: now - 2*hour, StartDate: now - hour},
+		{EventID: 2, Type: api.ReminderType_NUDGE, Offset: 24 * hour, DueDate: now + 2*hour, StartDate: now + 26*hour},
+		{EventID: 3, Type: api.ReminderType_REMINDER, Offset: hour, DueDate: now + 5*hour, StartDate: now + 6*hour},
+	}
+
+	for _, dto := range reminders {
+		if err := d.Insert(dto); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Range spans several buckets
+	results, err := d.FindDue(now-3*hour, now+3*hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || *results[0] != *reminders[0] || *results[1] != *reminders[1] {
+		t.Fatalf("Unexpected due reminders: %v", results)
+	}
+
+	if err := d.Delete(reminders[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if results, err := d.FindDue(now-3*hour, now); err != api.ErrNoResults {
+		t.Fatalf("Reminder not deleted (%v, %v)", results, err)
+	}
+}
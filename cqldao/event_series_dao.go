@@ -0,0 +1,172 @@
+package cqldao
+
+import (
+	"github.com/d3ce1t/areyouin-server/api"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	// Series that still generate occurrences are kept in a single partition.
+	// They are removed as soon as their last occurrence is generated.
+	activeSeriesBucket = 0
+
+	seriesCols = `series_id, author_id, message, public, latitude, longitude, participants,
+		capacity, start_date, duration, frequency, repeat_interval, repeat_count, repeat_until,
+		time_zone, generated, skipped, finished`
+)
+
+type EventSeriesDAO struct {
+	session *GocqlSession
+}
+
+func NewEventSeriesDAO(session api.DbSession) api.EventSeriesDAO {
+	reconnectIfNeeded(session)
+	return &EventSeriesDAO{session: session.(*GocqlSession)}
+}
+
+func (d *EventSeriesDAO) Load(seriesID int64) (*api.EventSeriesDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT ` + seriesCols + ` FROM event_series WHERE series_id = ?`
+
+	series := new(api.EventSeriesDTO)
+	var latitude, longitude *float32
+	var frequency int
+	var capacity *int32
+
+	err := d.session.Query(stmt, seriesID).Scan(&series.ID, &series.AuthorID,
+		&series.Description, &series.IsPublic, &latitude, &longitude, &series.Participants,
+		&capacity, &series.StartDate, &series.Duration, &frequency, &series.Interval, &series.Count,
+		&series.Until, &series.TimeZone, &series.Generated, &series.Skipped, &series.Finished)
+
+	if err != nil {
+		return nil, convErr(err)
+	}
+
+	series.Frequency = api.RecurrenceFrequency(frequency)
+
+	if capacity != nil {
+		series.Capacity = *capacity
+	}
+
+	if latitude != nil && longitude != nil {
+		series.Location = &api.LocationDTO{Latitude: *latitude, Longitude: *longitude}
+	}
+
+	return series, nil
+}
+
+func (d *EventSeriesDAO) LoadAllActive() ([]*api.EventSeriesDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT series_id FROM active_event_series WHERE bucket = ?`
+	iter := d.session.Query(stmt, activeSeriesBucket).Iter()
+
+	var seriesID int64
+	var results []*api.EventSeriesDTO
+
+	for iter.Scan(&seriesID) {
+		series, err := d.Load(seriesID)
+		if err != nil {
+			iter.Close()
+			return nil, err
+		}
+		results = append(results, series)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convErr(err)
+	}
+
+	return results, nil
+}
+
+// Insert stores a series replacing the previous one. Series is added to the
+// list of active series unless it's finished, in which case it's removed from
+// there.
+func (d *EventSeriesDAO) Insert(series *api.EventSeriesDTO) error {
+
+	checkSession(d.session)
+
+	if series == nil || series.ID == 0 || series.AuthorID == 0 {
+		return ErrIllegalArguments
+	}
+
+	var latitude, longitude *float32
+	if series.Location != nil {
+		latitude = &series.Location.Latitude
+		longitude = &series.Location.Longitude
+	}
+
+	stmtSeries := `INSERT INTO event_series (` + seriesCols + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	batch := d.session.NewBatch(gocql.LoggedBatch)
+
+	batch.Query(stmtSeries, series.ID, series.AuthorID, series.Description, series.IsPublic,
+		latitude, longitude, series.Participants, series.Capacity, series.StartDate, series.Duration,
+		int(series.Frequency), series.Interval, series.Count, series.Until,
+		series.TimeZone, series.Generated, series.Skipped, series.Finished)
+
+	if series.Finished {
+		batch.Query(`DELETE FROM active_event_series WHERE bucket = ? AND series_id = ?`,
+			activeSeriesBucket, series.ID)
+	} else {
+		batch.Query(`INSERT INTO active_event_series (bucket, series_id) VALUES (?, ?)`,
+			activeSeriesBucket, series.ID)
+	}
+
+	return convErr(d.session.ExecuteBatch(batch))
+}
+
+func (d *EventSeriesDAO) InsertOccurrence(seriesID int64, eventID int64) error {
+
+	checkSession(d.session)
+
+	if seriesID == 0 || eventID == 0 {
+		return ErrIllegalArguments
+	}
+
+	stmt := `INSERT INTO events_by_series (series_id, event_id) VALUES (?, ?)`
+	return convErr(d.session.Query(stmt, seriesID, eventID).Exec())
+}
+
+// FindOccurrences returns IDs of events generated by a series ordered by
+// event_id, which is also creation order
+func (d *EventSeriesDAO) FindOccurrences(seriesID int64) ([]int64, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT event_id FROM events_by_series WHERE series_id = ?`
+	iter := d.session.Query(stmt, seriesID).Iter()
+
+	var eventID int64
+	var results []int64
+
+	for iter.Scan(&eventID) {
+		results = append(results, eventID)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convErr(err)
+	}
+
+	if len(results) == 0 {
+		return nil, api.ErrNoResults
+	}
+
+	return results, nil
+}
+
+func (d *EventSeriesDAO) DeleteAll() error {
+	checkSession(d.session)
+	for _, table := range []string{"event_series", "active_event_series", "events_by_series"} {
+		if err := d.session.Query(`TRUNCATE ` + table).Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package cqldao
+
+import (
+	"testing"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+func TestEventSeriesDAO_InsertAndLoad(t *testing.T) {
+
+	d := NewEventSeriesDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.Load(1); err != api.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	series := &api.EventSeriesDTO{
+		ID:           1,
+		AuthorID:     10,
+		Description:  "Weekly football",
+		Location:     &api.LocationDTO{Latitude: 40.4, Longitude: -3.7},
+		Participants: []int64{10, 20},
+		Capacity:     10,
+		StartDate:    1700000000000,
+		Duration:     3600000,
+		Frequency:    api.RecurrenceFrequency_WEEKLY,
+		Interval:     1,
+		Count:        10,
+		Generated:    4,
+	}
+
+	if err := d.Insert(series); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := d.Load(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Frequency != api.RecurrenceFrequency_WEEKLY || result.Count != 10 || result.Generated != 4 ||
+		result.Capacity != 10 ||
+		result.Location == nil || len(result.Participants) != 2 || result.Description != series.Description {
+		t.Fatalf("Read back different series than inserted: %+v", result)
+	}
+
+	active, err := d.LoadAllActive()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(active) != 1 || active[0].ID != 1 {
+		t.Fatalf("Expected series 1 to be active, got %v", active)
+	}
+
+	series.Finished = true
+	if err := d.Insert(series); err != nil {
+		t.Fatal(err)
+	}
+
+	if active, err := d.LoadAllActive(); err != nil || len(active) != 0 {
+		t.Fatalf("Expected no active series, got %v (%v)", active, err)
+	}
+}
+
+func TestEventSeriesDAO_Occurrences(t *testing.T) {
+
+	d := NewEventSeriesDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.FindOccurrences(1); err != api.ErrNoResults {
+		t.Fatalf("Expected ErrNoResults, got %v", err)
+	}
+
+	for _, eventID := range []int64{30, 10, 20} {
+		if err := d.InsertOccurrence(1, eventID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	occurrences, err := d.FindOccurrences(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(occurrences) != 3 || occurrences[0] != 10 || occurrences[2] != 30 {
+		t.Fatalf("Unexpected occurrences %v", occurrences)
+	}
+}
@@ -0,0 +1,123 @@
+package cqldao
+
+import (
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+type NotificationSettingsDAO struct {
+	session *GocqlSession
+}
+
+func NewNotificationSettingsDAO(session api.DbSession) api.NotificationSettingsDAO {
+	reconnectIfNeeded(session)
+	return &NotificationSettingsDAO{session: session.(*GocqlSession)}
+}
+
+// Load reads notification settings of a user. Returns api.ErrNotFound if user
+// has never stored them.
+func (d *NotificationSettingsDAO) Load(userID int64) (*api.NotificationSettingsDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT user_id, disabled_categories, quiet_hours_enabled, quiet_hours_start,
+		quiet_hours_end, time_zone FROM notification_settings_by_user WHERE user_id = ?`
+
+	settings := new(api.NotificationSettingsDTO)
+	var categories []int
+
+	err := d.session.Query(stmt, userID).Scan(&settings.UserID, &categories,
+		&settings.QuietHoursEnabled, &settings.QuietHoursStart, &settings.QuietHoursEnd,
+		&settings.TimeZone)
+
+	if err != nil {
+		return nil, convErr(err)
+	}
+
+	for _, category := range categories {
+		settings.DisabledCategories = append(settings.DisabledCategories,
+			api.NotificationCategory(category))
+	}
+
+	return settings, nil
+}
+
+// Insert stores notification settings of a user replacing previous ones
+func (d *NotificationSettingsDAO) Insert(settings *api.NotificationSettingsDTO) error {
+
+	checkSession(d.session)
+
+	if settings == nil || settings.UserID == 0 {
+		return ErrIllegalArguments
+	}
+
+	categories := make([]int, 0, len(settings.DisabledCategories))
+	for _, category := range settings.DisabledCategories {
+		categories = append(categories, int(category))
+	}
+
+	stmt := `INSERT INTO notification_settings_by_user (user_id, disabled_categories,
+		quiet_hours_enabled, quiet_hours_start, quiet_hours_end, time_zone)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	err := d.session.Query(stmt, settings.UserID, categories, settings.QuietHoursEnabled,
+		settings.QuietHoursStart, settings.QuietHoursEnd, settings.TimeZone).Exec()
+
+	return convErr(err)
+}
+
+// FindMutedEvents returns IDs of events muted by a user ordered by event_id
+func (d *NotificationSettingsDAO) FindMutedEvents(userID int64) ([]int64, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT event_id FROM muted_events_by_user WHERE user_id = ?`
+	iter := d.session.Query(stmt, userID).Iter()
+
+	var eventID int64
+	var results []int64
+
+	for iter.Scan(&eventID) {
+		results = append(results, eventID)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convErr(err)
+	}
+
+	if len(results) == 0 {
+		return nil, api.ErrNoResults
+	}
+
+	return results, nil
+}
+
+// InsertMutedEvent mutes an event for a user. Mute expires after ttl, which
+// is usually the time left for the event to end.
+func (d *NotificationSettingsDAO) InsertMutedEvent(userID int64, eventID int64, ttl time.Duration) error {
+
+	checkSession(d.session)
+
+	seconds := int(ttl.Seconds())
+	if userID == 0 || eventID == 0 || seconds <= 0 {
+		return ErrIllegalArguments
+	}
+
+	stmt := `INSERT INTO muted_events_by_user (user_id, event_id) VALUES (?, ?) USING TTL ?`
+	return convErr(d.session.Query(stmt, userID, eventID, seconds).Exec())
+}
+
+func (d *NotificationSettingsDAO) DeleteMutedEvent(userID int64, eventID int64) error {
+	checkSession(d.session)
+	stmt := `DELETE FROM muted_events_by_user WHERE user_id = ? AND event_id = ?`
+	return convErr(d.session.Query(stmt, userID, eventID).Exec())
+}
+
+func (d *NotificationSettingsDAO) DeleteAll() error {
+	checkSession(d.session)
+	if err := d.session.Query(`TRUNCATE notification_settings_by_user`).Exec(); err != nil {
+		return err
+	}
+	return d.session.Query(`TRUNCATE muted_events_by_user`).Exec()
+}
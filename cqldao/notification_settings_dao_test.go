@@ -0,0 +1,68 @@
+package cqldao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+func TestNotificationSettingsDAO_InsertAndLoad(t *testing.T) {
+
+	d := NewNotificationSettingsDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.Load(1); err != api.ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	settings := &api.NotificationSettingsDTO{
+		UserID:             1,
+		DisabledCategories: []api.NotificationCategory{api.NotificationCategory_EVENT_RESPONSE},
+		QuietHoursEnabled:  true,
+		QuietHoursStart:    1380,
+		QuietHoursEnd:      450,
+		TimeZone:           "Europe/Madrid",
+	}
+
+	if err := d.Insert(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := d.Load(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.QuietHoursStart != 1380 || result.QuietHoursEnd != 450 || result.TimeZone != "Europe/Madrid" ||
+		len(result.DisabledCategories) != 1 || result.DisabledCategories[0] != api.NotificationCategory_EVENT_RESPONSE {
+		t.Fatalf("Read back different settings than inserted: %+v", result)
+	}
+}
+
+func TestNotificationSettingsDAO_MuteEvents(t *testing.T) {
+
+	d := NewNotificationSettingsDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, eventID := range []int64{10, 20} {
+		if err := d.InsertMutedEvent(1, eventID, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := d.DeleteMutedEvent(1, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := d.FindMutedEvents(1)
+	if err != nil || len(events) != 1 || events[0] != 20 {
+		t.Fatalf("Unexpected muted events (%v, %v)", events, err)
+	}
+}
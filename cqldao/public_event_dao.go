@@ -0,0 +1,101 @@
+package cqldao
+
+import (
+	"sort"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+const (
+	maxPublicEventsPerGeohash = 100
+)
+
+type PublicEventDAO struct {
+	session *GocqlSession
+}
+
+func NewPublicEventDAO(session api.DbSession) api.PublicEventDAO {
+	reconnectIfNeeded(session)
+	return &PublicEventDAO{session: session.(*GocqlSession)}
+}
+
+// Insert adds an event to the index. Entries expire when the event ends, so
+// an entry must be deleted and inserted again if its end date changes.
+func (d *PublicEventDAO) Insert(entry *api.PublicEventEntryDTO) error {
+
+	checkSession(d.session)
+
+	if entry == nil || entry.EventID == 0 || entry.Geohash == "" {
+		return ErrIllegalArguments
+	}
+
+	ttl := int(utils.MillisToTimeUTC(entry.EndDate).Sub(utils.GetCurrentTimeUTC()).Seconds())
+	if ttl <= 0 {
+		return ErrIllegalArguments
+	}
+
+	stmt := `INSERT INTO public_events_by_geohash (geohash, start_date, event_id, latitude, longitude)
+		VALUES (?, ?, ?, ?, ?) USING TTL ?`
+	q := d.session.Query(stmt, entry.Geohash, entry.StartDate, entry.EventID,
+		entry.Location.Latitude, entry.Location.Longitude, ttl)
+	return convErr(q.Exec())
+}
+
+func (d *PublicEventDAO) Delete(entry *api.PublicEventEntryDTO) error {
+
+	checkSession(d.session)
+
+	if entry == nil || entry.EventID == 0 || entry.Geohash == "" {
+		return ErrIllegalArguments
+	}
+
+	stmt := `DELETE FROM public_events_by_geohash WHERE geohash = ? AND start_date = ? AND event_id = ?`
+	q := d.session.Query(stmt, entry.Geohash, entry.StartDate, entry.EventID)
+	return convErr(q.Exec())
+}
+
+// FindAllForward returns public events in the given geohash buckets that start
+// after fromDate. Results are sorted by start date.
+func (d *PublicEventDAO) FindAllForward(geohashes []string, fromDate time.Time) ([]*api.PublicEventEntryDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT geohash, start_date, event_id, latitude, longitude
+		FROM public_events_by_geohash
+		WHERE geohash = ? AND start_date > ? LIMIT ?`
+
+	dateMillis := utils.TimeToMillis(fromDate)
+	results := make([]*api.PublicEventEntryDTO, 0, len(geohashes)*10)
+	q := d.session.Query(stmt)
+
+	for _, geohash := range geohashes {
+
+		iter := q.Bind(geohash, dateMillis, maxPublicEventsPerGeohash).Iter()
+
+		for {
+			entry := new(api.PublicEventEntryDTO)
+			if !iter.Scan(&entry.Geohash, &entry.StartDate, &entry.EventID,
+				&entry.Location.Latitude, &entry.Location.Longitude) {
+				break
+			}
+			results = append(results, entry)
+		}
+
+		if err := iter.Close(); err != nil {
+			return nil, convErr(err)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].StartDate < results[j].StartDate
+	})
+
+	return results, nil
+}
+
+func (d *PublicEventDAO) DeleteAll() error {
+	checkSession(d.session)
+	return d.session.Query(`TRUNCATE public_events_by_geohash`).Exec()
+}
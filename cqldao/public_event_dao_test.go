@@ -0,0 +1,47 @@
+package cqldao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+func TestPublicEventDAO_InsertExpires(t *testing.T) {
+
+	d := NewPublicEventDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := utils.GetCurrentTimeUTC()
+	entry := &api.PublicEventEntryDTO{
+		EventID:   1,
+		Geohash:   "ezjm",
+		StartDate: utils.TimeToMillis(now.Add(time.Hour)),
+		EndDate:   utils.TimeToMillis(now.Add(2 * time.Hour)),
+		Location:  api.LocationDTO{Latitude: 40.4168, Longitude: -3.7038},
+	}
+
+	if err := d.Insert(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	var ttl int
+	stmt := `SELECT TTL(latitude) FROM public_events_by_geohash WHERE geohash = ? AND start_date = ? AND event_id = ?`
+	if err := session.Query(stmt, entry.Geohash, entry.StartDate, entry.EventID).Scan(&ttl); err != nil {
+		t.Fatal(err)
+	}
+
+	if ttl <= int((time.Hour+59*time.Minute)/time.Second) || ttl > int(2*time.Hour/time.Second) {
+		t.Fatalf("Expected entry to expire when event ends, got TTL %v", ttl)
+	}
+
+	// Event already finished
+	entry.EndDate = utils.TimeToMillis(now.Add(-time.Minute))
+	if err := d.Insert(entry); err != ErrIllegalArguments {
+		t.Fatalf("Expected ErrIllegalArguments, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package cqldao
+
+import (
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+type PushTokenDAO struct {
+	session *GocqlSession
+}
+
+func NewPushTokenDAO(session api.DbSession) api.PushTokenDAO {
+	reconnectIfNeeded(session)
+	return &PushTokenDAO{session: session.(*GocqlSession)}
+}
+
+// Insert stores the push token of a device. If the device already had one, it
+// is replaced.
+func (d *PushTokenDAO) Insert(token *api.PushTokenDTO) error {
+
+	checkSession(d.session)
+
+	if token == nil || token.UserID == 0 || token.Token == "" {
+		return ErrIllegalArguments
+	}
+
+	stmt := `INSERT INTO push_tokens_by_user (user_id, device_id, token, platform,
+		network_version, last_seen) VALUES (?, ?, ?, ?, ?, ?)`
+
+	err := d.session.Query(stmt, token.UserID, token.DeviceID, token.Token, token.Platform,
+		token.Version, token.LastSeen).Exec()
+
+	return convErr(err)
+}
+
+// FindAll returns push tokens of every device of userID ordered by device_id
+func (d *PushTokenDAO) FindAll(userID int64) ([]*api.PushTokenDTO, error) {
+
+	checkSession(d.session)
+
+	stmt := `SELECT user_id, device_id, token, platform, network_version, last_seen
+		FROM push_tokens_by_user WHERE user_id = ?`
+
+	iter := d.session.Query(stmt, userID).Iter()
+
+	var results []*api.PushTokenDTO
+
+	for {
+		token := new(api.PushTokenDTO)
+		if !iter.Scan(&token.UserID, &token.DeviceID, &token.Token, &token.Platform,
+			&token.Version, &token.LastSeen) {
+			break
+		}
+		results = append(results, token)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convErr(err)
+	}
+
+	if len(results) == 0 {
+		return nil, api.ErrNoResults
+	}
+
+	return results, nil
+}
+
+func (d *PushTokenDAO) Delete(userID int64, deviceID string) error {
+	checkSession(d.session)
+	stmt := `DELETE FROM push_tokens_by_user WHERE user_id = ? AND device_id = ?`
+	return convErr(d.session.Query(stmt, userID, deviceID).Exec())
+}
+
+func (d *PushTokenDAO) DeleteAll() error {
+	checkSession(d.session)
+	return d.session.Query(`TRUNCATE push_tokens_by_user`).Exec()
+}
@@ -0,0 +1,44 @@
+package cqldao
+
+import (
+	"testing"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+func TestPushTokenDAO_InsertAndDelete(t *testing.T) {
+
+	d := NewPushTokenDAO(session)
+
+	if err := d.DeleteAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := []*api.PushTokenDTO{
+		{UserID: 1, DeviceID: "phone", Token: "token-1", Platform: "Android", Version: 3, LastSeen: 1000},
+		{UserID: 1, DeviceID: "tablet", Token: "token-2", Platform: "iOS", Version: 3, LastSeen: 2000},
+	}
+
+	for _, dto := range tokens {
+		if err := d.Insert(dto); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := d.FindAll(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || *results[0] != *tokens[0] || *results[1] != *tokens[1] {
+		t.Fatalf("Read back different tokens than inserted")
+	}
+
+	if err := d.Delete(1, "phone"); err != nil {
+		t.Fatal(err)
+	}
+
+	if results, err := d.FindAll(1); err != nil || len(results) != 1 || results[0].DeviceID != "tablet" {
+		t.Fatalf("Device not deleted (%v, %v)", results, err)
+	}
+}
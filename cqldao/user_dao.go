@@ -447,6 +447,101 @@ func (d *UserDAO) SetIIDToken(userID int64, iidToken *api.IIDTokenDTO) error {
 	return convErr(err)
 }
 
+func (d *UserDAO) DeleteIIDToken(userID int64) error {
+
+	checkSession(d.session)
+
+	if userID == 0 {
+		return api.ErrInvalidArg
+	}
+
+	stmt := `DELETE iid_token, network_version, platform FROM user_account WHERE user_id = ?`
+	return convErr(d.session.Query(stmt, userID).Exec())
+}
+
+// LoadPosition reads last known position of a user and the range used to
+// discover public events around it. Fields are zero if they have never been set.
+func (d *UserDAO) LoadPosition(userID int64) (*api.UserPositionDTO, error) {
+
+	checkSession(d.session)
+
+	if userID == 0 {
+		return nil, api.ErrNotFound
+	}
+
+	stmt := `SELECT latitude, longitude, position_error, position_range, position_updated
+		FROM user_account WHERE user_id = ?`
+	q := d.session.Query(stmt, userID)
+
+	position := new(api.UserPositionDTO)
+
+	if err := q.Scan(&position.Location.Latitude, &position.Location.Longitude,
+		&position.EstimationError, &position.RangeInMeters, &position.LastUpdate); err != nil {
+		return nil, convErr(err)
+	}
+
+	return position, nil
+}
+
+func (d *UserDAO) SetPosition(userID int64, position *api.UserPositionDTO) error {
+
+	checkSession(d.session)
+
+	if userID == 0 || position == nil {
+		return api.ErrInvalidArg
+	}
+
+	stmt := `UPDATE user_account SET latitude = ?, longitude = ?, position_error = ?,
+		position_updated = ? WHERE user_id = ?`
+	err := d.session.Query(stmt, position.Location.Latitude, position.Location.Longitude,
+		position.EstimationError, position.LastUpdate, userID).Exec()
+	return convErr(err)
+}
+
+func (d *UserDAO) SetPositionRange(userID int64, rangeInMeters float32) error {
+
+	checkSession(d.session)
+
+	if userID == 0 {
+		return api.ErrInvalidArg
+	}
+
+	stmt := `UPDATE user_account SET position_range = ? WHERE user_id = ?`
+	err := d.session.Query(stmt, rangeInMeters, userID).Exec()
+	return convErr(err)
+}
+
+// LoadLanguage reads language of a user. It's empty if it has never been set.
+func (d *UserDAO) LoadLanguage(userID int64) (string, error) {
+
+	checkSession(d.session)
+
+	if userID == 0 {
+		return "", api.ErrNotFound
+	}
+
+	var language string
+
+	stmt := `SELECT language FROM user_account WHERE user_id = ?`
+	if err := d.session.Query(stmt, userID).Scan(&language); err != nil {
+		return "", convErr(err)
+	}
+
+	return language, nil
+}
+
+func (d *UserDAO) SetLanguage(userID int64, language string) error {
+
+	checkSession(d.session)
+
+	if userID == 0 {
+		return api.ErrInvalidArg
+	}
+
+	stmt := `UPDATE user_account SET language = ? WHERE user_id = ?`
+	return convErr(d.session.Query(stmt, language, userID).Exec())
+}
+
 // User information is spread in three tables: user_account, user_email_credentials
 // and user_facebook_credentials. So, in order to delete a user, it's needed an
 // user_id, e-mail and, likely, a Facebook ID. For the sake of safety, a read is
@@ -9,9 +9,10 @@ require (
 	github.com/disintegration/imaging v1.6.2
 	github.com/gocql/gocql v0.0.0-20200410100145-b454769479c6
 	github.com/golang/protobuf v1.4.0
-	github.com/golang/snappy v0.0.1 // indirect
+	github.com/golang/snappy v0.0.1
 	github.com/google/go-gcm v0.0.0-20170214170421-f387343038b1
 	github.com/google/uuid v1.1.1 // indirect
+	github.com/gorilla/websocket v1.4.2
 	github.com/huandu/facebook v2.3.1+incompatible
 	github.com/imkira/go-observer v1.0.3
 	github.com/jpillora/backoff v1.0.0 // indirect
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"image"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/d3ce1t/areyouin-server/api"
@@ -24,6 +25,8 @@ type AccountManager struct {
 	friendDAO      api.FriendDAO
 	accessTokenDAO api.AccessTokenDAO
 	logDAO         api.LogDAO
+	pushTokenDAO   api.PushTokenDAO
+	settingsDAO    api.NotificationSettingsDAO
 	accountSignal  observer.Property
 }
 
@@ -36,6 +39,8 @@ func newAccountManager(parent *AyiModel, session api.DbSession) *AccountManager
 		friendDAO:      cqldao.NewFriendDAO(session),
 		accessTokenDAO: cqldao.NewAccessTokenDAO(session),
 		logDAO:         cqldao.NewLogDAO(session),
+		pushTokenDAO:   cqldao.NewPushTokenDAO(session),
+		settingsDAO:    cqldao.NewNotificationSettingsDAO(session),
 		accountSignal:  observer.NewProperty(nil),
 	}
 }
@@ -252,27 +257,290 @@ func (self *AccountManager) GetUserAccountByFacebook(fbId string) (*UserAccount,
 	return user, nil
 }
 
-func (self *AccountManager) GetPushToken(userId int64) (*IIDToken, error) {
+// GetPushTokens returns push tokens of every device of a user. The token
+// stored in the user account before devices were tracked is returned as well,
+// with an empty device ID, until a device registers it again.
+func (m *AccountManager) GetPushTokens(userID int64) ([]*IIDToken, error) {
 
-	tokenDTO, err := self.userDAO.LoadIIDToken(userId)
+	tokens, legacyToken, err := m.loadPushTokens(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return newIIDTokenFromDTO(tokenDTO), nil
+	if legacyToken != nil {
+		tokens = append(tokens, legacyToken)
+	}
+
+	return tokens, nil
+}
+
+// loadPushTokens returns tokens of tracked devices and, if it hasn't been
+// replaced yet, the token stored before devices were tracked
+func (m *AccountManager) loadPushTokens(userID int64) ([]*IIDToken, *IIDToken, error) {
+
+	tokensDTO, err := m.pushTokenDAO.FindAll(userID)
+	if err != nil && err != api.ErrNoResults {
+		return nil, nil, err
+	}
+
+	tokens := make([]*IIDToken, 0, len(tokensDTO))
+	for _, dto := range tokensDTO {
+		tokens = append(tokens, newIIDTokenFromPushTokenDTO(dto))
+	}
+
+	legacyDTO, err := m.userDAO.LoadIIDToken(userID)
+	if err == api.ErrNoResults || err == api.ErrNotFound {
+		return tokens, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	if legacyDTO.Token == "" {
+		return tokens, nil, nil
+	}
+
+	for _, token := range tokens {
+		if token.deviceID == "" || token.token == legacyDTO.Token {
+			return tokens, nil, nil
+		}
+	}
+
+	return tokens, newIIDTokenFromDTO(legacyDTO), nil
+}
+
+// GetPushToken returns the push token of a device of a user
+func (m *AccountManager) GetPushToken(userID int64, deviceID string) (*IIDToken, error) {
+
+	tokens, err := m.GetPushTokens(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.DeviceID() == deviceID {
+			return token, nil
+		}
+	}
+
+	return nil, ErrNotFound
 }
 
+// SetPushToken stores the push token of the device of pushToken, replacing
+// any previous token of that device. If pushToken has an empty token, the
+// token of the device is removed. Token stored before devices were tracked is
+// removed once a device registers it.
 func (m *AccountManager) SetPushToken(userID int64, pushToken *IIDToken) error {
 
-	err := m.userDAO.SetIIDToken(userID, pushToken.AsDTO())
+	if pushToken.Token() == "" {
+		return m.removePushToken(userID, pushToken.DeviceID())
+	}
+
+	_, legacyToken, err := m.loadPushTokens(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := m.pushTokenDAO.Insert(pushToken.asPushTokenDTO(userID)); err != nil {
+		return err
+	}
+
+	if legacyToken != nil && legacyToken.Token() == pushToken.Token() {
+		return m.userDAO.DeleteIIDToken(userID)
+	}
+
+	return nil
+}
+
+// TouchPushToken updates when a device of a user was last seen and returns
+// its push token. Token stored before devices were tracked is moved to the
+// devices table when a client that doesn't send a device ID authenticates.
+func (m *AccountManager) TouchPushToken(userID int64, deviceID string) (*IIDToken, error) {
+
+	tokens, legacyToken, err := m.loadPushTokens(userID)
 	if err != nil {
+		return nil, err
+	}
+
+	var token *IIDToken
+	for _, t := range tokens {
+		if t.DeviceID() == deviceID {
+			token = t
+		}
+	}
+
+	if token == nil && deviceID == "" && legacyToken != nil {
+		token = legacyToken
+	}
+
+	if token == nil {
+		return nil, ErrNotFound
+	}
+
+	token.lastSeen = utils.GetCurrentTimeMillis()
+
+	if err := m.pushTokenDAO.Insert(token.asPushTokenDTO(userID)); err != nil {
+		return nil, err
+	}
+
+	if token == legacyToken {
+		if err := m.userDAO.DeleteIIDToken(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return token, nil
+}
+
+// RevokePushToken removes the push token of a device of a user, so that it
+// doesn't receive notifications anymore.
+//
+// Prominent Errors:
+// - ErrNotFound
+func (m *AccountManager) RevokePushToken(userID int64, deviceID string) error {
+
+	if _, err := m.GetPushToken(userID, deviceID); err != nil {
 		return err
 	}
 
-	//user.iidToken = pushToken
+	return m.removePushToken(userID, deviceID)
+}
+
+func (m *AccountManager) removePushToken(userID int64, deviceID string) error {
+
+	if err := m.pushTokenDAO.Delete(userID, deviceID); err != nil {
+		return err
+	}
+
+	// Token stored before devices were tracked
+	if deviceID == "" {
+		return m.userDAO.DeleteIIDToken(userID)
+	}
+
 	return nil
 }
 
+// GetUserPosition returns last known position of a user. If user has never sent
+// its position, LastUpdate is zero.
+func (m *AccountManager) GetUserPosition(userID int64) (*UserPosition, error) {
+
+	positionDTO, err := m.userDAO.LoadPosition(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	position := &UserPosition{
+		Location:        *newLocationFromDTO(&positionDTO.Location),
+		EstimationError: positionDTO.EstimationError,
+		RangeInMeters:   positionDTO.RangeInMeters,
+	}
+
+	if positionDTO.LastUpdate != 0 {
+		position.LastUpdate = utils.MillisToTimeUTC(positionDTO.LastUpdate)
+	}
+
+	return position, nil
+}
+
+func (m *AccountManager) SetUserPosition(userID int64, location *Location, estimationError float32) error {
+
+	if !IsValidLocation(location) {
+		return ErrInvalidLocation
+	}
+
+	positionDTO := &api.UserPositionDTO{
+		Location:        *location.AsDTO(),
+		EstimationError: estimationError,
+		LastUpdate:      utils.GetCurrentTimeMillis(),
+	}
+
+	return m.userDAO.SetPosition(userID, positionDTO)
+}
+
+// SetUserPositionRange sets the distance around user position where public
+// events are looked for. Zero means default range.
+func (m *AccountManager) SetUserPositionRange(userID int64, rangeInMeters float32) error {
+
+	if rangeInMeters < 0 || rangeInMeters > publicEventsMaxRange {
+		return ErrIllegalArgument
+	}
+
+	return m.userDAO.SetPositionRange(userID, rangeInMeters)
+}
+
+// GetUserLanguage returns language tag of the client last used by a user
+// (i.e. "es" or "en-GB"). It's empty if unknown.
+func (m *AccountManager) GetUserLanguage(userID int64) (string, error) {
+	return m.userDAO.LoadLanguage(userID)
+}
+
+// SetUserLanguage stores language tag sent by the client of a user
+func (m *AccountManager) SetUserLanguage(userID int64, language string) error {
+
+	language = strings.TrimSpace(language)
+
+	if language == "" || len(language) > maxLanguageTagLength {
+		return ErrIllegalArgument
+	}
+
+	return m.userDAO.SetLanguage(userID, language)
+}
+
+// GetNotificationSettings returns notification settings of a user. If user
+// has never changed them, every notification is enabled.
+func (m *AccountManager) GetNotificationSettings(userID int64) (*NotificationSettings, error) {
+
+	settings := &NotificationSettings{}
+
+	settingsDTO, err := m.settingsDAO.Load(userID)
+	if err == nil {
+		settings = newNotificationSettingsFromDTO(settingsDTO)
+	} else if err != api.ErrNotFound {
+		return nil, err
+	}
+
+	mutedEvents, err := m.settingsDAO.FindMutedEvents(userID)
+	if err != nil && err != api.ErrNoResults {
+		return nil, err
+	}
+
+	settings.MutedEvents = mutedEvents
+
+	return settings, nil
+}
+
+// SetNotificationSettings stores notification settings of a user. Muted
+// events of settings are ignored.
+//
+// Prominent Errors:
+// - ErrIllegalArgument
+func (m *AccountManager) SetNotificationSettings(userID int64, settings *NotificationSettings) error {
+
+	if settings == nil || !settings.isValid() {
+		return ErrIllegalArgument
+	}
+
+	return m.settingsDAO.Insert(settings.asDTO(userID))
+}
+
+// MuteEvent stops or resumes notifications of event for a user. Mute lasts
+// until the event ends.
+//
+// Prominent Errors:
+// - ErrEventNotWritable
+func (m *AccountManager) MuteEvent(userID int64, event *Event, mute bool) error {
+
+	if !mute {
+		return m.settingsDAO.DeleteMutedEvent(userID, event.Id())
+	}
+
+	ttl := event.EndDate().Sub(utils.GetCurrentTimeUTC())
+	if ttl <= 0 {
+		return ErrEventNotWritable
+	}
+
+	return m.settingsDAO.InsertMutedEvent(userID, event.Id(), ttl)
+}
+
 func (m *AccountManager) SetFacebookAccessToken(user *UserAccount, accessToken string) error {
 
 	// Check if user has Facebook. Otherwise, access token cannot be set before account is linked
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+// legacyTokenDAOStub keeps the push token stored in user account before
+// devices were tracked
+type legacyTokenDAOStub struct {
+	api.UserDAO
+	token *api.IIDTokenDTO
+}
+
+func (s *legacyTokenDAOStub) LoadIIDToken(userID int64) (*api.IIDTokenDTO, error) {
+	if s.token == nil {
+		return &api.IIDTokenDTO{}, nil
+	}
+	return s.token, nil
+}
+
+func (s *legacyTokenDAOStub) DeleteIIDToken(userID int64) error {
+	s.token = nil
+	return nil
+}
+
+type pushTokenDAOStub struct {
+	api.PushTokenDAO
+	tokens map[string]*api.PushTokenDTO // deviceID -> token
+}
+
+func (s *pushTokenDAOStub) Insert(token *api.PushTokenDTO) error {
+	s.tokens[token.DeviceID] = token
+	return nil
+}
+
+func (s *pushTokenDAOStub) FindAll(userID int64) ([]*api.PushTokenDTO, error) {
+	if len(s.tokens) == 0 {
+		return nil, api.ErrNoResults
+	}
+	var tokens []*api.PushTokenDTO
+	for _, token := range s.tokens {
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (s *pushTokenDAOStub) Delete(userID int64, deviceID string) error {
+	delete(s.tokens, deviceID)
+	return nil
+}
+
+func newPushTokensTestManager(legacyToken string) (*AccountManager, *legacyTokenDAOStub) {
+	userDAO := &legacyTokenDAOStub{token: &api.IIDTokenDTO{Token: legacyToken, Platform: "iOS"}}
+	manager := &AccountManager{
+		userDAO:      userDAO,
+		pushTokenDAO: &pushTokenDAOStub{tokens: make(map[string]*api.PushTokenDTO)},
+	}
+	return manager, userDAO
+}
+
+func pushTokensByDevice(t *testing.T, m *AccountManager) map[string]string {
+	tokens, err := m.GetPushTokens(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := make(map[string]string)
+	for _, token := range tokens {
+		result[token.DeviceID()] = token.Token()
+	}
+	return result
+}
+
+func TestPushTokensLegacyAndNewDevice(t *testing.T) {
+
+	manager, userDAO := newPushTokensTestManager("legacy-token")
+
+	// A new device registers its token
+	if _, err := manager.TouchPushToken(100, "tablet"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := manager.SetPushToken(100, NewIIDToken("tablet", "tablet-token", 3, "Android")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Legacy device still receives notifications
+	tokens := pushTokensByDevice(t, manager)
+	if len(tokens) != 2 || tokens[""] != "legacy-token" || tokens["tablet"] != "tablet-token" {
+		t.Fatalf("unexpected tokens %v", tokens)
+	}
+
+	if userDAO.token == nil {
+		t.Fatal("legacy token removed")
+	}
+
+	// Legacy device authenticates again with a client that sends no device ID
+	token, err := manager.TouchPushToken(100, "")
+	if err != nil || token.Token() != "legacy-token" {
+		t.Fatalf("legacy token not touched: %v", err)
+	}
+
+	if userDAO.token != nil {
+		t.Fatal("legacy token not moved")
+	}
+
+	tokens = pushTokensByDevice(t, manager)
+	if len(tokens) != 2 || tokens[""] != "legacy-token" {
+		t.Fatalf("unexpected tokens %v", tokens)
+	}
+}
+
+func TestPushTokensLegacyRegisteredAgain(t *testing.T) {
+
+	manager, userDAO := newPushTokensTestManager("legacy-token")
+
+	// Updated client registers the same token with its device ID
+	if err := manager.SetPushToken(100, NewIIDToken("phone", "legacy-token", 3, "iOS")); err != nil {
+		t.Fatal(err)
+	}
+
+	if userDAO.token != nil {
+		t.Fatal("legacy token not removed")
+	}
+
+	tokens := pushTokensByDevice(t, manager)
+	if len(tokens) != 1 || tokens["phone"] != "legacy-token" {
+		t.Fatalf("unexpected tokens %v", tokens)
+	}
+}
@@ -0,0 +1,190 @@
+package model
+
+import (
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// ChangeProposal represents a change of an event proposed by one of its
+// participants. The change is applied only if participants accept it before
+// the voting deadline.
+type ChangeProposal struct {
+	id          int32
+	eventID     int64
+	authorID    int64
+	createdDate time.Time // Seconds precision
+	deadline    time.Time // Seconds precision
+	startDate   time.Time // Zero if start and end date are not changed
+	endDate     time.Time // Zero if start and end date are not changed
+	description string    // Empty if description is not changed
+	votesTotal  int
+	status      api.ChangeProposalStatus
+	votes       map[int64]bool // userID -> accepted
+}
+
+func newChangeProposalFromDTO(dto *api.ChangeProposalDTO) *ChangeProposal {
+
+	proposal := &ChangeProposal{
+		id:          dto.ChangeID,
+		eventID:     dto.EventID,
+		authorID:    dto.AuthorID,
+		createdDate: utils.MillisToTimeUTC(dto.CreatedDate).Truncate(time.Second),
+		deadline:    utils.MillisToTimeUTC(dto.Deadline).Truncate(time.Second),
+		description: dto.Description,
+		votesTotal:  int(dto.VotesTotal),
+		status:      dto.Status,
+		votes:       make(map[int64]bool),
+	}
+
+	if dto.StartDate != 0 {
+		proposal.startDate = utils.MillisToTimeUTC(dto.StartDate).Truncate(time.Second)
+	}
+
+	if dto.EndDate != 0 {
+		proposal.endDate = utils.MillisToTimeUTC(dto.EndDate).Truncate(time.Second)
+	}
+
+	for userID, accepted := range dto.Votes {
+		proposal.votes[userID] = accepted
+	}
+
+	return proposal
+}
+
+func (p *ChangeProposal) Id() int32 {
+	return p.id
+}
+
+func (p *ChangeProposal) EventID() int64 {
+	return p.eventID
+}
+
+func (p *ChangeProposal) AuthorID() int64 {
+	return p.authorID
+}
+
+func (p *ChangeProposal) CreatedDate() time.Time {
+	return p.createdDate
+}
+
+func (p *ChangeProposal) Deadline() time.Time {
+	return p.deadline
+}
+
+func (p *ChangeProposal) StartDate() time.Time {
+	return p.startDate
+}
+
+func (p *ChangeProposal) EndDate() time.Time {
+	return p.endDate
+}
+
+func (p *ChangeProposal) Description() string {
+	return p.description
+}
+
+func (p *ChangeProposal) IsDateChange() bool {
+	return !p.startDate.IsZero()
+}
+
+func (p *ChangeProposal) IsDescriptionChange() bool {
+	return p.description != ""
+}
+
+func (p *ChangeProposal) Status() api.ChangeProposalStatus {
+	return p.status
+}
+
+func (p *ChangeProposal) IsFinished() bool {
+	return p.status != api.ChangeProposalStatus_VOTING
+}
+
+func (p *ChangeProposal) IsAccepted() bool {
+	return p.status == api.ChangeProposalStatus_ACCEPTED
+}
+
+func (p *ChangeProposal) HasVoted(userID int64) bool {
+	_, ok := p.votes[userID]
+	return ok
+}
+
+func (p *ChangeProposal) VotesReceived() int {
+	return len(p.votes)
+}
+
+func (p *ChangeProposal) VotesTotal() int {
+	return p.votesTotal
+}
+
+func (p *ChangeProposal) NumAccepted() int {
+	accepted := 0
+	for _, v := range p.votes {
+		if v {
+			accepted++
+		}
+	}
+	return accepted
+}
+
+// Result returns if voting can be finished with the votes received so far and,
+// in that case, if the change has been accepted. A change is accepted early
+// when more than half of the voters accept it, and discarded early when it
+// cannot reach that majority anymore. Once the deadline has been reached, the
+// change is accepted if it has more accept votes than reject votes.
+func (p *ChangeProposal) Result(currentTime time.Time) (finished bool, accepted bool) {
+
+	numAccepted := p.NumAccepted()
+	numRejected := len(p.votes) - numAccepted
+
+	if numAccepted*2 > p.votesTotal {
+		return true, true
+	}
+
+	if numRejected*2 >= p.votesTotal {
+		return true, false
+	}
+
+	if len(p.votes) >= p.votesTotal || !currentTime.Before(p.deadline) {
+		return true, numAccepted > numRejected
+	}
+
+	return false, false
+}
+
+func (p *ChangeProposal) AsDTO() *api.ChangeProposalDTO {
+
+	dto := &api.ChangeProposalDTO{
+		EventID:     p.eventID,
+		ChangeID:    p.id,
+		AuthorID:    p.authorID,
+		CreatedDate: utils.TimeToMillis(p.createdDate),
+		Deadline:    utils.TimeToMillis(p.deadline),
+		Description: p.description,
+		VotesTotal:  int32(p.votesTotal),
+		Status:      p.status,
+		Votes:       make(map[int64]bool),
+	}
+
+	if !p.startDate.IsZero() {
+		dto.StartDate = utils.TimeToMillis(p.startDate)
+		dto.EndDate = utils.TimeToMillis(p.endDate)
+	}
+
+	for userID, accepted := range p.votes {
+		dto.Votes[userID] = accepted
+	}
+
+	return dto
+}
+
+func (p *ChangeProposal) Clone() *ChangeProposal {
+	proposalCopy := new(ChangeProposal)
+	*proposalCopy = *p
+	proposalCopy.votes = make(map[int64]bool)
+	for userID, accepted := range p.votes {
+		proposalCopy.votes[userID] = accepted
+	}
+	return proposalCopy
+}
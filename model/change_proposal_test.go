@@ -0,0 +1,270 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/imkira/go-observer"
+)
+
+type proposalDAOStub struct {
+	api.ChangeProposalDAO
+	status   map[int64]api.ChangeProposalStatus // eventID -> last status set
+	inserted map[int64]*api.ChangeProposalDTO   // eventID -> last proposal inserted
+}
+
+func (s *proposalDAOStub) Insert(proposal *api.ChangeProposalDTO) error {
+	s.inserted[proposal.EventID] = proposal
+	return nil
+}
+
+func (s *proposalDAOStub) Load(eventID int64, changeID int32) (*api.ChangeProposalDTO, error) {
+	return &api.ChangeProposalDTO{EventID: eventID, ChangeID: changeID}, nil
+}
+
+func (s *proposalDAOStub) InsertVote(eventID int64, changeID int32, userID int64, accept bool) error {
+	return nil
+}
+
+func (s *proposalDAOStub) SetStatus(eventID int64, changeID int32, status api.ChangeProposalStatus) error {
+	s.status[eventID] = status
+	return nil
+}
+
+// votingEventDAOStub loads events with the given participants
+type votingEventDAOStub struct {
+	api.EventDAO
+	participants []int64
+}
+
+func (s *votingEventDAOStub) LoadEvents(ids ...int64) ([]*api.EventDTO, error) {
+	var events []*api.EventDTO
+	for _, id := range ids {
+		dto := &api.EventDTO{Id: id, Participants: make(map[int64]*api.ParticipantDTO)}
+		for _, pID := range s.participants {
+			dto.Participants[pID] = &api.ParticipantDTO{UserID: pID, EventID: id}
+		}
+		events = append(events, dto)
+	}
+	return events, nil
+}
+
+func newVotingTestManager(participants ...int64) (*EventManager, *proposalDAOStub) {
+	proposalDAO := &proposalDAOStub{
+		status:   make(map[int64]api.ChangeProposalStatus),
+		inserted: make(map[int64]*api.ChangeProposalDTO),
+	}
+	manager := &EventManager{
+		eventDAO:        &votingEventDAOStub{participants: participants},
+		proposalDAO:     proposalDAO,
+		eventSignal:     observer.NewProperty(nil),
+		votingProposals: newVotingProposals(),
+	}
+	return manager, proposalDAO
+}
+
+func newTestProposal(eventID int64, votesTotal int, deadline time.Time, votes map[int64]bool) *ChangeProposal {
+	return &ChangeProposal{
+		id:          1,
+		eventID:     eventID,
+		authorID:    1,
+		deadline:    deadline,
+		description: "New description",
+		votesTotal:  votesTotal,
+		status:      api.ChangeProposalStatus_VOTING,
+		votes:       votes,
+	}
+}
+
+func TestChangeProposalResult(t *testing.T) {
+
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	deadline := now.Add(time.Hour)
+
+	tests := []struct {
+		votesTotal  int
+		votes       map[int64]bool
+		currentTime time.Time
+		finished    bool
+		accepted    bool
+	}{
+		// Majority accepts before deadline
+		{5, map[int64]bool{1: true, 2: true, 3: true}, now, true, true},
+		// Majority cannot be reached anymore
+		{5, map[int64]bool{1: true, 2: false, 3: false, 4: false}, now, true, false},
+		{4, map[int64]bool{1: false, 2: false}, now, true, false},
+		// Not enough votes yet
+		{5, map[int64]bool{1: true, 2: true}, now, false, false},
+		{4, map[int64]bool{1: true, 2: true}, now, false, false},
+		// Deadline reached, more accepts than rejects
+		{5, map[int64]bool{1: true, 2: true, 3: false}, deadline, true, true},
+		// Deadline reached, tie
+		{5, map[int64]bool{1: true, 2: false}, deadline, true, false},
+		// Only author voted
+		{1, map[int64]bool{1: true}, now, true, true},
+		{5, map[int64]bool{1: true}, deadline.Add(time.Second), true, true},
+	}
+
+	for i, test := range tests {
+		proposal := newTestProposal(100, test.votesTotal, deadline, test.votes)
+		finished, accepted := proposal.Result(test.currentTime)
+		if finished != test.finished || accepted != test.accepted {
+			t.Fatalf("test %v: expected (%v, %v), got (%v, %v)", i, test.finished,
+				test.accepted, finished, accepted)
+		}
+	}
+}
+
+func TestVoteChange(t *testing.T) {
+
+	manager, proposalDAO := newVotingTestManager(1, 2, 3, 4)
+	deadline := time.Now().Add(time.Hour)
+	manager.votingProposals.proposals[100] = newTestProposal(100, 4, deadline, map[int64]bool{1: true})
+
+	if _, err := manager.VoteChange(100, 2, 2, true); err != ErrVotingFinished {
+		t.Fatalf("expected ErrVotingFinished for unknown change, got %v", err)
+	}
+
+	if _, err := manager.VoteChange(100, 1, 5, true); err != ErrParticipantNotFound {
+		t.Fatalf("expected ErrParticipantNotFound, got %v", err)
+	}
+
+	proposal, err := manager.VoteChange(100, 1, 2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.VotesReceived() != 2 || proposal.NumAccepted() != 1 || proposal.IsFinished() {
+		t.Fatalf("unexpected votes (%v received, %v accepted)", proposal.VotesReceived(), proposal.NumAccepted())
+	}
+
+	if _, err := manager.VoteChange(100, 1, 2, true); err != ErrAlreadyVoted {
+		t.Fatalf("expected ErrAlreadyVoted, got %v", err)
+	}
+
+	// Half of the participants reject it, so it cannot be accepted anymore
+	proposal, err = manager.VoteChange(100, 1, 3, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proposal.IsFinished() || proposal.IsAccepted() {
+		t.Fatalf("expected discarded proposal, got status %v", proposal.Status())
+	}
+
+	if proposalDAO.status[100] != api.ChangeProposalStatus_DISCARDED {
+		t.Fatalf("discarded status not persisted")
+	}
+
+	if _, err := manager.GetVotingChangeProposal(100); err != ErrNotFound {
+		t.Fatalf("finished proposal still in voting")
+	}
+}
+
+func TestFinishExpiredVotings(t *testing.T) {
+
+	manager, proposalDAO := newVotingTestManager(1, 2, 3, 4, 5)
+	stream := manager.Observe()
+	now := time.Now()
+
+	// Deadline reached with a tie
+	manager.votingProposals.proposals[100] = newTestProposal(100, 5, now.Add(-time.Second),
+		map[int64]bool{1: true, 2: false})
+
+	// Deadline not reached yet
+	manager.votingProposals.proposals[200] = newTestProposal(200, 5, now.Add(time.Hour),
+		map[int64]bool{1: true, 2: false})
+
+	manager.finishExpiredVotings()
+
+	if _, err := manager.GetVotingChangeProposal(100); err != ErrNotFound {
+		t.Fatal("expired proposal still in voting")
+	}
+
+	if proposalDAO.status[100] != api.ChangeProposalStatus_DISCARDED {
+		t.Fatalf("expected discarded status, got %v", proposalDAO.status[100])
+	}
+
+	if proposal, err := manager.GetVotingChangeProposal(200); err != nil || proposal.IsFinished() {
+		t.Fatal("proposal finished before its deadline")
+	}
+
+	if _, ok := proposalDAO.status[200]; ok {
+		t.Fatal("status of proposal in voting changed")
+	}
+
+	select {
+	case <-stream.Changes():
+		stream.Next()
+		signal := stream.Value().(*Signal)
+		if signal.Type != SignalVotingFinished || signal.Data["EventID"].(int64) != 100 {
+			t.Fatalf("unexpected signal %v", signal.Type)
+		}
+	default:
+		t.Fatal("voting finished signal not emitted")
+	}
+}
+
+func newVotingTestEvent(eventID int64, cancelled bool, participants ...int64) *Event {
+	dto := &api.EventDTO{Id: eventID, Cancelled: cancelled, Participants: make(map[int64]*api.ParticipantDTO)}
+	for _, pID := range participants {
+		dto.Participants[pID] = &api.ParticipantDTO{UserID: pID, EventID: eventID}
+	}
+	return newEventFromDTO(dto)
+}
+
+func TestUpdateVotingProposalCancelledEvent(t *testing.T) {
+
+	manager, proposalDAO := newVotingTestManager(1, 2, 3)
+	stream := manager.Observe()
+	manager.votingProposals.proposals[100] = newTestProposal(100, 3, time.Now().Add(-time.Second),
+		map[int64]bool{1: true})
+
+	manager.updateVotingProposal(newVotingTestEvent(100, true, 1, 2, 3))
+
+	if proposalDAO.status[100] != api.ChangeProposalStatus_DISCARDED {
+		t.Fatalf("expected discarded status, got %v", proposalDAO.status[100])
+	}
+
+	if _, err := manager.GetVotingChangeProposal(100); err != ErrNotFound {
+		t.Fatal("proposal of cancelled event still in voting")
+	}
+
+	// Neither finished now nor later, so participants aren't notified
+	manager.finishExpiredVotings()
+
+	select {
+	case <-stream.Changes():
+		t.Fatal("signal emitted for proposal of cancelled event")
+	default:
+	}
+}
+
+func TestUpdateVotingProposalParticipantsChanged(t *testing.T) {
+
+	manager, proposalDAO := newVotingTestManager(1, 3, 4)
+	manager.votingProposals.proposals[100] = newTestProposal(100, 5, time.Now().Add(time.Hour),
+		map[int64]bool{1: true, 2: false, 3: true, 5: false})
+
+	// Participants 2 and 5 removed, 4 added
+	manager.updateVotingProposal(newVotingTestEvent(100, false, 1, 3, 4))
+
+	proposal, err := manager.GetVotingChangeProposal(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.VotesTotal() != 3 || proposal.VotesReceived() != 2 || proposal.HasVoted(2) || proposal.HasVoted(5) {
+		t.Fatalf("unexpected votes (%v of %v)", proposal.VotesReceived(), proposal.VotesTotal())
+	}
+
+	if dto := proposalDAO.inserted[100]; dto == nil || dto.VotesTotal != 3 || len(dto.Votes) != 2 {
+		t.Fatal("updated votes not persisted")
+	}
+
+	// Two accepts out of three voters are a majority now
+	if finished, accepted := proposal.Result(time.Now()); !finished || !accepted {
+		t.Fatalf("expected accepted proposal, got (%v, %v)", finished, accepted)
+	}
+}
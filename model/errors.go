@@ -25,6 +25,12 @@ var (
 	ErrInvalidEndDate       = errors.New("invalid end date")
 	ErrParticipantsRequired = errors.New("participants required")
 	ErrCannotArchive        = errors.New("cannot archive event")
+	ErrInvalidLocation      = errors.New("invalid location")
+	ErrLocationRequired     = errors.New("public events require a location")
+	ErrInvalidRecurrence    = errors.New("invalid recurrence")
+	ErrEventNotRecurring    = errors.New("event isn't recurring")
+	ErrInvalidCapacity      = errors.New("invalid event capacity")
+	ErrInvalidQuorum        = errors.New("invalid event quorum")
 
 	ErrModelInitError        = errors.New("model init error")
 	ErrModelAlreadyExist     = errors.New("cannot register model because it already exists")
@@ -39,6 +45,18 @@ var (
 	ErrEmptyInbox                = errors.New("user inbox is empty")
 	ErrAlreadyFriends            = errors.New("already friends")
 	ErrFriendRequestAlreadyExist = errors.New("friend request already exists")
+	ErrSyncCursorExpired         = errors.New("sync cursor is too old")
+	ErrInvalidSyncCursor         = errors.New("sync cursor is in the future")
+
+	// Change proposals
+	ErrInvalidChangeProposal = errors.New("change proposal does not change anything")
+	ErrChangeAlreadyProposed = errors.New("event has already a change proposal in voting")
+	ErrVotingFinished        = errors.New("voting has already finished")
+	ErrAlreadyVoted          = errors.New("user has already voted")
+
+	// Comments
+	ErrInvalidComment     = errors.New("invalid comment")
+	ErrCommentNotWritable = errors.New("comment isn't writable")
 
 	ErrAccountNotLinkedToFacebook = errors.New("account isn't linked to facebook")
 
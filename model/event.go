@@ -10,18 +10,25 @@ import (
 )
 
 type Event struct {
-	id            int64
-	authorID      int64
-	authorName    string
-	description   string
-	pictureDigest []byte
-	createdDate   time.Time // Seconds precision
-	modifiedDate  time.Time // Seconds precision
-	inboxPosition time.Time // Seconds precision
-	startDate     time.Time // Seconds precision
-	endDate       time.Time // Seconds precision
-	cancelled     bool
-	Participants  *ParticipantList
+	id             int64
+	authorID       int64
+	authorName     string
+	description    string
+	pictureDigest  []byte
+	createdDate    time.Time // Seconds precision
+	modifiedDate   time.Time // Seconds precision
+	inboxPosition  time.Time // Seconds precision
+	startDate      time.Time // Seconds precision
+	endDate        time.Time // Seconds precision
+	cancelled      bool
+	isPublic       bool
+	location       *Location
+	seriesID       int64
+	capacity       int // Zero if unlimited
+	quorum         int // Zero if event has no quorum
+	quorumDeadline time.Time
+	confirmed      bool
+	Participants   *ParticipantList
 
 	// Owner of this event object in RAM
 	owner int64
@@ -33,6 +40,17 @@ type Event struct {
 	// point to that object
 	oldEvent *Event
 
+	// Set when a new recurring event is built, so that its series is created
+	// when the event is saved
+	recurrence *Recurrence
+
+	// Set when a modification must be applied also to the occurrences of the
+	// series the event belongs to
+	applyToSeries bool
+
+	// Set when event is cancelled because quorum wasn't reached by the deadline
+	quorumFailed bool
+
 	// Indicate if this object has a copy in database. For instance,
 	// an event loaded from db will have isPersisted set. However, a
 	// modified event will have it unset.
@@ -53,18 +71,26 @@ func newEventFromDTO(dto *api.EventDTO) *Event {
 		startDate:     utils.MillisToTimeUTC(dto.StartDate).Truncate(time.Second),
 		endDate:       utils.MillisToTimeUTC(dto.EndDate).Truncate(time.Second),
 		cancelled:     dto.Cancelled,
+		isPublic:      dto.IsPublic,
+		location:      newLocationFromDTO(dto.Location),
+		seriesID:      dto.SeriesID,
+		capacity:      int(dto.Capacity),
+		quorum:        int(dto.Quorum),
+		confirmed:     dto.Confirmed,
 		Participants:  newParticipantList(),
 		timestamp:     dto.Timestamp,
 	}
 
+	if dto.QuorumDeadline != 0 {
+		event.quorumDeadline = utils.MillisToTimeUTC(dto.QuorumDeadline).Truncate(time.Second)
+	}
+
 	for _, p := range dto.Participants {
 		event.Participants.participants[p.UserID] = newParticipantFromDTO(p)
-		if p.Response == api.AttendanceResponse_ASSIST {
-			event.Participants.numAttendees++
-		}
 	}
 
 	event.Participants.numGuests = len(event.Participants.participants)
+	event.Participants.computeAttendance(event.capacity)
 
 	return event
 }
@@ -144,6 +170,8 @@ func (e *Event) PictureDigest() []byte {
 	return e.pictureDigest
 }
 
+// NumAttendees returns how many participants will attend the event. Those on
+// the waitlist aren't included.
 func (e *Event) NumAttendees() int {
 	return e.Participants.numAttendees
 }
@@ -171,6 +199,62 @@ func (e *Event) IsCancelled() bool {
 	return e.cancelled
 }
 
+func (e *Event) IsPublic() bool {
+	return e.isPublic
+}
+
+// Location returns where event takes place or nil if it has no location
+func (e *Event) Location() *Location {
+	if e.location == nil {
+		return nil
+	}
+	locationCopy := *e.location
+	return &locationCopy
+}
+
+// SeriesID returns the ID of the first occurrence of a recurring event, or
+// zero if event isn't recurring
+func (e *Event) SeriesID() int64 {
+	return e.seriesID
+}
+
+func (e *Event) IsRecurring() bool {
+	return e.seriesID != 0
+}
+
+// Capacity returns the max number of attendees, or zero if unlimited
+func (e *Event) Capacity() int {
+	return e.capacity
+}
+
+// Quorum returns the min number of attendees the event needs to take place,
+// or zero if it has no quorum
+func (e *Event) Quorum() int {
+	return e.quorum
+}
+
+// QuorumDeadline returns when event is cancelled if quorum hasn't been reached
+func (e *Event) QuorumDeadline() time.Time {
+	return e.quorumDeadline
+}
+
+// IsConfirmed returns true if quorum was reached. Events without quorum are
+// never confirmed.
+func (e *Event) IsConfirmed() bool {
+	return e.confirmed
+}
+
+// IsQuorumPending returns true if event is waiting for quorum to be reached
+func (e *Event) IsQuorumPending() bool {
+	return e.quorum > 0 && !e.confirmed && !e.cancelled
+}
+
+// IsQuorumFailed returns true if event was cancelled because quorum wasn't
+// reached. It's only known right after the cancellation.
+func (e *Event) IsQuorumFailed() bool {
+	return e.quorumFailed
+}
+
 func (e *Event) Timestamp() int64 {
 	return e.timestamp
 }
@@ -186,6 +270,13 @@ func (e *Event) Equal(other *Event) bool {
 		e.startDate.Equal(other.startDate) &&
 		e.endDate.Equal(other.endDate) &&
 		e.cancelled == other.cancelled &&
+		e.isPublic == other.isPublic &&
+		e.location.Equal(other.location) &&
+		e.seriesID == other.seriesID &&
+		e.capacity == other.capacity &&
+		e.quorum == other.quorum &&
+		e.quorumDeadline.Equal(other.quorumDeadline) &&
+		e.confirmed == other.confirmed &&
 		e.timestamp == other.timestamp &&
 		e.Participants.Equal(other.Participants)
 }
@@ -196,6 +287,7 @@ func (e *Event) IsZero() bool {
 		e.createdDate.IsZero() && e.modifiedDate.IsZero() &&
 		e.inboxPosition.IsZero() && e.startDate.IsZero() &&
 		e.endDate.IsZero() && e.cancelled == false &&
+		e.isPublic == false && e.location == nil &&
 		e.Participants == nil
 }
 
@@ -212,8 +304,18 @@ func (e *Event) AsDTO() *api.EventDTO {
 		StartDate:     utils.TimeToMillis(e.startDate),
 		EndDate:       utils.TimeToMillis(e.endDate),
 		Cancelled:     e.cancelled,
+		IsPublic:      e.isPublic,
+		Location:      e.location.AsDTO(),
 		Participants:  make(map[int64]*api.ParticipantDTO),
 		Timestamp:     e.timestamp,
+		SeriesID:      e.seriesID,
+		Capacity:      int32(e.capacity),
+		Quorum:        int32(e.quorum),
+		Confirmed:     e.confirmed,
+	}
+
+	if !e.quorumDeadline.IsZero() {
+		dto.QuorumDeadline = utils.TimeToMillis(e.quorumDeadline)
 	}
 
 	for _, v := range e.Participants.participants {
@@ -240,5 +342,6 @@ func (e *Event) CloneWithEmptyParticipants() *Event {
 	eventCopy.Participants = newParticipantList()
 	eventCopy.Participants.numGuests = e.Participants.numGuests
 	eventCopy.Participants.numAttendees = e.Participants.numAttendees
+	eventCopy.Participants.waitlist = e.Participants.Waitlist()
 	return eventCopy
 }
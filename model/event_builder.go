@@ -15,6 +15,11 @@ type EventBuilder interface {
 	SetStartDate(date time.Time) EventBuilder
 	SetEndDate(date time.Time) EventBuilder
 	SetDescription(desc string) EventBuilder
+	SetPublic(public bool) EventBuilder
+	SetLocation(location *Location) EventBuilder
+	SetRecurrence(recurrence *Recurrence) EventBuilder
+	SetCapacity(capacity int) EventBuilder
+	SetQuorum(quorum int, deadline time.Time) EventBuilder
 	ParticipantAdder() ParticipantAdder
 	Build() (*Event, error)
 }
@@ -26,12 +31,19 @@ type eventBuilder struct {
 	startDate          time.Time
 	endDate            time.Time
 	description        string
+	isPublic           bool
+	location           *Location
+	recurrence         *Recurrence
+	capacity           int
+	quorum             int
+	quorumDeadline     time.Time
+	seriesID           int64 // Set when building an occurrence of a series
 	participantBuilder *participantListCreator
 	eventManager       *EventManager
 	//pictureDigest []byte
 }
 
-func (m *EventManager) newEventBuilder() EventBuilder {
+func (m *EventManager) NewEventBuilder() EventBuilder {
 	return &eventBuilder{
 		createdDate:        utils.GetCurrentTimeUTC(),
 		participantBuilder: m.newParticipantListCreator(),
@@ -69,6 +81,49 @@ func (b *eventBuilder) SetDescription(desc string) EventBuilder {
 	return b
 }
 
+func (b *eventBuilder) SetPublic(public bool) EventBuilder {
+	b.isPublic = public
+	return b
+}
+
+func (b *eventBuilder) SetLocation(location *Location) EventBuilder {
+	if location != nil {
+		locationCopy := *location
+		b.location = &locationCopy
+	} else {
+		b.location = nil
+	}
+	return b
+}
+
+// SetRecurrence makes the event repeat. Event is the first occurrence of the
+// series. A nil recurrence means the event doesn't repeat.
+func (b *eventBuilder) SetRecurrence(recurrence *Recurrence) EventBuilder {
+	if recurrence != nil {
+		recurrenceCopy := *recurrence
+		b.recurrence = &recurrenceCopy
+	} else {
+		b.recurrence = nil
+	}
+	return b
+}
+
+// SetCapacity sets the max number of attendees, author included. Zero means
+// unlimited.
+func (b *eventBuilder) SetCapacity(capacity int) EventBuilder {
+	b.capacity = capacity
+	return b
+}
+
+// SetQuorum sets the min number of attendees, author included, the event needs
+// to take place. Event is cancelled if quorum isn't reached by deadline. Zero
+// quorum and deadline mean no quorum. Recurring events cannot have quorum.
+func (b *eventBuilder) SetQuorum(quorum int, deadline time.Time) EventBuilder {
+	b.quorum = quorum
+	b.quorumDeadline = deadline.Truncate(time.Second)
+	return b
+}
+
 func (b *eventBuilder) ParticipantAdder() ParticipantAdder {
 	return b.participantBuilder
 }
@@ -101,22 +156,36 @@ func (b *eventBuilder) Build() (*Event, error) {
 		return nil, err
 	}
 
+	participants.computeAttendance(b.capacity)
+
+	// A recurring event starts its own series
+	if b.recurrence != nil {
+		b.seriesID = b.eventID
+	}
+
 	// Build event
 	event := &Event{
-		id:            b.eventID,
-		authorID:      b.author.id,
-		authorName:    b.author.name,
-		description:   b.description,
-		createdDate:   b.createdDate.Truncate(time.Second),
-		inboxPosition: b.startDate,
-		startDate:     b.startDate,
-		endDate:       b.endDate,
-		Participants:  participants,
-		modifiedDate:  b.createdDate.Truncate(time.Second),
-		timestamp:     timestamp,
-		owner:         b.author.id,
-		isPersisted:   false,
-		oldEvent:      nil,
+		id:             b.eventID,
+		authorID:       b.author.id,
+		authorName:     b.author.name,
+		description:    b.description,
+		createdDate:    b.createdDate.Truncate(time.Second),
+		inboxPosition:  b.startDate,
+		startDate:      b.startDate,
+		endDate:        b.endDate,
+		isPublic:       b.isPublic,
+		location:       b.location,
+		seriesID:       b.seriesID,
+		capacity:       b.capacity,
+		quorum:         b.quorum,
+		quorumDeadline: b.quorumDeadline,
+		Participants:   participants,
+		modifiedDate:   b.createdDate.Truncate(time.Second),
+		timestamp:      timestamp,
+		owner:          b.author.id,
+		isPersisted:    false,
+		oldEvent:       nil,
+		recurrence:     b.recurrence,
 	}
 
 	return event, nil
@@ -145,6 +214,28 @@ func (b *eventBuilder) validateData() error {
 		return ErrInvalidEndDate
 	}
 
+	if b.location != nil && !IsValidLocation(b.location) {
+		return ErrInvalidLocation
+	}
+
+	if b.isPublic && b.location == nil {
+		return ErrLocationRequired
+	}
+
+	if !IsValidCapacity(b.capacity) {
+		return ErrInvalidCapacity
+	}
+
+	if !IsValidQuorum(b.quorum, b.quorumDeadline, b.startDate, b.createdDate) ||
+		(b.capacity > 0 && b.quorum > b.capacity) || (b.quorum > 0 && b.recurrence != nil) {
+		return ErrInvalidQuorum
+	}
+
+	if b.recurrence != nil && (!b.recurrence.IsValid() ||
+		!b.recurrence.HasOccurrence(b.startDate, 1)) {
+		return ErrInvalidRecurrence
+	}
+
 	// Build() always insert author as participant. So Len() will never return 0
 	/*if b.participantBuilder.Len() == 0 {
 		return ErrParticipantsRequired
@@ -0,0 +1,184 @@
+package model
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/cqldao"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+const (
+	// Changes older than this are removed from the change log, so clients
+	// with an older cursor must download their inbox again
+	changeLogRetention = 30 * 24 * time.Hour
+
+	// Cursors come from server clocks, which may be a bit ahead of each other
+	syncCursorMaxSkew = 2 * time.Minute
+
+	// Changes are logged after the event is persisted, so a change may be
+	// inserted with a timestamp older than changes already in the log. Sync
+	// doesn't return changes newer than this, so that they have time to
+	// settle and cursors never go past a change not inserted yet.
+	syncCursorLag = 10 * time.Second
+)
+
+// changeLogClock assigns change log timestamps. They are server time in
+// microseconds and strictly increase even if the clock goes backward.
+type changeLogClock struct {
+	mutex sync.Mutex
+	last  int64
+}
+
+func (c *changeLogClock) now() int64 {
+
+	defer c.mutex.Unlock()
+	c.mutex.Lock()
+
+	timestamp := utils.GetCurrentTimeMicros()
+	if timestamp <= c.last {
+		timestamp = c.last + 1
+	}
+
+	c.last = timestamp
+	return timestamp
+}
+
+// EventChanges contains events of a user that changed after a sync cursor.
+// Events contains current state of new, modified or cancelled events and
+// RemovedEvents the IDs of the events where user is no longer invited. Cursor
+// and CursorEventID are the values to use in the next sync. If HasMore is
+// true, there are still changes after them.
+type EventChanges struct {
+	Events        []*Event
+	RemovedEvents []int64
+	Cursor        int64
+	CursorEventID int64
+	HasMore       bool
+}
+
+// GetEventChangesSince returns the changes of the events of userID that
+// come after cursor, where cursor is a timestamp in microseconds and
+// cursorEventID the ID of the last event read with that timestamp. A zero
+// cursor returns no changes but the current cursor. Clients get it before
+// downloading their inbox, so that they don't miss changes made meanwhile.
+// Returned cursor is never newer than syncCursorLag ago, so the same event
+// may be returned by consecutive syncs.
+//
+// Preconditions:
+// - (1) Cursor must be zero or inside the change log retention window
+// - (2) Cursor must not be in the future
+func (m *EventManager) GetEventChangesSince(userID int64, cursor int64, cursorEventID int64) (*EventChanges, error) {
+
+	currentTime := utils.GetCurrentTimeUTC()
+	settledTime := currentTime.Add(-syncCursorLag).UnixNano() / int64(time.Microsecond)
+
+	if cursor == 0 {
+		return &EventChanges{Cursor: settledTime}, nil
+	}
+
+	// Check precondition (1)
+	if cursor < 0 || cursor < currentTime.Add(-changeLogRetention).UnixNano()/int64(time.Microsecond) {
+		return nil, ErrSyncCursorExpired
+	}
+
+	// Check precondition (2)
+	if cursor > currentTime.Add(syncCursorMaxSkew).UnixNano()/int64(time.Microsecond) {
+		return nil, ErrInvalidSyncCursor
+	}
+
+	changes := &EventChanges{Cursor: cursor, CursorEventID: cursorEventID}
+
+	entries, err := m.changeLogDAO.FindAllSince(userID, cursor, cursorEventID)
+	if err == api.ErrNoResults {
+		return changes, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	changes.HasMore = len(entries) >= cqldao.MAX_CHANGES_IN_SYNC_LIST
+
+	// Changes that haven't settled yet are left for a later sync
+	settled := len(entries)
+	for settled > 0 && entries[settled-1].Timestamp > settledTime {
+		settled--
+	}
+
+	if settled < len(entries) {
+		entries = entries[:settled]
+		changes.HasMore = false
+	}
+
+	if len(entries) == 0 {
+		return changes, nil
+	}
+
+	// Next page starts after the last event read. Other changes of that event
+	// with the same timestamp are skipped, but they don't matter because the
+	// current state of the event is sent.
+	changes.Cursor = entries[len(entries)-1].Timestamp
+	changes.CursorEventID = entries[len(entries)-1].EventID
+
+	// Last change of each event decides if it was removed
+	removed := make(map[int64]bool)
+	var eventIDs []int64
+
+	for _, entry := range entries {
+		if _, ok := removed[entry.EventID]; !ok {
+			eventIDs = append(eventIDs, entry.EventID)
+		}
+		removed[entry.EventID] = entry.Type == api.EventChangeType_REMOVED
+	}
+
+	var changedIDs []int64
+	for _, eventID := range eventIDs {
+		if removed[eventID] {
+			changes.RemovedEvents = append(changes.RemovedEvents, eventID)
+		} else {
+			changedIDs = append(changedIDs, eventID)
+		}
+	}
+
+	if len(changedIDs) == 0 {
+		return changes, nil
+	}
+
+	eventsDTO, err := m.eventDAO.LoadEvents(changedIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range eventsDTO {
+		event := newEventFromDTO(dto)
+		event.isPersisted = true
+		if _, ok := event.Participants.Get(userID); !ok {
+			changes.RemovedEvents = append(changes.RemovedEvents, event.Id())
+			continue
+		}
+		changes.Events = append(changes.Events, event)
+	}
+
+	return changes, nil
+}
+
+// logEventChange adds a change of an event to the change log of the given
+// users. Entries are timestamped when they are inserted, so that syncs don't
+// skip them. Change log is not critical, so errors are only logged.
+func (m *EventManager) logEventChange(eventID int64, changeType api.EventChangeType, userIDs ...int64) {
+
+	if len(userIDs) == 0 {
+		return
+	}
+
+	change := &api.EventChangeDTO{
+		Timestamp: m.changeLogClock.now(),
+		EventID:   eventID,
+		Type:      changeType,
+	}
+
+	if err := m.changeLogDAO.Insert(change, userIDs...); err != nil {
+		log.Printf("* Log change of event %v error: %v\n", eventID, err)
+	}
+}
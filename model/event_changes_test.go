@@ -0,0 +1,278 @@
+package model
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/cqldao"
+)
+
+// changeLogStub keeps change log of a single user sorted like
+// event_changes_by_user table does
+type changeLogStub struct {
+	api.EventChangeLogDAO
+	entries []*api.EventChangeDTO
+}
+
+func (s *changeLogStub) FindAllSince(userID int64, timestamp int64, eventID int64) ([]*api.EventChangeDTO, error) {
+
+	var result []*api.EventChangeDTO
+
+	for _, entry := range s.entries {
+		if entry.Timestamp > timestamp || (entry.Timestamp == timestamp && entry.EventID > eventID) {
+			result = append(result, entry)
+			if len(result) == cqldao.MAX_CHANGES_IN_SYNC_LIST {
+				break
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, api.ErrNoResults
+	}
+
+	return result, nil
+}
+
+func (s *changeLogStub) Insert(change *api.EventChangeDTO, userIDs ...int64) error {
+	s.add(change.Timestamp, change.EventID, change.Type)
+	return nil
+}
+
+// age moves every entry back in time as if d had elapsed
+func (s *changeLogStub) age(d time.Duration) {
+	for _, entry := range s.entries {
+		entry.Timestamp -= int64(d / time.Microsecond)
+	}
+}
+
+func (s *changeLogStub) add(timestamp int64, eventID int64, changeType api.EventChangeType) {
+	s.entries = append(s.entries, &api.EventChangeDTO{Timestamp: timestamp, EventID: eventID, Type: changeType})
+	sort.SliceStable(s.entries, func(i, j int) bool {
+		if s.entries[i].Timestamp != s.entries[j].Timestamp {
+			return s.entries[i].Timestamp < s.entries[j].Timestamp
+		}
+		return s.entries[i].EventID < s.entries[j].EventID
+	})
+}
+
+// eventDAOStub loads events where userID is invited
+type eventDAOStub struct {
+	api.EventDAO
+	userID int64
+}
+
+func (s *eventDAOStub) LoadEvents(ids ...int64) ([]*api.EventDTO, error) {
+	var events []*api.EventDTO
+	for _, id := range ids {
+		events = append(events, &api.EventDTO{
+			Id: id,
+			Participants: map[int64]*api.ParticipantDTO{
+				s.userID: {UserID: s.userID, EventID: id},
+			},
+		})
+	}
+	return events, nil
+}
+
+func newChangesTestManager(userID int64) (*EventManager, *changeLogStub) {
+	changeLog := &changeLogStub{}
+	manager := &EventManager{
+		changeLogDAO: changeLog,
+		eventDAO:     &eventDAOStub{userID: userID},
+	}
+	return manager, changeLog
+}
+
+func microseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Microsecond)
+}
+
+func TestGetEventChangesSinceSameTimestampPages(t *testing.T) {
+
+	userID := int64(1)
+	manager, changeLog := newChangesTestManager(userID)
+
+	// More changes than a page can hold, all of them with the same timestamp
+	timestamp := microseconds(time.Now().Add(-time.Hour))
+	total := cqldao.MAX_CHANGES_IN_SYNC_LIST + 50
+	for i := 1; i <= total; i++ {
+		changeLog.add(timestamp, int64(i), api.EventChangeType_INFO_CHANGED)
+	}
+
+	read := make(map[int64]bool)
+	cursor, cursorEventID := timestamp-1, int64(0)
+
+	for page := 0; ; page++ {
+
+		if page > 2 {
+			t.Fatalf("too many pages")
+		}
+
+		changes, err := manager.GetEventChangesSince(userID, cursor, cursorEventID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, event := range changes.Events {
+			if read[event.Id()] {
+				t.Fatalf("event %v read twice", event.Id())
+			}
+			read[event.Id()] = true
+		}
+
+		cursor, cursorEventID = changes.Cursor, changes.CursorEventID
+
+		if !changes.HasMore {
+			break
+		}
+	}
+
+	if len(read) != total {
+		t.Fatalf("expected %v events, got %v", total, len(read))
+	}
+
+	if cursor != timestamp || cursorEventID != int64(total) {
+		t.Fatalf("unexpected cursor (%v, %v)", cursor, cursorEventID)
+	}
+}
+
+func TestGetEventChangesSinceRemovedEvents(t *testing.T) {
+
+	userID := int64(1)
+	manager, changeLog := newChangesTestManager(userID)
+
+	timestamp := microseconds(time.Now().Add(-time.Hour))
+	changeLog.add(timestamp, 10, api.EventChangeType_INFO_CHANGED)
+	changeLog.add(timestamp+1, 10, api.EventChangeType_REMOVED)
+	changeLog.add(timestamp+1, 20, api.EventChangeType_REMOVED)
+	changeLog.add(timestamp+2, 20, api.EventChangeType_INVITED)
+
+	changes, err := manager.GetEventChangesSince(userID, timestamp-1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes.RemovedEvents) != 1 || changes.RemovedEvents[0] != 10 {
+		t.Fatalf("expected event 10 removed, got %v", changes.RemovedEvents)
+	}
+
+	if len(changes.Events) != 1 || changes.Events[0].Id() != 20 {
+		t.Fatalf("expected event 20 changed, got %v events", len(changes.Events))
+	}
+
+	if changes.HasMore || changes.Cursor != timestamp+2 || changes.CursorEventID != 20 {
+		t.Fatalf("unexpected cursor (%v, %v)", changes.Cursor, changes.CursorEventID)
+	}
+
+	// Nothing left after cursor
+	changes, err = manager.GetEventChangesSince(userID, changes.Cursor, changes.CursorEventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes.Events) != 0 || len(changes.RemovedEvents) != 0 || changes.Cursor != timestamp+2 {
+		t.Fatalf("expected no changes")
+	}
+}
+
+func TestGetEventChangesSinceZeroCursor(t *testing.T) {
+
+	userID := int64(1)
+	manager, changeLog := newChangesTestManager(userID)
+	changeLog.add(microseconds(time.Now().Add(-time.Hour)), 10, api.EventChangeType_INVITED)
+
+	before := microseconds(time.Now().Add(-syncCursorLag))
+
+	changes, err := manager.GetEventChangesSince(userID, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes.Events) != 0 || len(changes.RemovedEvents) != 0 || changes.HasMore {
+		t.Fatalf("expected no changes")
+	}
+
+	if changes.Cursor < before || changes.Cursor > microseconds(time.Now().Add(-syncCursorLag)) {
+		t.Fatalf("expected current cursor, got %v", changes.Cursor)
+	}
+}
+
+func TestGetEventChangesSinceLateInsert(t *testing.T) {
+
+	userID := int64(1)
+	manager, changeLog := newChangesTestManager(userID)
+	now := time.Now()
+
+	changeLog.add(microseconds(now.Add(-time.Hour)), 10, api.EventChangeType_INVITED)
+	changeLog.add(microseconds(now.Add(-time.Second)), 20, api.EventChangeType_INVITED)
+
+	// Recent change is not returned until it settles
+	changes, err := manager.GetEventChangesSince(userID, microseconds(now.Add(-2*time.Hour)), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes.Events) != 1 || changes.Events[0].Id() != 10 || changes.HasMore {
+		t.Fatalf("expected only event 10, got %v events", len(changes.Events))
+	}
+
+	// Change inserted late with an older timestamp than the last one
+	changeLog.add(microseconds(now.Add(-2*time.Second)), 30, api.EventChangeType_INVITED)
+	changeLog.age(syncCursorLag)
+
+	changes, err = manager.GetEventChangesSince(userID, changes.Cursor-int64(syncCursorLag/time.Microsecond),
+		changes.CursorEventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes.Events) != 2 || changes.Events[0].Id() != 30 || changes.Events[1].Id() != 20 {
+		t.Fatalf("expected events 30 and 20, got %v events", len(changes.Events))
+	}
+}
+
+func TestLogEventChangeTimestamps(t *testing.T) {
+
+	manager, changeLog := newChangesTestManager(1)
+
+	// Clock went backward
+	manager.changeLogClock.last = microseconds(time.Now().Add(time.Hour))
+
+	manager.logEventChange(10, api.EventChangeType_INVITED, 1)
+	manager.logEventChange(20, api.EventChangeType_INVITED, 1)
+
+	if len(changeLog.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v", len(changeLog.entries))
+	}
+
+	if changeLog.entries[0].EventID != 10 || changeLog.entries[1].Timestamp <= changeLog.entries[0].Timestamp {
+		t.Fatal("change log timestamps don't increase")
+	}
+}
+
+func TestGetEventChangesSinceInvalidCursor(t *testing.T) {
+
+	userID := int64(1)
+	manager, _ := newChangesTestManager(userID)
+	now := time.Now()
+
+	tests := []struct {
+		cursor int64
+		err    error
+	}{
+		{-1, ErrSyncCursorExpired},
+		{microseconds(now.Add(-changeLogRetention - time.Minute)), ErrSyncCursorExpired},
+		{microseconds(now.Add(syncCursorMaxSkew + time.Minute)), ErrInvalidSyncCursor},
+		{microseconds(now.Add(-changeLogRetention + time.Minute)), nil},
+		{microseconds(now.Add(time.Minute)), nil},
+	}
+
+	for i, test := range tests {
+		if _, err := manager.GetEventChangesSince(userID, test.cursor, 0); err != test.err {
+			t.Fatalf("test %v: expected %v, got %v", i, test.err, err)
+		}
+	}
+}
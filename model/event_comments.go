@@ -0,0 +1,278 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/idgen"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// Comment is a message posted by a participant in the discussion thread of
+// an event
+type Comment struct {
+	eventID     int64
+	id          int64
+	authorID    int64
+	authorName  string
+	message     string
+	createdDate time.Time
+	editedDate  time.Time // Zero if never edited
+}
+
+func newCommentFromDTO(dto *api.EventCommentDTO) *Comment {
+
+	comment := &Comment{
+		eventID:     dto.EventID,
+		id:          dto.CommentID,
+		authorID:    dto.AuthorID,
+		authorName:  dto.AuthorName,
+		message:     dto.Message,
+		createdDate: utils.MillisToTimeUTC(dto.CreatedDate),
+	}
+
+	if dto.EditedDate != 0 {
+		comment.editedDate = utils.MillisToTimeUTC(dto.EditedDate)
+	}
+
+	return comment
+}
+
+func (c *Comment) EventID() int64 {
+	return c.eventID
+}
+
+func (c *Comment) Id() int64 {
+	return c.id
+}
+
+func (c *Comment) AuthorID() int64 {
+	return c.authorID
+}
+
+func (c *Comment) AuthorName() string {
+	return c.authorName
+}
+
+func (c *Comment) Message() string {
+	return c.message
+}
+
+func (c *Comment) CreatedDate() time.Time {
+	return c.createdDate
+}
+
+func (c *Comment) EditedDate() time.Time {
+	return c.editedDate
+}
+
+func (c *Comment) IsEdited() bool {
+	return !c.editedDate.IsZero()
+}
+
+func (c *Comment) AsDTO() *api.EventCommentDTO {
+
+	dto := &api.EventCommentDTO{
+		EventID:     c.eventID,
+		CommentID:   c.id,
+		AuthorID:    c.authorID,
+		AuthorName:  c.authorName,
+		Message:     c.message,
+		CreatedDate: utils.TimeToMillis(c.createdDate),
+	}
+
+	if !c.editedDate.IsZero() {
+		dto.EditedDate = utils.TimeToMillis(c.editedDate)
+	}
+
+	return dto
+}
+
+// PostComment adds a comment to the discussion thread of an event.
+//
+// Preconditions:
+// - (1) Event exists and isn't cancelled
+// - (2) User must have received this invitation, i.e. user is in event participant list
+// - (3) Message isn't empty nor too long
+func (m *EventManager) PostComment(eventID int64, userID int64, message string) (*Comment, error) {
+
+	// Check precondition (1)
+	event, err := m.LoadEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.IsCancelled() {
+		return nil, ErrEventNotWritable
+	}
+
+	// Check precondition (2)
+	participant, ok := event.Participants.Get(userID)
+	if !ok {
+		return nil, ErrParticipantNotFound
+	}
+
+	// Check precondition (3)
+	if !IsValidComment(message) {
+		return nil, ErrInvalidComment
+	}
+
+	comment := &Comment{
+		eventID:     eventID,
+		id:          idgen.NewID(),
+		authorID:    userID,
+		authorName:  participant.Name(),
+		message:     strings.TrimSpace(message),
+		createdDate: utils.GetCurrentTimeUTC().Truncate(time.Millisecond),
+	}
+
+	if err := m.commentDAO.Insert(comment.AsDTO()); err != nil {
+		return nil, err
+	}
+
+	m.emitCommentSignal(SignalCommentPosted, event, comment, userID)
+
+	return comment, nil
+}
+
+// EditComment changes the message of a comment.
+//
+// Preconditions:
+// - (1) Event exists and isn't cancelled
+// - (2) User is still a participant of the event and is the author of the comment
+// - (3) Message isn't empty nor too long
+func (m *EventManager) EditComment(eventID int64, commentID int64, userID int64, message string) (*Comment, error) {
+
+	// Check precondition (1) and (2)
+	event, comment, err := m.loadCommentForUser(eventID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.IsCancelled() {
+		return nil, ErrEventNotWritable
+	}
+
+	if comment.authorID != userID {
+		return nil, ErrCommentNotWritable
+	}
+
+	// Check precondition (3)
+	if !IsValidComment(message) {
+		return nil, ErrInvalidComment
+	}
+
+	editedComment := *comment
+	editedComment.message = strings.TrimSpace(message)
+	editedComment.editedDate = utils.GetCurrentTimeUTC().Truncate(time.Millisecond)
+
+	if err := m.commentDAO.Insert(editedComment.AsDTO()); err != nil {
+		return nil, err
+	}
+
+	m.emitCommentSignal(SignalCommentEdited, event, &editedComment, userID)
+
+	return &editedComment, nil
+}
+
+// DeleteComment removes a comment. Comments can be deleted by their author
+// and by the author of the event.
+//
+// Preconditions:
+// - (1) Event exists
+// - (2) User is a participant of the event and is the author of either the comment or the event
+func (m *EventManager) DeleteComment(eventID int64, commentID int64, userID int64) error {
+
+	// Check precondition (1) and (2)
+	event, comment, err := m.loadCommentForUser(eventID, commentID, userID)
+	if err != nil {
+		return err
+	}
+
+	if comment.authorID != userID && event.AuthorID() != userID {
+		return ErrCommentNotWritable
+	}
+
+	if err := m.commentDAO.Delete(eventID, commentID); err != nil {
+		return err
+	}
+
+	m.emitCommentSignal(SignalCommentDeleted, event, comment, userID)
+
+	return nil
+}
+
+// GetComments returns a page of comments of an event, newest first. Comments
+// are older than beforeID, or the newest ones if beforeID is zero. A zero
+// limit means the default page size. The returned bool is true if there are
+// older comments.
+//
+// Preconditions:
+// - (1) User must have received this invitation, i.e. user is in event participant list
+func (m *EventManager) GetComments(eventID int64, userID int64, beforeID int64, limit int) ([]*Comment, bool, error) {
+
+	// Check precondition (1)
+	if _, err := m.GetEventForUser(userID, eventID); err != nil {
+		return nil, false, err
+	}
+
+	if limit <= 0 {
+		limit = commentsPageDefaultSize
+	} else if limit > commentsPageMaxSize {
+		limit = commentsPageMaxSize
+	}
+
+	// Ask for one more to know if there are older comments
+	commentsDTO, err := m.commentDAO.FindPage(eventID, beforeID, limit+1)
+	if err == api.ErrNoResults {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(commentsDTO) > limit
+	if hasMore {
+		commentsDTO = commentsDTO[:limit]
+	}
+
+	comments := make([]*Comment, 0, len(commentsDTO))
+	for _, dto := range commentsDTO {
+		comments = append(comments, newCommentFromDTO(dto))
+	}
+
+	return comments, hasMore, nil
+}
+
+// Loads event and comment checking that userID is a participant of the event
+func (m *EventManager) loadCommentForUser(eventID int64, commentID int64, userID int64) (*Event, *Comment, error) {
+
+	event, err := m.LoadEvent(eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, ok := event.Participants.Get(userID); !ok {
+		return nil, nil, ErrParticipantNotFound
+	}
+
+	commentDTO, err := m.commentDAO.Load(eventID, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return event, newCommentFromDTO(commentDTO), nil
+}
+
+// Emits signalType about comment. UserID is who posted, edited or deleted it.
+func (m *EventManager) emitCommentSignal(signalType SignalType, event *Event, comment *Comment, userID int64) {
+	m.eventSignal.Update(&Signal{
+		Type: signalType,
+		Data: map[string]interface{}{
+			"EventID": event.Id(),
+			"Event":   event,
+			"Comment": comment,
+			"UserID":  userID,
+		},
+	})
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// authoredEventDAOStub loads events of the given authors
+type authoredEventDAOStub struct {
+	api.EventDAO
+	authors map[int64]int64 // eventID -> authorID
+}
+
+func (s *authoredEventDAOStub) LoadEvents(ids ...int64) ([]*api.EventDTO, error) {
+	var events []*api.EventDTO
+	for _, id := range ids {
+		events = append(events, &api.EventDTO{Id: id, AuthorId: s.authors[id]})
+	}
+	return events, nil
+}
+
+// historyFinderDAOStub records which history was read and from which date
+type historyFinderDAOStub struct {
+	api.EventHistoryDAO
+	authored map[int64][]int64 // authorID -> eventIDs
+	called   string
+	fromDate time.Time
+}
+
+func (s *historyFinderDAOStub) find(called string, authorID int64, fromDate time.Time) ([]int64, error) {
+	s.called = called
+	s.fromDate = fromDate
+	if len(s.authored[authorID]) == 0 {
+		return nil, api.ErrNoResults
+	}
+	return s.authored[authorID], nil
+}
+
+func (s *historyFinderDAOStub) FindAuthoredForward(authorID int64, fromDate time.Time) ([]int64, error) {
+	return s.find("FindAuthoredForward", authorID, fromDate)
+}
+
+func (s *historyFinderDAOStub) FindAuthoredBackward(authorID int64, fromDate time.Time) ([]int64, error) {
+	return s.find("FindAuthoredBackward", authorID, fromDate)
+}
+
+func TestGetRecentAuthoredEvents(t *testing.T) {
+
+	manager := &EventManager{
+		eventDAO:   &authoredEventDAOStub{authors: map[int64]int64{10: 1, 20: 2, 30: 1}},
+		userEvents: newUserEvents(),
+	}
+
+	for _, eventID := range []int64{10, 20, 30} {
+		manager.userEvents.Insert(1, eventID)
+		manager.userEvents.Insert(2, eventID)
+	}
+	manager.userEvents.Insert(3, 20)
+
+	events, err := manager.GetRecentAuthoredEvents(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %v", len(events))
+	}
+
+	for _, event := range events {
+		if event.AuthorID() != 1 {
+			t.Fatalf("event %v isn't authored by user", event.Id())
+		}
+	}
+
+	// Invited to events of others only
+	if _, err := manager.GetRecentAuthoredEvents(3); err != ErrEmptyInbox {
+		t.Fatalf("expected ErrEmptyInbox, got %v", err)
+	}
+}
+
+func TestGetAuthoredEventsHistory(t *testing.T) {
+
+	historyDAO := &historyFinderDAOStub{authored: map[int64][]int64{1: {10, 30}}}
+	manager := &EventManager{
+		eventDAO:        &authoredEventDAOStub{authors: map[int64]int64{10: 1, 30: 1}},
+		eventHistoryDAO: historyDAO,
+	}
+
+	now := utils.GetCurrentTimeUTC().Truncate(time.Second)
+	past := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		start    time.Time
+		end      time.Time
+		called   string
+		fromDate time.Time
+	}{
+		// Older events
+		{now, past, "FindAuthoredBackward", now},
+		// Newer events
+		{past, now, "FindAuthoredForward", past},
+		// Window after current time is moved back to current time
+		{now.Add(time.Hour), past, "FindAuthoredBackward", now},
+	}
+
+	for i, test := range tests {
+
+		events, err := manager.GetAuthoredEventsHistory(1, test.start, test.end)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(events) != 2 {
+			t.Fatalf("test %v: expected 2 events, got %v", i, len(events))
+		}
+
+		// Current time may have moved to next second
+		if historyDAO.called != test.called || historyDAO.fromDate.Before(test.fromDate) ||
+			historyDAO.fromDate.After(test.fromDate.Add(time.Second)) {
+			t.Fatalf("test %v: unexpected %v from %v", i, historyDAO.called, historyDAO.fromDate)
+		}
+	}
+
+	if _, err := manager.GetAuthoredEventsHistory(2, now, past); err != ErrEmptyInbox {
+		t.Fatalf("expected ErrEmptyInbox, got %v", err)
+	}
+}
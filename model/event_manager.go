@@ -24,8 +24,19 @@ type EventManager struct {
 	eventHistoryDAO api.EventHistoryDAO
 	thumbDAO        api.ThumbnailDAO
 	settingsDAO     api.SettingsDAO
+	proposalDAO     api.ChangeProposalDAO
+	publicEventDAO  api.PublicEventDAO
+	changeLogDAO    api.EventChangeLogDAO
+	reminderDAO     api.EventReminderDAO
+	seriesDAO       api.EventSeriesDAO
+	commentDAO      api.EventCommentDAO
 	eventSignal     observer.Property
 	userEvents      *UserEvents
+	votingProposals *VotingProposals
+	activeSeries    *ActiveSeries
+	quorumEvents    *QuorumEvents
+	reminderOffsets *ReminderOffsets
+	changeLogClock  changeLogClock
 
 	// Date till events have been archived. This date included. In other words,
 	// events before or equal to this date have been reviewed and archived.
@@ -43,8 +54,18 @@ func newEventManager(parent *AyiModel, session api.DbSession) *EventManager {
 		timelineDAO:     cqldao.NewTimeLineDAO(session),
 		thumbDAO:        cqldao.NewThumbnailDAO(session),
 		settingsDAO:     cqldao.NewSettingsDAO(session),
+		proposalDAO:     cqldao.NewChangeProposalDAO(session),
+		publicEventDAO:  cqldao.NewPublicEventDAO(session),
+		changeLogDAO:    cqldao.NewEventChangeLogDAO(session),
+		reminderDAO:     cqldao.NewEventReminderDAO(session),
+		seriesDAO:       cqldao.NewEventSeriesDAO(session),
+		commentDAO:      cqldao.NewEventCommentDAO(session),
 		eventSignal:     observer.NewProperty(nil),
 		userEvents:      newUserEvents(),
+		votingProposals: newVotingProposals(),
+		activeSeries:    newActiveSeries(),
+		quorumEvents:    newQuorumEvents(),
+		reminderOffsets: newDefaultReminderOffsets(),
 	}
 
 	if err := evManager.readLastArchiveTime(); err != nil {
@@ -57,6 +78,16 @@ func newEventManager(parent *AyiModel, session api.DbSession) *EventManager {
 		panic(ErrModelInitError)
 	}
 
+	if err := evManager.loadVotingProposals(); err != nil {
+		log.Printf("newEventManagerError: %v\n", err)
+		panic(ErrModelInitError)
+	}
+
+	if err := evManager.loadActiveSeries(); err != nil {
+		log.Printf("newEventManagerError: %v\n", err)
+		panic(ErrModelInitError)
+	}
+
 	return evManager
 }
 
@@ -105,17 +136,25 @@ func (m *EventManager) startJobManager() {
 		}
 	}
 
+	runJobs := func() {
+		archiveJob()
+		m.finishExpiredVotings()
+		m.sendDueReminders()
+		m.generatePendingOccurrences()
+		m.checkPendingQuorums()
+	}
+
 	for {
 		currentTime := utils.GetCurrentTimeUTC()
 		timeSinceLastTime := currentTime.Sub(lastTime)
 
 		if timeSinceLastTime >= 1*time.Minute {
-			archiveJob()
+			runJobs()
 		} else {
 			nextMinute := currentTime.Truncate(time.Minute).Add(time.Minute)
 			select {
 			case <-time.After(nextMinute.Sub(currentTime)):
-				archiveJob()
+				runJobs()
 			}
 		}
 	}
@@ -124,7 +163,7 @@ func (m *EventManager) startJobManager() {
 func (m *EventManager) NewEvent(author *UserAccount, createdDate time.Time, startDate time.Time, endDate time.Time,
 	description string, participants []int64) (*Event, error) {
 
-	b := m.newEventBuilder().
+	b := m.NewEventBuilder().
 		SetAuthor(author).
 		SetCreatedDate(createdDate).
 		SetStartDate(startDate).
@@ -251,6 +290,7 @@ func (m *EventManager) ChangeEventPicture(event *Event, picture []byte) error {
 
 	// Emit signal
 	if modified {
+		m.logEventChange(event.Id(), api.EventChangeType_INFO_CHANGED, event.Participants.Ids()...)
 		m.emitEventoInfoChanged(event)
 	}
 
@@ -301,8 +341,21 @@ func (m *EventManager) ChangeParticipantResponse(eventID int64, userID int64, re
 		}
 
 		// Emit signal
+		m.logEventChange(eventID, api.EventChangeType_PARTICIPANT_CHANGED, event.Participants.Ids()...)
 		m.emitParticipantChanged(participant, modifiedParticipant)
 
+		// A spot may have been released for those on the waitlist
+		modifiedEvent := event.Clone()
+		modifiedEvent.Participants.participants[userID] = modifiedParticipant
+		modifiedEvent.Participants.computeAttendance(modifiedEvent.capacity)
+
+		if promoted := modifiedEvent.Participants.promotedFrom(event.Participants); len(promoted) > 0 {
+			m.emitParticipantsPromoted(modifiedEvent, promoted)
+		}
+
+		// Response may be the one the quorum was waiting for
+		m.confirmIfQuorumReached(modifiedEvent)
+
 		return modifiedParticipant, nil
 	}
 
@@ -350,6 +403,7 @@ func (m *EventManager) ChangeDeliveryState(userID int64, state api.InvitationSta
 		}
 
 		// Emit signal
+		m.logEventChange(event.Id(), api.EventChangeType_PARTICIPANT_CHANGED, event.Participants.Ids()...)
 		m.emitParticipantChanged(participant, modifiedParticipant)
 
 		return modifiedParticipant, nil
@@ -408,7 +462,45 @@ func (m *EventManager) GetRecentEvents(userID int64) ([]*Event, error) {
 	return events, nil
 }
 
+// GetRecentAuthoredEvents returns events created by userID that have not been
+// archived yet
+func (m *EventManager) GetRecentAuthoredEvents(userID int64) ([]*Event, error) {
+
+	events, err := m.GetRecentEvents(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	authoredEvents := make([]*Event, 0, len(events))
+	for _, event := range events {
+		if event.AuthorID() == userID {
+			authoredEvents = append(authoredEvents, event)
+		}
+	}
+
+	if len(authoredEvents) == 0 {
+		return nil, ErrEmptyInbox
+	}
+
+	return authoredEvents, nil
+}
+
 func (m *EventManager) GetEventsHistory(userID int64, start time.Time, end time.Time) ([]*Event, error) {
+	return m.getHistoryAux(userID, start, end, m.eventHistoryDAO.FindAllForward,
+		m.eventHistoryDAO.FindAllBackward)
+}
+
+// GetAuthoredEventsHistory returns archived events created by userID. Window
+// semantics are the same as in GetEventsHistory.
+func (m *EventManager) GetAuthoredEventsHistory(userID int64, start time.Time, end time.Time) ([]*Event, error) {
+	return m.getHistoryAux(userID, start, end, m.eventHistoryDAO.FindAuthoredForward,
+		m.eventHistoryDAO.FindAuthoredBackward)
+}
+
+type historyFinder func(userID int64, fromDate time.Time) ([]int64, error)
+
+func (m *EventManager) getHistoryAux(userID int64, start time.Time, end time.Time,
+	findForward historyFinder, findBackward historyFinder) ([]*Event, error) {
 
 	currentTime := utils.GetCurrentTimeUTC().Truncate(time.Second)
 
@@ -426,9 +518,9 @@ func (m *EventManager) GetEventsHistory(userID int64, start time.Time, end time.
 	var err error
 
 	if start.Before(end) {
-		eventIDs, err = m.eventHistoryDAO.FindAllForward(userID, start.Truncate(time.Second))
+		eventIDs, err = findForward(userID, start.Truncate(time.Second))
 	} else {
-		eventIDs, err = m.eventHistoryDAO.FindAllBackward(userID, start.Truncate(time.Second))
+		eventIDs, err = findBackward(userID, start.Truncate(time.Second))
 	}
 
 	if err == api.ErrNoResults {
@@ -541,6 +633,17 @@ func (m *EventManager) emitParticipantChanged(oldParticipant *Participant, newPa
 	})
 }
 
+func (m *EventManager) emitParticipantsPromoted(event *Event, promoted []int64) {
+	m.eventSignal.Update(&Signal{
+		Type: SignalParticipantsPromoted,
+		Data: map[string]interface{}{
+			"EventID":  event.Id(),
+			"Event":    event,
+			"Promoted": promoted,
+		},
+	})
+}
+
 func (m *EventManager) emitEventCancelled(event *Event, cancelledBy int64) {
 	m.eventSignal.Update(&Signal{
 		Type: SignalEventCancelled,
@@ -564,6 +667,61 @@ func (m *EventManager) emitEventParticipantsInvited(event *Event, newParticipant
 	})
 }
 
+func (m *EventManager) emitEventParticipantsRemoved(event *Event, removedParticipants []int64) {
+	m.eventSignal.Update(&Signal{
+		Type: SignalEventParticipantsRemoved,
+		Data: map[string]interface{}{
+			"EventID":             event.Id(),
+			"RemovedParticipants": removedParticipants,
+			"Event":               event,
+		},
+	})
+}
+
+func (m *EventManager) emitEventChangeProposed(event *Event, proposal *ChangeProposal) {
+	m.eventSignal.Update(&Signal{
+		Type: SignalEventChangeProposed,
+		Data: map[string]interface{}{
+			"EventID":  event.Id(),
+			"Event":    event,
+			"Proposal": proposal,
+		},
+	})
+}
+
+func (m *EventManager) emitVotingStatusChanged(event *Event, proposal *ChangeProposal) {
+	m.eventSignal.Update(&Signal{
+		Type: SignalVotingStatusChanged,
+		Data: map[string]interface{}{
+			"EventID":  event.Id(),
+			"Event":    event,
+			"Proposal": proposal,
+		},
+	})
+}
+
+func (m *EventManager) emitVotingFinished(event *Event, proposal *ChangeProposal) {
+	m.eventSignal.Update(&Signal{
+		Type: SignalVotingFinished,
+		Data: map[string]interface{}{
+			"EventID":  event.Id(),
+			"Event":    event,
+			"Proposal": proposal,
+		},
+	})
+}
+
+func (m *EventManager) emitEventReminder(event *Event, reminder *EventReminder) {
+	m.eventSignal.Update(&Signal{
+		Type: SignalEventReminder,
+		Data: map[string]interface{}{
+			"EventID":  event.Id(),
+			"Event":    event,
+			"Reminder": reminder,
+		},
+	})
+}
+
 func (m *EventManager) emitNewEvent(event *Event) {
 	m.eventSignal.Update(&Signal{
 		Type: SignalNewEvent,
@@ -605,6 +763,18 @@ func (m *EventManager) saveNewEvent(event *Event) error {
 		m.userEvents.Insert(pID, event.Id())
 	}
 
+	// Add to public events index
+	m.updatePublicEventIndex(event, nil)
+
+	// Add to participants change log
+	m.logEventChange(event.Id(), api.EventChangeType_INVITED, event.Participants.Ids()...)
+
+	// Schedule reminders before start
+	m.scheduleReminders(event)
+
+	// Wait for quorum
+	m.quorumEvents.update(event)
+
 	// If code failed before reaching this point, a timeline entry
 	// could exist that doesn't point to any event. Moreover, if
 	// 'add to inbox' failed, event would exist only in database
@@ -613,6 +783,14 @@ func (m *EventManager) saveNewEvent(event *Event) error {
 	// Emit signal
 	m.emitNewEvent(event)
 
+	// Start series of a recurring event. Event is already saved, so a failure
+	// here only prevents further occurrences from being generated.
+	if event.recurrence != nil {
+		if err := m.createSeries(event); err != nil {
+			log.Printf("* Create series of event %v error: %v\n", event.Id(), err)
+		}
+	}
+
 	return nil
 }
 
@@ -644,6 +822,44 @@ func (m *EventManager) saveModifiedEvent(event *Event) error {
 	// TODO: If event is immutable should not do this
 	event.isPersisted = true
 
+	// Update public events index
+	m.updatePublicEventIndex(event, oldEvent)
+
+	// Keep waiting for quorum only if it's still pending
+	m.quorumEvents.update(event)
+
+	// Reschedule reminders if start date changed
+	if event.cancelled {
+		m.unscheduleReminders(oldEvent)
+	} else if !event.startDate.Equal(oldEvent.startDate) {
+		m.unscheduleReminders(oldEvent)
+		m.scheduleReminders(event)
+	}
+
+	// Remove cancelled invitations from user's inbox and history
+	removedParticipants := m.ExtractNewParticipants(oldEvent, event)
+
+	for pID := range removedParticipants {
+		m.userEvents.Remove(pID, event.Id())
+		if err := m.eventHistoryDAO.Delete(pID, newTimeLineEntry(oldEvent)); err != nil {
+			log.Printf("* Remove event %v from user %v history error: %v\n", event.Id(), pID, err)
+		}
+	}
+
+	// Proposed changes can't be applied to a cancelled event, and votes must
+	// come from current participants. Accepted proposals don't cancel events
+	// nor change participants, so this isn't reached while finishing a voting.
+	if event.cancelled || len(removedParticipants) > 0 || event.NumGuests() != oldEvent.NumGuests() {
+		m.updateVotingProposal(event)
+	}
+
+	// Emit signal
+	if len(removedParticipants) > 0 {
+		m.logEventChange(event.Id(), api.EventChangeType_REMOVED, ParticipantMapKeys(removedParticipants)...)
+		m.logEventChange(event.Id(), api.EventChangeType_PARTICIPANT_CHANGED, event.Participants.Ids()...)
+		m.emitEventParticipantsRemoved(event, ParticipantMapKeys(removedParticipants))
+	}
+
 	if !event.cancelled {
 
 		newParticipants := m.ExtractNewParticipants(event, oldEvent)
@@ -655,14 +871,28 @@ func (m *EventManager) saveModifiedEvent(event *Event) error {
 
 		// Emit signal
 		if m.isEventInfoChanged(event, oldEvent) {
+			m.logEventChange(event.Id(), api.EventChangeType_INFO_CHANGED, event.Participants.Ids()...)
 			m.emitEventoInfoChanged(event)
 		}
 
+		// Emit signal
+		if event.confirmed && !oldEvent.confirmed {
+			m.logEventChange(event.Id(), api.EventChangeType_INFO_CHANGED, event.Participants.Ids()...)
+			m.emitEventConfirmed(event)
+		}
+
 		// Emit signal
 		if len(newParticipants) > 0 {
+			m.logEventChange(event.Id(), api.EventChangeType_INVITED, ParticipantMapKeys(newParticipants)...)
+			m.logEventChange(event.Id(), api.EventChangeType_PARTICIPANT_CHANGED, oldEvent.Participants.Ids()...)
 			m.emitEventParticipantsInvited(event, ParticipantMapKeys(newParticipants), oldEvent.Participants.Ids())
 		}
 
+		// Removed attendees or a higher capacity release spots
+		if promoted := event.Participants.promotedFrom(oldEvent.Participants); len(promoted) > 0 {
+			m.emitParticipantsPromoted(event, promoted)
+		}
+
 	} else {
 
 		// Do not remove from user's inbox in order to give users a
@@ -672,12 +902,41 @@ func (m *EventManager) saveModifiedEvent(event *Event) error {
 		// add here logic to remove events from inbox.
 
 		// Emit cancelled
+		m.logEventChange(event.Id(), api.EventChangeType_CANCELLED, event.Participants.Ids()...)
 		m.emitEventCancelled(event, event.owner)
 	}
 
+	// Quorum may be reached with the new participants, capacity or quorum.
+	// Confirmed events aren't pending anymore, so saving the confirmation
+	// stops here.
+	m.confirmIfQuorumReached(event)
+
+	// Apply the same changes to the rest of the series
+	if event.applyToSeries {
+		if err := m.applyToSeries(event, oldEvent); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// newTimeLineEntry returns the entry that represents event in the timeline and
+// in the events history
+func newTimeLineEntry(event *Event) *api.TimeLineEntryDTO {
+
+	entryDTO := &api.TimeLineEntryDTO{
+		EventID:  event.Id(),
+		Position: event.EndDate(),
+	}
+
+	if event.IsCancelled() {
+		entryDTO.Position = event.InboxPosition()
+	}
+
+	return entryDTO
+}
+
 // ExtractNewParticipants extracts participants from extractList that are not in baseList
 func (m *EventManager) ExtractNewParticipants(extractEvent *Event, baseEvent *Event) map[int64]*Participant {
 
@@ -700,6 +959,11 @@ func (m *EventManager) isEventInfoChanged(event *Event, oldEvent *Event) bool {
 		event.inboxPosition.Equal(oldEvent.inboxPosition) &&
 		event.description == oldEvent.description &&
 		event.cancelled == oldEvent.cancelled &&
+		event.isPublic == oldEvent.isPublic &&
+		event.location.Equal(oldEvent.location) &&
+		event.capacity == oldEvent.capacity &&
+		event.quorum == oldEvent.quorum &&
+		event.quorumDeadline.Equal(oldEvent.quorumDeadline) &&
 		bytes.Equal(event.pictureDigest, oldEvent.pictureDigest) {
 
 		return false
@@ -782,8 +1046,9 @@ func (m *EventManager) loadActiveEvents() error {
 	err = m.eventDAO.RangeEvents(func(event *api.EventDTO) error {
 
 		// WORKAROUND: Include cancelled events so that clients have a chance
-		// to get the event with the cancelled state. When an API for get last
-		// changes from last time is available, enable this code.
+		// to get the event with the cancelled state. Clients that sync through
+		// GetEventChangesSince do not need it, but older ones still do. Enable
+		// this code when those clients are no longer supported.
 
 		if event.Cancelled {
 			// Time line should not include cancelled events
@@ -799,6 +1064,11 @@ func (m *EventManager) loadActiveEvents() error {
 			m.userEvents.Insert(pID, event.Id)
 		}
 
+		// Keep waiting for quorum
+		if event.Quorum > 0 && !event.Confirmed && !event.Cancelled {
+			m.quorumEvents.add(event.Id)
+		}
+
 		return nil
 
 	}, eventIDs...)
@@ -810,7 +1080,9 @@ func (m *EventManager) loadActiveEvents() error {
 	return nil
 }
 
-func (m *EventManager) archiveEvent(event *api.EventDTO) error {
+// newArchiveEntry returns the entry that represents an archived event in the
+// events history
+func newArchiveEntry(event *api.EventDTO) *api.TimeLineEntryDTO {
 
 	entryDTO := &api.TimeLineEntryDTO{
 		EventID:  event.Id,
@@ -821,6 +1093,13 @@ func (m *EventManager) archiveEvent(event *api.EventDTO) error {
 		entryDTO.Position = utils.MillisToTimeUTC(event.InboxPosition).Truncate(time.Second)
 	}
 
+	return entryDTO
+}
+
+func (m *EventManager) archiveEvent(event *api.EventDTO) error {
+
+	entryDTO := newArchiveEntry(event)
+
 	for pID := range event.Participants {
 
 		// Insert into event history
@@ -829,9 +1108,41 @@ func (m *EventManager) archiveEvent(event *api.EventDTO) error {
 		}
 	}
 
+	// Insert into author history
+	if err := m.eventHistoryDAO.InsertAuthored(event.AuthorId, entryDTO); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// BackfillAuthoredHistory adds to the history of their authors the events that
+// were archived or cancelled before that history existed. Entries already in
+// the history are written again with the same values, so it's safe to run it
+// more than once. Returns how many events were added.
+func (m *EventManager) BackfillAuthoredHistory() (int, error) {
+
+	lastArchiveTime := m.lastArchiveTime
+	count := 0
+
+	err := m.eventDAO.RangeAll(func(event *api.EventDTO) error {
+
+		// Events that haven't been archived yet are added when archived
+		if !event.Cancelled && utils.MillisToTimeUTC(event.EndDate).After(lastArchiveTime) {
+			return nil
+		}
+
+		if err := m.eventHistoryDAO.InsertAuthored(event.AuthorId, newArchiveEntry(event)); err != nil {
+			return err
+		}
+
+		count++
+		return nil
+	})
+
+	return count, err
+}
+
 // Read finished or cancelled events since last time and archives them,
 // i.e. events are move from user's recent events to events history
 func (m *EventManager) archiveFinishedEventsSinceLastCheck() error {
@@ -917,6 +1228,17 @@ func (m *EventManager) deleteAllEvents() error {
 	if err := m.eventDAO.DeleteAll(); err != nil {
 		return err
 	}
+	if err := m.changeLogDAO.DeleteAll(); err != nil {
+		return err
+	}
+	if err := m.seriesDAO.DeleteAll(); err != nil {
+		return err
+	}
+	if err := m.commentDAO.DeleteAll(); err != nil {
+		return err
+	}
 	m.userEvents.Clear()
+	m.activeSeries.series = make(map[int64]*EventSeries)
+	m.quorumEvents.clear()
 	return nil
 }
@@ -5,7 +5,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/d3ce1t/areyouin-server/api"
 	"github.com/d3ce1t/areyouin-server/utils"
 )
 
@@ -14,8 +13,14 @@ type EventModifier interface {
 	SetStartDate(date time.Time) EventModifier
 	SetEndDate(date time.Time) EventModifier
 	SetDescription(desc string) EventModifier
+	SetPublic(public bool) EventModifier
+	SetLocation(location *Location) EventModifier
+	SetCapacity(capacity int) EventModifier
+	SetQuorum(quorum int, deadline time.Time) EventModifier
 	ParticipantAdder() ParticipantAdder
+	RemoveParticipant(userID int64) EventModifier
 	SetCancelled(cancelled bool) EventModifier
+	SetApplyToSeries(applyToSeries bool) EventModifier
 	Build() (*Event, error)
 }
 
@@ -29,6 +34,13 @@ type eventModifier struct {
 	startDate          time.Time
 	endDate            time.Time
 	description        string
+	isPublic           bool
+	location           *Location
+	seriesID           int64
+	capacity           int
+	quorum             int
+	quorumDeadline     time.Time
+	confirmed          bool
 	participantBuilder *participantListCreator
 	eventManager       *EventManager
 	pictureDigest      []byte
@@ -36,17 +48,26 @@ type eventModifier struct {
 	modifiedDate        time.Time
 	cancelled           bool
 	currentParticipants map[int64]*Participant
+	removedParticipants map[int64]bool
 	startDateChanged    bool
 	endDateChanged      bool
+	capacityChanged     bool
+	quorumChanged       bool
+	applyToSeries       bool
 	sourceEvent         *Event
 }
 
 func (m *EventManager) NewEventModifier(event *Event, ownerID int64) EventModifier {
+	return m.newEventModifier(event, ownerID)
+}
+
+func (m *EventManager) newEventModifier(event *Event, ownerID int64) *eventModifier {
 
 	b := &eventModifier{
 		ownerID:             ownerID,
 		modifiedDate:        utils.GetCurrentTimeUTC(),
 		currentParticipants: make(map[int64]*Participant),
+		removedParticipants: make(map[int64]bool),
 		participantBuilder:  m.newParticipantListCreator(),
 		sourceEvent:         event, // event is immutable
 		eventManager:        m,
@@ -62,6 +83,13 @@ func (m *EventManager) NewEventModifier(event *Event, ownerID int64) EventModifi
 		b.description = event.description
 		b.pictureDigest = bytes.Repeat(event.pictureDigest, 1)
 		b.cancelled = event.cancelled
+		b.isPublic = event.isPublic
+		b.location = event.location
+		b.seriesID = event.seriesID
+		b.capacity = event.capacity
+		b.quorum = event.quorum
+		b.quorumDeadline = event.quorumDeadline
+		b.confirmed = event.confirmed
 
 		for k, p := range event.Participants.participants {
 			b.currentParticipants[k] = p.Clone()
@@ -99,15 +127,71 @@ func (b *eventModifier) SetDescription(desc string) EventModifier {
 	return b
 }
 
+func (b *eventModifier) SetPublic(public bool) EventModifier {
+	b.isPublic = public
+	return b
+}
+
+// SetLocation sets where event takes place. A nil location removes it.
+func (b *eventModifier) SetLocation(location *Location) EventModifier {
+	if location != nil {
+		locationCopy := *location
+		b.location = &locationCopy
+	} else {
+		b.location = nil
+	}
+	return b
+}
+
+// SetCapacity sets the max number of attendees. Zero means unlimited.
+// Capacity cannot be lower than current attendees, so that nobody who was
+// told to be in is moved to the waitlist.
+func (b *eventModifier) SetCapacity(capacity int) EventModifier {
+	b.capacity = capacity
+	b.capacityChanged = true
+	return b
+}
+
 func (b *eventModifier) SetCancelled(cancelled bool) EventModifier {
 	b.cancelled = cancelled
 	return b
 }
 
+// SetApplyToSeries makes the modification apply also to later occurrences of
+// the series that haven't started yet, and to occurrences generated later.
+// Otherwise, only this occurrence is modified.
+func (b *eventModifier) SetApplyToSeries(applyToSeries bool) EventModifier {
+	b.applyToSeries = applyToSeries
+	return b
+}
+
 func (b *eventModifier) ParticipantAdder() ParticipantAdder {
 	return b.participantBuilder
 }
 
+// SetQuorum sets the min number of attendees the event needs to take place and
+// the deadline to reach it. Zero quorum and deadline remove it. Quorum cannot
+// be changed once it has been reached.
+func (b *eventModifier) SetQuorum(quorum int, deadline time.Time) EventModifier {
+	b.quorum = quorum
+	b.quorumDeadline = deadline.Truncate(time.Second)
+	b.quorumChanged = true
+	return b
+}
+
+// setConfirmed marks the event as confirmed. Events are only confirmed by the
+// model once their quorum is reached, so this isn't part of EventModifier.
+func (b *eventModifier) setConfirmed() *eventModifier {
+	b.confirmed = true
+	return b
+}
+
+// RemoveParticipant cancels the invitation of an existing participant
+func (b *eventModifier) RemoveParticipant(userID int64) EventModifier {
+	b.removedParticipants[userID] = true
+	return b
+}
+
 func (b *eventModifier) Build() (*Event, error) {
 
 	if err := b.validateData(); err != nil {
@@ -118,22 +202,30 @@ func (b *eventModifier) Build() (*Event, error) {
 	b.participantBuilder.SetTimestamp(timestamp)
 
 	event := &Event{
-		id:            b.eventID,
-		authorID:      b.authorID,
-		authorName:    b.authorName,
-		description:   b.description,
-		createdDate:   b.createdDate,
-		inboxPosition: b.startDate,
-		startDate:     b.startDate,
-		endDate:       b.endDate,
-		pictureDigest: bytes.Repeat(b.pictureDigest, 1),
-		cancelled:     b.cancelled,
-		Participants:  newParticipantList(),
-		owner:         b.ownerID,
-		modifiedDate:  b.modifiedDate.Truncate(time.Second),
-		timestamp:     timestamp,
-		isPersisted:   false,
-		oldEvent:      b.sourceEvent,
+		id:             b.eventID,
+		authorID:       b.authorID,
+		authorName:     b.authorName,
+		description:    b.description,
+		createdDate:    b.createdDate,
+		inboxPosition:  b.startDate,
+		startDate:      b.startDate,
+		endDate:        b.endDate,
+		pictureDigest:  bytes.Repeat(b.pictureDigest, 1),
+		cancelled:      b.cancelled,
+		isPublic:       b.isPublic,
+		location:       b.location,
+		seriesID:       b.seriesID,
+		capacity:       b.capacity,
+		quorum:         b.quorum,
+		quorumDeadline: b.quorumDeadline,
+		confirmed:      b.confirmed,
+		Participants:   newParticipantList(),
+		owner:          b.ownerID,
+		modifiedDate:   b.modifiedDate.Truncate(time.Second),
+		timestamp:      timestamp,
+		isPersisted:    false,
+		oldEvent:       b.sourceEvent,
+		applyToSeries:  b.applyToSeries,
 	}
 
 	// Event is cancelled
@@ -143,6 +235,9 @@ func (b *eventModifier) Build() (*Event, error) {
 
 	// Copy current participants
 	for k, p := range b.currentParticipants {
+		if b.removedParticipants[k] {
+			continue
+		}
 		if b.sourceEvent != nil && !b.sourceEvent.isPersisted {
 			p.nameTS = timestamp
 			p.responseTS = timestamp
@@ -150,9 +245,6 @@ func (b *eventModifier) Build() (*Event, error) {
 		}
 		// Participant is immutable so I can assign the pointer
 		event.Participants.participants[k] = p
-		if p.response == api.AttendanceResponse_ASSIST {
-			event.Participants.numAttendees++
-		}
 	}
 
 	// New participants added
@@ -166,13 +258,11 @@ func (b *eventModifier) Build() (*Event, error) {
 		for k, v := range newParticipants.participants {
 			// Participant is immutable so I can assign the pointer
 			event.Participants.participants[k] = v
-			if v.response == api.AttendanceResponse_ASSIST {
-				event.Participants.numAttendees++
-			}
 		}
 	}
 
 	event.Participants.numGuests = len(event.Participants.participants)
+	event.Participants.computeAttendance(event.capacity)
 
 	return event, nil
 }
@@ -199,6 +289,10 @@ func (b *eventModifier) validateData() error {
 		return ErrEventNotWritable
 	}
 
+	if b.applyToSeries && b.seriesID == 0 {
+		return ErrEventNotRecurring
+	}
+
 	if !IsValidDescription(b.description) {
 		return ErrInvalidDescription
 	}
@@ -211,7 +305,44 @@ func (b *eventModifier) validateData() error {
 		return ErrInvalidEndDate
 	}
 
-	totalParticipants := b.participantBuilder.Len() + len(b.currentParticipants)
+	if b.location != nil && !IsValidLocation(b.location) {
+		return ErrInvalidLocation
+	}
+
+	if b.isPublic && b.location == nil {
+		return ErrLocationRequired
+	}
+
+	if b.capacityChanged && (!IsValidCapacity(b.capacity) ||
+		(b.capacity > 0 && b.sourceEvent != nil && b.capacity < b.sourceEvent.NumAttendees())) {
+		return ErrInvalidCapacity
+	}
+
+	if b.quorumChanged && (b.confirmed ||
+		!IsValidQuorum(b.quorum, b.quorumDeadline, b.startDate, b.modifiedDate.Truncate(time.Second))) {
+		return ErrInvalidQuorum
+	}
+
+	// A pending quorum must be decided before event starts
+	if b.quorum > 0 && !b.confirmed && !IsValidQuorumDeadline(b.quorumDeadline, b.startDate) {
+		return ErrInvalidQuorum
+	}
+
+	if b.quorum > 0 && b.capacity > 0 && b.quorum > b.capacity {
+		return ErrInvalidQuorum
+	}
+
+	for pID := range b.removedParticipants {
+		if _, ok := b.currentParticipants[pID]; !ok {
+			return ErrParticipantNotFound
+		}
+		// Author cannot be removed from its own event
+		if pID == b.authorID {
+			return ErrInvalidParticipant
+		}
+	}
+
+	totalParticipants := b.participantBuilder.Len() + len(b.currentParticipants) - len(b.removedParticipants)
 	if totalParticipants == 0 {
 		return ErrParticipantsRequired
 	}
@@ -0,0 +1,236 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+	"github.com/imkira/go-observer"
+)
+
+// replaceEventDAOStub keeps the last event written by Replace
+type replaceEventDAOStub struct {
+	api.EventDAO
+	replaced *api.EventDTO
+}
+
+func (s *replaceEventDAOStub) Replace(oldEvent *api.EventDTO, newEvent *api.EventDTO) error {
+	s.replaced = newEvent
+	return nil
+}
+
+// historyDAOStub keeps entries deleted from users history
+type historyDAOStub struct {
+	api.EventHistoryDAO
+	deleted map[int64]*api.TimeLineEntryDTO // userID -> entry
+}
+
+func (s *historyDAOStub) Delete(userID int64, event *api.TimeLineEntryDTO) error {
+	s.deleted[userID] = event
+	return nil
+}
+
+type removalTestManager struct {
+	*EventManager
+	eventDAO   *replaceEventDAOStub
+	historyDAO *historyDAOStub
+	changeLog  *changeLogStub
+}
+
+func newRemovalTestManager() *removalTestManager {
+	m := &removalTestManager{
+		eventDAO:   &replaceEventDAOStub{},
+		historyDAO: &historyDAOStub{deleted: make(map[int64]*api.TimeLineEntryDTO)},
+		changeLog:  &changeLogStub{},
+	}
+	m.EventManager = &EventManager{
+		eventDAO:        m.eventDAO,
+		eventHistoryDAO: m.historyDAO,
+		changeLogDAO:    m.changeLog,
+		eventSignal:     observer.NewProperty(nil),
+		userEvents:      newUserEvents(),
+		votingProposals: newVotingProposals(),
+		quorumEvents:    newQuorumEvents(),
+	}
+	return m
+}
+
+// newRemovalTestEvent returns a persisted event of author 1 with capacity for
+// two attendees. Participants 1 and 2 attend, 3 is on the waitlist and 4
+// hasn't answered yet.
+func newRemovalTestEvent(m *removalTestManager) *Event {
+
+	now := utils.GetCurrentTimeUTC()
+	timestamp := microseconds(now.Add(-time.Hour))
+
+	dto := &api.EventDTO{
+		Id:           100,
+		AuthorId:     1,
+		AuthorName:   "Author",
+		Description:  "Dinner with friends on Friday",
+		CreatedDate:  utils.TimeToMillis(now.Add(-time.Hour)),
+		StartDate:    utils.TimeToMillis(now.Add(2 * time.Hour)),
+		EndDate:      utils.TimeToMillis(now.Add(3 * time.Hour)),
+		Timestamp:    timestamp,
+		Capacity:     2,
+		Participants: make(map[int64]*api.ParticipantDTO),
+	}
+
+	responses := []api.AttendanceResponse{
+		api.AttendanceResponse_ASSIST,
+		api.AttendanceResponse_ASSIST,
+		api.AttendanceResponse_ASSIST,
+		api.AttendanceResponse_NO_RESPONSE,
+	}
+
+	for i, response := range responses {
+		pID := int64(i + 1)
+		dto.Participants[pID] = &api.ParticipantDTO{UserID: pID, EventID: dto.Id, Response: response,
+			NameTS: timestamp, ResponseTS: timestamp + pID, StatusTS: timestamp}
+		m.userEvents.Insert(pID, dto.Id)
+	}
+
+	event := newEventFromDTO(dto)
+	event.isPersisted = true
+
+	return event
+}
+
+// emittedSignals returns signals emitted since stream was last read
+func emittedSignals(stream observer.Stream) map[SignalType]*Signal {
+	signals := make(map[SignalType]*Signal)
+	for stream.HasNext() {
+		signal := stream.Next().(*Signal)
+		signals[signal.Type] = signal
+	}
+	return signals
+}
+
+func TestRemoveParticipant(t *testing.T) {
+
+	m := newRemovalTestManager()
+	event := newRemovalTestEvent(m)
+	stream := m.Observe()
+
+	if !event.Participants.IsWaitlisted(3) {
+		t.Fatal("participant 3 should be on the waitlist")
+	}
+
+	modifiedEvent, err := m.NewEventModifier(event, 1).RemoveParticipant(2).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := modifiedEvent.Participants.Get(2); ok || modifiedEvent.NumGuests() != 3 {
+		t.Fatalf("participant not removed (%v guests)", modifiedEvent.NumGuests())
+	}
+
+	if err := m.SaveEvent(modifiedEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	// Participant row is removed when event is replaced
+	if dto := m.eventDAO.replaced; dto == nil || dto.Participants[2] != nil || len(dto.Participants) != 3 {
+		t.Fatal("removed participant still written")
+	}
+
+	if entry := m.historyDAO.deleted[2]; entry == nil || entry.EventID != 100 || !entry.Position.Equal(event.EndDate()) {
+		t.Fatal("event not removed from participant history")
+	}
+
+	if len(m.historyDAO.deleted) != 1 {
+		t.Fatalf("history of %v users changed", len(m.historyDAO.deleted))
+	}
+
+	for _, eventID := range m.userEvents.FindAll(2) {
+		if eventID == 100 {
+			t.Fatal("event still in removed participant inbox")
+		}
+	}
+
+	if len(m.userEvents.FindAll(3)) != 1 {
+		t.Fatal("event removed from remaining participant inbox")
+	}
+
+	var removedLogged bool
+	for _, entry := range m.changeLog.entries {
+		removedLogged = removedLogged || entry.Type == api.EventChangeType_REMOVED
+	}
+
+	if !removedLogged {
+		t.Fatal("removal not logged")
+	}
+
+	signals := emittedSignals(stream)
+
+	removed := signals[SignalEventParticipantsRemoved]
+	if removed == nil {
+		t.Fatal("participants removed signal not emitted")
+	}
+
+	if ids := removed.Data["RemovedParticipants"].([]int64); len(ids) != 1 || ids[0] != 2 {
+		t.Fatalf("unexpected removed participants %v", ids)
+	}
+
+	// Removed attendee releases a spot for the waitlisted one
+	promoted := signals[SignalParticipantsPromoted]
+	if promoted == nil {
+		t.Fatal("participants promoted signal not emitted")
+	}
+
+	if ids := promoted.Data["Promoted"].([]int64); len(ids) != 1 || ids[0] != 3 {
+		t.Fatalf("unexpected promoted participants %v", ids)
+	}
+
+	if modifiedEvent.Participants.IsWaitlisted(3) || modifiedEvent.NumAttendees() != 2 {
+		t.Fatal("waitlisted participant not promoted")
+	}
+}
+
+func TestRemoveParticipantInvalid(t *testing.T) {
+
+	m := newRemovalTestManager()
+	event := newRemovalTestEvent(m)
+
+	tests := []struct {
+		userID int64
+		err    error
+	}{
+		{1, ErrInvalidParticipant},  // Author
+		{5, ErrParticipantNotFound}, // Unknown participant
+	}
+
+	for i, test := range tests {
+		if _, err := m.NewEventModifier(event, 1).RemoveParticipant(test.userID).Build(); err != test.err {
+			t.Fatalf("test %v: expected %v, got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestRemoveParticipantNoPromotion(t *testing.T) {
+
+	m := newRemovalTestManager()
+	event := newRemovalTestEvent(m)
+	stream := m.Observe()
+
+	// Participant who hasn't answered doesn't release any spot
+	modifiedEvent, err := m.NewEventModifier(event, 1).RemoveParticipant(4).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.SaveEvent(modifiedEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	signals := emittedSignals(stream)
+
+	if signals[SignalEventParticipantsRemoved] == nil {
+		t.Fatal("participants removed signal not emitted")
+	}
+
+	if signals[SignalParticipantsPromoted] != nil || !modifiedEvent.Participants.IsWaitlisted(3) {
+		t.Fatal("waitlisted participant promoted")
+	}
+}
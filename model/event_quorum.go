@@ -0,0 +1,153 @@
+package model
+
+import (
+	"log"
+	"sync"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// QuorumEvents keeps the IDs of events whose quorum hasn't been reached yet
+type QuorumEvents struct {
+	mutex  sync.Mutex
+	events map[int64]bool
+}
+
+func newQuorumEvents() *QuorumEvents {
+	return &QuorumEvents{
+		events: make(map[int64]bool),
+	}
+}
+
+// update starts or stops tracking event depending on whether it is still
+// waiting for its quorum
+func (q *QuorumEvents) update(event *Event) {
+
+	defer q.mutex.Unlock()
+	q.mutex.Lock()
+
+	if event.IsQuorumPending() {
+		q.events[event.Id()] = true
+	} else {
+		delete(q.events, event.Id())
+	}
+}
+
+func (q *QuorumEvents) add(eventID int64) {
+	defer q.mutex.Unlock()
+	q.mutex.Lock()
+	q.events[eventID] = true
+}
+
+func (q *QuorumEvents) remove(eventID int64) {
+	defer q.mutex.Unlock()
+	q.mutex.Lock()
+	delete(q.events, eventID)
+}
+
+func (q *QuorumEvents) ids() []int64 {
+
+	defer q.mutex.Unlock()
+	q.mutex.Lock()
+
+	ids := make([]int64, 0, len(q.events))
+	for eventID := range q.events {
+		ids = append(ids, eventID)
+	}
+
+	return ids
+}
+
+func (q *QuorumEvents) clear() {
+	defer q.mutex.Unlock()
+	q.mutex.Lock()
+	q.events = make(map[int64]bool)
+}
+
+// checkPendingQuorums confirms events that have reached their quorum and
+// cancels those whose deadline has passed without reaching it. Events that
+// cannot be saved are checked again on next run.
+func (m *EventManager) checkPendingQuorums() {
+
+	currentTime := utils.GetCurrentTimeUTC()
+
+	for _, eventID := range m.quorumEvents.ids() {
+
+		event, err := m.LoadEvent(eventID)
+		if err == ErrNotFound {
+			m.quorumEvents.remove(eventID)
+			continue
+		} else if err != nil {
+			log.Printf("* Check quorum of event %v error: %v\n", eventID, err)
+			continue
+		}
+
+		// Events that started meanwhile, for instance because server was down,
+		// are left as they are
+		if !event.IsQuorumPending() || event.Status() != api.EventState_NOT_STARTED {
+			m.quorumEvents.remove(eventID)
+			continue
+		}
+
+		if event.NumAttendees() >= event.Quorum() {
+			if err := m.confirmEvent(event); err != nil {
+				log.Printf("* Confirm event %v error: %v\n", eventID, err)
+			}
+		} else if !currentTime.Before(event.QuorumDeadline()) {
+			if err := m.cancelEventWithoutQuorum(event); err != nil {
+				log.Printf("* Cancel event %v without quorum error: %v\n", eventID, err)
+			}
+		}
+	}
+}
+
+// confirmIfQuorumReached confirms event as soon as enough participants attend.
+// Events that cannot be confirmed now are checked again by checkPendingQuorums.
+func (m *EventManager) confirmIfQuorumReached(event *Event) {
+	if event.IsQuorumPending() && event.NumAttendees() >= event.Quorum() {
+		if err := m.confirmEvent(event); err != nil {
+			log.Printf("* Confirm event %v error: %v\n", event.Id(), err)
+		}
+	}
+}
+
+// confirmEvent marks event as confirmed on behalf of its author
+func (m *EventManager) confirmEvent(event *Event) error {
+
+	modifiedEvent, err := m.newEventModifier(event, event.AuthorID()).
+		setConfirmed().
+		Build()
+
+	if err != nil {
+		return err
+	}
+
+	return m.SaveEvent(modifiedEvent)
+}
+
+// cancelEventWithoutQuorum cancels event on behalf of its author
+func (m *EventManager) cancelEventWithoutQuorum(event *Event) error {
+
+	modifiedEvent, err := m.NewEventModifier(event, event.AuthorID()).
+		SetCancelled(true).
+		Build()
+
+	if err != nil {
+		return err
+	}
+
+	modifiedEvent.quorumFailed = true
+
+	return m.SaveEvent(modifiedEvent)
+}
+
+func (m *EventManager) emitEventConfirmed(event *Event) {
+	m.eventSignal.Update(&Signal{
+		Type: SignalEventConfirmed,
+		Data: map[string]interface{}{
+			"EventID": event.Id(),
+			"Event":   event,
+		},
+	})
+}
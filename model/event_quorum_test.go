@@ -0,0 +1,185 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+	"github.com/imkira/go-observer"
+)
+
+func TestIsValidQuorum(t *testing.T) {
+
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	startDate := now.Add(48 * time.Hour)
+
+	tests := []struct {
+		quorum   int
+		deadline time.Time
+		valid    bool
+	}{
+		{0, time.Time{}, true},
+		{0, now.Add(time.Hour), false},
+		{3, time.Time{}, false},
+		{3, now.Add(time.Hour), true},
+		{3, startDate.Add(-quorumDeadlineMinDiff), true},
+		{3, startDate.Add(-quorumDeadlineMinDiff + time.Second), false},
+		{3, startDate, false},
+		{3, startDate.Add(time.Second), false},
+		{3, now, false},
+		{-1, now.Add(time.Hour), false},
+		{eventMaxCapacity + 1, now.Add(time.Hour), false},
+	}
+
+	for i, test := range tests {
+		if valid := IsValidQuorum(test.quorum, test.deadline, startDate, now); valid != test.valid {
+			t.Fatalf("test %v: expected %v, got %v", i, test.valid, valid)
+		}
+	}
+}
+
+func TestIsValidQuorumDeadline(t *testing.T) {
+
+	startDate := time.Date(2024, 1, 12, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		deadline time.Time
+		valid    bool
+	}{
+		{startDate.Add(-24 * time.Hour), true},
+		{startDate.Add(-quorumDeadlineMinDiff), true},
+		{startDate.Add(-time.Minute), false},
+		{startDate, false},
+		{startDate.Add(time.Hour), false},
+	}
+
+	for i, test := range tests {
+		if valid := IsValidQuorumDeadline(test.deadline, startDate); valid != test.valid {
+			t.Fatalf("test %v: expected %v, got %v", i, test.valid, valid)
+		}
+	}
+}
+
+func TestQuorumEvents_Update(t *testing.T) {
+
+	quorumEvents := newQuorumEvents()
+
+	pending := &Event{id: 1, quorum: 3}
+	confirmed := &Event{id: 2, quorum: 3, confirmed: true}
+	cancelled := &Event{id: 3, quorum: 3, cancelled: true}
+	noQuorum := &Event{id: 4}
+
+	for _, event := range []*Event{pending, confirmed, cancelled, noQuorum} {
+		quorumEvents.update(event)
+	}
+
+	if ids := quorumEvents.ids(); len(ids) != 1 || ids[0] != pending.id {
+		t.Fatalf("expected only event %v to be pending, got %v", pending.id, ids)
+	}
+
+	// Event reaches quorum
+	pending.confirmed = true
+	quorumEvents.update(pending)
+
+	if ids := quorumEvents.ids(); len(ids) != 0 {
+		t.Fatalf("expected no pending events, got %v", ids)
+	}
+}
+
+// quorumEventDAOStub stores a single event
+type quorumEventDAOStub struct {
+	api.EventDAO
+	event *api.EventDTO
+}
+
+func (s *quorumEventDAOStub) LoadEvents(ids ...int64) ([]*api.EventDTO, error) {
+	return []*api.EventDTO{s.event}, nil
+}
+
+func (s *quorumEventDAOStub) InsertParticipant(participant *api.ParticipantDTO) error {
+	s.event.Participants[participant.UserID] = participant
+	return nil
+}
+
+func (s *quorumEventDAOStub) Replace(oldEvent *api.EventDTO, newEvent *api.EventDTO) error {
+	s.event = newEvent
+	return nil
+}
+
+// newQuorumTestManager returns a manager with an event of author 1 that needs
+// two attendees. Only its author attends yet.
+func newQuorumTestManager() (*EventManager, *quorumEventDAOStub) {
+
+	now := utils.GetCurrentTimeUTC()
+	timestamp := microseconds(now.Add(-time.Hour))
+
+	dto := &api.EventDTO{
+		Id:             100,
+		AuthorId:       1,
+		AuthorName:     "Author",
+		Description:    "Dinner with friends on Friday",
+		CreatedDate:    utils.TimeToMillis(now.Add(-time.Hour)),
+		StartDate:      utils.TimeToMillis(now.Add(3 * time.Hour)),
+		EndDate:        utils.TimeToMillis(now.Add(4 * time.Hour)),
+		Timestamp:      timestamp,
+		Quorum:         2,
+		QuorumDeadline: utils.TimeToMillis(now.Add(time.Hour)),
+		Participants:   make(map[int64]*api.ParticipantDTO),
+	}
+
+	for _, pID := range []int64{1, 2, 3} {
+		dto.Participants[pID] = &api.ParticipantDTO{UserID: pID, EventID: dto.Id, Name: "Participant",
+			NameTS: timestamp, ResponseTS: timestamp, StatusTS: timestamp}
+	}
+	dto.Participants[1].Response = api.AttendanceResponse_ASSIST
+
+	eventDAO := &quorumEventDAOStub{event: dto}
+	manager := &EventManager{
+		eventDAO:        eventDAO,
+		changeLogDAO:    &changeLogStub{},
+		eventSignal:     observer.NewProperty(nil),
+		userEvents:      newUserEvents(),
+		votingProposals: newVotingProposals(),
+		quorumEvents:    newQuorumEvents(),
+	}
+	manager.quorumEvents.add(dto.Id)
+
+	return manager, eventDAO
+}
+
+func TestConfirmEventOnResponse(t *testing.T) {
+
+	manager, eventDAO := newQuorumTestManager()
+	stream := manager.Observe()
+
+	// Quorum not reached yet
+	if _, err := manager.ChangeParticipantResponse(100, 2, api.AttendanceResponse_MAYBE); err != nil {
+		t.Fatal(err)
+	}
+
+	if eventDAO.event.Confirmed || emittedSignals(stream)[SignalEventConfirmed] != nil {
+		t.Fatal("event confirmed without quorum")
+	}
+
+	// Response reaches the quorum
+	if _, err := manager.ChangeParticipantResponse(100, 3, api.AttendanceResponse_ASSIST); err != nil {
+		t.Fatal(err)
+	}
+
+	if !eventDAO.event.Confirmed {
+		t.Fatal("event not confirmed")
+	}
+
+	if eventDAO.event.Participants[3].Response != api.AttendanceResponse_ASSIST {
+		t.Fatal("response lost on confirmation")
+	}
+
+	if emittedSignals(stream)[SignalEventConfirmed] == nil {
+		t.Fatal("event confirmed signal not emitted")
+	}
+
+	if len(manager.quorumEvents.ids()) != 0 {
+		t.Fatal("confirmed event still waiting for quorum")
+	}
+}
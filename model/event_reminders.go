@@ -0,0 +1,219 @@
+package model
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// Reminders due longer ago than this, for instance because server was down,
+// are discarded instead of sent late.
+const reminderMaxDelay = 15 * time.Minute
+
+// Offsets before event start used when none are configured
+var (
+	defaultReminderOffsets = []time.Duration{24 * time.Hour, 1 * time.Hour}
+	defaultNudgeOffsets    = []time.Duration{24 * time.Hour}
+)
+
+// EventReminder is a reminder of an event sent some time before it starts.
+// Reminders go to participants who will or may attend, and nudges to those
+// who haven't answered yet.
+type EventReminder struct {
+	eventID      int64
+	reminderType api.ReminderType
+	offset       time.Duration
+	dueDate      time.Time
+	startDate    time.Time
+}
+
+func newEventReminderFromDTO(dto *api.EventReminderDTO) *EventReminder {
+	return &EventReminder{
+		eventID:      dto.EventID,
+		reminderType: dto.Type,
+		offset:       time.Duration(dto.Offset) * time.Millisecond,
+		dueDate:      utils.MillisToTimeUTC(dto.DueDate),
+		startDate:    utils.MillisToTimeUTC(dto.StartDate),
+	}
+}
+
+func (r *EventReminder) EventID() int64 {
+	return r.eventID
+}
+
+func (r *EventReminder) Type() api.ReminderType {
+	return r.reminderType
+}
+
+// Offset is the time before event start the reminder is sent at
+func (r *EventReminder) Offset() time.Duration {
+	return r.offset
+}
+
+func (r *EventReminder) DueDate() time.Time {
+	return r.dueDate
+}
+
+// IsRecipient returns true if participant should receive the reminder
+func (r *EventReminder) IsRecipient(participant *Participant) bool {
+	switch r.reminderType {
+	case api.ReminderType_REMINDER:
+		return participant.Response() == api.AttendanceResponse_ASSIST ||
+			participant.Response() == api.AttendanceResponse_MAYBE
+	case api.ReminderType_NUDGE:
+		return participant.Response() == api.AttendanceResponse_NO_RESPONSE
+	}
+	return false
+}
+
+func (r *EventReminder) AsDTO() *api.EventReminderDTO {
+	return &api.EventReminderDTO{
+		EventID:   r.eventID,
+		Type:      r.reminderType,
+		Offset:    int64(r.offset / time.Millisecond),
+		DueDate:   utils.TimeToMillis(r.dueDate),
+		StartDate: utils.TimeToMillis(r.startDate),
+	}
+}
+
+// ReminderOffsets holds how long before event start reminders and nudges are
+// sent.
+type ReminderOffsets struct {
+	Reminders []time.Duration
+	Nudges    []time.Duration
+}
+
+func newDefaultReminderOffsets() *ReminderOffsets {
+	return &ReminderOffsets{
+		Reminders: defaultReminderOffsets,
+		Nudges:    defaultNudgeOffsets,
+	}
+}
+
+// remindersFor returns reminders of event that are due after now, sorted by
+// due date.
+func (o *ReminderOffsets) remindersFor(event *Event, now time.Time) []*EventReminder {
+
+	var reminders []*EventReminder
+
+	add := func(reminderType api.ReminderType, offsets []time.Duration) {
+		for _, offset := range offsets {
+			dueDate := event.StartDate().Add(-offset)
+			if !dueDate.After(now) {
+				continue
+			}
+			reminders = append(reminders, &EventReminder{
+				eventID:      event.Id(),
+				reminderType: reminderType,
+				offset:       offset,
+				dueDate:      dueDate,
+				startDate:    event.StartDate(),
+			})
+		}
+	}
+
+	add(api.ReminderType_REMINDER, o.Reminders)
+	add(api.ReminderType_NUDGE, o.Nudges)
+
+	sort.Slice(reminders, func(i, j int) bool {
+		return reminders[i].dueDate.Before(reminders[j].dueDate)
+	})
+
+	return reminders
+}
+
+// Offsets must be positive and not greater than the farthest start date an
+// event may have
+func isValidReminderOffset(offset time.Duration) bool {
+	return offset > 0 && offset <= startDateMaxDiff
+}
+
+// SetReminderOffsets changes how long before event start reminders and nudges
+// are sent. Invalid offsets are ignored. It only affects events saved from now
+// on, and must be called before background tasks are started.
+func (m *EventManager) SetReminderOffsets(reminders []time.Duration, nudges []time.Duration) {
+
+	filter := func(offsets []time.Duration) []time.Duration {
+		valid := make([]time.Duration, 0, len(offsets))
+		for _, offset := range offsets {
+			if isValidReminderOffset(offset) {
+				valid = append(valid, offset)
+			} else {
+				log.Printf("* Ignored invalid reminder offset %v\n", offset)
+			}
+		}
+		return valid
+	}
+
+	m.reminderOffsets = &ReminderOffsets{
+		Reminders: filter(reminders),
+		Nudges:    filter(nudges),
+	}
+}
+
+// scheduleReminders stores reminders of event. Errors are only logged so that
+// saving an event doesn't fail because of them.
+func (m *EventManager) scheduleReminders(event *Event) {
+	for _, reminder := range m.reminderOffsets.remindersFor(event, utils.GetCurrentTimeUTC()) {
+		if err := m.reminderDAO.Insert(reminder.AsDTO()); err != nil {
+			log.Printf("* Schedule reminder of event %v error: %v\n", event.Id(), err)
+		}
+	}
+}
+
+// unscheduleReminders removes pending reminders of event. Reminders scheduled
+// with other offsets are discarded when they're due because their start date
+// doesn't match the event one.
+func (m *EventManager) unscheduleReminders(event *Event) {
+	for _, reminder := range m.reminderOffsets.remindersFor(event, utils.GetCurrentTimeUTC()) {
+		if err := m.reminderDAO.Delete(reminder.AsDTO()); err != nil {
+			log.Printf("* Unschedule reminder of event %v error: %v\n", event.Id(), err)
+		}
+	}
+}
+
+// sendDueReminders emits a signal for each reminder that is due. Reminders are
+// removed once processed, so they survive restarts until they're sent.
+func (m *EventManager) sendDueReminders() {
+
+	currentTime := utils.GetCurrentTimeUTC()
+
+	remindersDTO, err := m.reminderDAO.FindDue(utils.TimeToMillis(currentTime.Add(-reminderMaxDelay)),
+		utils.TimeToMillis(currentTime))
+
+	if err == api.ErrNoResults {
+		return
+	} else if err != nil {
+		log.Printf("* Find due reminders error: %v\n", err)
+		return
+	}
+
+	for _, dto := range remindersDTO {
+
+		reminder := newEventReminderFromDTO(dto)
+
+		event, err := m.LoadEvent(reminder.eventID)
+		if err != nil && err != ErrNotFound {
+			// Retry later
+			log.Printf("* Reminder of event %v cannot be sent: %v\n", reminder.eventID, err)
+			continue
+		}
+
+		if err := m.reminderDAO.Delete(dto); err != nil {
+			log.Printf("* Remove reminder of event %v error: %v\n", reminder.eventID, err)
+			continue
+		}
+
+		// Skip reminders of events that were removed, cancelled, have started
+		// or were rescheduled.
+		if event == nil || event.Status() != api.EventState_NOT_STARTED ||
+			!event.StartDate().Equal(reminder.startDate) {
+			continue
+		}
+
+		m.emitEventReminder(event, reminder)
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+func TestReminderOffsets_RemindersFor(t *testing.T) {
+
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	event := &Event{id: 1, startDate: now.Add(2 * time.Hour)}
+
+	offsets := &ReminderOffsets{
+		Reminders: []time.Duration{24 * time.Hour, 1 * time.Hour, 30 * time.Minute},
+		Nudges:    []time.Duration{90 * time.Minute},
+	}
+
+	reminders := offsets.remindersFor(event, now)
+
+	// One day before start is already in the past
+	expected := []struct {
+		reminderType api.ReminderType
+		offset       time.Duration
+	}{
+		{api.ReminderType_NUDGE, 90 * time.Minute},
+		{api.ReminderType_REMINDER, 1 * time.Hour},
+		{api.ReminderType_REMINDER, 30 * time.Minute},
+	}
+
+	if len(reminders) != len(expected) {
+		t.Fatalf("expected %v reminders, got %v", len(expected), len(reminders))
+	}
+
+	for i, reminder := range reminders {
+		if reminder.Type() != expected[i].reminderType || reminder.Offset() != expected[i].offset ||
+			!reminder.DueDate().Equal(event.StartDate().Add(-expected[i].offset)) {
+			t.Fatalf("reminder %v: unexpected %+v", i, reminder)
+		}
+		if dto := reminder.AsDTO(); *newEventReminderFromDTO(dto) != *reminder {
+			t.Fatalf("reminder %v: DTO conversion mismatch", i)
+		}
+	}
+}
+
+func TestEventReminder_IsRecipient(t *testing.T) {
+
+	reminder := &EventReminder{reminderType: api.ReminderType_REMINDER}
+	nudge := &EventReminder{reminderType: api.ReminderType_NUDGE}
+
+	tests := []struct {
+		response api.AttendanceResponse
+		reminder bool
+		nudge    bool
+	}{
+		{api.AttendanceResponse_ASSIST, true, false},
+		{api.AttendanceResponse_MAYBE, true, false},
+		{api.AttendanceResponse_NO_ASSIST, false, false},
+		{api.AttendanceResponse_NO_RESPONSE, false, true},
+	}
+
+	for i, test := range tests {
+		participant := NewParticipant(1, "Ana", test.response, api.InvitationStatus_SERVER_DELIVERED)
+		if reminder.IsRecipient(participant) != test.reminder || nudge.IsRecipient(participant) != test.nudge {
+			t.Fatalf("test %v: unexpected recipients", i)
+		}
+	}
+}
+
+func TestIsValidReminderOffset(t *testing.T) {
+	for offset, want := range map[time.Duration]bool{
+		0:                    false,
+		-time.Hour:           false,
+		time.Hour:            true,
+		startDateMaxDiff:     true,
+		startDateMaxDiff + 1: false,
+	} {
+		if isValidReminderOffset(offset) != want {
+			t.Fatalf("offset %v: expected %v", offset, want)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+package model
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// Occurrences of a recurring event are generated this long before they start
+const seriesGenerationWindow = 30 * 24 * time.Hour
+
+// EventSeries is a recurring event. Occurrences are regular events generated
+// ahead of time from a template, which is the first occurrence with the
+// modifications applied later to the whole series.
+type EventSeries struct {
+	id           int64
+	authorID     int64
+	description  string
+	isPublic     bool
+	location     *Location
+	participants []int64 // Author not included
+	capacity     int
+	startDate    time.Time
+	duration     time.Duration
+	recurrence   *Recurrence
+	generated    int
+	skipped      int // Generated but not created
+	finished     bool
+}
+
+func newEventSeriesFromDTO(dto *api.EventSeriesDTO) *EventSeries {
+
+	series := &EventSeries{
+		id:           dto.ID,
+		authorID:     dto.AuthorID,
+		description:  dto.Description,
+		isPublic:     dto.IsPublic,
+		location:     newLocationFromDTO(dto.Location),
+		participants: dto.Participants,
+		capacity:     int(dto.Capacity),
+		startDate:    utils.MillisToTimeUTC(dto.StartDate),
+		duration:     time.Duration(dto.Duration) * time.Millisecond,
+		recurrence: &Recurrence{
+			Frequency: dto.Frequency,
+			Interval:  int(dto.Interval),
+			Count:     int(dto.Count),
+			TimeZone:  dto.TimeZone,
+		},
+		generated: int(dto.Generated),
+		skipped:   int(dto.Skipped),
+		finished:  dto.Finished,
+	}
+
+	if dto.Until != 0 {
+		series.recurrence.Until = utils.MillisToTimeUTC(dto.Until)
+	}
+
+	return series
+}
+
+// newEventSeries creates a series whose first occurrence is event
+func newEventSeries(event *Event) *EventSeries {
+
+	series := &EventSeries{
+		id:          event.Id(),
+		authorID:    event.AuthorID(),
+		description: event.Description(),
+		isPublic:    event.IsPublic(),
+		location:    event.Location(),
+		capacity:    event.Capacity(),
+		startDate:   event.StartDate(),
+		duration:    event.EndDate().Sub(event.StartDate()),
+		recurrence:  event.recurrence,
+		generated:   1,
+	}
+
+	for _, pID := range event.Participants.Ids() {
+		if pID != event.AuthorID() {
+			series.participants = append(series.participants, pID)
+		}
+	}
+
+	series.finished = !series.recurrence.HasOccurrence(series.startDate, series.generated)
+
+	return series
+}
+
+func (s *EventSeries) Id() int64 {
+	return s.id
+}
+
+func (s *EventSeries) Recurrence() *Recurrence {
+	recurrenceCopy := *s.recurrence
+	return &recurrenceCopy
+}
+
+// nextStartDate returns start date of the next occurrence to be generated
+func (s *EventSeries) nextStartDate() time.Time {
+	return s.recurrence.Occurrence(s.startDate, s.generated)
+}
+
+func (s *EventSeries) AsDTO() *api.EventSeriesDTO {
+
+	dto := &api.EventSeriesDTO{
+		ID:           s.id,
+		AuthorID:     s.authorID,
+		Description:  s.description,
+		IsPublic:     s.isPublic,
+		Location:     s.location.AsDTO(),
+		Participants: s.participants,
+		Capacity:     int32(s.capacity),
+		StartDate:    utils.TimeToMillis(s.startDate),
+		Duration:     int64(s.duration / time.Millisecond),
+		Frequency:    s.recurrence.Frequency,
+		Interval:     int32(s.recurrence.Interval),
+		Count:        int32(s.recurrence.Count),
+		TimeZone:     s.recurrence.TimeZone,
+		Generated:    int32(s.generated),
+		Skipped:      int32(s.skipped),
+		Finished:     s.finished,
+	}
+
+	if !s.recurrence.Until.IsZero() {
+		dto.Until = utils.TimeToMillis(s.recurrence.Until)
+	}
+
+	return dto
+}
+
+// ActiveSeries keeps recurring events that still have occurrences to be
+// generated
+type ActiveSeries struct {
+	mutex  sync.Mutex
+	series map[int64]*EventSeries // seriesID -> series
+}
+
+func newActiveSeries() *ActiveSeries {
+	return &ActiveSeries{
+		series: make(map[int64]*EventSeries),
+	}
+}
+
+func (m *EventManager) loadActiveSeries() error {
+
+	seriesDTO, err := m.seriesDAO.LoadAllActive()
+	if err != nil {
+		return err
+	}
+
+	for _, dto := range seriesDTO {
+		m.activeSeries.series[dto.ID] = newEventSeriesFromDTO(dto)
+	}
+
+	return nil
+}
+
+// createSeries starts the series of a new recurring event and generates the
+// occurrences that fall into the generation window
+func (m *EventManager) createSeries(event *Event) error {
+
+	defer m.activeSeries.mutex.Unlock()
+	m.activeSeries.mutex.Lock()
+
+	series := newEventSeries(event)
+
+	if err := m.seriesDAO.Insert(series.AsDTO()); err != nil {
+		return err
+	}
+
+	if err := m.seriesDAO.InsertOccurrence(series.id, event.Id()); err != nil {
+		return err
+	}
+
+	if !series.finished {
+		m.activeSeries.series[series.id] = series
+		m.generateOccurrences(series, utils.GetCurrentTimeUTC())
+	}
+
+	return nil
+}
+
+// generatePendingOccurrences generates occurrences of every active series
+// that have entered the generation window
+func (m *EventManager) generatePendingOccurrences() {
+
+	defer m.activeSeries.mutex.Unlock()
+	m.activeSeries.mutex.Lock()
+
+	currentTime := utils.GetCurrentTimeUTC()
+
+	for _, series := range m.activeSeries.series {
+		m.generateOccurrences(series, currentTime)
+	}
+}
+
+// generateOccurrences creates occurrences of series that start before the
+// end of the generation window. Occurrences that cannot be created, for
+// instance because server was down and its start date isn't valid anymore,
+// are skipped and counted in the series. Must be called with activeSeries
+// mutex held.
+func (m *EventManager) generateOccurrences(series *EventSeries, currentTime time.Time) {
+
+	windowEnd := currentTime.Add(seriesGenerationWindow)
+	initialGenerated := series.generated
+	initialSkipped := series.skipped
+
+	if series.finished || series.nextStartDate().After(windowEnd) {
+		return
+	}
+
+	author, err := m.parent.Accounts.GetUserAccount(series.authorID)
+	if err != nil {
+		// Retry on next run
+		log.Printf("* Generate occurrences of series %v error: %v\n", series.id, err)
+		return
+	}
+
+	for !series.finished && !series.nextStartDate().After(windowEnd) {
+
+		startDate := series.nextStartDate()
+
+		if !IsValidStartDate(startDate, currentTime) {
+			log.Printf("* Skipped occurrence of series %v at %v: %v\n", series.id, startDate, ErrInvalidStartDate)
+			series.skipped++
+		} else if event, err := m.buildOccurrence(series, author, startDate); err != nil {
+			log.Printf("* Skipped occurrence of series %v at %v: %v\n", series.id, startDate, err)
+			series.skipped++
+		} else if err := m.saveOccurrence(series, event); err != nil {
+			// Retry on next run
+			log.Printf("* Generate occurrence of series %v error: %v\n", series.id, err)
+			break
+		}
+
+		series.generated++
+		series.finished = !series.recurrence.HasOccurrence(series.startDate, series.generated)
+	}
+
+	if series.generated == initialGenerated {
+		return
+	}
+
+	if series.skipped > initialSkipped {
+		log.Printf("* Series %v skipped %v of %v occurrences (%v of %v so far)\n", series.id,
+			series.skipped-initialSkipped, series.generated-initialGenerated, series.skipped, series.generated)
+	}
+
+	if err := m.seriesDAO.Insert(series.AsDTO()); err != nil {
+		log.Printf("* Save series %v error: %v\n", series.id, err)
+	}
+
+	if series.finished {
+		delete(m.activeSeries.series, series.id)
+	}
+}
+
+// buildOccurrence creates an occurrence of series from its template
+func (m *EventManager) buildOccurrence(series *EventSeries, author *UserAccount, startDate time.Time) (*Event, error) {
+
+	b := m.NewEventBuilder().
+		SetAuthor(author).
+		SetStartDate(startDate).
+		SetEndDate(startDate.Add(series.duration)).
+		SetDescription(series.description).
+		SetPublic(series.isPublic).
+		SetLocation(series.location).
+		SetCapacity(series.capacity)
+
+	for _, pID := range series.participants {
+		b.ParticipantAdder().AddUserID(pID)
+	}
+
+	b.(*eventBuilder).seriesID = series.id
+
+	return b.Build()
+}
+
+// saveOccurrence persists an occurrence of series. Occurrence is linked to the
+// series first, so that an occurrence is never left out of series edits.
+func (m *EventManager) saveOccurrence(series *EventSeries, event *Event) error {
+
+	if err := m.seriesDAO.InsertOccurrence(series.id, event.Id()); err != nil {
+		return err
+	}
+
+	return m.saveNewEvent(event)
+}
+
+// applyToSeries applies the changes made to event, an occurrence of a series,
+// to the later occurrences that haven't started yet and to the template of
+// the series. Start date is moved as much as event start date was moved.
+// Occurrences that cannot be modified, for instance because their new start
+// date would be too far away, are left unchanged.
+func (m *EventManager) applyToSeries(event *Event, oldEvent *Event) error {
+
+	defer m.activeSeries.mutex.Unlock()
+	m.activeSeries.mutex.Lock()
+
+	series, ok := m.activeSeries.series[event.seriesID]
+	if !ok {
+		seriesDTO, err := m.seriesDAO.Load(event.seriesID)
+		if err != nil {
+			return err
+		}
+		series = newEventSeriesFromDTO(seriesDTO)
+	}
+
+	startDelta := event.StartDate().Sub(oldEvent.StartDate())
+	duration := event.EndDate().Sub(event.StartDate())
+	newParticipants := m.ExtractNewParticipants(event, oldEvent)
+	removedParticipants := m.ExtractNewParticipants(oldEvent, event)
+
+	// Update template
+	series.description = event.Description()
+	series.isPublic = event.IsPublic()
+	series.location = event.Location()
+	series.capacity = event.Capacity()
+	series.startDate = series.startDate.Add(startDelta)
+	series.duration = duration
+
+	participants := make([]int64, 0, len(series.participants)+len(newParticipants))
+	for _, pID := range series.participants {
+		if _, ok := removedParticipants[pID]; !ok {
+			participants = append(participants, pID)
+		}
+	}
+	for pID := range newParticipants {
+		participants = append(participants, pID)
+	}
+	series.participants = participants
+
+	if event.IsCancelled() {
+		series.finished = true
+		delete(m.activeSeries.series, series.id)
+	}
+
+	if err := m.seriesDAO.Insert(series.AsDTO()); err != nil {
+		return err
+	}
+
+	// Update occurrences
+	occurrences, err := m.seriesDAO.FindOccurrences(series.id)
+	if err != nil && err != api.ErrNoResults {
+		return err
+	}
+
+	for _, occurrenceID := range occurrences {
+
+		if occurrenceID == event.Id() {
+			continue
+		}
+
+		occurrence, err := m.LoadEvent(occurrenceID)
+		if err == ErrNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if occurrence.Status() != api.EventState_NOT_STARTED ||
+			occurrence.StartDate().Before(oldEvent.StartDate()) {
+			continue
+		}
+
+		modifier := m.NewEventModifier(occurrence, event.owner).
+			SetDescription(event.Description()).
+			SetPublic(event.IsPublic()).
+			SetLocation(event.Location()).
+			SetCancelled(event.IsCancelled())
+
+		if startDelta != 0 {
+			modifier.SetStartDate(occurrence.StartDate().Add(startDelta))
+		}
+
+		// Occurrences with more attendees than the new capacity keep theirs
+		if event.Capacity() != occurrence.Capacity() &&
+			(event.Capacity() == 0 || event.Capacity() >= occurrence.NumAttendees()) {
+			modifier.SetCapacity(event.Capacity())
+		}
+
+		if startDelta != 0 || duration != occurrence.EndDate().Sub(occurrence.StartDate()) {
+			modifier.SetEndDate(occurrence.StartDate().Add(startDelta).Add(duration))
+		}
+
+		for pID := range newParticipants {
+			if _, ok := occurrence.Participants.Get(pID); !ok {
+				modifier.ParticipantAdder().AddUserID(pID)
+			}
+		}
+
+		for pID := range removedParticipants {
+			if _, ok := occurrence.Participants.Get(pID); ok {
+				modifier.RemoveParticipant(pID)
+			}
+		}
+
+		modifiedOccurrence, err := modifier.Build()
+		if err != nil {
+			log.Printf("* Apply changes to occurrence %v of series %v error: %v\n", occurrenceID, series.id, err)
+			continue
+		}
+
+		if !m.isEventInfoChanged(modifiedOccurrence, occurrence) &&
+			modifiedOccurrence.NumGuests() == occurrence.NumGuests() &&
+			len(m.ExtractNewParticipants(modifiedOccurrence, occurrence)) == 0 {
+			continue
+		}
+
+		if err := m.saveModifiedEvent(modifiedOccurrence); err != nil {
+			log.Printf("* Save occurrence %v of series %v error: %v\n", occurrenceID, series.id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+type seriesDAOStub struct {
+	api.EventSeriesDAO
+	saved *api.EventSeriesDTO
+}
+
+func (s *seriesDAOStub) Insert(series *api.EventSeriesDTO) error {
+	s.saved = series
+	return nil
+}
+
+type authorDAOStub struct {
+	api.UserDAO
+	err error
+}
+
+func (s *authorDAOStub) Load(userID int64) (*api.UserDTO, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &api.UserDTO{Id: userID, Name: "Author"}, nil
+}
+
+func newSeriesTestManager(authorErr error) (*EventManager, *seriesDAOStub) {
+	seriesDAO := &seriesDAOStub{}
+	manager := &EventManager{
+		parent: &AyiModel{
+			Accounts: &AccountManager{userDAO: &authorDAOStub{err: authorErr}},
+		},
+		seriesDAO:    seriesDAO,
+		activeSeries: newActiveSeries(),
+	}
+	return manager, seriesDAO
+}
+
+// newPastSeries returns a weekly series of count occurrences whose first
+// one started three weeks ago
+func newPastSeries(count int) *EventSeries {
+	return &EventSeries{
+		id:         100,
+		authorID:   1,
+		startDate:  time.Now().UTC().Add(-21 * 24 * time.Hour),
+		duration:   time.Hour,
+		recurrence: &Recurrence{Frequency: api.RecurrenceFrequency_WEEKLY, Interval: 1, Count: count},
+		generated:  1,
+	}
+}
+
+func TestGenerateOccurrencesSkipped(t *testing.T) {
+
+	manager, seriesDAO := newSeriesTestManager(nil)
+	series := newPastSeries(3)
+	manager.activeSeries.series[series.id] = series
+
+	manager.generateOccurrences(series, time.Now().UTC())
+
+	if series.generated != 3 || series.skipped != 2 || !series.finished {
+		t.Fatalf("expected 2 of 3 occurrences skipped, got %v of %v", series.skipped, series.generated)
+	}
+
+	if dto := seriesDAO.saved; dto == nil || dto.Skipped != 2 || !dto.Finished {
+		t.Fatal("skipped occurrences not persisted")
+	}
+
+	if _, ok := manager.activeSeries.series[series.id]; ok {
+		t.Fatal("finished series still active")
+	}
+}
+
+func TestGenerateOccurrencesAuthorError(t *testing.T) {
+
+	manager, seriesDAO := newSeriesTestManager(errors.New("connection lost"))
+	series := newPastSeries(3)
+	manager.activeSeries.series[series.id] = series
+
+	manager.generateOccurrences(series, time.Now().UTC())
+
+	// Nothing is skipped, occurrences are generated on next run
+	if series.generated != 1 || series.skipped != 0 || series.finished || seriesDAO.saved != nil {
+		t.Fatalf("unexpected series state (%v generated, %v skipped)", series.generated, series.skipped)
+	}
+}
@@ -0,0 +1,370 @@
+package model
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// VotingProposals keeps change proposals whose voting is in progress. There is
+// at most one proposal in voting state per event.
+type VotingProposals struct {
+	mutex     sync.Mutex
+	proposals map[int64]*ChangeProposal // eventID -> proposal
+}
+
+func newVotingProposals() *VotingProposals {
+	return &VotingProposals{
+		proposals: make(map[int64]*ChangeProposal),
+	}
+}
+
+func (m *EventManager) loadVotingProposals() error {
+
+	proposalsDTO, err := m.proposalDAO.LoadAllVoting()
+	if err != nil {
+		return err
+	}
+
+	for _, dto := range proposalsDTO {
+		m.votingProposals.proposals[dto.EventID] = newChangeProposalFromDTO(dto)
+	}
+
+	return nil
+}
+
+// ProposeChange starts a voting so that participants of an event can decide if
+// the proposed start date, end date or description is applied. An empty
+// description and zero dates mean that those fields are not changed. If only
+// one of the dates is provided, the other one is kept from the event.
+//
+// Assumptions:
+// - (1) User who performs this operation has permissions
+//
+// Preconditions:
+// - (1) Event is valid and persisted
+// - (2) Event must have not started
+// - (3) User must have received this invitation, i.e. user is in event participant list
+// - (4) There is no other change proposal in voting state for the same event
+// - (5) Proposal must change something and proposed values must be valid
+func (m *EventManager) ProposeChange(eventID int64, userID int64, startDate time.Time,
+	endDate time.Time, description string) (*ChangeProposal, error) {
+
+	defer m.votingProposals.mutex.Unlock()
+	m.votingProposals.mutex.Lock()
+
+	// Check precondition (1)
+	event, err := m.LoadEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check precondition (2)
+	if event.Status() != api.EventState_NOT_STARTED {
+		return nil, ErrEventNotWritable
+	}
+
+	// Check precondition (3)
+	if _, ok := event.Participants.Get(userID); !ok {
+		return nil, ErrParticipantNotFound
+	}
+
+	// Check precondition (4)
+	if _, ok := m.votingProposals.proposals[eventID]; ok {
+		return nil, ErrChangeAlreadyProposed
+	}
+
+	// Voting must finish early enough to let the change be applied before
+	// the event starts
+	currentTime := utils.GetCurrentTimeUTC().Truncate(time.Second)
+	deadline := currentTime.Add(changeVotingMaxTime)
+	if latestDeadline := event.StartDate().Add(-startDateMinDiff); latestDeadline.Before(deadline) {
+		deadline = latestDeadline
+	}
+
+	if !deadline.After(currentTime) {
+		return nil, ErrEventNotWritable
+	}
+
+	proposal := &ChangeProposal{
+		eventID:     eventID,
+		authorID:    userID,
+		createdDate: currentTime,
+		deadline:    deadline,
+		votesTotal:  event.NumGuests(),
+		status:      api.ChangeProposalStatus_VOTING,
+		votes:       map[int64]bool{userID: true}, // Author accepts its own proposal
+	}
+
+	// Check precondition (5)
+	if !startDate.IsZero() || !endDate.IsZero() {
+
+		if startDate.IsZero() {
+			startDate = event.StartDate()
+		}
+
+		if endDate.IsZero() {
+			endDate = event.EndDate()
+		}
+
+		startDate = startDate.Truncate(time.Second)
+		endDate = endDate.Truncate(time.Second)
+
+		if !startDate.Equal(event.StartDate()) || !endDate.Equal(event.EndDate()) {
+
+			if !IsValidStartDate(startDate, deadline) {
+				return nil, ErrInvalidStartDate
+			}
+
+			if !IsValidEndDate(endDate, startDate) {
+				return nil, ErrInvalidEndDate
+			}
+
+			proposal.startDate = startDate
+			proposal.endDate = endDate
+		}
+	}
+
+	description = strings.TrimSpace(description)
+
+	if description != "" && description != event.Description() {
+		if !IsValidDescription(description) {
+			return nil, ErrInvalidDescription
+		}
+		proposal.description = description
+	}
+
+	if !proposal.IsDateChange() && !proposal.IsDescriptionChange() {
+		return nil, ErrInvalidChangeProposal
+	}
+
+	// Get next change ID for this event
+	previousProposals, err := m.proposalDAO.LoadAll(eventID)
+	if err == api.ErrNoResults {
+		proposal.id = 1
+	} else if err != nil {
+		return nil, err
+	} else {
+		proposal.id = previousProposals[0].ChangeID + 1
+	}
+
+	// Persist
+	if err := m.proposalDAO.Insert(proposal.AsDTO()); err != nil {
+		return nil, err
+	}
+
+	m.votingProposals.proposals[eventID] = proposal
+
+	// Emit signal
+	m.emitEventChangeProposed(event, proposal.Clone())
+
+	// Voting may finish right now, i.e. event has only one participant
+	if finished, accepted := proposal.Result(currentTime); finished {
+		m.finishVoting(proposal, accepted)
+	}
+
+	return proposal.Clone(), nil
+}
+
+// VoteChange registers the vote of a participant for a change proposal. Once
+// the proposal has enough votes, voting finishes and the change is applied
+// or discarded.
+//
+// Assumptions:
+// - (1) User who performs this operation has permissions
+//
+// Preconditions:
+// - (1) Change proposal exists and its voting has not finished
+// - (2) User must be a participant of the event
+// - (3) User must not have voted this proposal before
+func (m *EventManager) VoteChange(eventID int64, changeID int32, userID int64, accept bool) (*ChangeProposal, error) {
+
+	defer m.votingProposals.mutex.Unlock()
+	m.votingProposals.mutex.Lock()
+
+	// Check precondition (1)
+	proposal, ok := m.votingProposals.proposals[eventID]
+	if !ok || proposal.id != changeID {
+		if _, err := m.proposalDAO.Load(eventID, changeID); err != nil {
+			return nil, err
+		}
+		return nil, ErrVotingFinished
+	}
+
+	// Check precondition (2)
+	event, err := m.LoadEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := event.Participants.Get(userID); !ok {
+		return nil, ErrParticipantNotFound
+	}
+
+	// Check precondition (3)
+	if proposal.HasVoted(userID) {
+		return nil, ErrAlreadyVoted
+	}
+
+	// Persist
+	if err := m.proposalDAO.InsertVote(eventID, changeID, userID, accept); err != nil {
+		return nil, err
+	}
+
+	proposal.votes[userID] = accept
+
+	// Emit signal
+	m.emitVotingStatusChanged(event, proposal.Clone())
+
+	if finished, accepted := proposal.Result(utils.GetCurrentTimeUTC()); finished {
+		m.finishVoting(proposal, accepted)
+	}
+
+	return proposal.Clone(), nil
+}
+
+// GetVotingChangeProposal returns the change proposal of an event whose voting
+// is in progress
+func (m *EventManager) GetVotingChangeProposal(eventID int64) (*ChangeProposal, error) {
+
+	defer m.votingProposals.mutex.Unlock()
+	m.votingProposals.mutex.Lock()
+
+	proposal, ok := m.votingProposals.proposals[eventID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return proposal.Clone(), nil
+}
+
+// Finish votings that have reached their deadline
+func (m *EventManager) finishExpiredVotings() {
+
+	defer m.votingProposals.mutex.Unlock()
+	m.votingProposals.mutex.Lock()
+
+	currentTime := utils.GetCurrentTimeUTC()
+
+	for _, proposal := range m.votingProposals.proposals {
+		if finished, accepted := proposal.Result(currentTime); finished {
+			m.finishVoting(proposal, accepted)
+		}
+	}
+}
+
+// updateVotingProposal keeps the change proposal of event whose voting is in
+// progress consistent with a modification of the event. If event has been
+// cancelled, the proposal is discarded without notifying participants.
+// Otherwise, votes of removed participants are dropped and the number of
+// voters is updated. votingProposals mutex must not be held by the caller.
+func (m *EventManager) updateVotingProposal(event *Event) {
+
+	defer m.votingProposals.mutex.Unlock()
+	m.votingProposals.mutex.Lock()
+
+	proposal, ok := m.votingProposals.proposals[event.Id()]
+	if !ok {
+		return
+	}
+
+	if event.IsCancelled() {
+		if err := m.proposalDAO.SetStatus(proposal.eventID, proposal.id, api.ChangeProposalStatus_DISCARDED); err != nil {
+			log.Printf("* Change proposal %v of cancelled event %v cannot be discarded: %v\n", proposal.id, proposal.eventID, err)
+			return
+		}
+		proposal.status = api.ChangeProposalStatus_DISCARDED
+		delete(m.votingProposals.proposals, proposal.eventID)
+		return
+	}
+
+	for userID := range proposal.votes {
+		if _, ok := event.Participants.Get(userID); !ok {
+			delete(proposal.votes, userID)
+		}
+	}
+
+	proposal.votesTotal = event.NumGuests()
+
+	// Insert replaces votes and total of the stored proposal
+	if err := m.proposalDAO.Insert(proposal.AsDTO()); err != nil {
+		log.Printf("* Change proposal %v of event %v votes cannot be updated: %v\n", proposal.id, proposal.eventID, err)
+	}
+}
+
+// finishVoting applies or discards the proposed change and persists the
+// final status of the proposal. If an accepted change cannot be applied, for
+// instance because event has started meanwhile, then it is discarded.
+// votingProposals mutex must be held by the caller.
+func (m *EventManager) finishVoting(proposal *ChangeProposal, accepted bool) {
+
+	var event *Event
+	var err error
+
+	if accepted {
+		if event, err = m.applyChangeProposal(proposal); err != nil {
+			log.Printf("* Change proposal %v of event %v cannot be applied: %v\n", proposal.id, proposal.eventID, err)
+			accepted = false
+		}
+	}
+
+	if accepted {
+		proposal.status = api.ChangeProposalStatus_ACCEPTED
+	} else {
+		proposal.status = api.ChangeProposalStatus_DISCARDED
+	}
+
+	if err := m.proposalDAO.SetStatus(proposal.eventID, proposal.id, proposal.status); err != nil {
+		// Keep it in voting state in order to retry later
+		log.Printf("* Change proposal %v of event %v status cannot be saved: %v\n", proposal.id, proposal.eventID, err)
+		proposal.status = api.ChangeProposalStatus_VOTING
+		return
+	}
+
+	delete(m.votingProposals.proposals, proposal.eventID)
+
+	if event == nil {
+		if event, err = m.LoadEvent(proposal.eventID); err != nil {
+			log.Printf("* Voting finished but event %v cannot be loaded: %v\n", proposal.eventID, err)
+			return
+		}
+	}
+
+	// Emit signal
+	m.emitVotingFinished(event, proposal.Clone())
+}
+
+func (m *EventManager) applyChangeProposal(proposal *ChangeProposal) (*Event, error) {
+
+	event, err := m.LoadEvent(proposal.eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Changes are made on behalf of the event author
+	b := m.NewEventModifier(event, event.AuthorID())
+
+	if proposal.IsDateChange() {
+		b.SetStartDate(proposal.startDate)
+		b.SetEndDate(proposal.endDate)
+	}
+
+	if proposal.IsDescriptionChange() {
+		b.SetDescription(proposal.description)
+	}
+
+	modifiedEvent, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.SaveEvent(modifiedEvent); err != nil {
+		return nil, err
+	}
+
+	return modifiedEvent, nil
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+const minutesPerDay = 24 * 60
+
+// NotificationSettings holds which notifications a user wants to receive.
+// Quiet hours are minutes since midnight in TimeZone. If start is greater
+// than end, the window spans midnight. MutedEvents are read-only here, use
+// AccountManager.MuteEvent to change them.
+type NotificationSettings struct {
+	DisabledCategories []api.NotificationCategory
+	QuietHoursEnabled  bool
+	QuietHoursStart    int
+	QuietHoursEnd      int
+	TimeZone           string
+	MutedEvents        []int64
+}
+
+func newNotificationSettingsFromDTO(dto *api.NotificationSettingsDTO) *NotificationSettings {
+	return &NotificationSettings{
+		DisabledCategories: dto.DisabledCategories,
+		QuietHoursEnabled:  dto.QuietHoursEnabled,
+		QuietHoursStart:    int(dto.QuietHoursStart),
+		QuietHoursEnd:      int(dto.QuietHoursEnd),
+		TimeZone:           dto.TimeZone,
+	}
+}
+
+// IsCategoryEnabled returns true if notifications of category are sent
+func (s *NotificationSettings) IsCategoryEnabled(category api.NotificationCategory) bool {
+	for _, disabled := range s.DisabledCategories {
+		if disabled == category {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEventMuted returns true if notifications of eventID aren't sent
+func (s *NotificationSettings) IsEventMuted(eventID int64) bool {
+	for _, muted := range s.MutedEvents {
+		if muted == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+// InQuietHours returns true if t falls into the quiet hours window in user
+// time zone. An empty window (start equal to end) never matches.
+func (s *NotificationSettings) InQuietHours(t time.Time) bool {
+
+	if !s.QuietHoursEnabled || s.QuietHoursStart == s.QuietHoursEnd {
+		return false
+	}
+
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+
+	if s.QuietHoursStart < s.QuietHoursEnd {
+		return minute >= s.QuietHoursStart && minute < s.QuietHoursEnd
+	}
+
+	// Window spans midnight
+	return minute >= s.QuietHoursStart || minute < s.QuietHoursEnd
+}
+
+// Allows returns true if a notification of category about eventID may be sent
+// at t. Notifications not related to an event use zero eventID.
+func (s *NotificationSettings) Allows(category api.NotificationCategory, eventID int64, t time.Time) bool {
+
+	if !s.IsCategoryEnabled(category) || s.InQuietHours(t) {
+		return false
+	}
+
+	if eventID != 0 && s.IsEventMuted(eventID) {
+		return false
+	}
+
+	return true
+}
+
+func (s *NotificationSettings) isValid() bool {
+
+	if s.QuietHoursStart < 0 || s.QuietHoursStart >= minutesPerDay ||
+		s.QuietHoursEnd < 0 || s.QuietHoursEnd >= minutesPerDay {
+		return false
+	}
+
+	for _, category := range s.DisabledCategories {
+		if category < api.NotificationCategory_EVENT_INVITATION ||
+			category > api.NotificationCategory_EVENT_COMMENT {
+			return false
+		}
+	}
+
+	// Empty means UTC
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return false
+	}
+
+	return true
+}
+
+func (s *NotificationSettings) asDTO(userID int64) *api.NotificationSettingsDTO {
+	return &api.NotificationSettingsDTO{
+		UserID:             userID,
+		DisabledCategories: s.DisabledCategories,
+		QuietHoursEnabled:  s.QuietHoursEnabled,
+		QuietHoursStart:    int32(s.QuietHoursStart),
+		QuietHoursEnd:      int32(s.QuietHoursEnd),
+		TimeZone:           s.TimeZone,
+	}
+}
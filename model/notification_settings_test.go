@@ -0,0 +1,102 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+func TestNotificationSettings_QuietHours(t *testing.T) {
+
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	night := &NotificationSettings{
+		QuietHoursEnabled: true,
+		QuietHoursStart:   23 * 60,
+		QuietHoursEnd:     7*60 + 30,
+		TimeZone:          "Europe/Madrid",
+	}
+
+	afternoon := &NotificationSettings{
+		QuietHoursEnabled: true,
+		QuietHoursStart:   14 * 60,
+		QuietHoursEnd:     16 * 60,
+	}
+
+	tests := []struct {
+		settings *NotificationSettings
+		time     time.Time
+		want     bool
+	}{
+		{night, time.Date(2024, 1, 10, 23, 0, 0, 0, madrid), true},
+		{night, time.Date(2024, 1, 10, 3, 0, 0, 0, madrid), true},
+		{night, time.Date(2024, 1, 10, 7, 29, 0, 0, madrid), true},
+		{night, time.Date(2024, 1, 10, 7, 30, 0, 0, madrid), false},
+		{night, time.Date(2024, 1, 10, 22, 30, 0, 0, time.UTC), true}, // 23:30 in Madrid
+		{night, time.Date(2024, 1, 10, 12, 0, 0, 0, madrid), false},
+		{afternoon, time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC), true}, // Empty time zone is UTC
+		{afternoon, time.Date(2024, 1, 10, 14, 30, 0, 0, madrid), false}, // 13:30 in UTC
+		{&NotificationSettings{QuietHoursEnabled: true, QuietHoursStart: 60, QuietHoursEnd: 60},
+			time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC), false},
+		{&NotificationSettings{QuietHoursStart: 0, QuietHoursEnd: 1439},
+			time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC), false},
+	}
+
+	for i, test := range tests {
+		if got := test.settings.InQuietHours(test.time); got != test.want {
+			t.Fatalf("test %v: expected %v, got %v", i, test.want, got)
+		}
+	}
+}
+
+func TestNotificationSettings_Allows(t *testing.T) {
+
+	settings := &NotificationSettings{
+		DisabledCategories: []api.NotificationCategory{api.NotificationCategory_EVENT_RESPONSE},
+		MutedEvents:        []int64{100},
+	}
+
+	now := time.Now()
+
+	tests := []struct {
+		category api.NotificationCategory
+		eventID  int64
+		want     bool
+	}{
+		{api.NotificationCategory_EVENT_RESPONSE, 200, false},
+		{api.NotificationCategory_EVENT_CHANGE, 200, true},
+		{api.NotificationCategory_EVENT_CHANGE, 100, false},
+		{api.NotificationCategory_NEW_FRIEND, 0, true},
+	}
+
+	for i, test := range tests {
+		if got := settings.Allows(test.category, test.eventID, now); got != test.want {
+			t.Fatalf("test %v: expected %v, got %v", i, test.want, got)
+		}
+	}
+}
+
+func TestNotificationSettings_Validation(t *testing.T) {
+
+	tests := []struct {
+		settings *NotificationSettings
+		want     bool
+	}{
+		{&NotificationSettings{}, true},
+		{&NotificationSettings{QuietHoursStart: 1320, QuietHoursEnd: 420, TimeZone: "America/New_York"}, true},
+		{&NotificationSettings{QuietHoursStart: 1440}, false},
+		{&NotificationSettings{QuietHoursEnd: -1}, false},
+		{&NotificationSettings{TimeZone: "Mars/Olympus"}, false},
+		{&NotificationSettings{DisabledCategories: []api.NotificationCategory{8}}, false},
+	}
+
+	for i, test := range tests {
+		if got := test.settings.isValid(); got != test.want {
+			t.Fatalf("test %v: expected %v, got %v", i, test.want, got)
+		}
+	}
+}
@@ -1,8 +1,15 @@
 package model
 
+import (
+	"sort"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
 type ParticipantList struct {
 	numAttendees int
 	numGuests    int
+	waitlist     []int64 // Promotion order
 	participants map[int64]*Participant
 }
 
@@ -41,6 +48,68 @@ func (l *ParticipantList) NumGuests() int {
 	return l.numGuests
 }
 
+// Waitlist returns participants who answered ASSIST when the event was full,
+// in the order they will be promoted to attendees
+func (l *ParticipantList) Waitlist() []int64 {
+	waitlist := make([]int64, len(l.waitlist))
+	copy(waitlist, l.waitlist)
+	return waitlist
+}
+
+func (l *ParticipantList) IsWaitlisted(id int64) bool {
+	for _, pID := range l.waitlist {
+		if pID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// computeAttendance counts attendees of an event with capacity. Participants
+// who answered ASSIST are attendees in answer order until capacity is reached.
+// The rest are on the waitlist. Zero capacity means unlimited.
+func (l *ParticipantList) computeAttendance(capacity int) {
+
+	var assistants []*Participant
+	for _, p := range l.participants {
+		if p.response == api.AttendanceResponse_ASSIST {
+			assistants = append(assistants, p)
+		}
+	}
+
+	sort.Slice(assistants, func(i, j int) bool {
+		if assistants[i].responseTS != assistants[j].responseTS {
+			return assistants[i].responseTS < assistants[j].responseTS
+		}
+		return assistants[i].id < assistants[j].id
+	})
+
+	l.numAttendees = len(assistants)
+	l.waitlist = nil
+
+	if capacity > 0 && len(assistants) > capacity {
+		l.numAttendees = capacity
+		for _, p := range assistants[capacity:] {
+			l.waitlist = append(l.waitlist, p.id)
+		}
+	}
+}
+
+// promotedFrom returns participants who were on the waitlist of oldList and
+// are attendees in l
+func (l *ParticipantList) promotedFrom(oldList *ParticipantList) []int64 {
+
+	var promoted []int64
+
+	for _, pID := range oldList.waitlist {
+		if p, ok := l.participants[pID]; ok && p.response == api.AttendanceResponse_ASSIST && !l.IsWaitlisted(pID) {
+			promoted = append(promoted, pID)
+		}
+	}
+
+	return promoted
+}
+
 func (l *ParticipantList) Equal(other *ParticipantList) bool {
 
 	if len(l.participants) != len(other.participants) ||
@@ -60,6 +129,7 @@ func (l *ParticipantList) Equal(other *ParticipantList) bool {
 func (l *ParticipantList) Clone() *ParticipantList {
 	copy := new(ParticipantList)
 	*copy = *l
+	copy.waitlist = l.Waitlist()
 	copy.participants = make(map[int64]*Participant)
 	for _, p := range l.participants {
 		copy.participants[p.id] = p.Clone()
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+func newTestParticipantList(responses map[int64]api.AttendanceResponse, responseTS map[int64]int64) *ParticipantList {
+	list := newParticipantList()
+	for id, response := range responses {
+		list.participants[id] = &Participant{id: id, response: response, responseTS: responseTS[id]}
+	}
+	list.numGuests = len(list.participants)
+	return list
+}
+
+func TestParticipantList_ComputeAttendance(t *testing.T) {
+
+	responses := map[int64]api.AttendanceResponse{
+		1: api.AttendanceResponse_ASSIST,
+		2: api.AttendanceResponse_ASSIST,
+		3: api.AttendanceResponse_MAYBE,
+		4: api.AttendanceResponse_ASSIST,
+		5: api.AttendanceResponse_ASSIST,
+	}
+
+	// Answer order is 1, 5, 2, 4
+	responseTS := map[int64]int64{1: 10, 2: 30, 3: 20, 4: 40, 5: 20}
+
+	tests := []struct {
+		capacity     int
+		numAttendees int
+		waitlist     []int64
+	}{
+		{0, 4, nil},
+		{4, 4, nil},
+		{10, 4, nil},
+		{2, 2, []int64{2, 4}},
+		{3, 3, []int64{4}},
+	}
+
+	for i, test := range tests {
+		list := newTestParticipantList(responses, responseTS)
+		list.computeAttendance(test.capacity)
+		if list.NumAttendees() != test.numAttendees || !reflect.DeepEqual(list.waitlist, test.waitlist) {
+			t.Fatalf("test %v: expected %v attendees and waitlist %v, got %v and %v", i,
+				test.numAttendees, test.waitlist, list.NumAttendees(), list.waitlist)
+		}
+	}
+}
+
+func TestParticipantList_PromotedFrom(t *testing.T) {
+
+	responses := map[int64]api.AttendanceResponse{
+		1: api.AttendanceResponse_ASSIST,
+		2: api.AttendanceResponse_ASSIST,
+		3: api.AttendanceResponse_ASSIST,
+		4: api.AttendanceResponse_ASSIST,
+	}
+	responseTS := map[int64]int64{1: 10, 2: 20, 3: 30, 4: 40}
+
+	oldList := newTestParticipantList(responses, responseTS)
+	oldList.computeAttendance(2)
+
+	// Participant 1 doesn't assist anymore, so 3 takes the spot
+	newList := oldList.Clone()
+	newList.participants[1] = &Participant{id: 1, response: api.AttendanceResponse_NO_ASSIST, responseTS: 50}
+	newList.computeAttendance(2)
+
+	if promoted := newList.promotedFrom(oldList); !reflect.DeepEqual(promoted, []int64{3}) {
+		t.Fatalf("expected participant 3 to be promoted, got %v", promoted)
+	}
+
+	if !newList.IsWaitlisted(4) || newList.IsWaitlisted(3) {
+		t.Fatalf("unexpected waitlist %v", newList.waitlist)
+	}
+
+	// Unlimited capacity promotes everyone on the waitlist
+	newList = oldList.Clone()
+	newList.computeAttendance(0)
+
+	if promoted := newList.promotedFrom(oldList); !reflect.DeepEqual(promoted, []int64{3, 4}) {
+		t.Fatalf("expected participants 3 and 4 to be promoted, got %v", promoted)
+	}
+}
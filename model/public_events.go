@@ -0,0 +1,117 @@
+package model
+
+import (
+	"log"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+const (
+	// Geohash cells of precision 4 are about 39x19 km at the equator
+	publicEventsGeohashPrecision = 4
+)
+
+// GetPublicEvents returns public events that have not started yet and take
+// place inside the circle defined by location and rangeInMeters. A range lower
+// or equal to zero means default range. Returned events have an empty
+// participant list.
+func (m *EventManager) GetPublicEvents(location *Location, rangeInMeters float64) ([]*Event, error) {
+
+	if !IsValidLocation(location) {
+		return nil, ErrInvalidLocation
+	}
+
+	if rangeInMeters <= 0 {
+		rangeInMeters = publicEventsDefaultRange
+	} else if rangeInMeters > publicEventsMaxRange {
+		rangeInMeters = publicEventsMaxRange
+	}
+
+	latitude := float64(location.Latitude)
+	longitude := float64(location.Longitude)
+	geohashes := utils.GeohashesInRange(latitude, longitude, rangeInMeters, publicEventsGeohashPrecision)
+
+	entries, err := m.publicEventDAO.FindAllForward(geohashes, utils.GetCurrentTimeUTC())
+	if err != nil {
+		return nil, err
+	}
+
+	var eventIDs []int64
+	for _, entry := range entries {
+		distance := utils.DistanceInMeters(latitude, longitude,
+			float64(entry.Location.Latitude), float64(entry.Location.Longitude))
+		if distance <= rangeInMeters {
+			eventIDs = append(eventIDs, entry.EventID)
+		}
+	}
+
+	if len(eventIDs) == 0 {
+		return nil, ErrEmptyInbox
+	}
+
+	eventsDTO, err := m.eventDAO.LoadEvents(eventIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*Event, 0, len(eventsDTO))
+	for _, dto := range eventsDTO {
+		event := newEventFromDTO(dto)
+		event.isPersisted = true
+		// Index may be stale if a previous update failed
+		if !event.IsPublic() || event.Status() != api.EventState_NOT_STARTED {
+			continue
+		}
+		events = append(events, event.CloneWithEmptyParticipants())
+	}
+
+	if len(events) == 0 {
+		return nil, ErrEmptyInbox
+	}
+
+	return events, nil
+}
+
+// updatePublicEventIndex keeps public events index in sync with event. oldEvent
+// may be nil if event is new. Entries of cancelled events are removed, and
+// entries are written again if location or dates change, so that they expire
+// when the event ends.
+func (m *EventManager) updatePublicEventIndex(event *Event, oldEvent *Event) {
+
+	oldEntry := newPublicEventEntry(oldEvent)
+	newEntry := newPublicEventEntry(event)
+
+	if oldEntry != nil && newEntry != nil && *oldEntry == *newEntry {
+		return
+	}
+
+	if oldEntry != nil {
+		if err := m.publicEventDAO.Delete(oldEntry); err != nil {
+			log.Printf("* Remove public event %v from index error: %v\n", oldEvent.Id(), err)
+		}
+	}
+
+	if newEntry != nil {
+		if err := m.publicEventDAO.Insert(newEntry); err != nil {
+			log.Printf("* Add public event %v to index error: %v\n", event.Id(), err)
+		}
+	}
+}
+
+func newPublicEventEntry(event *Event) *api.PublicEventEntryDTO {
+
+	if event == nil || !event.isPublic || event.cancelled || event.location == nil {
+		return nil
+	}
+
+	return &api.PublicEventEntryDTO{
+		EventID: event.id,
+		Geohash: utils.GeohashEncode(float64(event.location.Latitude),
+			float64(event.location.Longitude), publicEventsGeohashPrecision),
+		StartDate: utils.TimeToMillis(event.startDate.Truncate(time.Second)),
+		EndDate:   utils.TimeToMillis(event.endDate.Truncate(time.Second)),
+		Location:  *event.location.AsDTO(),
+	}
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// publicEventDAOStub records changes made to the public events index
+type publicEventDAOStub struct {
+	api.PublicEventDAO
+	inserted []*api.PublicEventEntryDTO
+	deleted  []*api.PublicEventEntryDTO
+}
+
+func (s *publicEventDAOStub) Insert(entry *api.PublicEventEntryDTO) error {
+	s.inserted = append(s.inserted, entry)
+	return nil
+}
+
+func (s *publicEventDAOStub) Delete(entry *api.PublicEventEntryDTO) error {
+	s.deleted = append(s.deleted, entry)
+	return nil
+}
+
+func newPublicTestEvent(startDate time.Time, endDate time.Time, location *Location, cancelled bool) *Event {
+	return &Event{
+		id:        100,
+		isPublic:  true,
+		startDate: startDate,
+		endDate:   endDate,
+		location:  location,
+		cancelled: cancelled,
+	}
+}
+
+func TestUpdatePublicEventIndex(t *testing.T) {
+
+	now := utils.GetCurrentTimeUTC().Truncate(time.Second)
+	start, end := now.Add(time.Hour), now.Add(2*time.Hour)
+	madrid := &Location{Latitude: 40.4168, Longitude: -3.7038}
+	paris := &Location{Latitude: 48.8566, Longitude: 2.3522}
+
+	event := newPublicTestEvent(start, end, madrid, false)
+
+	tests := []struct {
+		event    *Event
+		oldEvent *Event
+		inserted bool
+		deleted  bool
+	}{
+		// New event
+		{event, nil, true, false},
+		// Unchanged
+		{event, newPublicTestEvent(start, end, madrid, false), false, false},
+		// End date changed, entry must expire later
+		{newPublicTestEvent(start, end.Add(time.Hour), madrid, false), event, true, true},
+		// Start date changed
+		{newPublicTestEvent(start.Add(time.Hour), end.Add(time.Hour), madrid, false), event, true, true},
+		// Location changed
+		{newPublicTestEvent(start, end, paris, false), event, true, true},
+		// Cancelled
+		{newPublicTestEvent(start, end, madrid, true), event, false, true},
+	}
+
+	for i, test := range tests {
+
+		publicEventDAO := &publicEventDAOStub{}
+		manager := &EventManager{publicEventDAO: publicEventDAO}
+
+		manager.updatePublicEventIndex(test.event, test.oldEvent)
+
+		if (len(publicEventDAO.inserted) == 1) != test.inserted || (len(publicEventDAO.deleted) == 1) != test.deleted {
+			t.Fatalf("test %v: expected inserted %v and deleted %v, got %v and %v", i, test.inserted,
+				test.deleted, len(publicEventDAO.inserted), len(publicEventDAO.deleted))
+		}
+
+		if test.inserted && publicEventDAO.inserted[0].EndDate != utils.TimeToMillis(test.event.EndDate()) {
+			t.Fatalf("test %v: entry doesn't expire when event ends", i)
+		}
+
+		if test.deleted && publicEventDAO.deleted[0].StartDate != utils.TimeToMillis(test.oldEvent.StartDate()) {
+			t.Fatalf("test %v: old entry not deleted", i)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+const (
+	recurrenceMaxInterval    = 99
+	recurrenceMaxOccurrences = 100
+)
+
+// Recurrence describes how an event repeats. Occurrences happen every Interval
+// days, weeks or months depending on Frequency. A recurrence must end, either
+// after Count occurrences or at Until, whichever comes first. Zero values mean
+// no limit, but at least one of them is required. Occurrences keep the local
+// time of the first one in TimeZone, an IANA name (UTC if empty), so that
+// they don't shift on daylight saving time changes.
+type Recurrence struct {
+	Frequency api.RecurrenceFrequency
+	Interval  int
+	Count     int
+	Until     time.Time
+	TimeZone  string
+}
+
+// IsValid returns true if recurrence has a known frequency, a valid interval
+// and an end
+func (r *Recurrence) IsValid() bool {
+
+	if r.Frequency < api.RecurrenceFrequency_DAILY || r.Frequency > api.RecurrenceFrequency_MONTHLY {
+		return false
+	}
+
+	if r.Interval < 1 || r.Interval > recurrenceMaxInterval {
+		return false
+	}
+
+	if r.Count < 0 || r.Count > recurrenceMaxOccurrences {
+		return false
+	}
+
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return false
+	}
+
+	return r.Count > 0 || !r.Until.IsZero()
+}
+
+// Occurrence returns the start date of occurrence index, being zero the
+// first one, in the location of first. Dates are computed in the time zone of
+// the recurrence. Monthly occurrences that fall on a day the month doesn't
+// have, are moved to the last day of that month.
+func (r *Recurrence) Occurrence(first time.Time, index int) time.Time {
+
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := first.In(loc)
+
+	switch r.Frequency {
+	case api.RecurrenceFrequency_DAILY:
+		return local.AddDate(0, 0, index*r.Interval).In(first.Location())
+	case api.RecurrenceFrequency_WEEKLY:
+		return local.AddDate(0, 0, 7*index*r.Interval).In(first.Location())
+	case api.RecurrenceFrequency_MONTHLY:
+		year, month, day := local.Date()
+		month += time.Month(index * r.Interval)
+		// Day zero of next month is the last day of month
+		if lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day(); day > lastDay {
+			day = lastDay
+		}
+		return time.Date(year, month, day, local.Hour(), local.Minute(), local.Second(),
+			local.Nanosecond(), loc).In(first.Location())
+	}
+
+	return first
+}
+
+// HasOccurrence returns true if occurrence index exists
+func (r *Recurrence) HasOccurrence(first time.Time, index int) bool {
+
+	if index < 0 || index >= recurrenceMaxOccurrences {
+		return false
+	}
+
+	if r.Count > 0 && index >= r.Count {
+		return false
+	}
+
+	if !r.Until.IsZero() && r.Occurrence(first, index).After(r.Until) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+func TestRecurrence_Validation(t *testing.T) {
+
+	until := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		recurrence *Recurrence
+		want       bool
+	}{
+		{&Recurrence{Frequency: api.RecurrenceFrequency_WEEKLY, Interval: 1, Count: 10}, true},
+		{&Recurrence{Frequency: api.RecurrenceFrequency_DAILY, Interval: 2, Until: until}, true},
+		{&Recurrence{Frequency: api.RecurrenceFrequency_MONTHLY, Interval: 1, Count: 3, Until: until}, true},
+		{&Recurrence{Frequency: api.RecurrenceFrequency_WEEKLY, Interval: 1}, false}, // Never ends
+		{&Recurrence{Frequency: api.RecurrenceFrequency_NONE, Interval: 1, Count: 10}, false},
+		{&Recurrence{Frequency: api.RecurrenceFrequency_DAILY, Interval: 0, Count: 10}, false},
+		{&Recurrence{Frequency: api.RecurrenceFrequency_DAILY, Interval: 1, Count: recurrenceMaxOccurrences + 1}, false},
+		{&Recurrence{Frequency: api.RecurrenceFrequency_DAILY, Interval: 1, Count: 10, TimeZone: "Europe/Madrid"}, true},
+		{&Recurrence{Frequency: api.RecurrenceFrequency_DAILY, Interval: 1, Count: 10, TimeZone: "Mars/Olympus"}, false},
+	}
+
+	for i, test := range tests {
+		if got := test.recurrence.IsValid(); got != test.want {
+			t.Fatalf("test %v: expected %v, got %v", i, test.want, got)
+		}
+	}
+}
+
+func TestRecurrence_Occurrence(t *testing.T) {
+
+	first := time.Date(2024, 1, 31, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		frequency api.RecurrenceFrequency
+		interval  int
+		index     int
+		want      time.Time
+	}{
+		{api.RecurrenceFrequency_DAILY, 1, 0, first},
+		{api.RecurrenceFrequency_DAILY, 3, 2, time.Date(2024, 2, 6, 18, 30, 0, 0, time.UTC)},
+		{api.RecurrenceFrequency_WEEKLY, 2, 1, time.Date(2024, 2, 14, 18, 30, 0, 0, time.UTC)},
+		{api.RecurrenceFrequency_MONTHLY, 1, 1, time.Date(2024, 2, 29, 18, 30, 0, 0, time.UTC)}, // Clamped
+		{api.RecurrenceFrequency_MONTHLY, 1, 2, time.Date(2024, 3, 31, 18, 30, 0, 0, time.UTC)},
+		{api.RecurrenceFrequency_MONTHLY, 3, 1, time.Date(2024, 4, 30, 18, 30, 0, 0, time.UTC)},
+		{api.RecurrenceFrequency_MONTHLY, 1, 12, time.Date(2025, 1, 31, 18, 30, 0, 0, time.UTC)},
+	}
+
+	for i, test := range tests {
+		recurrence := &Recurrence{Frequency: test.frequency, Interval: test.interval, Count: 100}
+		if got := recurrence.Occurrence(first, test.index); !got.Equal(test.want) {
+			t.Fatalf("test %v: expected %v, got %v", i, test.want, got)
+		}
+	}
+}
+
+func TestRecurrence_OccurrenceTimeZone(t *testing.T) {
+
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// 18:30 in Madrid, sent as UTC by clients. Summer time begins on March 31
+	first := time.Date(2024, 3, 24, 18, 30, 0, 0, madrid).UTC()
+
+	tests := []struct {
+		frequency api.RecurrenceFrequency
+		index     int
+		want      time.Time
+	}{
+		{api.RecurrenceFrequency_DAILY, 7, time.Date(2024, 3, 31, 18, 30, 0, 0, madrid)},
+		{api.RecurrenceFrequency_WEEKLY, 1, time.Date(2024, 3, 31, 18, 30, 0, 0, madrid)},
+		{api.RecurrenceFrequency_WEEKLY, 31, time.Date(2024, 10, 27, 18, 30, 0, 0, madrid)},
+		{api.RecurrenceFrequency_MONTHLY, 1, time.Date(2024, 4, 24, 18, 30, 0, 0, madrid)},
+	}
+
+	for i, test := range tests {
+		recurrence := &Recurrence{Frequency: test.frequency, Interval: 1, Count: 100, TimeZone: "Europe/Madrid"}
+		got := recurrence.Occurrence(first, test.index)
+		if !got.Equal(test.want) || got.Location() != time.UTC {
+			t.Fatalf("test %v: expected %v, got %v", i, test.want.UTC(), got)
+		}
+	}
+
+	// Without time zone, occurrences keep UTC time and shift an hour in Madrid
+	recurrence := &Recurrence{Frequency: api.RecurrenceFrequency_WEEKLY, Interval: 1, Count: 100}
+	if got := recurrence.Occurrence(first, 1).In(madrid); got.Hour() != 19 {
+		t.Fatalf("expected 19:30 in Madrid, got %v", got)
+	}
+}
+
+func TestRecurrence_HasOccurrence(t *testing.T) {
+
+	first := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	byCount := &Recurrence{Frequency: api.RecurrenceFrequency_WEEKLY, Interval: 1, Count: 3}
+	byDate := &Recurrence{Frequency: api.RecurrenceFrequency_DAILY, Interval: 1,
+		Until: time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		recurrence *Recurrence
+		index      int
+		want       bool
+	}{
+		{byCount, 0, true},
+		{byCount, 2, true},
+		{byCount, 3, false},
+		{byDate, 4, true}, // Until is included
+		{byDate, 5, false},
+		{byDate, -1, false},
+	}
+
+	for i, test := range tests {
+		if got := test.recurrence.HasOccurrence(first, test.index); got != test.want {
+			t.Fatalf("test %v: expected %v, got %v", i, test.want, got)
+		}
+	}
+}
@@ -18,9 +18,35 @@ const (
 	// Event participant list changed (added or removed participants)
 	SignalEventParticipantsInvited SignalType = iota
 
+	// Invitation of some participants was cancelled
+	SignalEventParticipantsRemoved SignalType = iota
+
 	// Participant changed (response, invitationStatus)
 	SignalParticipantChanged SignalType = iota
 
+	// A participant proposed a change of an event
+	SignalEventChangeProposed SignalType = iota
+
+	// A participant voted a change proposal
+	SignalVotingStatusChanged SignalType = iota
+
+	// Voting of a change proposal finished (accepted or discarded)
+	SignalVotingFinished SignalType = iota
+
+	// An event is about to start (reminder or nudge)
+	SignalEventReminder SignalType = iota
+
+	// A participant posted, edited or deleted a comment in an event
+	SignalCommentPosted  SignalType = iota
+	SignalCommentEdited  SignalType = iota
+	SignalCommentDeleted SignalType = iota
+
+	// Participants on the waitlist of an event became attendees
+	SignalParticipantsPromoted SignalType = iota
+
+	// An event reached its quorum
+	SignalEventConfirmed SignalType = iota
+
 	// Users
 
 	// New registered user
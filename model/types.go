@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/d3ce1t/areyouin-server/api"
 )
 
@@ -43,3 +45,36 @@ func (p *Picture) AsDTO() *api.PictureDTO {
 		Digest:  p.Digest,
 	}
 }
+
+type Location struct {
+	Latitude  float32
+	Longitude float32
+}
+
+func newLocationFromDTO(dto *api.LocationDTO) *Location {
+	if dto == nil {
+		return nil
+	}
+	return &Location{Latitude: dto.Latitude, Longitude: dto.Longitude}
+}
+
+func (l *Location) Equal(other *Location) bool {
+	if l == nil || other == nil {
+		return l == other
+	}
+	return l.Latitude == other.Latitude && l.Longitude == other.Longitude
+}
+
+func (l *Location) AsDTO() *api.LocationDTO {
+	if l == nil {
+		return nil
+	}
+	return &api.LocationDTO{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+type UserPosition struct {
+	Location        Location
+	EstimationError float32
+	RangeInMeters   float32
+	LastUpdate      time.Time
+}
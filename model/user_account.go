@@ -2,6 +2,7 @@ package model
 
 import (
 	"strings"
+	"time"
 
 	"github.com/d3ce1t/areyouin-server/api"
 	"github.com/d3ce1t/areyouin-server/idgen"
@@ -258,13 +259,20 @@ type IIDToken struct {
 	// Protocol Version stored when IIDtoken was received
 	version  int
 	platform string
+	// Device that registered the token. Empty for tokens stored before
+	// devices were tracked.
+	deviceID string
+	lastSeen int64
 }
 
-func NewIIDToken(token string, version int, platform string) *IIDToken {
+func NewIIDToken(deviceID string, token string, version int, platform string) *IIDToken {
 	return &IIDToken{
 		token:    token,
 		version:  version,
-		platform: platform}
+		platform: platform,
+		deviceID: deviceID,
+		lastSeen: utils.GetCurrentTimeMillis(),
+	}
 }
 
 func newIIDTokenFromDTO(dto *api.IIDTokenDTO) *IIDToken {
@@ -275,6 +283,16 @@ func newIIDTokenFromDTO(dto *api.IIDTokenDTO) *IIDToken {
 	}
 }
 
+func newIIDTokenFromPushTokenDTO(dto *api.PushTokenDTO) *IIDToken {
+	return &IIDToken{
+		token:    dto.Token,
+		version:  dto.Version,
+		platform: dto.Platform,
+		deviceID: dto.DeviceID,
+		lastSeen: dto.LastSeen,
+	}
+}
+
 func (t *IIDToken) Token() string {
 	return t.token
 }
@@ -287,6 +305,26 @@ func (t *IIDToken) Platform() string {
 	return t.platform
 }
 
+func (t *IIDToken) DeviceID() string {
+	return t.deviceID
+}
+
+// LastSeen returns when the device was last connected
+func (t *IIDToken) LastSeen() time.Time {
+	return utils.MillisToTimeUTC(t.lastSeen)
+}
+
+func (t *IIDToken) asPushTokenDTO(userID int64) *api.PushTokenDTO {
+	return &api.PushTokenDTO{
+		UserID:   userID,
+		DeviceID: t.deviceID,
+		Token:    t.token,
+		Platform: t.platform,
+		Version:  t.version,
+		LastSeen: t.lastSeen,
+	}
+}
+
 func (t IIDToken) AsDTO() *api.IIDTokenDTO {
 	return &api.IIDTokenDTO{
 		Token:    t.token,
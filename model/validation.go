@@ -14,17 +14,32 @@ const (
 	UserNameMaxLength     = 50
 	UserPictureMaxWidth   = 512
 	UserPictureMaxHeight  = 512
+	maxLanguageTagLength  = 35 // BCP 47 recommended max length
 
 	// Event
 	descriptionMinLength  = 15
 	descriptionMaxLength  = 500
 	eventPictureMaxWidth  = 1280
 	eventPictureMaxHeight = 720
+	commentMaxLength      = 1000
+	eventMaxCapacity      = 1000
 
 	startDateMinDiff = 30 * time.Minute     // 30 minutes
 	startDateMaxDiff = 365 * 24 * time.Hour // 1 year
 	endDateMinDiff   = 30 * time.Minute     // 30 minutes (from start date)
 	endDateMaxDiff   = 7 * 24 * time.Hour   // 1 week (from start date)
+
+	changeVotingMaxTime = 1 * time.Hour // Time participants have to vote a change
+
+	quorumDeadlineMinDiff = 15 * time.Minute // 15 minutes (before start date)
+
+	// Public events
+	publicEventsDefaultRange = 10000 // 10 km
+	publicEventsMaxRange     = 50000 // 50 km
+
+	// Comments
+	commentsPageDefaultSize = 20
+	commentsPageMaxSize     = 100
 )
 
 // DateOption enum
@@ -81,6 +96,43 @@ func IsValidDescription(description string) bool {
 	return true
 }
 
+// IsValidCapacity returns true if capacity is zero, that means unlimited, or
+// a positive number not greater than the max capacity
+func IsValidCapacity(capacity int) bool {
+	return capacity >= 0 && capacity <= eventMaxCapacity
+}
+
+// IsValidQuorum returns true if event has no quorum nor deadline, or quorum is
+// a positive number not greater than the max capacity and the deadline is
+// after currentTime and valid for the start date of the event
+func IsValidQuorum(quorum int, deadline time.Time, startDate time.Time, currentTime time.Time) bool {
+	if quorum == 0 {
+		return deadline.IsZero()
+	}
+	return quorum > 0 && quorum <= eventMaxCapacity &&
+		deadline.After(currentTime) && IsValidQuorumDeadline(deadline, startDate)
+}
+
+// IsValidQuorumDeadline returns true if deadline is at least quorumDeadlineMinDiff
+// before start date, so that quorum is decided while event can still be
+// confirmed or cancelled
+func IsValidQuorumDeadline(deadline time.Time, startDate time.Time) bool {
+	return !deadline.After(startDate.Add(-quorumDeadlineMinDiff))
+}
+
+func IsValidComment(message string) bool {
+	trimMessage := strings.TrimSpace(message)
+	return trimMessage != "" && len(trimMessage) <= commentMaxLength
+}
+
+func IsValidLocation(location *Location) bool {
+	if location == nil || location.Latitude < -90 || location.Latitude > 90 ||
+		location.Longitude < -180 || location.Longitude > 180 {
+		return false
+	}
+	return true
+}
+
 func IsValidName(name string) bool {
 	trimName := strings.TrimSpace(name)
 	if trimName == "" || len(trimName) < UserNameMinLength || len(trimName) > UserNameMaxLength {
@@ -0,0 +1,7 @@
+package protocol
+
+// Optional features a client can declare in Hello.Capabilities
+const (
+	// Client acknowledges responses with payload other than OK and ERROR
+	CAPABILITY_ACK_RESPONSES = "ack_responses"
+)
@@ -0,0 +1,229 @@
+// Package capture reads and writes AyiPacket capture files.
+//
+// A capture file starts with a short magic header followed by one record per
+// packet. Each record stores when the packet was seen, its direction, the user
+// of the session, its packet type and the packet itself (header and payload)
+// exactly as it was sent over the wire:
+//
+//	timestamp (int64, unix nanoseconds)
+//	direction (uint8)
+//	user id   (int64)
+//	type      (uint8)
+//	length    (uint32)
+//	packet    (length bytes)
+//
+// All integers are big endian.
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+)
+
+const (
+	magic           = "AYICAP\x00\x01"
+	recordHeaderLen = 8 + 1 + 8 + 1 + 4
+	maxRecordLen    = 2 * 1024 * 1024
+	FileExtension   = ".ayicap"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid capture file")
+	ErrRecordTooBig  = errors.New("capture record too big")
+	ErrInvalidPacket = errors.New("packet cannot be marshaled")
+)
+
+type Direction uint8
+
+const (
+	Inbound  Direction = iota // Client to server
+	Outbound                  // Server to client
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "IN"
+	case Outbound:
+		return "OUT"
+	default:
+		return "??"
+	}
+}
+
+// Record is a packet read from a capture
+type Record struct {
+	Time      time.Time
+	Direction Direction
+	UserID    int64
+	Type      proto.PacketType
+	Packet    *proto.AyiPacket
+}
+
+// Writer appends packets to a capture. It is safe to use from several
+// goroutines, i.e. the read and write loops of a session.
+type Writer struct {
+	mutex  sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// Create creates a new capture file at path
+func Create(path string) (*Writer, error) {
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := NewWriter(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	w.closer = file
+	return w, nil
+}
+
+// NewWriter writes capture header to w and returns a Writer for it
+func NewWriter(w io.Writer) (*Writer, error) {
+	if _, err := io.WriteString(w, magic); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w}, nil
+}
+
+// Write appends packet to the capture. Records are written with a single call
+// to the underlying writer so that a capture is readable while it is written.
+func (w *Writer) Write(direction Direction, userID int64, packet *proto.AyiPacket) error {
+
+	data := packet.Marshal()
+	if data == nil {
+		return ErrInvalidPacket
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, recordHeaderLen+len(data)))
+	binary.Write(buf, binary.BigEndian, time.Now().UnixNano())
+	buf.WriteByte(byte(direction))
+	binary.Write(buf, binary.BigEndian, userID)
+	buf.WriteByte(byte(packet.Type()))
+	binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	buf.Write(data)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.w == nil {
+		return os.ErrClosed
+	}
+
+	_, err := w.w.Write(buf.Bytes())
+	return err
+}
+
+// Close closes the capture. Further writes fail.
+func (w *Writer) Close() error {
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.w = nil
+	if w.closer != nil {
+		return w.closer.Close()
+	}
+
+	return nil
+}
+
+// Reader reads records from a capture
+type Reader struct {
+	r      *bufio.Reader
+	closer io.Closer
+}
+
+// Open opens the capture file at path
+func Open(path string) (*Reader, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	r.closer = file
+	return r, nil
+}
+
+// NewReader checks capture header from r and returns a Reader for it
+func NewReader(r io.Reader) (*Reader, error) {
+
+	reader := &Reader{r: bufio.NewReader(r)}
+
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(reader.r, header); err != nil || string(header) != magic {
+		return nil, ErrInvalidFormat
+	}
+
+	return reader, nil
+}
+
+// Next returns next record in the capture or io.EOF at the end of it. A record
+// truncated because the capture was still being written returns
+// io.ErrUnexpectedEOF.
+func (r *Reader) Next() (*Record, error) {
+
+	header := make([]byte, recordHeaderLen)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return nil, err
+	}
+
+	record := &Record{
+		Time:      time.Unix(0, int64(binary.BigEndian.Uint64(header[0:8]))),
+		Direction: Direction(header[8]),
+		UserID:    int64(binary.BigEndian.Uint64(header[9:17])),
+		Type:      proto.PacketType(header[17]),
+	}
+
+	length := binary.BigEndian.Uint32(header[18:22])
+	if length > maxRecordLen {
+		return nil, ErrRecordTooBig
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	packet, err := proto.ReadPacket(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	record.Packet = packet
+	return record, nil
+}
+
+// Close closes the capture file if the reader was created with Open
+func (r *Reader) Close() error {
+	if r.closer != nil {
+		return r.closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package capture
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+)
+
+func TestWriteAndReadCapture(t *testing.T) {
+
+	buf := &bytes.Buffer{}
+
+	w, err := NewWriter(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := proto.NewPacket(proto.VERSION_3).Request(proto.M_CANCEL_EVENT, &proto.CancelEvent{EventId: 1234})
+	request.Header.SetToken(5)
+	response := proto.NewPacket(proto.VERSION_3).Ok(proto.M_CANCEL_EVENT)
+	response.Header.SetToken(5 | 1<<15)
+
+	if err := w.Write(Inbound, 100, request); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(Outbound, 100, response); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.Direction != Inbound || record.UserID != 100 || record.Type != proto.M_CANCEL_EVENT ||
+		record.Packet.Id() != 5 {
+		t.Fatalf("unexpected record %v", record)
+	}
+
+	message, err := record.Packet.DecodeMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := message.(*proto.CancelEvent); !ok || msg.EventId != 1234 {
+		t.Fatalf("unexpected message %v", message)
+	}
+
+	record, err = r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.Direction != Outbound || record.Type != proto.M_OK || !record.Packet.IsResponse() ||
+		record.Packet.ResponseId() != 5 {
+		t.Fatalf("unexpected record %v", record)
+	}
+
+	if _, err := r.Next(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestTruncatedCapture(t *testing.T) {
+
+	buf := &bytes.Buffer{}
+
+	w, _ := NewWriter(buf)
+	w.Write(Inbound, 100, proto.NewPacket(proto.VERSION_3).Ping())
+
+	data := buf.Bytes()
+	r, err := NewReader(bytes.NewReader(data[:len(data)-2]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Next(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected unexpected EOF, got %v", err)
+	}
+}
+
+func TestInvalidCapture(t *testing.T) {
+	if _, err := NewReader(bytes.NewReader([]byte("not a capture"))); err != ErrInvalidFormat {
+		t.Fatalf("expected invalid format, got %v", err)
+	}
+}
+
+func TestWriteAfterClose(t *testing.T) {
+	w, _ := NewWriter(&bytes.Buffer{})
+	w.Close()
+	if err := w.Write(Inbound, 1, proto.NewPacket(proto.VERSION_3).Ping()); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,79 @@
+package protocol
+
+import (
+	"github.com/golang/snappy"
+)
+
+const (
+	COMPRESSION_SNAPPY = "snappy"
+
+	// Payloads smaller than this aren't worth compressing
+	minCompressSizeBytes = 512
+
+	// Max size of a payload once decompressed. Compressed payloads are still
+	// limited to maxPayloadSizeBytes when read.
+	maxDecodedPayloadSizeBytes = 8 * maxPayloadSizeBytes
+)
+
+// SupportsCompression returns true if a client supports compressed payloads
+// with the given algorithm according to its HELLO message
+func SupportsCompression(hello *Hello, algorithm string) bool {
+	for _, c := range hello.Compression {
+		if c == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalCompressed is like Marshal but compresses the payload with snappy.
+// Small payloads, or those that don't shrink, are sent as they are. Packet
+// isn't modified, so it can be written to sessions that don't support
+// compression as well.
+func (packet *AyiPacket) MarshalCompressed() []byte {
+
+	header, ok := packet.Header.(*AyiHeaderV2)
+	if !ok || len(packet.Data) < minCompressSizeBytes {
+		return packet.Marshal()
+	}
+
+	data := snappy.Encode(nil, packet.Data)
+	if len(data) >= len(packet.Data) {
+		return packet.Marshal()
+	}
+
+	compressedHeader := *header
+	compressedHeader.SetCompressed(true)
+	compressedHeader.SetSize(uint(len(data)))
+
+	compressed := &AyiPacket{
+		Header: &compressedHeader,
+		Data:   data,
+	}
+
+	return compressed.Marshal()
+}
+
+// Replaces a compressed payload with its decompressed version
+func (packet *AyiPacket) decompress() error {
+
+	size, err := snappy.DecodedLen(packet.Data)
+	if err != nil {
+		return ErrInvalidCompression
+	}
+
+	if size > maxDecodedPayloadSizeBytes {
+		return ErrMaxPayloadExceeded
+	}
+
+	data, err := snappy.Decode(nil, packet.Data)
+	if err != nil {
+		return ErrInvalidCompression
+	}
+
+	packet.Data = data
+	packet.Header.SetCompressed(false)
+	packet.Header.SetSize(uint(len(data)))
+
+	return nil
+}
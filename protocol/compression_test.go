@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/d3ce1t/areyouin-server/protocol/core"
+)
+
+func newEventsListPacket(numEvents int) *AyiPacket {
+	events := make([]*core.Event, 0, numEvents)
+	for i := 0; i < numEvents; i++ {
+		events = append(events, &core.Event{EventId: int64(i), Message: strings.Repeat("Let's meet ", 10)})
+	}
+	packet := NewPacket(VERSION_3).EventsList(events)
+	packet.Header.SetToken(10)
+	return packet
+}
+
+func TestCompressedPacketRoundtrip(t *testing.T) {
+
+	packet := newEventsListPacket(50)
+
+	data := packet.MarshalCompressed()
+	if len(data) >= len(packet.Marshal()) {
+		t.Fatalf("payload not compressed (%v >= %v bytes)", len(data), len(packet.Marshal()))
+	}
+
+	// Original packet is not modified
+	if packet.Header.IsCompressed() {
+		t.Fatal("original packet modified")
+	}
+
+	read, err := ReadPacket(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read.Header.IsCompressed() || read.Id() != 10 || read.Type() != M_EVENTS_LIST ||
+		!bytes.Equal(read.Data, packet.Data) || read.Header.GetSize() != uint(len(packet.Data)) {
+		t.Fatalf("unexpected packet %v", read)
+	}
+}
+
+func TestSmallPayloadNotCompressed(t *testing.T) {
+
+	packet := newEventsListPacket(1)
+
+	if !bytes.Equal(packet.MarshalCompressed(), packet.Marshal()) {
+		t.Fatal("small payload compressed")
+	}
+}
+
+func TestInvalidCompressedPayload(t *testing.T) {
+
+	packet := NewPacket(VERSION_3).Request(M_CANCEL_EVENT, nil)
+	packet.Header.SetCompressed(true)
+	packet.Data = []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01}
+	packet.Header.SetSize(uint(len(packet.Data)))
+
+	if _, err := ReadPacket(bytes.NewReader(packet.Marshal())); err != ErrInvalidCompression {
+		t.Fatalf("expected invalid compression, got %v", err)
+	}
+}
+
+func TestSupportsCompression(t *testing.T) {
+
+	if SupportsCompression(&Hello{}, COMPRESSION_SNAPPY) {
+		t.Fatal("compression not requested")
+	}
+
+	if !SupportsCompression(&Hello{Compression: []string{"gzip", COMPRESSION_SNAPPY}}, COMPRESSION_SNAPPY) {
+		t.Fatal("snappy not detected")
+	}
+}
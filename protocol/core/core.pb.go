@@ -171,21 +171,27 @@ func (*UserAccount) ProtoMessage()               {}
 func (*UserAccount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
 type Event struct {
-	EventId       int64                       `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
-	AuthorId      int64                       `protobuf:"varint,2,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
-	AuthorName    string                      `protobuf:"bytes,3,opt,name=author_name,json=authorName" json:"author_name,omitempty"`
-	StartDate     int64                       `protobuf:"varint,4,opt,name=start_date,json=startDate" json:"start_date,omitempty"`
-	EndDate       int64                       `protobuf:"varint,5,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
-	Message       string                      `protobuf:"bytes,6,opt,name=message" json:"message,omitempty"`
-	IsPublic      bool                        `protobuf:"varint,7,opt,name=is_public,json=isPublic" json:"is_public,omitempty"`
-	Geolocation   *Location                   `protobuf:"bytes,8,opt,name=geolocation" json:"geolocation,omitempty"`
-	NumAttendees  int32                       `protobuf:"varint,9,opt,name=num_attendees,json=numAttendees" json:"num_attendees,omitempty"`
-	NumGuests     int32                       `protobuf:"varint,10,opt,name=num_guests,json=numGuests" json:"num_guests,omitempty"`
-	CreatedDate   int64                       `protobuf:"varint,11,opt,name=created_date,json=createdDate" json:"created_date,omitempty"`
-	Participants  map[int64]*EventParticipant `protobuf:"bytes,12,rep,name=participants" json:"participants,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	InboxPosition int64                       `protobuf:"varint,13,opt,name=inbox_position,json=inboxPosition" json:"inbox_position,omitempty"`
-	State         EventState                  `protobuf:"varint,14,opt,name=state,enum=core.EventState" json:"state,omitempty"`
-	PictureDigest []byte                      `protobuf:"bytes,15,opt,name=picture_digest,json=pictureDigest,proto3" json:"picture_digest,omitempty"`
+	EventId        int64                       `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	AuthorId       int64                       `protobuf:"varint,2,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
+	AuthorName     string                      `protobuf:"bytes,3,opt,name=author_name,json=authorName" json:"author_name,omitempty"`
+	StartDate      int64                       `protobuf:"varint,4,opt,name=start_date,json=startDate" json:"start_date,omitempty"`
+	EndDate        int64                       `protobuf:"varint,5,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
+	Message        string                      `protobuf:"bytes,6,opt,name=message" json:"message,omitempty"`
+	IsPublic       bool                        `protobuf:"varint,7,opt,name=is_public,json=isPublic" json:"is_public,omitempty"`
+	Geolocation    *Location                   `protobuf:"bytes,8,opt,name=geolocation" json:"geolocation,omitempty"`
+	NumAttendees   int32                       `protobuf:"varint,9,opt,name=num_attendees,json=numAttendees" json:"num_attendees,omitempty"`
+	NumGuests      int32                       `protobuf:"varint,10,opt,name=num_guests,json=numGuests" json:"num_guests,omitempty"`
+	CreatedDate    int64                       `protobuf:"varint,11,opt,name=created_date,json=createdDate" json:"created_date,omitempty"`
+	Participants   map[int64]*EventParticipant `protobuf:"bytes,12,rep,name=participants" json:"participants,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	InboxPosition  int64                       `protobuf:"varint,13,opt,name=inbox_position,json=inboxPosition" json:"inbox_position,omitempty"`
+	State          EventState                  `protobuf:"varint,14,opt,name=state,enum=core.EventState" json:"state,omitempty"`
+	PictureDigest  []byte                      `protobuf:"bytes,15,opt,name=picture_digest,json=pictureDigest,proto3" json:"picture_digest,omitempty"`
+	SeriesId       int64                       `protobuf:"varint,16,opt,name=series_id,json=seriesId" json:"series_id,omitempty"`
+	Capacity       int32                       `protobuf:"varint,17,opt,name=capacity" json:"capacity,omitempty"`
+	Waitlist       []int64                     `protobuf:"varint,18,rep,packed,name=waitlist" json:"waitlist,omitempty"`
+	Quorum         int32                       `protobuf:"varint,19,opt,name=quorum" json:"quorum,omitempty"`
+	QuorumDeadline int64                       `protobuf:"varint,20,opt,name=quorum_deadline,json=quorumDeadline" json:"quorum_deadline,omitempty"`
+	Confirmed      bool                        `protobuf:"varint,21,opt,name=confirmed" json:"confirmed,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -292,64 +298,70 @@ func init() {
 func init() { proto.RegisterFile("core.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 936 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x55, 0x5f, 0x8f, 0xdb, 0x44,
-	0x10, 0x27, 0x76, 0x7c, 0x71, 0xc6, 0x49, 0x9a, 0x6e, 0x2b, 0x30, 0x85, 0x42, 0x09, 0xe2, 0x8f,
-	0x02, 0x8a, 0xaa, 0x83, 0x87, 0x8a, 0xb7, 0x34, 0xf1, 0x85, 0xa8, 0x91, 0x13, 0xad, 0x7d, 0xad,
-	0x90, 0x90, 0x22, 0xc7, 0xde, 0xa6, 0x56, 0x12, 0xaf, 0xf1, 0x9f, 0x40, 0xf8, 0x0c, 0x7c, 0x0a,
-	0x5e, 0xf9, 0x8a, 0x3c, 0x30, 0xbb, 0xeb, 0x5c, 0xc2, 0xdd, 0xf1, 0xc0, 0xdb, 0xcc, 0x6f, 0x66,
-	0x67, 0x7e, 0x3f, 0xef, 0xcc, 0x1a, 0x20, 0xe4, 0x19, 0x1b, 0xa4, 0x19, 0x2f, 0x38, 0xa9, 0x0b,
-	0xbb, 0xf7, 0x47, 0x0d, 0xac, 0xeb, 0x9c, 0x65, 0xc3, 0x30, 0xe4, 0x65, 0x52, 0x10, 0x02, 0xf5,
-	0x24, 0xd8, 0x31, 0xbb, 0xf6, 0xac, 0xf6, 0x75, 0x93, 0x4a, 0x9b, 0x3c, 0x06, 0x83, 0xed, 0x82,
-	0x78, 0x6b, 0x6b, 0x12, 0x54, 0x0e, 0xb1, 0xa1, 0x91, 0xc6, 0x61, 0x51, 0x66, 0xcc, 0xd6, 0x11,
-	0x6f, 0xd1, 0xa3, 0x4b, 0xbe, 0x80, 0x4e, 0x65, 0x2e, 0xa3, 0x78, 0xcd, 0xf2, 0xc2, 0xae, 0xcb,
-	0x84, 0x76, 0x85, 0x8e, 0x25, 0x28, 0x5a, 0xbd, 0x5d, 0x4d, 0x23, 0xdb, 0x50, 0xad, 0x84, 0xdd,
-	0xfb, 0xbb, 0x0e, 0x86, 0xb3, 0x67, 0x48, 0xe4, 0x43, 0x30, 0x99, 0x30, 0x96, 0x71, 0x24, 0xc9,
-	0xe8, 0xb4, 0x21, 0xfd, 0x69, 0x44, 0x3e, 0x82, 0x66, 0x50, 0x16, 0xef, 0x78, 0x26, 0x62, 0x9a,
-	0x8c, 0x99, 0x0a, 0xc0, 0xe0, 0xa7, 0x60, 0x55, 0x41, 0xa9, 0x43, 0x97, 0xc5, 0x41, 0x41, 0xae,
-	0x50, 0xf3, 0x14, 0x20, 0x2f, 0x82, 0xac, 0x58, 0x46, 0x41, 0xc1, 0x24, 0x33, 0x9d, 0x36, 0x25,
-	0x32, 0x46, 0x40, 0xf6, 0x4d, 0x22, 0x15, 0x34, 0xaa, 0xbe, 0x49, 0x24, 0x43, 0xa8, 0x78, 0xc7,
-	0xf2, 0x3c, 0x58, 0x33, 0xfb, 0x42, 0x96, 0x3d, 0xba, 0x82, 0x51, 0x9c, 0x2f, 0xd3, 0x72, 0xb5,
-	0x8d, 0x43, 0xbb, 0x81, 0x31, 0x93, 0x9a, 0x71, 0xbe, 0x90, 0x3e, 0x79, 0x0e, 0xd6, 0x9a, 0xf1,
-	0x2d, 0x0f, 0x83, 0x22, 0xe6, 0x89, 0x6d, 0x62, 0xd8, 0xba, 0xec, 0x0c, 0xe4, 0x55, 0xcc, 0x2a,
-	0x94, 0x9e, 0xa7, 0x90, 0xcf, 0xa1, 0x9d, 0x94, 0xbb, 0x65, 0x50, 0x14, 0xd8, 0x9a, 0xb1, 0xdc,
-	0x6e, 0xe2, 0x19, 0x83, 0xb6, 0x10, 0x1c, 0x1e, 0x31, 0xa1, 0x43, 0x24, 0xad, 0x4b, 0xfc, 0x96,
-	0xb9, 0x0d, 0x32, 0xa3, 0x89, 0xc8, 0x44, 0x02, 0xe4, 0x33, 0x68, 0x85, 0x19, 0x43, 0xda, 0x95,
-	0x16, 0x4b, 0x6a, 0xb1, 0x2a, 0x4c, 0xea, 0x19, 0x42, 0x2b, 0x45, 0xd9, 0x71, 0x18, 0xa7, 0x41,
-	0x82, 0x35, 0x5a, 0xcf, 0x74, 0x64, 0xf6, 0x54, 0x31, 0x93, 0xb7, 0x30, 0x58, 0x9c, 0xc5, 0x9d,
-	0xa4, 0xc8, 0x0e, 0xf4, 0x5f, 0x47, 0xc4, 0x55, 0xc7, 0xc9, 0x8a, 0xff, 0xb6, 0x4c, 0x79, 0x1e,
-	0x4b, 0x79, 0x6d, 0xd9, 0xa7, 0x2d, 0xd1, 0x45, 0x05, 0x92, 0x2f, 0xc1, 0xc0, 0x2f, 0x8c, 0x2c,
-	0x3a, 0x18, 0xed, 0x5c, 0x76, 0xcf, 0x5a, 0x78, 0x02, 0xa7, 0x2a, 0x7c, 0xcf, 0xe4, 0x3c, 0xb8,
-	0x67, 0x72, 0x9e, 0xbc, 0x81, 0x87, 0x77, 0x88, 0x91, 0x2e, 0xe8, 0x1b, 0x76, 0xa8, 0x66, 0x45,
-	0x98, 0xe4, 0x5b, 0x30, 0xf6, 0xc1, 0xb6, 0x64, 0x72, 0x46, 0xac, 0xcb, 0xf7, 0xcf, 0xba, 0x9e,
-	0x1d, 0xa7, 0x2a, 0xe9, 0x07, 0xed, 0x45, 0xad, 0x37, 0x06, 0xf3, 0x78, 0x23, 0xe4, 0x09, 0x98,
-	0x5b, 0xb4, 0x8a, 0x32, 0x52, 0xdb, 0xa0, 0xd1, 0x1b, 0x9f, 0x7c, 0x0c, 0xcd, 0x2d, 0x4f, 0xd6,
-	0x2a, 0xa8, 0xc9, 0xe0, 0x09, 0xe8, 0xfd, 0x55, 0x83, 0xee, 0xed, 0x2e, 0xe4, 0x03, 0x68, 0x94,
-	0xb8, 0x67, 0xa7, 0x71, 0xbe, 0x10, 0x2e, 0x0e, 0xec, 0x71, 0xe3, 0xb4, 0xb3, 0x8d, 0xfb, 0x1e,
-	0xcc, 0x8c, 0xe5, 0x29, 0x4f, 0x72, 0x35, 0xc1, 0x9d, 0x4b, 0x5b, 0x91, 0x57, 0xd7, 0x1f, 0x24,
-	0x21, 0xa3, 0x55, 0x9c, 0xde, 0x64, 0xe2, 0xa9, 0x66, 0xc4, 0xb6, 0xf1, 0x9e, 0x65, 0x2c, 0x92,
-	0x83, 0xdd, 0x39, 0x6a, 0x9e, 0x26, 0xfb, 0xb8, 0x90, 0xb2, 0xc4, 0xe7, 0x2e, 0x73, 0x7a, 0x4a,
-	0xec, 0xfd, 0x0c, 0x17, 0x57, 0x59, 0x8c, 0x55, 0xff, 0x1f, 0xc5, 0xbb, 0x57, 0xa5, 0xdf, 0x73,
-	0x55, 0xbd, 0x9f, 0xc0, 0x98, 0x64, 0xbc, 0x4c, 0x49, 0x07, 0xb4, 0xaa, 0xae, 0x41, 0xd1, 0xba,
-	0xb7, 0x26, 0x62, 0x79, 0xfc, 0xbb, 0x92, 0x6c, 0x50, 0x69, 0xab, 0xa5, 0xdb, 0xad, 0x58, 0x96,
-	0xa3, 0x24, 0x5d, 0xac, 0x63, 0xe5, 0xf6, 0x0e, 0xd0, 0x56, 0xc4, 0x29, 0xfb, 0x45, 0xcc, 0xbc,
-	0xd8, 0xc2, 0xb7, 0x12, 0x38, 0x29, 0x30, 0x15, 0xf0, 0x1f, 0x1a, 0x6e, 0x1e, 0x36, 0xfd, 0xfc,
-	0x61, 0xbb, 0xbd, 0x39, 0xf5, 0x3b, 0x9b, 0xd3, 0x7b, 0x01, 0x8f, 0xae, 0x82, 0x90, 0xad, 0x38,
-	0xdf, 0xe0, 0xc3, 0x89, 0x8f, 0x80, 0xcf, 0x37, 0x2c, 0x11, 0x27, 0x03, 0xe9, 0x2e, 0x0b, 0xe1,
-	0x57, 0x8f, 0xa8, 0x15, 0x9c, 0x52, 0xfa, 0xdf, 0x40, 0xdb, 0x3b, 0x24, 0xe1, 0x4b, 0xf6, 0x2e,
-	0xd8, 0xc7, 0xbc, 0xcc, 0x48, 0x0b, 0x4c, 0x9f, 0x5e, 0xbb, 0xa3, 0xa1, 0xef, 0x74, 0xdf, 0x13,
-	0xde, 0x82, 0x3a, 0x9e, 0x43, 0x5f, 0x3b, 0xdd, 0x5a, 0xdf, 0x03, 0x72, 0xf7, 0xc2, 0xc9, 0x03,
-	0xb0, 0xdc, 0xf9, 0x12, 0xb3, 0x16, 0x73, 0xd7, 0x13, 0x87, 0xda, 0xd0, 0x44, 0x60, 0xe8, 0x79,
-	0x53, 0xcf, 0xef, 0xd6, 0xc8, 0x43, 0x68, 0x8f, 0x86, 0xae, 0x3b, 0xf7, 0x8f, 0x90, 0x46, 0x00,
-	0x2e, 0x2a, 0x5b, 0xef, 0x4f, 0x00, 0x4e, 0x8b, 0xa7, 0x8a, 0xf9, 0x4b, 0xcf, 0x1f, 0x52, 0xdf,
-	0x19, 0x63, 0x31, 0x0b, 0x1a, 0x73, 0x77, 0x32, 0x9f, 0xba, 0x13, 0x2c, 0x85, 0x74, 0xae, 0xa6,
-	0xee, 0xd4, 0xfb, 0x11, 0x43, 0x9a, 0xe8, 0x83, 0x85, 0x47, 0xce, 0x6c, 0x86, 0xae, 0xde, 0x5f,
-	0x40, 0xf7, 0xf6, 0x5c, 0xe1, 0x12, 0xb6, 0x90, 0xca, 0xd8, 0x99, 0x4d, 0x5f, 0x3b, 0x54, 0xd6,
-	0x7b, 0x0c, 0x5d, 0x29, 0x87, 0x9e, 0xa1, 0x35, 0x81, 0x8e, 0x66, 0x53, 0xc7, 0xf5, 0xcf, 0x50,
-	0xad, 0xff, 0x1c, 0x1e, 0x55, 0xff, 0xa1, 0x45, 0xc6, 0xf7, 0x71, 0xc4, 0x32, 0xff, 0x90, 0x32,
-	0x41, 0xe9, 0xda, 0x7d, 0xe5, 0xce, 0xdf, 0xb8, 0xea, 0x0b, 0x5d, 0x0d, 0x47, 0xce, 0xcb, 0xf9,
-	0xfc, 0x55, 0xb7, 0xf6, 0xf2, 0x2b, 0xf8, 0x84, 0xe5, 0x83, 0x94, 0xb1, 0x74, 0xcb, 0x06, 0x41,
-	0xc6, 0x0e, 0xbc, 0x8c, 0x93, 0x41, 0x1e, 0x6d, 0x06, 0x09, 0x2b, 0x7e, 0xe5, 0xd9, 0xe6, 0x4f,
-	0xad, 0x3e, 0xc2, 0x0d, 0x58, 0x5d, 0xc8, 0x9f, 0xde, 0x77, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff,
-	0x50, 0xf9, 0x79, 0x09, 0x02, 0x07, 0x00, 0x00,
+	// 1035 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdd, 0x8e, 0xdb, 0x36,
+	0x13, 0x8d, 0xfc, 0xb7, 0xf6, 0xf8, 0x27, 0x0a, 0x93, 0x2f, 0x9f, 0x9a, 0x34, 0xad, 0xeb, 0xa2,
+	0x8d, 0xb1, 0x2d, 0x8c, 0x60, 0x9b, 0x8b, 0xa0, 0x77, 0x8e, 0xad, 0xdd, 0x0a, 0x59, 0xc8, 0x06,
+	0xed, 0x24, 0x28, 0x50, 0x40, 0xe0, 0x4a, 0x93, 0x0d, 0xb1, 0x36, 0xa5, 0x90, 0x94, 0x53, 0xf7,
+	0x19, 0xfa, 0x14, 0xbd, 0xed, 0x4d, 0x1f, 0xb1, 0x20, 0x25, 0xaf, 0xdd, 0xec, 0xf6, 0xa2, 0x77,
+	0x73, 0xce, 0x0c, 0x39, 0x73, 0xc4, 0x43, 0x0a, 0x20, 0x4e, 0x25, 0x8e, 0x32, 0x99, 0xea, 0x94,
+	0xd4, 0x4c, 0x3c, 0xf8, 0xdd, 0x81, 0xf6, 0x6b, 0x85, 0x72, 0x1c, 0xc7, 0x69, 0x2e, 0x34, 0x21,
+	0x50, 0x13, 0x6c, 0x8d, 0x9e, 0xd3, 0x77, 0x86, 0x2d, 0x6a, 0x63, 0xf2, 0x00, 0xea, 0xb8, 0x66,
+	0x7c, 0xe5, 0x55, 0x2c, 0x59, 0x00, 0xe2, 0xc1, 0x51, 0xc6, 0x63, 0x9d, 0x4b, 0xf4, 0xaa, 0x7d,
+	0x67, 0xd8, 0xa1, 0x3b, 0x48, 0xbe, 0x81, 0x5e, 0x19, 0x46, 0x09, 0xbf, 0x44, 0xa5, 0xbd, 0x9a,
+	0x2d, 0xe8, 0x96, 0xec, 0xd4, 0x92, 0xa6, 0xd5, 0xbb, 0x8b, 0x20, 0xf1, 0xea, 0x45, 0x2b, 0x13,
+	0x0f, 0xfe, 0x6a, 0x40, 0xdd, 0xdf, 0xa0, 0xd0, 0xe4, 0x33, 0x68, 0xa2, 0x09, 0x22, 0x9e, 0xd8,
+	0x61, 0xaa, 0xf4, 0xc8, 0xe2, 0x20, 0x21, 0x8f, 0xa1, 0xc5, 0x72, 0xfd, 0x3e, 0x95, 0x26, 0x57,
+	0xb1, 0xb9, 0x66, 0x41, 0x04, 0x09, 0xf9, 0x12, 0xda, 0x65, 0xd2, 0xea, 0xa8, 0xda, 0xcd, 0xa1,
+	0xa0, 0x42, 0xa3, 0xe6, 0x09, 0x80, 0xd2, 0x4c, 0xea, 0x28, 0x61, 0x1a, 0xed, 0x64, 0x55, 0xda,
+	0xb2, 0xcc, 0x94, 0x69, 0xb4, 0x7d, 0x45, 0x52, 0x24, 0xeb, 0x65, 0x5f, 0x91, 0xd8, 0x94, 0x07,
+	0x47, 0x6b, 0x54, 0x8a, 0x5d, 0xa2, 0xd7, 0xb0, 0xdb, 0xee, 0xa0, 0x99, 0x88, 0xab, 0x28, 0xcb,
+	0x2f, 0x56, 0x3c, 0xf6, 0x8e, 0xfa, 0xce, 0xb0, 0x49, 0x9b, 0x5c, 0xcd, 0x2d, 0x26, 0xcf, 0xa0,
+	0x7d, 0x89, 0xe9, 0x2a, 0x8d, 0x99, 0xe6, 0xa9, 0xf0, 0x9a, 0x7d, 0x67, 0xd8, 0x3e, 0xe9, 0x8d,
+	0xec, 0x51, 0x9c, 0x97, 0x2c, 0x3d, 0x2c, 0x21, 0x5f, 0x43, 0x57, 0xe4, 0xeb, 0x88, 0x69, 0x8d,
+	0x22, 0x41, 0x54, 0x5e, 0xab, 0xef, 0x0c, 0xeb, 0xb4, 0x23, 0xf2, 0xf5, 0x78, 0xc7, 0x19, 0x1d,
+	0xa6, 0xe8, 0x32, 0x47, 0xa5, 0x95, 0x07, 0xb6, 0xa2, 0x25, 0xf2, 0xf5, 0x99, 0x25, 0xc8, 0x57,
+	0xd0, 0x89, 0x25, 0x32, 0x8d, 0xa5, 0x96, 0xb6, 0xd5, 0xd2, 0x2e, 0x39, 0xab, 0x67, 0x0c, 0x9d,
+	0x8c, 0x49, 0xcd, 0x63, 0x9e, 0x31, 0xa1, 0x95, 0xd7, 0xe9, 0x57, 0x87, 0xed, 0x93, 0x27, 0xc5,
+	0x64, 0xf6, 0x14, 0x46, 0xf3, 0x83, 0xbc, 0x2f, 0xb4, 0xdc, 0xd2, 0x7f, 0x2c, 0x31, 0x47, 0xcd,
+	0xc5, 0x45, 0xfa, 0x6b, 0x94, 0xa5, 0x8a, 0x5b, 0x79, 0x5d, 0xdb, 0xa7, 0x6b, 0xd9, 0x79, 0x49,
+	0x92, 0x6f, 0xa1, 0xae, 0xb4, 0x99, 0xa2, 0xd7, 0x77, 0x86, 0xbd, 0x13, 0xf7, 0xa0, 0xc5, 0xc2,
+	0xf0, 0xb4, 0x48, 0xdf, 0xe2, 0x9c, 0xbb, 0xb7, 0x39, 0xe7, 0x31, 0xb4, 0x14, 0x4a, 0x8e, 0xca,
+	0x18, 0xc0, 0x2d, 0x0c, 0x50, 0x10, 0x41, 0x42, 0x1e, 0x41, 0x33, 0x66, 0x19, 0x8b, 0xb9, 0xde,
+	0x7a, 0xf7, 0xec, 0x57, 0xb9, 0xc6, 0x26, 0xf7, 0x91, 0x71, 0xbd, 0xe2, 0x4a, 0x7b, 0xa4, 0x5f,
+	0x35, 0xeb, 0x76, 0x98, 0x3c, 0x84, 0xc6, 0x87, 0x3c, 0x95, 0xf9, 0xda, 0xbb, 0x6f, 0x57, 0x95,
+	0x88, 0x3c, 0x85, 0xbb, 0x45, 0x14, 0x25, 0xc8, 0x92, 0x15, 0x17, 0xe8, 0x3d, 0xb0, 0x2d, 0x7b,
+	0x05, 0x3d, 0x2d, 0x59, 0xf2, 0x39, 0xb4, 0xe2, 0x54, 0xbc, 0xe3, 0x72, 0x8d, 0x89, 0xf7, 0x3f,
+	0x6b, 0x82, 0x3d, 0xf1, 0xe8, 0x2d, 0xdc, 0xbb, 0xf1, 0x31, 0x89, 0x0b, 0xd5, 0x2b, 0xdc, 0x96,
+	0xfe, 0x36, 0x21, 0xf9, 0x1e, 0xea, 0x1b, 0xb6, 0xca, 0xd1, 0xfa, 0xba, 0x7d, 0xf2, 0xf0, 0xe0,
+	0x4b, 0x1d, 0x2c, 0xa7, 0x45, 0xd1, 0x8f, 0x95, 0x17, 0xce, 0x60, 0x0a, 0xcd, 0x9d, 0x8b, 0x8c,
+	0xbe, 0x15, 0xd3, 0x5c, 0xe7, 0x49, 0x71, 0x83, 0x2b, 0xf4, 0x1a, 0x9b, 0xf1, 0x56, 0xa9, 0xb8,
+	0x2c, 0x92, 0x15, 0x9b, 0xdc, 0x13, 0x83, 0x3f, 0x1d, 0x70, 0x3f, 0xed, 0x42, 0xfe, 0x0f, 0x47,
+	0xb9, 0x42, 0xb9, 0xbf, 0x82, 0x0d, 0x03, 0x83, 0xe4, 0xfa, 0x95, 0xa8, 0x1c, 0xbc, 0x12, 0xcf,
+	0xa1, 0x29, 0x51, 0x65, 0xa9, 0x50, 0xc5, 0xad, 0xeb, 0x9d, 0x78, 0xc5, 0xf0, 0x85, 0x65, 0x99,
+	0x88, 0x91, 0x96, 0x79, 0x7a, 0x5d, 0x49, 0x9e, 0x43, 0x2b, 0xc1, 0x15, 0xdf, 0xa0, 0xc4, 0xc4,
+	0x5e, 0xc6, 0xde, 0x4e, 0x73, 0x20, 0x36, 0x5c, 0x5b, 0x59, 0xc6, 0x22, 0xb9, 0xa2, 0xfb, 0xc2,
+	0xc1, 0x2f, 0xd0, 0x38, 0x95, 0x1c, 0x45, 0xf2, 0xdf, 0x46, 0xbc, 0x69, 0xaf, 0xea, 0x2d, 0xf6,
+	0x1a, 0xfc, 0x0c, 0xf5, 0x33, 0x99, 0xe6, 0x19, 0xe9, 0x41, 0xa5, 0xdc, 0xb7, 0x4e, 0x2b, 0xfc,
+	0xf6, 0x3d, 0x09, 0xd4, 0x14, 0xff, 0xad, 0x90, 0x5c, 0xa7, 0x36, 0x2e, 0x1e, 0x8a, 0xf5, 0x05,
+	0x4a, 0xe5, 0xd5, 0xac, 0xcb, 0x76, 0x70, 0xb0, 0x85, 0x6e, 0x31, 0x38, 0xc5, 0x0f, 0x79, 0x69,
+	0xe5, 0x77, 0x96, 0xd8, 0x2b, 0x68, 0x16, 0xc4, 0xbf, 0x68, 0xb8, 0x7e, 0x8c, 0xab, 0x87, 0x8f,
+	0xf1, 0xa7, 0xb7, 0xbd, 0x76, 0xe3, 0xb6, 0x0f, 0x5e, 0xc0, 0xfd, 0x53, 0x16, 0xe3, 0x45, 0x9a,
+	0x5e, 0x8d, 0xe3, 0x18, 0x95, 0x5a, 0xa6, 0x57, 0x28, 0xcc, 0x4a, 0x66, 0x61, 0xa4, 0x0d, 0x2e,
+	0x1f, 0xfe, 0x36, 0xdb, 0x97, 0x1c, 0x7f, 0x07, 0xdd, 0xc5, 0x56, 0xc4, 0x2f, 0xf1, 0x3d, 0xdb,
+	0xf0, 0x34, 0x97, 0xa4, 0x03, 0xcd, 0x25, 0x7d, 0x1d, 0x4e, 0xc6, 0x4b, 0xdf, 0xbd, 0x63, 0xd0,
+	0x9c, 0xfa, 0x0b, 0x9f, 0xbe, 0xf1, 0x5d, 0xe7, 0x78, 0x01, 0xe4, 0xe6, 0x81, 0x93, 0xbb, 0xd0,
+	0x0e, 0x67, 0x11, 0xf5, 0x17, 0xf3, 0x59, 0xb8, 0x30, 0x8b, 0xba, 0xd0, 0x0a, 0x67, 0xd1, 0x78,
+	0xb1, 0x08, 0x16, 0x4b, 0xd7, 0x21, 0xf7, 0xa0, 0x3b, 0x19, 0x87, 0xe1, 0x6c, 0xb9, 0xa3, 0x2a,
+	0x04, 0xa0, 0x51, 0xc6, 0xd5, 0xe3, 0x33, 0x80, 0xfd, 0x63, 0x51, 0x6c, 0xb6, 0x8c, 0x16, 0xcb,
+	0x31, 0x5d, 0xfa, 0x53, 0xf7, 0x0e, 0x69, 0xc3, 0xd1, 0x2c, 0x3c, 0x9b, 0x05, 0xe1, 0x99, 0xeb,
+	0x98, 0x71, 0x4e, 0x83, 0x30, 0x58, 0xfc, 0xe4, 0x4f, 0xdd, 0x8a, 0xe9, 0x33, 0x19, 0x87, 0x13,
+	0xff, 0xfc, 0xdc, 0x9f, 0xba, 0xd5, 0xe3, 0x39, 0xb8, 0x9f, 0xfa, 0x8a, 0xb8, 0xd0, 0x09, 0x67,
+	0xd1, 0xd4, 0x3f, 0x0f, 0xde, 0xf8, 0xd4, 0xee, 0xf7, 0x00, 0x5c, 0x2b, 0x87, 0x1e, 0xb0, 0x8e,
+	0x61, 0x27, 0xe7, 0x81, 0x1f, 0x2e, 0x0f, 0xd8, 0xca, 0xf1, 0x33, 0xb8, 0x5f, 0xfe, 0x3b, 0xe7,
+	0x32, 0xdd, 0xf0, 0x04, 0xe5, 0x72, 0x9b, 0xa1, 0x19, 0xe9, 0x75, 0xf8, 0x2a, 0x9c, 0xbd, 0x0d,
+	0x8b, 0x2f, 0x74, 0x3a, 0x9e, 0xf8, 0x2f, 0x67, 0xb3, 0x57, 0xae, 0xf3, 0xf2, 0x29, 0x7c, 0x81,
+	0x6a, 0x94, 0x21, 0x66, 0x2b, 0x1c, 0x31, 0x89, 0xdb, 0x34, 0xe7, 0x62, 0xa4, 0x92, 0xab, 0x91,
+	0x40, 0xfd, 0x31, 0x95, 0x57, 0x7f, 0x54, 0x6a, 0x93, 0x54, 0xe2, 0x45, 0xc3, 0xfe, 0xa8, 0x7f,
+	0xf8, 0x7b, 0x00, 0xf0, 0x5f, 0xc1, 0xae, 0xb6, 0x07, 0x00, 0x00,
 }
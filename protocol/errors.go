@@ -34,6 +34,14 @@ const (
 	E_ACCOUNT_NOT_LINKED_TO_FACEBOOK
 	E_FORBIDDEN
 	E_INPUT_INVALID_PASSWORD
+	E_CHANGE_ALREADY_PROPOSED
+	E_VOTING_FINISHED
+	E_ALREADY_VOTED
+	E_INVALID_LOCATION
+	E_UNKNOWN_USER_POSITION
+	E_SYNC_CURSOR_EXPIRED
+	E_UPGRADE_REQUIRED // Hello
+	E_DEVICE_NOT_FOUND
 )
 
 var (
@@ -44,4 +52,5 @@ var (
 	ErrIncompleteWrite    = errors.New("incomplete write")
 	ErrUnknownMessage     = errors.New("unknown message")
 	ErrNoPayload          = errors.New("packet conveys no message")
+	ErrInvalidCompression = errors.New("invalid compressed payload")
 )
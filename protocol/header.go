@@ -9,10 +9,12 @@ type AyiHeader interface {
 	SetToken(token uint16)
 	SetType(packet_type PacketType)
 	SetSize(size uint)
+	SetCompressed(compressed bool)
 	GetVersion() uint32
 	GetToken() uint16
 	GetType() PacketType
 	GetSize() uint
+	IsCompressed() bool
 	String() string
 	Marshal(writer io.Writer) error
 }
@@ -21,6 +21,10 @@ func (h *AyiHeaderV2) SetSize(size uint) {
 	h.PayloadSize = uint32(size)
 }
 
+func (h *AyiHeaderV2) SetCompressed(compressed bool) {
+	h.Compressed = compressed
+}
+
 func (h *AyiHeaderV2) GetVersion() uint32 {
 	return h.Version
 }
@@ -37,6 +41,10 @@ func (h *AyiHeaderV2) GetSize() uint {
 	return uint(h.PayloadSize)
 }
 
+func (h *AyiHeaderV2) IsCompressed() bool {
+	return h.Compressed
+}
+
 func (h *AyiHeaderV2) Marshal(writer io.Writer) error {
 
 	header_data, err := proto.Marshal(h)
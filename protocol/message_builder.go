@@ -14,14 +14,30 @@ type MessageBuilder interface {
 	InvitationReceived(event *core.Event) *AyiPacket
 	AttendanceStatus(event_id int64, participants map[int64]*core.EventParticipant) *AyiPacket
 	AttendanceStatusWithNumGuests(event_id int64, status map[int64]*core.EventParticipant, num_guests int) *AyiPacket
+	AttendanceStatusWithWaitlist(event_id int64, participants map[int64]*core.EventParticipant, capacity int, waitlist []int64) *AyiPacket
+	ParticipantsRemoved(event_id int64, removed_participants []int64, num_guests int) *AyiPacket
+	InvitationCancelled(event_id int64) *AyiPacket
+	EventChangeProposed(proposal *EventChangeProposed) *AyiPacket
+	VotingStatus(status *VotingStatus) *AyiPacket
+	VotingFinished(status *VotingStatus) *AyiPacket
+	ChangeAccepted(event_id int64, change_id int32) *AyiPacket
+	ChangeDiscarded(event_id int64, change_id int32) *AyiPacket
+	CommentPosted(comment *EventComment) *AyiPacket
+	CommentEdited(comment *EventComment) *AyiPacket
+	CommentDeleted(event_id int64, comment_id int64) *AyiPacket
 	UserAccessGranted(user_id int64, auth_token string) *AyiPacket
 	Ok(msg_type PacketType) *AyiPacket
+	OkWithPayload(msg_type PacketType, payload Message) *AyiPacket
 	Error(msg_type PacketType, error_code int32) *AyiPacket
 	Ping() *AyiPacket
 	Pong() *AyiPacket
 	Event(event *core.Event) *AyiPacket
 	EventsList(events_list []*core.Event) *AyiPacket
 	EventsHistoryList(events_list []*core.Event, startWindow int64, endWindow int64) *AyiPacket
+	EventChanges(changes *EventChanges) *AyiPacket
+	DevicesList(devices []*Device) *AyiPacket
+	NotificationSettings(settings *NotificationSettings) *AyiPacket
+	CommentsList(event_id int64, comments []*EventComment, has_more bool) *AyiPacket
 	FriendsList(friends_list []*core.Friend) *AyiPacket
 	FacebookFriendsList(friends_list []*core.Friend) *AyiPacket
 	ClockResponse() *AyiPacket
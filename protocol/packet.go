@@ -31,6 +31,16 @@ func (packet *AyiPacket) String() string {
 	return str
 }
 
+// Copy returns a packet with its own header so that it can be written to
+// several sessions. Payload is shared and must not be modified.
+func (packet *AyiPacket) Copy() *AyiPacket {
+	header := *packet.Header.(*AyiHeaderV2)
+	return &AyiPacket{
+		Header: &header,
+		Data:   packet.Data,
+	}
+}
+
 func (packet *AyiPacket) Id() uint16 {
 	return packet.Header.GetToken()
 }
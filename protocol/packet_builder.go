@@ -1,8 +1,11 @@
 package protocol
 
 import (
+	"log"
+
 	core "github.com/d3ce1t/areyouin-server/protocol/core"
 	"github.com/d3ce1t/areyouin-server/utils"
+	proto "github.com/golang/protobuf/proto"
 )
 
 type PacketBuilder struct {
@@ -66,6 +69,79 @@ func (mb *PacketBuilder) AttendanceStatusWithNumGuests(event_id int64, participa
 	return mb.message
 }
 
+// AttendanceStatusWithWaitlist sends participants changes along with the
+// capacity of the event and its whole waitlist
+func (mb *PacketBuilder) AttendanceStatusWithWaitlist(event_id int64, participants map[int64]*core.EventParticipant, capacity int, waitlist []int64) *AyiPacket {
+	mb.message.Header.SetType(M_ATTENDANCE_STATUS)
+	participantsSlice := make([]*core.EventParticipant, 0, len(participants))
+	for _, v := range participants {
+		participantsSlice = append(participantsSlice, v)
+	}
+	mb.message.SetMessage(&AttendanceStatus{EventId: event_id, AttendanceStatus: participantsSlice,
+		Capacity: int32(capacity), Waitlist: waitlist})
+	return mb.message
+}
+
+func (mb *PacketBuilder) ParticipantsRemoved(event_id int64, removed_participants []int64, num_guests int) *AyiPacket {
+	mb.message.Header.SetType(M_ATTENDANCE_STATUS)
+	mb.message.SetMessage(&AttendanceStatus{EventId: event_id, RemovedParticipants: removed_participants, NumGuests: int32(num_guests)})
+	return mb.message
+}
+
+func (mb *PacketBuilder) InvitationCancelled(event_id int64) *AyiPacket {
+	mb.message.Header.SetType(M_INVITATION_CANCELLED)
+	mb.message.SetMessage(&InvitationCancelled{EventId: event_id})
+	return mb.message
+}
+
+func (mb *PacketBuilder) EventChangeProposed(proposal *EventChangeProposed) *AyiPacket {
+	mb.message.Header.SetType(M_EVENT_CHANGE_PROPOSED)
+	mb.message.SetMessage(proposal)
+	return mb.message
+}
+
+func (mb *PacketBuilder) VotingStatus(status *VotingStatus) *AyiPacket {
+	mb.message.Header.SetType(M_VOTING_STATUS)
+	mb.message.SetMessage(status)
+	return mb.message
+}
+
+func (mb *PacketBuilder) VotingFinished(status *VotingStatus) *AyiPacket {
+	mb.message.Header.SetType(M_VOTING_FINISHED)
+	mb.message.SetMessage(status)
+	return mb.message
+}
+
+func (mb *PacketBuilder) ChangeAccepted(event_id int64, change_id int32) *AyiPacket {
+	mb.message.Header.SetType(M_CHANGE_ACCEPTED)
+	mb.message.SetMessage(&ChangeAccepted{EventId: event_id, ChangeId: change_id})
+	return mb.message
+}
+
+func (mb *PacketBuilder) ChangeDiscarded(event_id int64, change_id int32) *AyiPacket {
+	mb.message.Header.SetType(M_CHANGE_DISCARDED)
+	mb.message.SetMessage(&ChangeDiscarded{EventId: event_id, ChangeId: change_id})
+	return mb.message
+}
+
+func (mb *PacketBuilder) CommentPosted(comment *EventComment) *AyiPacket {
+	mb.message.Header.SetType(M_COMMENT_POSTED)
+	mb.message.SetMessage(comment)
+	return mb.message
+}
+
+func (mb *PacketBuilder) CommentEdited(comment *EventComment) *AyiPacket {
+	mb.message.Header.SetType(M_COMMENT_EDITED)
+	mb.message.SetMessage(comment)
+	return mb.message
+}
+
+func (mb *PacketBuilder) CommentDeleted(event_id int64, comment_id int64) *AyiPacket {
+	mb.message.Header.SetType(M_COMMENT_DELETED)
+	mb.message.SetMessage(&EventComment{EventId: event_id, CommentId: comment_id})
+	return mb.message
+}
+
 func (mb *PacketBuilder) UserAccessGranted(user_id int64, auth_token string) *AyiPacket {
 	mb.message.Header.SetType(M_ACCESS_GRANTED)
 	mb.message.SetMessage(&AccessToken{UserId: user_id, AuthToken: auth_token})
@@ -78,6 +154,17 @@ func (mb *PacketBuilder) Ok(msg_type PacketType) *AyiPacket {
 	return mb.message
 }
 
+// Ok with a message as payload, i.e. ResumeInfo for USER AUTH
+func (mb *PacketBuilder) OkWithPayload(msg_type PacketType, payload Message) *AyiPacket {
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		log.Fatal("Marshaling error: ", err)
+	}
+	mb.message.Header.SetType(M_OK)
+	mb.message.SetMessage(&Ok{Type: uint32(msg_type), Payload: data})
+	return mb.message
+}
+
 func (mb *PacketBuilder) Error(msg_type PacketType, error_code int32) *AyiPacket {
 	mb.message.Header.SetType(M_ERROR)
 	mb.message.SetMessage(&Error{Type: uint32(msg_type), Error: error_code})
@@ -118,6 +205,30 @@ func (mb *PacketBuilder) EventsHistoryList(events_list []*core.Event, startWindo
 	return mb.message
 }
 
+func (mb *PacketBuilder) EventChanges(changes *EventChanges) *AyiPacket {
+	mb.message.Header.SetType(M_EVENT_CHANGES)
+	mb.message.SetMessage(changes)
+	return mb.message
+}
+
+func (mb *PacketBuilder) DevicesList(devices []*Device) *AyiPacket {
+	mb.message.Header.SetType(M_DEVICES_LIST)
+	mb.message.SetMessage(&DevicesList{Devices: devices})
+	return mb.message
+}
+
+func (mb *PacketBuilder) NotificationSettings(settings *NotificationSettings) *AyiPacket {
+	mb.message.Header.SetType(M_NOTIFICATION_SETTINGS)
+	mb.message.SetMessage(settings)
+	return mb.message
+}
+
+func (mb *PacketBuilder) CommentsList(event_id int64, comments []*EventComment, has_more bool) *AyiPacket {
+	mb.message.Header.SetType(M_COMMENTS_LIST)
+	mb.message.SetMessage(&CommentsList{EventId: event_id, Comments: comments, HasMore: has_more})
+	return mb.message
+}
+
 func (mb *PacketBuilder) FriendsList(friends_list []*core.Friend) *AyiPacket {
 	mb.message.Header.SetType(M_FRIENDS_LIST)
 	mb.message.SetMessage(&FriendsList{Friends: friends_list})
@@ -159,3 +270,14 @@ func (mb *PacketBuilder) FriendRequestsList(requests_list []*core.FriendRequest)
 	mb.message.SetMessage(&FriendRequestsList{FriendRequests: requests_list})
 	return mb.message
 }
+
+// Request builds a packet of any type. It is intended for clients, which send
+// messages that are not covered by MessageBuilder. Message may be nil if packet
+// has no payload.
+func (mb *PacketBuilder) Request(packet_type PacketType, message Message) *AyiPacket {
+	mb.message.Header.SetType(packet_type)
+	if message != nil {
+		mb.message.SetMessage(message)
+	}
+	return mb.message
+}
@@ -1,5 +1,7 @@
 package protocol
 
+import "fmt"
+
 type PacketType uint8
 
 // Modifiers
@@ -26,6 +28,13 @@ const (
 	M_USER_LINK_ACCOUNT
 	M_IMPORT_FACEBOOK_FRIENDS
 	M_SET_FACEBOOK_ACCESS_TOKEN
+	M_PROPOSE_EVENT_CHANGE
+	M_REVOKE_DEVICE
+	M_SET_NOTIFICATION_SETTINGS
+	M_MUTE_EVENT
+	M_POST_COMMENT
+	M_EDIT_COMMENT
+	M_DELETE_COMMENT
 	M_HELLO     = 0x3D
 	M_IID_TOKEN = 0x3E
 	M_USE_TLS   = 0x3F
@@ -51,6 +60,9 @@ const (
 	M_CHANGE_DISCARDED
 	M_ACCESS_GRANTED
 	M_FRIEND_REQUEST_RECEIVED
+	M_COMMENT_POSTED
+	M_COMMENT_EDITED
+	M_COMMENT_DELETED
 	M_OK    = 0x7E
 	M_ERROR = 0x7F
 )
@@ -72,6 +84,10 @@ const (
 	M_GET_GROUPS
 	M_GET_FRIEND_REQUESTS
 	M_GET_FACEBOOK_FRIENDS
+	M_SYNC_EVENTS
+	M_LIST_DEVICES
+	M_GET_NOTIFICATION_SETTINGS
+	M_GET_COMMENTS
 )
 
 // Responses
@@ -87,4 +103,107 @@ const (
 	M_EVENTS_HISTORY_LIST
 	M_FRIEND_REQUESTS_LIST
 	M_FACEBOOK_FRIENDS_LIST
+	M_EVENT_CHANGES
+	M_DEVICES_LIST
+	M_NOTIFICATION_SETTINGS
+	M_COMMENTS_LIST
 )
+
+var packetTypeNames = map[PacketType]string{
+	M_CREATE_EVENT:                  "CREATE_EVENT",
+	M_CANCEL_EVENT:                  "CANCEL_EVENT",
+	M_INVITE_USERS:                  "INVITE_USERS",
+	M_CANCEL_USERS_INVITATION:       "CANCEL_USERS_INVITATION",
+	M_CONFIRM_ATTENDANCE:            "CONFIRM_ATTENDANCE",
+	M_MODIFY_EVENT_DATE:             "MODIFY_EVENT_DATE",
+	M_MODIFY_EVENT_MESSAGE:          "MODIFY_EVENT_MESSAGE",
+	M_MODIFY_EVENT:                  "MODIFY_EVENT",
+	M_VOTE_CHANGE:                   "VOTE_CHANGE",
+	M_USER_POSITION:                 "USER_POSITION",
+	M_USER_POSITION_RANGE:           "USER_POSITION_RANGE",
+	M_USER_CREATE_ACCOUNT:           "USER_CREATE_ACCOUNT",
+	M_USER_NEW_AUTH_TOKEN:           "USER_NEW_AUTH_TOKEN",
+	M_USER_AUTH:                     "USER_AUTH",
+	M_CHANGE_PROFILE_PICTURE:        "CHANGE_PROFILE_PICTURE",
+	M_CHANGE_EVENT_PICTURE:          "CHANGE_EVENT_PICTURE",
+	M_SYNC_GROUPS:                   "SYNC_GROUPS",
+	M_CREATE_FRIEND_REQUEST:         "CREATE_FRIEND_REQUEST",
+	M_CONFIRM_FRIEND_REQUEST:        "CONFIRM_FRIEND_REQUEST",
+	M_USER_LINK_ACCOUNT:             "USER_LINK_ACCOUNT",
+	M_IMPORT_FACEBOOK_FRIENDS:       "IMPORT_FACEBOOK_FRIENDS",
+	M_SET_FACEBOOK_ACCESS_TOKEN:     "SET_FACEBOOK_ACCESS_TOKEN",
+	M_PROPOSE_EVENT_CHANGE:          "PROPOSE_EVENT_CHANGE",
+	M_REVOKE_DEVICE:                 "REVOKE_DEVICE",
+	M_SET_NOTIFICATION_SETTINGS:     "SET_NOTIFICATION_SETTINGS",
+	M_MUTE_EVENT:                    "MUTE_EVENT",
+	M_POST_COMMENT:                  "POST_COMMENT",
+	M_EDIT_COMMENT:                  "EDIT_COMMENT",
+	M_DELETE_COMMENT:                "DELETE_COMMENT",
+	M_HELLO:                         "HELLO",
+	M_IID_TOKEN:                     "IID_TOKEN",
+	M_USE_TLS:                       "USE_TLS",
+	M_EVENT_CREATED:                 "EVENT_CREATED",
+	M_EVENT_CANCELLED:               "EVENT_CANCELLED",
+	M_EVENT_EXPIRED:                 "EVENT_EXPIRED",
+	M_EVENT_DATE_MODIFIED:           "EVENT_DATE_MODIFIED",
+	M_EVENT_MESSAGE_MODIFIED:        "EVENT_MESSAGE_MODIFIED",
+	M_EVENT_MODIFIED:                "EVENT_MODIFIED",
+	M_INVITATION_RECEIVED:           "INVITATION_RECEIVED",
+	M_INVITATION_CANCELLED:          "INVITATION_CANCELLED",
+	M_ATTENDANCE_STATUS:             "ATTENDANCE_STATUS",
+	M_EVENT_CHANGE_DATE_PROPOSED:    "EVENT_CHANGE_DATE_PROPOSED",
+	M_EVENT_CHANGE_MESSAGE_PROPOSED: "EVENT_CHANGE_MESSAGE_PROPOSED",
+	M_EVENT_CHANGE_PROPOSED:         "EVENT_CHANGE_PROPOSED",
+	M_VOTING_STATUS:                 "VOTING_STATUS",
+	M_VOTING_FINISHED:               "VOTING_FINISHED",
+	M_CHANGE_ACCEPTED:               "CHANGE_ACCEPTED",
+	M_CHANGE_DISCARDED:              "CHANGE_DISCARDED",
+	M_ACCESS_GRANTED:                "ACCESS_GRANTED",
+	M_FRIEND_REQUEST_RECEIVED:       "FRIEND_REQUEST_RECEIVED",
+	M_COMMENT_POSTED:                "COMMENT_POSTED",
+	M_COMMENT_EDITED:                "COMMENT_EDITED",
+	M_COMMENT_DELETED:               "COMMENT_DELETED",
+	M_OK:                            "OK",
+	M_ERROR:                         "ERROR",
+	M_PING:                          "PING",
+	M_READ_EVENT:                    "READ_EVENT",
+	M_LIST_AUTHORED_EVENTS:          "LIST_AUTHORED_EVENTS",
+	M_LIST_PRIVATE_EVENTS:           "LIST_PRIVATE_EVENTS",
+	M_LIST_PUBLIC_EVENTS:            "LIST_PUBLIC_EVENTS",
+	M_HISTORY_AUTHORED_EVENTS:       "HISTORY_AUTHORED_EVENTS",
+	M_HISTORY_PRIVATE_EVENTS:        "HISTORY_PRIVATE_EVENTS",
+	M_HISTORY_PUBLIC_EVENTS:         "HISTORY_PUBLIC_EVENTS",
+	M_GET_USER_FRIENDS:              "GET_USER_FRIENDS",
+	M_CLOCK_REQUEST:                 "CLOCK_REQUEST",
+	M_GET_USER_ACCOUNT:              "GET_USER_ACCOUNT",
+	M_GET_ACCESS_TOKEN:              "GET_ACCESS_TOKEN",
+	M_GET_GROUPS:                    "GET_GROUPS",
+	M_GET_FRIEND_REQUESTS:           "GET_FRIEND_REQUESTS",
+	M_GET_FACEBOOK_FRIENDS:          "GET_FACEBOOK_FRIENDS",
+	M_SYNC_EVENTS:                   "SYNC_EVENTS",
+	M_LIST_DEVICES:                  "LIST_DEVICES",
+	M_GET_NOTIFICATION_SETTINGS:     "GET_NOTIFICATION_SETTINGS",
+	M_GET_COMMENTS:                  "GET_COMMENTS",
+	M_PONG:                          "PONG",
+	M_EVENT:                         "EVENT",
+	M_EVENTS_LIST:                   "EVENTS_LIST",
+	M_FRIENDS_LIST:                  "FRIENDS_LIST",
+	M_CLOCK_RESPONSE:                "CLOCK_RESPONSE",
+	M_USER_ACCOUNT:                  "USER_ACCOUNT",
+	M_ACCESS_TOKEN:                  "ACCESS_TOKEN",
+	M_GROUPS_LIST:                   "GROUPS_LIST",
+	M_EVENTS_HISTORY_LIST:           "EVENTS_HISTORY_LIST",
+	M_FRIEND_REQUESTS_LIST:          "FRIEND_REQUESTS_LIST",
+	M_FACEBOOK_FRIENDS_LIST:         "FACEBOOK_FRIENDS_LIST",
+	M_EVENT_CHANGES:                 "EVENT_CHANGES",
+	M_DEVICES_LIST:                  "DEVICES_LIST",
+	M_NOTIFICATION_SETTINGS:         "NOTIFICATION_SETTINGS",
+	M_COMMENTS_LIST:                 "COMMENTS_LIST",
+}
+
+func (t PacketType) String() string {
+	if name, ok := packetTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02X", uint8(t))
+}
@@ -94,6 +94,13 @@ func ReadPacket(reader io.Reader) (*AyiPacket, error) {
 		}
 	}
 
+	// Decompress payload so that it is transparent to the upper layers
+	if packet.Header.IsCompressed() {
+		if err := packet.decompress(); err != nil {
+			return nil, err
+		}
+	}
+
 	return packet, nil
 }
 
@@ -131,7 +138,7 @@ type Message interface {
 	ProtoMessage()
 }
 
-// Used by listener.go
+// Used by listener.go and client package
 func createEmptyMessage(packet_type PacketType) Message {
 
 	var message Message = nil
@@ -156,6 +163,8 @@ func createEmptyMessage(packet_type PacketType) Message {
 		message = &ModifyEvent{}
 	case M_VOTE_CHANGE:
 		message = &VoteChange{}
+	case M_PROPOSE_EVENT_CHANGE:
+		message = &EventChangeProposed{}
 	case M_USER_POSITION:
 		message = &UserPosition{}
 	case M_USER_POSITION_RANGE:
@@ -184,28 +193,89 @@ func createEmptyMessage(packet_type PacketType) Message {
 		message = &InstanceIDToken{}
 	case M_SET_FACEBOOK_ACCESS_TOKEN:
 		message = &core.FacebookAccessToken{}
+	case M_REVOKE_DEVICE:
+		message = &RevokeDevice{}
+	case M_SET_NOTIFICATION_SETTINGS:
+		message = &NotificationSettings{}
+	case M_MUTE_EVENT:
+		message = &MuteEvent{}
+	case M_POST_COMMENT, M_EDIT_COMMENT, M_DELETE_COMMENT:
+		message = &EventComment{}
 
 	// Requests
 	case M_PING:
 		message = &TimeInfo{}
 	case M_READ_EVENT:
 		message = &ReadEvent{}
-	/*case M_LIST_AUTHORED_EVENTS:
-	message = &ListCursor{}*/
+	// case M_LIST_AUTHORED_EVENTS: ListAuthoredEvents has no payload
 	/*case M_LIST_PRIVATE_EVENTS:
 	message = &ListCursor{}*/
-	/*case M_LIST_PUBLIC_EVENTS:
-	message = &ListPublicEvents{}*/
-	/*case M_HISTORY_AUTHORED_EVENTS:
-	fallthrough*/
+	case M_LIST_PUBLIC_EVENTS:
+		message = &EventListRequest{}
+	case M_HISTORY_AUTHORED_EVENTS:
+		fallthrough
 	case M_HISTORY_PRIVATE_EVENTS:
 		message = &EventListRequest{}
+	case M_SYNC_EVENTS:
+		message = &SyncEvents{}
+	case M_GET_COMMENTS:
+		message = &CommentListRequest{}
 	/*case M_HISTORY_PUBLIC_EVENTS:
 	message = &ListCursor{}*/
 	///case M_USER_FRIENDS: UserFriends has no payload
 	// Replies
 	case M_PONG:
 		message = &TimeInfo{}
+
+	// Messages sent by server. Used by client package
+	case M_EVENT_CREATED, M_EVENT_MODIFIED, M_INVITATION_RECEIVED, M_EVENT:
+		message = &core.Event{}
+	case M_EVENT_CANCELLED:
+		message = &EventCancelled{}
+	case M_EVENT_EXPIRED:
+		message = &EventExpired{}
+	case M_INVITATION_CANCELLED:
+		message = &InvitationCancelled{}
+	case M_ATTENDANCE_STATUS:
+		message = &AttendanceStatus{}
+	case M_EVENT_CHANGE_PROPOSED:
+		message = &EventChangeProposed{}
+	case M_VOTING_STATUS, M_VOTING_FINISHED:
+		message = &VotingStatus{}
+	case M_CHANGE_ACCEPTED:
+		message = &ChangeAccepted{}
+	case M_CHANGE_DISCARDED:
+		message = &ChangeDiscarded{}
+	case M_ACCESS_GRANTED, M_ACCESS_TOKEN:
+		message = &AccessToken{}
+	case M_FRIEND_REQUEST_RECEIVED:
+		message = &core.FriendRequest{}
+	case M_COMMENT_POSTED, M_COMMENT_EDITED, M_COMMENT_DELETED:
+		message = &EventComment{}
+	case M_OK:
+		message = &Ok{}
+	case M_ERROR:
+		message = &Error{}
+	case M_EVENTS_LIST, M_EVENTS_HISTORY_LIST:
+		message = &EventsList{}
+	case M_EVENT_CHANGES:
+		message = &EventChanges{}
+	case M_FRIENDS_LIST, M_FACEBOOK_FRIENDS_LIST:
+		message = &FriendsList{}
+	case M_CLOCK_RESPONSE:
+		message = &TimeInfo{}
+	case M_USER_ACCOUNT:
+		message = &core.UserAccount{}
+	case M_GROUPS_LIST:
+		message = &GroupsList{}
+	case M_FRIEND_REQUESTS_LIST:
+		message = &FriendRequestsList{}
+	case M_DEVICES_LIST:
+		message = &DevicesList{}
+	case M_NOTIFICATION_SETTINGS:
+		message = &NotificationSettings{}
+	case M_COMMENTS_LIST:
+		message = &CommentsList{}
 	}
 
 	return message
@@ -12,6 +12,7 @@ It has these top-level messages:
 	AyiHeaderV2
 	Hello
 	CreateEvent
+	Recurrence
 	CancelEvent
 	InviteUsers
 	CancelUsersInvitation
@@ -24,10 +25,15 @@ It has these top-level messages:
 	LinkAccount
 	NewAuthToken
 	AccessToken
+	ResumeInfo
 	InstanceIDToken
 	SyncGroups
 	CreateFriendRequest
 	ConfirmFriendRequest
+	RevokeDevice
+	NotificationSettings
+	MuteEvent
+	EventComment
 	EventCancelled
 	EventExpired
 	InvitationCancelled
@@ -40,11 +46,17 @@ It has these top-level messages:
 	Error
 	TimeInfo
 	ReadEvent
+	SyncEvents
 	EventListRequest
+	CommentListRequest
 	EventsList
+	EventChanges
+	CommentsList
 	FriendsList
 	GroupsList
 	FriendRequestsList
+	Device
+	DevicesList
 */
 package protocol
 
@@ -69,6 +81,60 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type RecurrenceFrequency int32
+
+const (
+	RecurrenceFrequency_R_NONE    RecurrenceFrequency = 0
+	RecurrenceFrequency_R_DAILY   RecurrenceFrequency = 1
+	RecurrenceFrequency_R_WEEKLY  RecurrenceFrequency = 2
+	RecurrenceFrequency_R_MONTHLY RecurrenceFrequency = 3
+)
+
+var RecurrenceFrequency_name = map[int32]string{
+	0: "R_NONE",
+	1: "R_DAILY",
+	2: "R_WEEKLY",
+	3: "R_MONTHLY",
+}
+var RecurrenceFrequency_value = map[string]int32{
+	"R_NONE":    0,
+	"R_DAILY":   1,
+	"R_WEEKLY":  2,
+	"R_MONTHLY": 3,
+}
+
+func (x RecurrenceFrequency) String() string {
+	return proto.EnumName(RecurrenceFrequency_name, int32(x))
+}
+func (RecurrenceFrequency) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+// MODIFY EVENT DATE
+// MODIFY EVENT MESSAGE
+// MODIFY EVENT
+type EventVisibility int32
+
+const (
+	EventVisibility_V_UNCHANGED EventVisibility = 0
+	EventVisibility_V_PRIVATE   EventVisibility = 1
+	EventVisibility_V_PUBLIC    EventVisibility = 2
+)
+
+var EventVisibility_name = map[int32]string{
+	0: "V_UNCHANGED",
+	1: "V_PRIVATE",
+	2: "V_PUBLIC",
+}
+var EventVisibility_value = map[string]int32{
+	"V_UNCHANGED": 0,
+	"V_PRIVATE":   1,
+	"V_PUBLIC":    2,
+}
+
+func (x EventVisibility) String() string {
+	return proto.EnumName(EventVisibility_name, int32(x))
+}
+func (EventVisibility) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
 // NEW AUTH TOKEN
 type AuthType int32
 
@@ -89,7 +155,47 @@ var AuthType_value = map[string]int32{
 func (x AuthType) String() string {
 	return proto.EnumName(AuthType_name, int32(x))
 }
-func (AuthType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (AuthType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+// SET NOTIFICATION SETTINGS
+type NotificationCategory int32
+
+const (
+	NotificationCategory_N_EVENT_INVITATION NotificationCategory = 0
+	NotificationCategory_N_EVENT_CANCELLED  NotificationCategory = 1
+	NotificationCategory_N_EVENT_RESPONSE   NotificationCategory = 2
+	NotificationCategory_N_EVENT_CHANGE     NotificationCategory = 3
+	NotificationCategory_N_FRIEND_REQUEST   NotificationCategory = 4
+	NotificationCategory_N_NEW_FRIEND       NotificationCategory = 5
+	NotificationCategory_N_EVENT_REMINDER   NotificationCategory = 6
+	NotificationCategory_N_EVENT_COMMENT    NotificationCategory = 7
+)
+
+var NotificationCategory_name = map[int32]string{
+	0: "N_EVENT_INVITATION",
+	1: "N_EVENT_CANCELLED",
+	2: "N_EVENT_RESPONSE",
+	3: "N_EVENT_CHANGE",
+	4: "N_FRIEND_REQUEST",
+	5: "N_NEW_FRIEND",
+	6: "N_EVENT_REMINDER",
+	7: "N_EVENT_COMMENT",
+}
+var NotificationCategory_value = map[string]int32{
+	"N_EVENT_INVITATION": 0,
+	"N_EVENT_CANCELLED":  1,
+	"N_EVENT_RESPONSE":   2,
+	"N_EVENT_CHANGE":     3,
+	"N_FRIEND_REQUEST":   4,
+	"N_NEW_FRIEND":       5,
+	"N_EVENT_REMINDER":   6,
+	"N_EVENT_COMMENT":    7,
+}
+
+func (x NotificationCategory) String() string {
+	return proto.EnumName(NotificationCategory_name, int32(x))
+}
+func (NotificationCategory) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 type ConfirmFriendRequest_FriendRequestResponse int32
 
@@ -111,7 +217,7 @@ func (x ConfirmFriendRequest_FriendRequestResponse) String() string {
 	return proto.EnumName(ConfirmFriendRequest_FriendRequestResponse_name, int32(x))
 }
 func (ConfirmFriendRequest_FriendRequestResponse) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{18, 0}
+	return fileDescriptor0, []int{20, 0}
 }
 
 // Header
@@ -120,6 +226,7 @@ type AyiHeaderV2 struct {
 	Token       uint32 `protobuf:"varint,2,opt,name=token" json:"token,omitempty"`
 	Type        uint32 `protobuf:"varint,3,opt,name=type" json:"type,omitempty"`
 	PayloadSize uint32 `protobuf:"varint,4,opt,name=payloadSize" json:"payloadSize,omitempty"`
+	Compressed  bool   `protobuf:"varint,5,opt,name=compressed" json:"compressed,omitempty"`
 }
 
 func (m *AyiHeaderV2) Reset()                    { *m = AyiHeaderV2{} }
@@ -129,11 +236,14 @@ func (*AyiHeaderV2) Descriptor() ([]byte, []int) { return fileDescriptor0, []int
 
 // Hello
 type Hello struct {
-	ProtocolVersion uint32 `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion" json:"protocol_version,omitempty"`
-	ClientVersion   string `protobuf:"bytes,2,opt,name=client_version,json=clientVersion" json:"client_version,omitempty"`
-	Platform        string `protobuf:"bytes,3,opt,name=platform" json:"platform,omitempty"`
-	PlatformVersion string `protobuf:"bytes,4,opt,name=platform_version,json=platformVersion" json:"platform_version,omitempty"`
-	Language        string `protobuf:"bytes,5,opt,name=language" json:"language,omitempty"`
+	ProtocolVersion uint32   `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion" json:"protocol_version,omitempty"`
+	ClientVersion   string   `protobuf:"bytes,2,opt,name=client_version,json=clientVersion" json:"client_version,omitempty"`
+	Platform        string   `protobuf:"bytes,3,opt,name=platform" json:"platform,omitempty"`
+	PlatformVersion string   `protobuf:"bytes,4,opt,name=platform_version,json=platformVersion" json:"platform_version,omitempty"`
+	Language        string   `protobuf:"bytes,5,opt,name=language" json:"language,omitempty"`
+	Compression     []string `protobuf:"bytes,6,rep,name=compression" json:"compression,omitempty"`
+	Capabilities    []string `protobuf:"bytes,7,rep,name=capabilities" json:"capabilities,omitempty"`
+	DeviceId        string   `protobuf:"bytes,8,opt,name=device_id,json=deviceId" json:"device_id,omitempty"`
 }
 
 func (m *Hello) Reset()                    { *m = Hello{} }
@@ -149,6 +259,13 @@ type CreateEvent struct {
 	EndDate      int64   `protobuf:"varint,4,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
 	Participants []int64 `protobuf:"varint,5,rep,packed,name=participants" json:"participants,omitempty"`
 	Picture      []byte  `protobuf:"bytes,6,opt,name=picture,proto3" json:"picture,omitempty"`
+	// bytes picture_digest = 4;
+	IsPublic       bool           `protobuf:"varint,7,opt,name=is_public,json=isPublic" json:"is_public,omitempty"`
+	Geolocation    *core.Location `protobuf:"bytes,8,opt,name=geolocation" json:"geolocation,omitempty"`
+	Recurrence     *Recurrence    `protobuf:"bytes,9,opt,name=recurrence" json:"recurrence,omitempty"`
+	Capacity       int32          `protobuf:"varint,10,opt,name=capacity" json:"capacity,omitempty"`
+	Quorum         int32          `protobuf:"varint,11,opt,name=quorum" json:"quorum,omitempty"`
+	QuorumDeadline int64          `protobuf:"varint,12,opt,name=quorum_deadline,json=quorumDeadline" json:"quorum_deadline,omitempty"`
 }
 
 func (m *CreateEvent) Reset()                    { *m = CreateEvent{} }
@@ -156,16 +273,48 @@ func (m *CreateEvent) String() string            { return proto.CompactTextStrin
 func (*CreateEvent) ProtoMessage()               {}
 func (*CreateEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
+func (m *CreateEvent) GetGeolocation() *core.Location {
+	if m != nil {
+		return m.Geolocation
+	}
+	return nil
+}
+
+func (m *CreateEvent) GetRecurrence() *Recurrence {
+	if m != nil {
+		return m.Recurrence
+	}
+	return nil
+}
+
+// Event repeats every interval days, weeks or months until count occurrences
+// or until date (millis), whatever comes first. Occurrences keep the local
+// time of the first one in time_zone (IANA name, e.g. Europe/Madrid), or UTC
+// if empty.
+type Recurrence struct {
+	Frequency RecurrenceFrequency `protobuf:"varint,1,opt,name=frequency,enum=protocol.RecurrenceFrequency" json:"frequency,omitempty"`
+	Interval  uint32              `protobuf:"varint,2,opt,name=interval" json:"interval,omitempty"`
+	Count     uint32              `protobuf:"varint,3,opt,name=count" json:"count,omitempty"`
+	Until     int64               `protobuf:"varint,4,opt,name=until" json:"until,omitempty"`
+	TimeZone  string              `protobuf:"bytes,5,opt,name=time_zone,json=timeZone" json:"time_zone,omitempty"`
+}
+
+func (m *Recurrence) Reset()                    { *m = Recurrence{} }
+func (m *Recurrence) String() string            { return proto.CompactTextString(m) }
+func (*Recurrence) ProtoMessage()               {}
+func (*Recurrence) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
 // CANCEL EVENT
 type CancelEvent struct {
-	EventId int64  `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
-	Reason  string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	EventId      int64  `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	Reason       string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	CancelSeries bool   `protobuf:"varint,3,opt,name=cancel_series,json=cancelSeries" json:"cancel_series,omitempty"`
 }
 
 func (m *CancelEvent) Reset()                    { *m = CancelEvent{} }
 func (m *CancelEvent) String() string            { return proto.CompactTextString(m) }
 func (*CancelEvent) ProtoMessage()               {}
-func (*CancelEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*CancelEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 // INVITE USERS
 type InviteUsers struct {
@@ -176,7 +325,7 @@ type InviteUsers struct {
 func (m *InviteUsers) Reset()                    { *m = InviteUsers{} }
 func (m *InviteUsers) String() string            { return proto.CompactTextString(m) }
 func (*InviteUsers) ProtoMessage()               {}
-func (*InviteUsers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*InviteUsers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 // CANCEL USERS INVITATION
 type CancelUsersInvitation struct {
@@ -187,7 +336,7 @@ type CancelUsersInvitation struct {
 func (m *CancelUsersInvitation) Reset()                    { *m = CancelUsersInvitation{} }
 func (m *CancelUsersInvitation) String() string            { return proto.CompactTextString(m) }
 func (*CancelUsersInvitation) ProtoMessage()               {}
-func (*CancelUsersInvitation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*CancelUsersInvitation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 // CONFIRM ATTENDANCE
 type ConfirmAttendance struct {
@@ -198,26 +347,39 @@ type ConfirmAttendance struct {
 func (m *ConfirmAttendance) Reset()                    { *m = ConfirmAttendance{} }
 func (m *ConfirmAttendance) String() string            { return proto.CompactTextString(m) }
 func (*ConfirmAttendance) ProtoMessage()               {}
-func (*ConfirmAttendance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*ConfirmAttendance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
-// MODIFY EVENT DATE
-// MODIFY EVENT MESSAGE
-// MODIFY EVENT
 type ModifyEvent struct {
-	EventId       int64   `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
-	Message       string  `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
-	StartDate     int64   `protobuf:"varint,3,opt,name=start_date,json=startDate" json:"start_date,omitempty"`
-	EndDate       int64   `protobuf:"varint,4,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
-	Picture       []byte  `protobuf:"bytes,5,opt,name=picture,proto3" json:"picture,omitempty"`
-	RemovePicture bool    `protobuf:"varint,6,opt,name=remove_picture,json=removePicture" json:"remove_picture,omitempty"`
-	ModifyDate    int64   `protobuf:"varint,7,opt,name=modify_date,json=modifyDate" json:"modify_date,omitempty"`
-	Participants  []int64 `protobuf:"varint,8,rep,packed,name=participants" json:"participants,omitempty"`
+	EventId           int64           `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	Message           string          `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	StartDate         int64           `protobuf:"varint,3,opt,name=start_date,json=startDate" json:"start_date,omitempty"`
+	EndDate           int64           `protobuf:"varint,4,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
+	Picture           []byte          `protobuf:"bytes,5,opt,name=picture,proto3" json:"picture,omitempty"`
+	RemovePicture     bool            `protobuf:"varint,6,opt,name=remove_picture,json=removePicture" json:"remove_picture,omitempty"`
+	ModifyDate        int64           `protobuf:"varint,7,opt,name=modify_date,json=modifyDate" json:"modify_date,omitempty"`
+	Participants      []int64         `protobuf:"varint,8,rep,packed,name=participants" json:"participants,omitempty"`
+	Visibility        EventVisibility `protobuf:"varint,9,opt,name=visibility,enum=protocol.EventVisibility" json:"visibility,omitempty"`
+	Geolocation       *core.Location  `protobuf:"bytes,10,opt,name=geolocation" json:"geolocation,omitempty"`
+	RemoveGeolocation bool            `protobuf:"varint,11,opt,name=remove_geolocation,json=removeGeolocation" json:"remove_geolocation,omitempty"`
+	ApplyToSeries     bool            `protobuf:"varint,12,opt,name=apply_to_series,json=applyToSeries" json:"apply_to_series,omitempty"`
+	Capacity          int32           `protobuf:"varint,13,opt,name=capacity" json:"capacity,omitempty"`
+	RemoveCapacity    bool            `protobuf:"varint,14,opt,name=remove_capacity,json=removeCapacity" json:"remove_capacity,omitempty"`
+	Quorum            int32           `protobuf:"varint,15,opt,name=quorum" json:"quorum,omitempty"`
+	QuorumDeadline    int64           `protobuf:"varint,16,opt,name=quorum_deadline,json=quorumDeadline" json:"quorum_deadline,omitempty"`
+	RemoveQuorum      bool            `protobuf:"varint,17,opt,name=remove_quorum,json=removeQuorum" json:"remove_quorum,omitempty"`
 }
 
 func (m *ModifyEvent) Reset()                    { *m = ModifyEvent{} }
 func (m *ModifyEvent) String() string            { return proto.CompactTextString(m) }
 func (*ModifyEvent) ProtoMessage()               {}
-func (*ModifyEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*ModifyEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ModifyEvent) GetGeolocation() *core.Location {
+	if m != nil {
+		return m.Geolocation
+	}
+	return nil
+}
 
 // VOTE CHANGE
 type VoteChange struct {
@@ -229,7 +391,7 @@ type VoteChange struct {
 func (m *VoteChange) Reset()                    { *m = VoteChange{} }
 func (m *VoteChange) String() string            { return proto.CompactTextString(m) }
 func (*VoteChange) ProtoMessage()               {}
-func (*VoteChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*VoteChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 // USER POSITION
 type UserPosition struct {
@@ -240,7 +402,7 @@ type UserPosition struct {
 func (m *UserPosition) Reset()                    { *m = UserPosition{} }
 func (m *UserPosition) String() string            { return proto.CompactTextString(m) }
 func (*UserPosition) ProtoMessage()               {}
-func (*UserPosition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*UserPosition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *UserPosition) GetGlobalCoordinates() *core.Location {
 	if m != nil {
@@ -257,7 +419,7 @@ type UserPositionRange struct {
 func (m *UserPositionRange) Reset()                    { *m = UserPositionRange{} }
 func (m *UserPositionRange) String() string            { return proto.CompactTextString(m) }
 func (*UserPositionRange) ProtoMessage()               {}
-func (*UserPositionRange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*UserPositionRange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 // CREATE USER ACCOUNT
 type CreateUserAccount struct {
@@ -273,7 +435,7 @@ type CreateUserAccount struct {
 func (m *CreateUserAccount) Reset()                    { *m = CreateUserAccount{} }
 func (m *CreateUserAccount) String() string            { return proto.CompactTextString(m) }
 func (*CreateUserAccount) ProtoMessage()               {}
-func (*CreateUserAccount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*CreateUserAccount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 // LINK ACCOUNT
 type LinkAccount struct {
@@ -286,7 +448,7 @@ type LinkAccount struct {
 func (m *LinkAccount) Reset()                    { *m = LinkAccount{} }
 func (m *LinkAccount) String() string            { return proto.CompactTextString(m) }
 func (*LinkAccount) ProtoMessage()               {}
-func (*LinkAccount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*LinkAccount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 type NewAuthToken struct {
 	Pass1 string   `protobuf:"bytes,1,opt,name=pass1" json:"pass1,omitempty"`
@@ -297,18 +459,31 @@ type NewAuthToken struct {
 func (m *NewAuthToken) Reset()                    { *m = NewAuthToken{} }
 func (m *NewAuthToken) String() string            { return proto.CompactTextString(m) }
 func (*NewAuthToken) ProtoMessage()               {}
-func (*NewAuthToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*NewAuthToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 // ACCESS GRANTED / USER AUTH / GET ACCESS TOKEN
 type AccessToken struct {
-	UserId    int64  `protobuf:"varint,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
-	AuthToken string `protobuf:"bytes,2,opt,name=auth_token,json=authToken" json:"auth_token,omitempty"`
+	UserId      int64  `protobuf:"varint,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	AuthToken   string `protobuf:"bytes,2,opt,name=auth_token,json=authToken" json:"auth_token,omitempty"`
+	ResumeToken string `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken" json:"resume_token,omitempty"`
 }
 
 func (m *AccessToken) Reset()                    { *m = AccessToken{} }
 func (m *AccessToken) String() string            { return proto.CompactTextString(m) }
 func (*AccessToken) ProtoMessage()               {}
-func (*AccessToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*AccessToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+// Payload of OK to USER AUTH
+type ResumeInfo struct {
+	ResumeToken   string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken" json:"resume_token,omitempty"`
+	Resumed       bool   `protobuf:"varint,2,opt,name=resumed" json:"resumed,omitempty"`
+	MissedPackets uint32 `protobuf:"varint,3,opt,name=missed_packets,json=missedPackets" json:"missed_packets,omitempty"`
+}
+
+func (m *ResumeInfo) Reset()                    { *m = ResumeInfo{} }
+func (m *ResumeInfo) String() string            { return proto.CompactTextString(m) }
+func (*ResumeInfo) ProtoMessage()               {}
+func (*ResumeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 // INSTANCE ID TOKEN
 type InstanceIDToken struct {
@@ -318,7 +493,7 @@ type InstanceIDToken struct {
 func (m *InstanceIDToken) Reset()                    { *m = InstanceIDToken{} }
 func (m *InstanceIDToken) String() string            { return proto.CompactTextString(m) }
 func (*InstanceIDToken) ProtoMessage()               {}
-func (*InstanceIDToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*InstanceIDToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 // SYNC GROUPS
 type SyncGroups struct {
@@ -330,7 +505,7 @@ type SyncGroups struct {
 func (m *SyncGroups) Reset()                    { *m = SyncGroups{} }
 func (m *SyncGroups) String() string            { return proto.CompactTextString(m) }
 func (*SyncGroups) ProtoMessage()               {}
-func (*SyncGroups) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*SyncGroups) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *SyncGroups) GetGroups() []*core.Group {
 	if m != nil {
@@ -347,7 +522,7 @@ type CreateFriendRequest struct {
 func (m *CreateFriendRequest) Reset()                    { *m = CreateFriendRequest{} }
 func (m *CreateFriendRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateFriendRequest) ProtoMessage()               {}
-func (*CreateFriendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*CreateFriendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 // CONFIRM FRIEND REQUEST
 type ConfirmFriendRequest struct {
@@ -358,7 +533,58 @@ type ConfirmFriendRequest struct {
 func (m *ConfirmFriendRequest) Reset()                    { *m = ConfirmFriendRequest{} }
 func (m *ConfirmFriendRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConfirmFriendRequest) ProtoMessage()               {}
-func (*ConfirmFriendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*ConfirmFriendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+// REVOKE DEVICE
+type RevokeDevice struct {
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId" json:"device_id,omitempty"`
+}
+
+func (m *RevokeDevice) Reset()                    { *m = RevokeDevice{} }
+func (m *RevokeDevice) String() string            { return proto.CompactTextString(m) }
+func (*RevokeDevice) ProtoMessage()               {}
+func (*RevokeDevice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+type NotificationSettings struct {
+	DisabledCategories []NotificationCategory `protobuf:"varint,1,rep,packed,name=disabled_categories,json=disabledCategories,enum=protocol.NotificationCategory" json:"disabled_categories,omitempty"`
+	QuietHoursEnabled  bool                   `protobuf:"varint,2,opt,name=quiet_hours_enabled,json=quietHoursEnabled" json:"quiet_hours_enabled,omitempty"`
+	QuietHoursStart    uint32                 `protobuf:"varint,3,opt,name=quiet_hours_start,json=quietHoursStart" json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd      uint32                 `protobuf:"varint,4,opt,name=quiet_hours_end,json=quietHoursEnd" json:"quiet_hours_end,omitempty"`
+	TimeZone           string                 `protobuf:"bytes,5,opt,name=time_zone,json=timeZone" json:"time_zone,omitempty"`
+	MutedEvents        []int64                `protobuf:"varint,6,rep,packed,name=muted_events,json=mutedEvents" json:"muted_events,omitempty"`
+}
+
+func (m *NotificationSettings) Reset()                    { *m = NotificationSettings{} }
+func (m *NotificationSettings) String() string            { return proto.CompactTextString(m) }
+func (*NotificationSettings) ProtoMessage()               {}
+func (*NotificationSettings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+// MUTE EVENT
+type MuteEvent struct {
+	EventId int64 `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	Mute    bool  `protobuf:"varint,2,opt,name=mute" json:"mute,omitempty"`
+}
+
+func (m *MuteEvent) Reset()                    { *m = MuteEvent{} }
+func (m *MuteEvent) String() string            { return proto.CompactTextString(m) }
+func (*MuteEvent) ProtoMessage()               {}
+func (*MuteEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+// POST COMMENT, EDIT COMMENT, DELETE COMMENT
+type EventComment struct {
+	EventId     int64  `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	CommentId   int64  `protobuf:"varint,2,opt,name=comment_id,json=commentId" json:"comment_id,omitempty"`
+	AuthorId    int64  `protobuf:"varint,3,opt,name=author_id,json=authorId" json:"author_id,omitempty"`
+	AuthorName  string `protobuf:"bytes,4,opt,name=author_name,json=authorName" json:"author_name,omitempty"`
+	Message     string `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	CreatedDate int64  `protobuf:"varint,6,opt,name=created_date,json=createdDate" json:"created_date,omitempty"`
+	EditedDate  int64  `protobuf:"varint,7,opt,name=edited_date,json=editedDate" json:"edited_date,omitempty"`
+}
+
+func (m *EventComment) Reset()                    { *m = EventComment{} }
+func (m *EventComment) String() string            { return proto.CompactTextString(m) }
+func (*EventComment) ProtoMessage()               {}
+func (*EventComment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 // EVENT CANCELLED
 type EventCancelled struct {
@@ -371,7 +597,7 @@ type EventCancelled struct {
 func (m *EventCancelled) Reset()                    { *m = EventCancelled{} }
 func (m *EventCancelled) String() string            { return proto.CompactTextString(m) }
 func (*EventCancelled) ProtoMessage()               {}
-func (*EventCancelled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*EventCancelled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *EventCancelled) GetEvent() *core.Event {
 	if m != nil {
@@ -388,7 +614,7 @@ type EventExpired struct {
 func (m *EventExpired) Reset()                    { *m = EventExpired{} }
 func (m *EventExpired) String() string            { return proto.CompactTextString(m) }
 func (*EventExpired) ProtoMessage()               {}
-func (*EventExpired) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*EventExpired) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 // INVITATION CANCELLED
 type InvitationCancelled struct {
@@ -398,19 +624,22 @@ type InvitationCancelled struct {
 func (m *InvitationCancelled) Reset()                    { *m = InvitationCancelled{} }
 func (m *InvitationCancelled) String() string            { return proto.CompactTextString(m) }
 func (*InvitationCancelled) ProtoMessage()               {}
-func (*InvitationCancelled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*InvitationCancelled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 // ATTENDANCE STATUS
 type AttendanceStatus struct {
-	EventId          int64                    `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
-	AttendanceStatus []*core.EventParticipant `protobuf:"bytes,2,rep,name=attendance_status,json=attendanceStatus" json:"attendance_status,omitempty"`
-	NumGuests        int32                    `protobuf:"varint,3,opt,name=num_guests,json=numGuests" json:"num_guests,omitempty"`
+	EventId             int64                    `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	AttendanceStatus    []*core.EventParticipant `protobuf:"bytes,2,rep,name=attendance_status,json=attendanceStatus" json:"attendance_status,omitempty"`
+	NumGuests           int32                    `protobuf:"varint,3,opt,name=num_guests,json=numGuests" json:"num_guests,omitempty"`
+	RemovedParticipants []int64                  `protobuf:"varint,4,rep,packed,name=removed_participants,json=removedParticipants" json:"removed_participants,omitempty"`
+	Capacity            int32                    `protobuf:"varint,5,opt,name=capacity" json:"capacity,omitempty"`
+	Waitlist            []int64                  `protobuf:"varint,6,rep,packed,name=waitlist" json:"waitlist,omitempty"`
 }
 
 func (m *AttendanceStatus) Reset()                    { *m = AttendanceStatus{} }
 func (m *AttendanceStatus) String() string            { return proto.CompactTextString(m) }
 func (*AttendanceStatus) ProtoMessage()               {}
-func (*AttendanceStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*AttendanceStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *AttendanceStatus) GetAttendanceStatus() []*core.EventParticipant {
 	if m != nil {
@@ -433,7 +662,7 @@ type EventChangeProposed struct {
 func (m *EventChangeProposed) Reset()                    { *m = EventChangeProposed{} }
 func (m *EventChangeProposed) String() string            { return proto.CompactTextString(m) }
 func (*EventChangeProposed) ProtoMessage()               {}
-func (*EventChangeProposed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*EventChangeProposed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 // VOTING STATUS
 // VOTING FINISHED
@@ -451,7 +680,7 @@ type VotingStatus struct {
 func (m *VotingStatus) Reset()                    { *m = VotingStatus{} }
 func (m *VotingStatus) String() string            { return proto.CompactTextString(m) }
 func (*VotingStatus) ProtoMessage()               {}
-func (*VotingStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*VotingStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 // CHANGE ACCEPTED
 type ChangeAccepted struct {
@@ -462,7 +691,7 @@ type ChangeAccepted struct {
 func (m *ChangeAccepted) Reset()                    { *m = ChangeAccepted{} }
 func (m *ChangeAccepted) String() string            { return proto.CompactTextString(m) }
 func (*ChangeAccepted) ProtoMessage()               {}
-func (*ChangeAccepted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*ChangeAccepted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 // CHANGE DISCARDED
 type ChangeDiscarded struct {
@@ -473,7 +702,7 @@ type ChangeDiscarded struct {
 func (m *ChangeDiscarded) Reset()                    { *m = ChangeDiscarded{} }
 func (m *ChangeDiscarded) String() string            { return proto.CompactTextString(m) }
 func (*ChangeDiscarded) ProtoMessage()               {}
-func (*ChangeDiscarded) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*ChangeDiscarded) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 // OK
 type Ok struct {
@@ -484,7 +713,7 @@ type Ok struct {
 func (m *Ok) Reset()                    { *m = Ok{} }
 func (m *Ok) String() string            { return proto.CompactTextString(m) }
 func (*Ok) ProtoMessage()               {}
-func (*Ok) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*Ok) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 // ERROR
 type Error struct {
@@ -495,7 +724,7 @@ type Error struct {
 func (m *Error) Reset()                    { *m = Error{} }
 func (m *Error) String() string            { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()               {}
-func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 // PING/PONG/CLOCK_RESPONSE
 type TimeInfo struct {
@@ -505,7 +734,7 @@ type TimeInfo struct {
 func (m *TimeInfo) Reset()                    { *m = TimeInfo{} }
 func (m *TimeInfo) String() string            { return proto.CompactTextString(m) }
 func (*TimeInfo) ProtoMessage()               {}
-func (*TimeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*TimeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 // READ EVENT
 type ReadEvent struct {
@@ -515,7 +744,7 @@ type ReadEvent struct {
 func (m *ReadEvent) Reset()                    { *m = ReadEvent{} }
 func (m *ReadEvent) String() string            { return proto.CompactTextString(m) }
 func (*ReadEvent) ProtoMessage()               {}
-func (*ReadEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*ReadEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 // LIST AUTHORED EVENTS
 // LIST PRIVATE EVENTS
@@ -523,6 +752,20 @@ func (*ReadEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3
 // HISTORY AUTHORED EVENTS
 // HISTORY PRIVATE EVENTS
 // HISTORY PUBLIC EVENTS
+// SYNC EVENTS
+// Cursor is the timestamp in microseconds and the event ID returned by the
+// previous sync. A zero cursor returns the current one, which clients get
+// before downloading their inbox.
+type SyncEvents struct {
+	Cursor        int64 `protobuf:"varint,1,opt,name=cursor" json:"cursor,omitempty"`
+	CursorEventId int64 `protobuf:"varint,2,opt,name=cursor_event_id,json=cursorEventId" json:"cursor_event_id,omitempty"`
+}
+
+func (m *SyncEvents) Reset()                    { *m = SyncEvents{} }
+func (m *SyncEvents) String() string            { return proto.CompactTextString(m) }
+func (*SyncEvents) ProtoMessage()               {}
+func (*SyncEvents) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
 type EventListRequest struct {
 	StartWindow     int64          `protobuf:"varint,1,opt,name=start_window,json=startWindow" json:"start_window,omitempty"`
 	EndWindow       int64          `protobuf:"varint,2,opt,name=end_window,json=endWindow" json:"end_window,omitempty"`
@@ -533,7 +776,7 @@ type EventListRequest struct {
 func (m *EventListRequest) Reset()                    { *m = EventListRequest{} }
 func (m *EventListRequest) String() string            { return proto.CompactTextString(m) }
 func (*EventListRequest) ProtoMessage()               {}
-func (*EventListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*EventListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *EventListRequest) GetUserCoordinates() *core.Location {
 	if m != nil {
@@ -542,6 +785,18 @@ func (m *EventListRequest) GetUserCoordinates() *core.Location {
 	return nil
 }
 
+// GET COMMENTS
+type CommentListRequest struct {
+	EventId  int64  `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	BeforeId int64  `protobuf:"varint,2,opt,name=before_id,json=beforeId" json:"before_id,omitempty"`
+	Limit    uint32 `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *CommentListRequest) Reset()                    { *m = CommentListRequest{} }
+func (m *CommentListRequest) String() string            { return proto.CompactTextString(m) }
+func (*CommentListRequest) ProtoMessage()               {}
+func (*CommentListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
 // EVENTS LIST
 type EventsList struct {
 	Event       []*core.Event `protobuf:"bytes,1,rep,name=event" json:"event,omitempty"`
@@ -552,7 +807,7 @@ type EventsList struct {
 func (m *EventsList) Reset()                    { *m = EventsList{} }
 func (m *EventsList) String() string            { return proto.CompactTextString(m) }
 func (*EventsList) ProtoMessage()               {}
-func (*EventsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*EventsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *EventsList) GetEvent() []*core.Event {
 	if m != nil {
@@ -561,6 +816,46 @@ func (m *EventsList) GetEvent() []*core.Event {
 	return nil
 }
 
+// EVENT CHANGES
+type EventChanges struct {
+	Events        []*core.Event `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+	RemovedEvents []int64       `protobuf:"varint,2,rep,packed,name=removed_events,json=removedEvents" json:"removed_events,omitempty"`
+	Cursor        int64         `protobuf:"varint,3,opt,name=cursor" json:"cursor,omitempty"`
+	HasMore       bool          `protobuf:"varint,4,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
+	CursorEventId int64         `protobuf:"varint,5,opt,name=cursor_event_id,json=cursorEventId" json:"cursor_event_id,omitempty"`
+}
+
+func (m *EventChanges) Reset()                    { *m = EventChanges{} }
+func (m *EventChanges) String() string            { return proto.CompactTextString(m) }
+func (*EventChanges) ProtoMessage()               {}
+func (*EventChanges) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *EventChanges) GetEvents() []*core.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// COMMENTS LIST
+type CommentsList struct {
+	EventId  int64           `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	Comments []*EventComment `protobuf:"bytes,2,rep,name=comments" json:"comments,omitempty"`
+	HasMore  bool            `protobuf:"varint,3,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
+}
+
+func (m *CommentsList) Reset()                    { *m = CommentsList{} }
+func (m *CommentsList) String() string            { return proto.CompactTextString(m) }
+func (*CommentsList) ProtoMessage()               {}
+func (*CommentsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *CommentsList) GetComments() []*EventComment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
 // FRIENDS LIST
 type FriendsList struct {
 	Friends []*core.Friend `protobuf:"bytes,1,rep,name=friends" json:"friends,omitempty"`
@@ -569,7 +864,7 @@ type FriendsList struct {
 func (m *FriendsList) Reset()                    { *m = FriendsList{} }
 func (m *FriendsList) String() string            { return proto.CompactTextString(m) }
 func (*FriendsList) ProtoMessage()               {}
-func (*FriendsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*FriendsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
 func (m *FriendsList) GetFriends() []*core.Friend {
 	if m != nil {
@@ -586,7 +881,7 @@ type GroupsList struct {
 func (m *GroupsList) Reset()                    { *m = GroupsList{} }
 func (m *GroupsList) String() string            { return proto.CompactTextString(m) }
 func (*GroupsList) ProtoMessage()               {}
-func (*GroupsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*GroupsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 func (m *GroupsList) GetGroups() []*core.Group {
 	if m != nil {
@@ -603,7 +898,7 @@ type FriendRequestsList struct {
 func (m *FriendRequestsList) Reset()                    { *m = FriendRequestsList{} }
 func (m *FriendRequestsList) String() string            { return proto.CompactTextString(m) }
 func (*FriendRequestsList) ProtoMessage()               {}
-func (*FriendRequestsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*FriendRequestsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *FriendRequestsList) GetFriendRequests() []*core.FriendRequest {
 	if m != nil {
@@ -612,10 +907,41 @@ func (m *FriendRequestsList) GetFriendRequests() []*core.FriendRequest {
 	return nil
 }
 
+// DEVICES LIST
+type Device struct {
+	DeviceId       string `protobuf:"bytes,1,opt,name=device_id,json=deviceId" json:"device_id,omitempty"`
+	Platform       string `protobuf:"bytes,2,opt,name=platform" json:"platform,omitempty"`
+	NetworkVersion uint32 `protobuf:"varint,3,opt,name=network_version,json=networkVersion" json:"network_version,omitempty"`
+	LastSeen       int64  `protobuf:"varint,4,opt,name=last_seen,json=lastSeen" json:"last_seen,omitempty"`
+	Current        bool   `protobuf:"varint,5,opt,name=current" json:"current,omitempty"`
+}
+
+func (m *Device) Reset()                    { *m = Device{} }
+func (m *Device) String() string            { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()               {}
+func (*Device) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+type DevicesList struct {
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices" json:"devices,omitempty"`
+}
+
+func (m *DevicesList) Reset()                    { *m = DevicesList{} }
+func (m *DevicesList) String() string            { return proto.CompactTextString(m) }
+func (*DevicesList) ProtoMessage()               {}
+func (*DevicesList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *DevicesList) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*AyiHeaderV2)(nil), "protocol.AyiHeaderV2")
 	proto.RegisterType((*Hello)(nil), "protocol.Hello")
 	proto.RegisterType((*CreateEvent)(nil), "protocol.CreateEvent")
+	proto.RegisterType((*Recurrence)(nil), "protocol.Recurrence")
 	proto.RegisterType((*CancelEvent)(nil), "protocol.CancelEvent")
 	proto.RegisterType((*InviteUsers)(nil), "protocol.InviteUsers")
 	proto.RegisterType((*CancelUsersInvitation)(nil), "protocol.CancelUsersInvitation")
@@ -628,10 +954,15 @@ func init() {
 	proto.RegisterType((*LinkAccount)(nil), "protocol.LinkAccount")
 	proto.RegisterType((*NewAuthToken)(nil), "protocol.NewAuthToken")
 	proto.RegisterType((*AccessToken)(nil), "protocol.AccessToken")
+	proto.RegisterType((*ResumeInfo)(nil), "protocol.ResumeInfo")
 	proto.RegisterType((*InstanceIDToken)(nil), "protocol.InstanceIDToken")
 	proto.RegisterType((*SyncGroups)(nil), "protocol.SyncGroups")
 	proto.RegisterType((*CreateFriendRequest)(nil), "protocol.CreateFriendRequest")
 	proto.RegisterType((*ConfirmFriendRequest)(nil), "protocol.ConfirmFriendRequest")
+	proto.RegisterType((*RevokeDevice)(nil), "protocol.RevokeDevice")
+	proto.RegisterType((*NotificationSettings)(nil), "protocol.NotificationSettings")
+	proto.RegisterType((*MuteEvent)(nil), "protocol.MuteEvent")
+	proto.RegisterType((*EventComment)(nil), "protocol.EventComment")
 	proto.RegisterType((*EventCancelled)(nil), "protocol.EventCancelled")
 	proto.RegisterType((*EventExpired)(nil), "protocol.EventExpired")
 	proto.RegisterType((*InvitationCancelled)(nil), "protocol.InvitationCancelled")
@@ -644,116 +975,194 @@ func init() {
 	proto.RegisterType((*Error)(nil), "protocol.Error")
 	proto.RegisterType((*TimeInfo)(nil), "protocol.TimeInfo")
 	proto.RegisterType((*ReadEvent)(nil), "protocol.ReadEvent")
+	proto.RegisterType((*SyncEvents)(nil), "protocol.SyncEvents")
 	proto.RegisterType((*EventListRequest)(nil), "protocol.EventListRequest")
+	proto.RegisterType((*CommentListRequest)(nil), "protocol.CommentListRequest")
 	proto.RegisterType((*EventsList)(nil), "protocol.EventsList")
+	proto.RegisterType((*EventChanges)(nil), "protocol.EventChanges")
+	proto.RegisterType((*CommentsList)(nil), "protocol.CommentsList")
 	proto.RegisterType((*FriendsList)(nil), "protocol.FriendsList")
 	proto.RegisterType((*GroupsList)(nil), "protocol.GroupsList")
 	proto.RegisterType((*FriendRequestsList)(nil), "protocol.FriendRequestsList")
+	proto.RegisterType((*Device)(nil), "protocol.Device")
+	proto.RegisterType((*DevicesList)(nil), "protocol.DevicesList")
+	proto.RegisterEnum("protocol.RecurrenceFrequency", RecurrenceFrequency_name, RecurrenceFrequency_value)
+	proto.RegisterEnum("protocol.EventVisibility", EventVisibility_name, EventVisibility_value)
 	proto.RegisterEnum("protocol.AuthType", AuthType_name, AuthType_value)
+	proto.RegisterEnum("protocol.NotificationCategory", NotificationCategory_name, NotificationCategory_value)
 	proto.RegisterEnum("protocol.ConfirmFriendRequest_FriendRequestResponse", ConfirmFriendRequest_FriendRequestResponse_name, ConfirmFriendRequest_FriendRequestResponse_value)
 }
 
 func init() { proto.RegisterFile("protocol.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1575 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0x5b, 0x6f, 0xdb, 0xc6,
-	0x12, 0x3e, 0x92, 0x2d, 0x59, 0x1a, 0x5d, 0x2c, 0xd3, 0x49, 0x8e, 0x92, 0x73, 0xcb, 0xe1, 0xc1,
-	0x71, 0x93, 0x16, 0x15, 0x1a, 0xb7, 0x79, 0x48, 0x83, 0x02, 0x95, 0x15, 0x27, 0x11, 0xea, 0xd8,
-	0x2e, 0xe3, 0xba, 0x8f, 0x04, 0x4d, 0xae, 0x6c, 0xc2, 0x14, 0x97, 0x25, 0x29, 0x39, 0x2e, 0xd0,
-	0x97, 0xf6, 0xa1, 0xcf, 0xfd, 0x09, 0xed, 0x9f, 0xe8, 0x4b, 0x9f, 0xfa, 0xcb, 0x3a, 0x3b, 0xb3,
-	0xbc, 0xc8, 0x75, 0x14, 0x20, 0x41, 0x5f, 0x04, 0xcd, 0xb7, 0xb3, 0x73, 0xdb, 0xd9, 0xf9, 0x96,
-	0xd0, 0x8d, 0x62, 0x99, 0x4a, 0x57, 0x06, 0x03, 0xfa, 0x63, 0x34, 0x32, 0xf9, 0x0e, 0xb8, 0x32,
-	0x16, 0x8c, 0x9a, 0x09, 0xb4, 0x86, 0x97, 0xfe, 0x73, 0xe1, 0x78, 0x22, 0x3e, 0xde, 0x36, 0xfa,
-	0xb0, 0x36, 0x17, 0x71, 0xe2, 0xcb, 0xb0, 0x5f, 0xb9, 0x5b, 0xb9, 0xd7, 0xb1, 0x32, 0xd1, 0xb8,
-	0x01, 0xb5, 0x54, 0x9e, 0x8b, 0xb0, 0x5f, 0x25, 0x9c, 0x05, 0xc3, 0x80, 0xd5, 0xf4, 0x32, 0x12,
-	0xfd, 0x15, 0x02, 0xe9, 0xbf, 0x71, 0x17, 0x5a, 0x91, 0x73, 0x19, 0x48, 0xc7, 0x7b, 0xe9, 0x7f,
-	0x2b, 0xfa, 0xab, 0xb4, 0x54, 0x86, 0xcc, 0xdf, 0x2a, 0x50, 0x7b, 0x2e, 0x82, 0x40, 0x1a, 0xf7,
-	0xa1, 0x97, 0x85, 0x65, 0x2f, 0x3a, 0x5e, 0xcf, 0xf0, 0x63, 0x1d, 0xc0, 0xff, 0xa1, 0xeb, 0x06,
-	0xbe, 0x08, 0xd3, 0x5c, 0x51, 0x45, 0xd2, 0xb4, 0x3a, 0x8c, 0x66, 0x6a, 0x77, 0xa0, 0x11, 0x05,
-	0x4e, 0x3a, 0x91, 0xf1, 0x94, 0xa2, 0x6a, 0x5a, 0xb9, 0x4c, 0xde, 0xf4, 0xff, 0xdc, 0xc8, 0x2a,
-	0xe9, 0xac, 0x67, 0x78, 0xc9, 0x4c, 0xe0, 0x84, 0xa7, 0x33, 0xe7, 0x54, 0xf4, 0x6b, 0x6c, 0x26,
-	0x93, 0xcd, 0xdf, 0x2b, 0xd0, 0x1a, 0xc5, 0xc2, 0x49, 0xc5, 0xee, 0x1c, 0x3d, 0xab, 0xa2, 0x4d,
-	0x45, 0x92, 0x28, 0xd5, 0x0a, 0xa9, 0x66, 0xa2, 0xf1, 0x5f, 0x68, 0xbb, 0xa4, 0xe8, 0xd9, 0x1e,
-	0xfe, 0x52, 0xc4, 0x2b, 0x56, 0x4b, 0x63, 0x4f, 0xf0, 0xc7, 0xf8, 0x17, 0x40, 0x92, 0x3a, 0x71,
-	0xca, 0x0a, 0x2b, 0xa4, 0xd0, 0x24, 0x84, 0x96, 0x6f, 0x43, 0x43, 0x84, 0x7a, 0xf7, 0x2a, 0x2d,
-	0xae, 0xa1, 0x4c, 0x4b, 0x26, 0xb4, 0x23, 0x54, 0xf3, 0x5d, 0x3f, 0x72, 0xc2, 0x34, 0xc1, 0x30,
-	0x57, 0x70, 0x79, 0x01, 0x53, 0xa1, 0x45, 0xbe, 0x9b, 0xce, 0x62, 0xd1, 0xaf, 0xe3, 0xee, 0xb6,
-	0x95, 0x89, 0xe6, 0xe7, 0x98, 0x83, 0x13, 0xba, 0x22, 0xe0, 0x1c, 0x94, 0x1f, 0xf5, 0xc7, 0xf6,
-	0x3d, 0x4a, 0x42, 0xf9, 0x51, 0xf2, 0xd8, 0x33, 0x6e, 0x41, 0x1d, 0xe3, 0x4d, 0xf2, 0x82, 0x6b,
-	0xc9, 0xdc, 0x83, 0xd6, 0x38, 0x9c, 0xfb, 0xa9, 0xf8, 0x2a, 0xc1, 0xa2, 0x2d, 0xb3, 0x70, 0x35,
-	0xd2, 0xea, 0x9f, 0x23, 0x35, 0x8f, 0xe1, 0x26, 0xc7, 0x43, 0xd6, 0xc8, 0xb0, 0x93, 0xaa, 0x93,
-	0x78, 0x47, 0xbb, 0x3e, 0x6c, 0x8c, 0x64, 0x38, 0xf1, 0xe3, 0xe9, 0x30, 0x4d, 0xb1, 0x74, 0xca,
-	0xc7, 0x32, 0x9b, 0x8f, 0xa0, 0xe5, 0xb8, 0xca, 0xb1, 0xed, 0x4a, 0x8f, 0x4f, 0xac, 0xbb, 0xdd,
-	0x1f, 0xd0, 0x95, 0x29, 0x2c, 0x58, 0x22, 0x89, 0x64, 0x98, 0x08, 0x0b, 0x58, 0x79, 0x84, 0xba,
-	0xe6, 0xf7, 0x55, 0x68, 0xbd, 0x90, 0x9e, 0x3f, 0xb9, 0x7c, 0x63, 0x4d, 0x4b, 0x2d, 0x53, 0x5d,
-	0x6c, 0x99, 0xb7, 0xef, 0x87, 0xd2, 0x59, 0xd7, 0x16, 0xce, 0x5a, 0x5d, 0x9d, 0x58, 0x4c, 0xe5,
-	0x5c, 0xd8, 0xe5, 0x66, 0x68, 0x58, 0x1d, 0x46, 0x0f, 0xb5, 0xda, 0x7f, 0xa0, 0x35, 0xa5, 0xf0,
-	0xd9, 0xfc, 0x1a, 0x99, 0x07, 0x86, 0xae, 0xed, 0xb8, 0xc6, 0xb5, 0xf5, 0x86, 0x63, 0x99, 0x8a,
-	0xd1, 0x19, 0xde, 0x96, 0xa5, 0x85, 0xfe, 0x07, 0x34, 0x5d, 0x52, 0x52, 0x6b, 0xaa, 0x08, 0x35,
-	0xab, 0xc1, 0x00, 0x2e, 0xfe, 0x0f, 0x3a, 0x8e, 0xeb, 0x8a, 0x28, 0xb5, 0x19, 0xa2, 0x42, 0x34,
-	0xac, 0x36, 0x83, 0x6c, 0xdc, 0x7c, 0x05, 0x6d, 0xd5, 0x2c, 0x87, 0x32, 0xf1, 0xa9, 0x53, 0x3e,
-	0x03, 0xe3, 0x34, 0x90, 0x27, 0x4e, 0x80, 0x47, 0x27, 0x63, 0xcf, 0x0f, 0x31, 0xe6, 0x84, 0xdc,
-	0xb6, 0xb6, 0xbb, 0x7c, 0x82, 0x7b, 0xd2, 0xa5, 0xae, 0xb2, 0x36, 0x58, 0x73, 0x54, 0x28, 0xaa,
-	0xe9, 0x20, 0x92, 0xd4, 0x9f, 0x92, 0x82, 0x2d, 0xe2, 0x58, 0xc6, 0x14, 0x57, 0xd5, 0x5a, 0x2f,
-	0xf0, 0x5d, 0x05, 0x9b, 0x8f, 0x61, 0xa3, 0xec, 0xd9, 0xa2, 0x5c, 0xb7, 0x60, 0x3d, 0xe6, 0x7c,
-	0x42, 0x7b, 0x2a, 0x52, 0xec, 0x62, 0xf2, 0x5d, 0xc5, 0x32, 0x53, 0x56, 0xe1, 0x0b, 0x02, 0xcd,
-	0x5f, 0x2b, 0xd8, 0x92, 0x34, 0x01, 0x94, 0x8d, 0xa1, 0xeb, 0xca, 0x19, 0x36, 0x0b, 0x4e, 0xd2,
-	0xd0, 0x99, 0x66, 0x13, 0x84, 0xfe, 0xab, 0x99, 0x2b, 0xa6, 0x8e, 0x1f, 0xe8, 0x1e, 0x61, 0x81,
-	0x26, 0x9c, 0x93, 0x24, 0x17, 0x18, 0x79, 0x3e, 0xe1, 0xb4, 0xac, 0x76, 0x44, 0x67, 0x32, 0x14,
-	0x7a, 0xac, 0xb1, 0xa0, 0x6c, 0x4f, 0x4e, 0xb0, 0xca, 0x3c, 0xc8, 0xe8, 0xbf, 0xea, 0x96, 0xc9,
-	0x09, 0x4f, 0xf4, 0x3a, 0x77, 0xa0, 0x16, 0xcb, 0x7d, 0xb4, 0xb6, 0x38, 0x33, 0x7e, 0xc6, 0xc1,
-	0xb7, 0xe7, 0x87, 0xe7, 0x59, 0xcc, 0x7f, 0x87, 0xb5, 0x19, 0xa6, 0x50, 0x1c, 0x6e, 0x5d, 0x89,
-	0x78, 0x7c, 0x0f, 0x41, 0xb1, 0xcd, 0xdc, 0x47, 0x52, 0xd1, 0x37, 0xe8, 0xb6, 0xbe, 0x41, 0xbc,
-	0xf3, 0x50, 0x2f, 0x1e, 0x21, 0x5f, 0x58, 0xb9, 0xaa, 0xea, 0x7d, 0x87, 0x15, 0x94, 0x49, 0xce,
-	0xad, 0xa9, 0x91, 0xbc, 0x29, 0x68, 0x99, 0x03, 0xe7, 0x24, 0xdb, 0x1a, 0x3c, 0x52, 0x98, 0x79,
-	0x02, 0xed, 0x7d, 0x71, 0x31, 0x9c, 0xa5, 0x67, 0x24, 0x53, 0x45, 0xb0, 0x3a, 0x0f, 0x74, 0x61,
-	0x59, 0xc8, 0xd0, 0xed, 0xac, 0xb2, 0x24, 0xe0, 0x09, 0x16, 0x6c, 0xd6, 0xdd, 0x36, 0x06, 0x39,
-	0x83, 0x92, 0x39, 0x15, 0x2b, 0xad, 0x9b, 0xbb, 0x48, 0x9a, 0xd8, 0x88, 0x49, 0xc2, 0x2e, 0x5e,
-	0x5b, 0x06, 0x95, 0x0f, 0xee, 0xb4, 0x0b, 0xe2, 0x54, 0xf9, 0x64, 0xa1, 0x99, 0xef, 0xc1, 0xfa,
-	0x38, 0xc4, 0xab, 0x8d, 0xf3, 0x64, 0xfc, 0x24, 0x8f, 0x96, 0x95, 0x75, 0xb4, 0x24, 0x98, 0x3f,
-	0x54, 0x00, 0x5e, 0x5e, 0x86, 0xee, 0xb3, 0x58, 0xce, 0xa2, 0x44, 0x29, 0xc9, 0x8b, 0x10, 0x4b,
-	0xcb, 0xde, 0x58, 0xc0, 0xea, 0xd4, 0x4f, 0x69, 0x9d, 0xc6, 0x60, 0x6b, 0xbb, 0xc5, 0x15, 0xa7,
-	0x3d, 0x96, 0x5e, 0x32, 0x3e, 0x85, 0x6e, 0x82, 0x86, 0xec, 0x13, 0x71, 0xe6, 0xcc, 0x7d, 0x39,
-	0x8b, 0x75, 0xae, 0x9b, 0xac, 0xac, 0x9c, 0xec, 0x64, 0x4b, 0x56, 0x27, 0x29, 0x8b, 0xe6, 0x07,
-	0xb0, 0xc9, 0x6d, 0xfb, 0x34, 0x46, 0xc2, 0xf5, 0x2c, 0xf1, 0xcd, 0x0c, 0xef, 0x45, 0xd1, 0xa4,
-	0x95, 0x52, 0x93, 0xaa, 0x26, 0xbf, 0xa1, 0xe7, 0xee, 0xa2, 0x3a, 0x5e, 0xfb, 0x09, 0x01, 0x45,
-	0xb9, 0x1a, 0x0c, 0x60, 0xc1, 0x0e, 0xa1, 0x11, 0xeb, 0xc9, 0xaa, 0xfb, 0xe6, 0x93, 0xe2, 0x10,
-	0xae, 0x33, 0x37, 0x58, 0x90, 0xf2, 0xa9, 0x9c, 0x5b, 0x31, 0x3f, 0x82, 0x9b, 0xd7, 0xaa, 0x18,
-	0x00, 0xf5, 0xd1, 0x70, 0x7f, 0xb4, 0xbb, 0xd7, 0xfb, 0x9b, 0xd1, 0x82, 0xb5, 0xd1, 0xc1, 0xfe,
-	0xd3, 0xb1, 0xf5, 0xa2, 0x57, 0x31, 0xbf, 0x83, 0x2e, 0x8d, 0x6f, 0x66, 0xa3, 0x40, 0x78, 0xc6,
-	0x4d, 0xa8, 0x5f, 0x9c, 0xc9, 0x22, 0xde, 0x1a, 0x4a, 0x18, 0x6c, 0x79, 0xb6, 0x55, 0x5f, 0x47,
-	0x99, 0x2b, 0x65, 0xca, 0xc4, 0xf7, 0x40, 0x8d, 0x54, 0xa8, 0x73, 0xf3, 0x23, 0x22, 0x77, 0x16,
-	0xaf, 0x98, 0xf7, 0xa1, 0x4d, 0xf2, 0xee, 0xab, 0xc8, 0x8f, 0x85, 0xb7, 0x64, 0x82, 0x62, 0x6e,
-	0x9b, 0x05, 0x4f, 0x16, 0xe1, 0x2e, 0xd9, 0xf1, 0x53, 0x05, 0x7a, 0x05, 0x89, 0xbd, 0xc4, 0x9d,
-	0xb3, 0xa5, 0xc4, 0x3d, 0x82, 0x0d, 0x27, 0x57, 0xb7, 0x13, 0xd2, 0xd7, 0xed, 0x75, 0xab, 0x14,
-	0xfb, 0x61, 0x41, 0x00, 0x56, 0xcf, 0xb9, 0x6a, 0x1f, 0x6f, 0x41, 0x38, 0x9b, 0xda, 0xa7, 0xaa,
-	0xfc, 0x09, 0x15, 0xa4, 0x66, 0x35, 0x11, 0x79, 0x46, 0x80, 0x1a, 0x2a, 0x9b, 0x5c, 0x70, 0x9a,
-	0xea, 0x38, 0x1a, 0x22, 0x99, 0x2c, 0x4d, 0x63, 0x39, 0x75, 0xbc, 0x13, 0x81, 0x66, 0xa4, 0x5c,
-	0x5b, 0x20, 0x65, 0xf3, 0xc7, 0x2a, 0xb4, 0x91, 0xd5, 0xfc, 0xf0, 0xf4, 0xcd, 0x35, 0xfb, 0x8b,
-	0x82, 0xc3, 0xa7, 0xa4, 0x08, 0x9c, 0x08, 0x2b, 0x63, 0x23, 0x17, 0x71, 0x84, 0xf8, 0x94, 0xd4,
-	0xd8, 0x11, 0x42, 0x8a, 0xe6, 0xe7, 0x48, 0xbd, 0x89, 0x1d, 0x0b, 0x57, 0xf8, 0x73, 0xe1, 0xd1,
-	0x64, 0xef, 0x58, 0x1d, 0x42, 0x2d, 0x0d, 0x2a, 0x9a, 0x67, 0xb5, 0x54, 0xa6, 0x4e, 0x40, 0x33,
-	0xbe, 0x63, 0x01, 0x41, 0x47, 0x0a, 0x51, 0x04, 0x33, 0xf1, 0x43, 0x3f, 0x39, 0x43, 0x0b, 0x0d,
-	0xe2, 0xdd, 0x5c, 0x36, 0x9f, 0x43, 0x97, 0xcf, 0x69, 0x48, 0x4c, 0xfc, 0xf6, 0xe7, 0x64, 0x8e,
-	0x61, 0x9d, 0x2d, 0x3d, 0xf1, 0x13, 0xd7, 0x89, 0xbd, 0x77, 0x30, 0xb5, 0x0d, 0xd5, 0x83, 0xf3,
-	0xfc, 0x5b, 0xa4, 0x52, 0xfa, 0x16, 0x51, 0x5c, 0xc6, 0x1f, 0x1e, 0xb4, 0x49, 0x71, 0x19, 0x8b,
-	0xe6, 0x03, 0xa8, 0x11, 0x97, 0x5f, 0xbb, 0x4d, 0xcd, 0xb4, 0x9c, 0xff, 0x6b, 0x16, 0x0b, 0xe6,
-	0x87, 0xd0, 0x50, 0x75, 0x1e, 0x87, 0x13, 0x49, 0x2f, 0xfb, 0x59, 0x1c, 0xab, 0x60, 0xe9, 0x38,
-	0x2a, 0xfa, 0x65, 0xcf, 0x98, 0x52, 0x33, 0xb7, 0xa0, 0x69, 0xe1, 0x77, 0xd5, 0x9b, 0xde, 0x82,
-	0x6a, 0x54, 0xf6, 0x48, 0x69, 0xcf, 0x57, 0xf3, 0x89, 0xc7, 0x24, 0xda, 0xe7, 0x46, 0xb9, 0xf0,
-	0x43, 0x4f, 0x5e, 0x64, 0xf6, 0x09, 0xfb, 0x9a, 0x20, 0xd5, 0x4b, 0xaa, 0x59, 0xb4, 0x02, 0x4f,
-	0xa0, 0x26, 0x22, 0x7a, 0xf9, 0x11, 0xf4, 0x88, 0x95, 0xca, 0x6f, 0xa1, 0x95, 0x6b, 0xdf, 0x42,
-	0xeb, 0x4a, 0xaf, 0xfc, 0x12, 0xba, 0xe6, 0x25, 0xc3, 0x5f, 0x71, 0x57, 0x5e, 0x32, 0x12, 0x80,
-	0x02, 0x4f, 0x54, 0xe4, 0xc5, 0x70, 0xab, 0x94, 0xf9, 0xa7, 0x3c, 0xdc, 0xd4, 0xa7, 0x61, 0x29,
-	0x83, 0xec, 0x73, 0xa8, 0x9c, 0xd4, 0x3f, 0xa1, 0x48, 0x21, 0xbb, 0x1f, 0x39, 0x60, 0x3e, 0x84,
-	0x16, 0x4f, 0x73, 0xf6, 0xb8, 0x85, 0x6f, 0x18, 0x16, 0xb5, 0xcf, 0x36, 0xfb, 0xd4, 0x13, 0x3f,
-	0x5b, 0xc4, 0xb3, 0x06, 0xa6, 0x4e, 0xda, 0x55, 0x10, 0x65, 0xe5, 0xb5, 0x44, 0x69, 0x7e, 0x09,
-	0xc6, 0x02, 0x6f, 0xf0, 0xd6, 0xc7, 0xd0, 0x9d, 0x2c, 0xa0, 0xda, 0xc4, 0xe6, 0x82, 0x5f, 0xcd,
-	0x34, 0x57, 0x54, 0xdf, 0xbf, 0x07, 0x8d, 0xec, 0x1d, 0x61, 0xb4, 0xf1, 0xbf, 0xbd, 0x3f, 0x3c,
-	0x1a, 0x1f, 0xef, 0x22, 0xff, 0x74, 0x01, 0x86, 0xf6, 0xd3, 0xe1, 0x68, 0x77, 0xe7, 0xe0, 0xe0,
-	0x8b, 0x5e, 0x65, 0x67, 0x0b, 0xfe, 0x2d, 0x92, 0x41, 0x24, 0x44, 0x14, 0x88, 0x81, 0x13, 0x8b,
-	0x4b, 0x39, 0xf3, 0xc3, 0x41, 0xe2, 0x9d, 0x0f, 0x42, 0x91, 0xe2, 0x3b, 0xef, 0xfc, 0x97, 0xea,
-	0xca, 0xf0, 0x70, 0xe7, 0xa4, 0x4e, 0xdc, 0xf8, 0xf1, 0x1f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x51,
-	0xbe, 0xc4, 0x27, 0xeb, 0x0f, 0x00, 0x00,
+	// 2686 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x4f, 0x6f, 0x1b, 0xc7,
+	0x15, 0xcf, 0x92, 0xa2, 0x44, 0x3e, 0xfe, 0xd5, 0xca, 0x4e, 0xe9, 0xb8, 0x4e, 0xe4, 0x0d, 0x62,
+	0x2b, 0x0e, 0x22, 0xc4, 0x6a, 0x72, 0x70, 0x82, 0xa2, 0xa0, 0x29, 0xda, 0x26, 0x22, 0x51, 0xca,
+	0x88, 0x66, 0x90, 0x5e, 0x16, 0xab, 0xdd, 0x91, 0x34, 0x10, 0xb9, 0xc3, 0xec, 0xcc, 0x4a, 0x61,
+	0x80, 0x9e, 0x7a, 0xe8, 0x07, 0xe8, 0x07, 0x28, 0x5a, 0xf4, 0x56, 0xa0, 0xb7, 0x22, 0xf7, 0x7e,
+	0x96, 0x7e, 0x81, 0xde, 0x7b, 0x28, 0x66, 0xde, 0xec, 0x3f, 0x99, 0xa1, 0x8a, 0x04, 0xbd, 0xed,
+	0xfb, 0xcd, 0x9b, 0x37, 0x6f, 0xde, 0xbc, 0x7f, 0x33, 0x0b, 0xad, 0x79, 0xc4, 0x25, 0xf7, 0xf9,
+	0x74, 0x57, 0x7f, 0xd8, 0xd5, 0x84, 0x7e, 0x07, 0x7c, 0x1e, 0x51, 0x44, 0x9d, 0x3f, 0x5a, 0x50,
+	0xef, 0x2d, 0xd8, 0x2b, 0xea, 0x05, 0x34, 0x9a, 0xec, 0xd9, 0x5d, 0xd8, 0xb8, 0xa2, 0x91, 0x60,
+	0x3c, 0xec, 0x5a, 0xdb, 0xd6, 0x4e, 0x93, 0x24, 0xa4, 0x7d, 0x07, 0x2a, 0x92, 0x5f, 0xd2, 0xb0,
+	0x5b, 0xd2, 0x38, 0x12, 0xb6, 0x0d, 0x6b, 0x72, 0x31, 0xa7, 0xdd, 0xb2, 0x06, 0xf5, 0xb7, 0xbd,
+	0x0d, 0xf5, 0xb9, 0xb7, 0x98, 0x72, 0x2f, 0x38, 0x61, 0xdf, 0xd3, 0xee, 0x9a, 0x1e, 0xca, 0x43,
+	0xf6, 0xbb, 0x00, 0x3e, 0x9f, 0xcd, 0x23, 0x2a, 0x04, 0x0d, 0xba, 0x95, 0x6d, 0x6b, 0xa7, 0x4a,
+	0x72, 0x88, 0xf3, 0xa7, 0x12, 0x54, 0x5e, 0xd1, 0xe9, 0x94, 0xdb, 0x1f, 0x42, 0x27, 0xd1, 0xdb,
+	0x2d, 0x2a, 0xd6, 0x4e, 0xf0, 0x89, 0x51, 0xf0, 0x03, 0x68, 0xf9, 0x53, 0x46, 0x43, 0x99, 0x32,
+	0x2a, 0x4d, 0x6b, 0xa4, 0x89, 0x68, 0xc2, 0xf6, 0x0e, 0x54, 0xe7, 0x53, 0x4f, 0x9e, 0xf1, 0x68,
+	0xa6, 0xb5, 0xae, 0x91, 0x94, 0xd6, 0xab, 0x99, 0xef, 0x54, 0xc8, 0x9a, 0xe6, 0x69, 0x27, 0x78,
+	0x4e, 0xcc, 0xd4, 0x0b, 0xcf, 0x63, 0xef, 0x9c, 0xea, 0x0d, 0xd4, 0x48, 0x4a, 0x2b, 0x03, 0x24,
+	0x9b, 0x51, 0x12, 0xd6, 0xb7, 0xcb, 0x3b, 0x35, 0x92, 0x87, 0x6c, 0x07, 0x1a, 0xbe, 0x37, 0xf7,
+	0x4e, 0xd9, 0x94, 0x49, 0x46, 0x45, 0x77, 0x43, 0xb3, 0x14, 0x30, 0xfb, 0x3e, 0xd4, 0x02, 0x7a,
+	0xc5, 0x7c, 0xea, 0xb2, 0xa0, 0x5b, 0xc5, 0x25, 0x10, 0x18, 0x06, 0xce, 0x5f, 0xcb, 0x50, 0xef,
+	0x47, 0xd4, 0x93, 0x74, 0x70, 0x45, 0x43, 0xa9, 0xce, 0x6d, 0x46, 0x85, 0x50, 0xda, 0x58, 0x9a,
+	0x35, 0x21, 0xed, 0x87, 0xd0, 0xf0, 0x35, 0x63, 0xe0, 0x06, 0x9e, 0xa4, 0xda, 0x28, 0x65, 0x52,
+	0x37, 0xd8, 0xbe, 0x27, 0xa9, 0xfd, 0x00, 0x40, 0x48, 0x2f, 0x92, 0xc8, 0x50, 0xd6, 0x0c, 0x35,
+	0x8d, 0xe8, 0xe1, 0x7b, 0x50, 0xa5, 0xa1, 0x99, 0xbd, 0xa6, 0x07, 0x37, 0x68, 0x88, 0x33, 0x1d,
+	0x68, 0xcc, 0xbd, 0x48, 0x32, 0x9f, 0xcd, 0xbd, 0x50, 0x8a, 0x6e, 0x65, 0xbb, 0xbc, 0x53, 0x26,
+	0x05, 0x4c, 0xa9, 0x36, 0x67, 0xbe, 0x8c, 0x23, 0xda, 0x5d, 0xdf, 0xb6, 0x76, 0x1a, 0x24, 0x21,
+	0xd5, 0x0e, 0x99, 0x70, 0xe7, 0xf1, 0xe9, 0x94, 0xf9, 0xdd, 0x0d, 0xed, 0x05, 0x55, 0x26, 0x8e,
+	0x35, 0x6d, 0x7f, 0x02, 0xf5, 0x73, 0xca, 0xa7, 0xdc, 0xf7, 0xa4, 0x32, 0xa2, 0x32, 0x40, 0x7d,
+	0xaf, 0xb5, 0xab, 0x7d, 0xf7, 0xc0, 0xa0, 0x24, 0xcf, 0x62, 0x7f, 0x0a, 0x10, 0x51, 0x3f, 0x8e,
+	0x22, 0x1a, 0xfa, 0xb4, 0x5b, 0xd3, 0x13, 0xee, 0xec, 0xa6, 0x61, 0x40, 0xd2, 0x31, 0x92, 0xe3,
+	0x53, 0x07, 0xa9, 0xcc, 0xee, 0x33, 0xb9, 0xe8, 0xc2, 0xb6, 0xb5, 0x53, 0x21, 0x29, 0x6d, 0xbf,
+	0x0d, 0xeb, 0xdf, 0xc6, 0x3c, 0x8a, 0x67, 0xdd, 0xba, 0x1e, 0x31, 0x94, 0xfd, 0x18, 0xda, 0xf8,
+	0xe5, 0x06, 0xd4, 0x0b, 0xa6, 0x2c, 0xa4, 0xdd, 0x86, 0x36, 0x4c, 0x0b, 0xe1, 0x7d, 0x83, 0x3a,
+	0x7f, 0xb7, 0x00, 0xb2, 0x75, 0xed, 0x2f, 0xa0, 0x76, 0x16, 0xd1, 0x6f, 0x63, 0x1a, 0xfa, 0x0b,
+	0x7d, 0x4e, 0xad, 0xbd, 0x07, 0xcb, 0x14, 0x7c, 0x91, 0x30, 0x91, 0x8c, 0x5f, 0x29, 0xca, 0x42,
+	0x49, 0xa3, 0x2b, 0x6f, 0x6a, 0x62, 0x30, 0xa5, 0x55, 0x70, 0xfa, 0x3c, 0x0e, 0xa5, 0x89, 0x43,
+	0x24, 0x14, 0x1a, 0x87, 0x92, 0x4d, 0xcd, 0xa9, 0x21, 0xa1, 0xac, 0x2e, 0xd9, 0x8c, 0xba, 0xdf,
+	0xf3, 0x30, 0x75, 0x5d, 0x05, 0xfc, 0x96, 0x87, 0xd4, 0xa1, 0x50, 0xef, 0x7b, 0xa1, 0x4f, 0xa7,
+	0xe8, 0x56, 0xea, 0xe8, 0xd5, 0x87, 0x72, 0x41, 0xcb, 0x1c, 0xbd, 0xa2, 0x87, 0x81, 0xb2, 0x4d,
+	0x44, 0x3d, 0x91, 0x86, 0x99, 0xa1, 0xec, 0xf7, 0xa1, 0xe9, 0x6b, 0x09, 0xae, 0xa0, 0x91, 0xf2,
+	0xed, 0xb2, 0x3e, 0xd8, 0x06, 0x82, 0x27, 0x1a, 0x73, 0x0e, 0xa0, 0x3e, 0x0c, 0xaf, 0x98, 0xa4,
+	0xaf, 0x05, 0x8d, 0xc4, 0xaa, 0x65, 0x6e, 0x7a, 0x58, 0xe9, 0x4d, 0x0f, 0x73, 0x26, 0x70, 0x17,
+	0x95, 0xd6, 0xd2, 0xb4, 0x60, 0xf4, 0x88, 0x9f, 0x29, 0x97, 0xc1, 0x66, 0x9f, 0x87, 0x67, 0x2c,
+	0x9a, 0xf5, 0xa4, 0xa4, 0x61, 0xa0, 0xd6, 0x58, 0x25, 0xf3, 0x19, 0xd4, 0x3d, 0x5f, 0x2d, 0xec,
+	0xfa, 0x3c, 0xc0, 0x48, 0x6b, 0xed, 0x75, 0xd1, 0x65, 0x33, 0x09, 0x84, 0x8a, 0x39, 0x0f, 0x05,
+	0x25, 0x80, 0xcc, 0x7d, 0x1e, 0x50, 0xe7, 0xdf, 0x6b, 0x50, 0x3f, 0xe4, 0x01, 0x3b, 0x5b, 0xdc,
+	0x6a, 0xf8, 0x5c, 0xa8, 0x97, 0x8a, 0xa1, 0xfe, 0xd3, 0xe3, 0x38, 0x17, 0xa3, 0x95, 0x62, 0x8c,
+	0x7e, 0x00, 0xad, 0x88, 0xce, 0xf8, 0x15, 0x75, 0xf3, 0x41, 0x5c, 0x25, 0x4d, 0x44, 0x8f, 0x0d,
+	0xdb, 0x7b, 0x50, 0x9f, 0x69, 0xf5, 0x51, 0xfc, 0x86, 0x16, 0x0f, 0x08, 0x2d, 0xcd, 0x14, 0xd5,
+	0x25, 0x99, 0xe2, 0x19, 0xc0, 0x15, 0x13, 0x4c, 0x67, 0xc0, 0x85, 0x0e, 0xe0, 0xd6, 0xde, 0xbd,
+	0x2c, 0x3e, 0xb4, 0x65, 0x26, 0x29, 0x03, 0xc9, 0x31, 0xdf, 0xcc, 0x16, 0x70, 0x7b, 0xb6, 0xf8,
+	0x18, 0x6c, 0xb3, 0xb1, 0xfc, 0xc4, 0xba, 0xde, 0xdc, 0x26, 0x8e, 0xbc, 0xcc, 0xb1, 0x3f, 0x82,
+	0xb6, 0x37, 0x9f, 0x4f, 0x17, 0xae, 0xe4, 0x89, 0x63, 0x37, 0xd0, 0x10, 0x1a, 0x1e, 0x73, 0xf4,
+	0xec, 0x42, 0x3a, 0x69, 0xde, 0x48, 0x27, 0x8f, 0xa1, 0x6d, 0x96, 0x4c, 0x59, 0x5a, 0x5a, 0x86,
+	0x31, 0x71, 0xff, 0xcd, 0xbc, 0xd3, 0xbe, 0x2d, 0xef, 0x74, 0x96, 0xe5, 0x1d, 0x15, 0x84, 0x66,
+	0x25, 0x23, 0x67, 0x13, 0x83, 0x10, 0xc1, 0xaf, 0x34, 0xe6, 0x30, 0x80, 0x09, 0x97, 0xb4, 0x7f,
+	0xe1, 0x85, 0xe7, 0x2b, 0xfd, 0xfa, 0x3e, 0xd4, 0x7c, 0xcd, 0xa4, 0xc6, 0x4a, 0x66, 0x53, 0x1a,
+	0x18, 0x06, 0x6a, 0x29, 0xcf, 0xf7, 0xe9, 0x5c, 0xba, 0x08, 0x25, 0xf1, 0x8e, 0x20, 0x0a, 0x77,
+	0xbe, 0x83, 0x86, 0x8a, 0xcd, 0x63, 0x2e, 0x98, 0xb6, 0xe6, 0xaf, 0xc1, 0x3e, 0x9f, 0xf2, 0x53,
+	0x6f, 0xea, 0xfa, 0x9c, 0x47, 0x01, 0x0b, 0x3d, 0x49, 0x45, 0xd7, 0x5a, 0x7a, 0x6a, 0x9b, 0xc8,
+	0xd9, 0xcf, 0x18, 0x55, 0x9d, 0xa6, 0x42, 0xb2, 0x99, 0x66, 0x70, 0x69, 0x14, 0xf1, 0x48, 0xeb,
+	0x55, 0x22, 0xed, 0x0c, 0x1f, 0x28, 0xd8, 0xf9, 0x02, 0x36, 0xf3, 0x2b, 0x13, 0xbd, 0xd7, 0x47,
+	0xd0, 0x8e, 0x70, 0x3f, 0xa1, 0x3b, 0xa3, 0x92, 0x46, 0xb8, 0x76, 0x89, 0x34, 0x35, 0x3c, 0x0c,
+	0x0f, 0x35, 0xe8, 0xfc, 0x60, 0xc1, 0x26, 0x56, 0x59, 0x25, 0xa3, 0xe7, 0x63, 0x5a, 0xb5, 0x61,
+	0x2d, 0xf4, 0x66, 0x49, 0xa1, 0xd5, 0xdf, 0x2a, 0xd5, 0xd2, 0x99, 0xc7, 0xa6, 0x26, 0x24, 0x91,
+	0xd0, 0xbd, 0x86, 0x27, 0xc4, 0x35, 0x8f, 0x82, 0xb4, 0xd7, 0x30, 0xb4, 0x9a, 0x31, 0xbf, 0xe0,
+	0x21, 0x86, 0x62, 0x8d, 0x20, 0xa1, 0x64, 0x9f, 0x9d, 0xb2, 0xc0, 0xe4, 0x65, 0xfd, 0xad, 0x82,
+	0xf3, 0xec, 0x14, 0x7b, 0xaf, 0x75, 0x0c, 0x78, 0x43, 0xe6, 0xc3, 0x76, 0xa3, 0x10, 0xb6, 0xce,
+	0x9f, 0x2d, 0xa8, 0x1f, 0xb0, 0xf0, 0x32, 0xd1, 0xf9, 0x17, 0xb0, 0x11, 0x0b, 0x1a, 0x65, 0x87,
+	0xbb, 0xae, 0xc8, 0x61, 0x60, 0x7f, 0x06, 0xaa, 0x31, 0xbc, 0x62, 0x01, 0x8d, 0x4c, 0xc2, 0xba,
+	0x67, 0x12, 0x16, 0xce, 0x3c, 0x36, 0x83, 0xe3, 0xc5, 0x9c, 0x92, 0x94, 0x55, 0xa5, 0x1a, 0x0f,
+	0x19, 0x5c, 0x96, 0xec, 0xad, 0x66, 0x90, 0xd4, 0x29, 0xf4, 0x30, 0x2a, 0x8e, 0x9b, 0x6c, 0x18,
+	0x70, 0xac, 0x30, 0xe7, 0x14, 0x1a, 0x23, 0x7a, 0xdd, 0x8b, 0xe5, 0x85, 0xa6, 0xb5, 0x45, 0x3c,
+	0x21, 0x9e, 0x1a, 0xc3, 0x22, 0x91, 0xa0, 0x7b, 0x89, 0x65, 0x35, 0x61, 0x3f, 0xca, 0xf5, 0x9d,
+	0xad, 0x3d, 0x3b, 0x4b, 0x12, 0x5a, 0x9c, 0xd2, 0x55, 0x8f, 0x3b, 0x17, 0x50, 0xef, 0xf9, 0x3e,
+	0x15, 0x02, 0x97, 0xf8, 0x51, 0x33, 0xa8, 0xfd, 0xc4, 0xf2, 0xc2, 0xcd, 0x5a, 0x5c, 0xb5, 0x9f,
+	0x54, 0xb5, 0x87, 0xd0, 0x88, 0xa8, 0x88, 0x67, 0xd4, 0x30, 0xe0, 0x86, 0xeb, 0x88, 0xe1, 0x6e,
+	0xe6, 0xaa, 0xd2, 0x2b, 0x72, 0x18, 0x9e, 0xf1, 0x37, 0x26, 0x58, 0x6f, 0x4c, 0x50, 0x87, 0x87,
+	0x24, 0xc6, 0x54, 0x95, 0x24, 0xa4, 0xca, 0xb9, 0x33, 0xa6, 0x1a, 0x61, 0x77, 0xee, 0xf9, 0x97,
+	0x54, 0x0a, 0x53, 0xd6, 0x9b, 0x88, 0x1e, 0x23, 0xe8, 0x3c, 0x86, 0xf6, 0x30, 0x14, 0x52, 0xd5,
+	0x94, 0xe1, 0x7e, 0x6a, 0xc2, 0xfc, 0x7a, 0x48, 0x38, 0xbf, 0xb7, 0x00, 0x4e, 0x16, 0xa1, 0xff,
+	0x32, 0xe2, 0xf1, 0x5c, 0x28, 0x26, 0x7e, 0x1d, 0xd2, 0xc8, 0x98, 0x00, 0x09, 0xfb, 0x7d, 0x58,
+	0x3f, 0xd7, 0xe3, 0xba, 0x14, 0xd6, 0xf7, 0xea, 0xe8, 0x06, 0x7a, 0x0e, 0x31, 0x43, 0xf6, 0xe7,
+	0xd0, 0x12, 0x8b, 0xd0, 0x77, 0x4f, 0xe9, 0x85, 0x77, 0xc5, 0x78, 0x1c, 0x99, 0x03, 0xd8, 0x42,
+	0x66, 0xb5, 0xc8, 0xf3, 0x64, 0x88, 0x34, 0x45, 0x9e, 0x74, 0x3e, 0x82, 0x2d, 0x8c, 0xa5, 0x17,
+	0x11, 0xa3, 0x61, 0x40, 0x54, 0x5f, 0x23, 0x64, 0x16, 0x39, 0x56, 0x2e, 0x72, 0x54, 0xe4, 0xdd,
+	0x31, 0xb5, 0xb7, 0xc8, 0x7e, 0x5f, 0xb5, 0x50, 0x0a, 0xc8, 0xce, 0xb0, 0x8a, 0xc0, 0x30, 0xb0,
+	0x8f, 0xa1, 0x1a, 0x99, 0xea, 0x6a, 0x9c, 0xf9, 0xd3, 0xcc, 0x33, 0x96, 0x89, 0xdb, 0x2d, 0x50,
+	0x69, 0x65, 0x4e, 0xa5, 0x38, 0x9f, 0xc0, 0xdd, 0xa5, 0x2c, 0x36, 0xc0, 0x7a, 0xbf, 0x37, 0xea,
+	0x0f, 0x0e, 0x3a, 0x6f, 0xd9, 0x75, 0xd8, 0xe8, 0x1f, 0x8d, 0x5e, 0x0c, 0xc9, 0x61, 0xc7, 0x72,
+	0x3e, 0x82, 0x06, 0xa1, 0x57, 0xfc, 0x92, 0xee, 0xeb, 0x5e, 0xbd, 0xd8, 0xc6, 0x5b, 0x37, 0xda,
+	0xf8, 0xbf, 0x95, 0xe0, 0xce, 0x88, 0x4b, 0x76, 0xc6, 0x30, 0xd9, 0x9d, 0x50, 0x29, 0x59, 0x78,
+	0x2e, 0xec, 0x23, 0xd8, 0x0a, 0x98, 0xf0, 0x4e, 0xa7, 0x34, 0x70, 0x7d, 0x4f, 0xd2, 0x73, 0xae,
+	0x4b, 0x8e, 0xb5, 0x5d, 0xde, 0x69, 0xed, 0xbd, 0x9b, 0x6d, 0x2a, 0x3f, 0xb9, 0x8f, 0x7c, 0x0b,
+	0x62, 0x27, 0x53, 0xfb, 0xe9, 0x4c, 0x7b, 0x17, 0xb6, 0xbe, 0x8d, 0x19, 0x95, 0xee, 0x05, 0x8f,
+	0x23, 0xe1, 0xd2, 0x50, 0x33, 0x18, 0xcf, 0xdb, 0xd4, 0x43, 0xaf, 0xd4, 0xc8, 0x00, 0x07, 0xec,
+	0x27, 0xb0, 0x99, 0xe7, 0xd7, 0x5d, 0x84, 0x71, 0xc3, 0x76, 0xc6, 0x7d, 0xa2, 0x60, 0x95, 0x4e,
+	0x8b, 0xb2, 0x03, 0x73, 0xe9, 0x6b, 0xe6, 0xe5, 0x06, 0x2b, 0x3b, 0x4f, 0x15, 0x31, 0xb3, 0x58,
+	0xdd, 0x52, 0x74, 0xd5, 0x11, 0xfa, 0xd6, 0x54, 0x26, 0x75, 0x8d, 0xe9, 0xca, 0x2f, 0x9c, 0xcf,
+	0xa1, 0x76, 0x18, 0x27, 0x37, 0x9e, 0x15, 0xf5, 0xca, 0x86, 0x35, 0x35, 0xcd, 0x6c, 0x4e, 0x7f,
+	0x3b, 0xff, 0xb2, 0xa0, 0xa1, 0x27, 0xf6, 0xf9, 0x6c, 0x76, 0xcb, 0xfc, 0x07, 0xfa, 0x7a, 0x3a,
+	0x33, 0x83, 0x78, 0x61, 0xaa, 0x19, 0x04, 0xcb, 0xa1, 0xca, 0x0c, 0x3c, 0x4a, 0x52, 0x5f, 0x99,
+	0x54, 0x11, 0x18, 0x06, 0xaa, 0x11, 0x32, 0x83, 0xba, 0x46, 0x60, 0xde, 0x03, 0x84, 0x46, 0xaa,
+	0x52, 0xe4, 0xda, 0xb7, 0xca, 0xea, 0x9b, 0xda, 0xfa, 0x9b, 0x37, 0xb5, 0xf7, 0xa0, 0x4e, 0x03,
+	0x96, 0x72, 0x98, 0x36, 0x0b, 0x21, 0xc5, 0xe0, 0xfc, 0x0e, 0x5a, 0xb8, 0x4b, 0xdd, 0x0f, 0xab,
+	0x83, 0xbc, 0x0b, 0xeb, 0xd7, 0x17, 0x3c, 0xdb, 0x65, 0xe5, 0xfa, 0x82, 0x0f, 0x83, 0xc2, 0xf6,
+	0x4b, 0x3f, 0xd6, 0xd9, 0x97, 0x0b, 0x9d, 0xfd, 0x43, 0xa8, 0x68, 0x16, 0xbd, 0xa9, 0x34, 0x41,
+	0xe8, 0xe5, 0x08, 0x8e, 0x38, 0x1f, 0x1a, 0x23, 0x0f, 0xbe, 0x9b, 0xb3, 0x88, 0x06, 0x2b, 0x8c,
+	0xec, 0x7c, 0x02, 0x5b, 0x59, 0xa7, 0x9e, 0xa9, 0xbb, 0x62, 0xc6, 0x7f, 0x2c, 0xe8, 0x64, 0x6d,
+	0xf4, 0x89, 0xf4, 0x64, 0xbc, 0xf2, 0xea, 0xd0, 0x87, 0x4d, 0x2f, 0x65, 0x57, 0x1e, 0x2c, 0xe3,
+	0x24, 0xb9, 0xbd, 0x9d, 0xd3, 0xfd, 0x38, 0x6b, 0x41, 0x49, 0xc7, 0xbb, 0x29, 0xff, 0x01, 0x40,
+	0x18, 0xcf, 0xdc, 0x73, 0x15, 0xfc, 0x98, 0x87, 0x2b, 0xa4, 0x16, 0xc6, 0xb3, 0x97, 0x1a, 0xb0,
+	0x9f, 0xc2, 0x1d, 0xec, 0xa9, 0x54, 0xae, 0xce, 0xb5, 0xb7, 0x6b, 0xda, 0x7b, 0xb7, 0xcc, 0x58,
+	0x6e, 0x89, 0x62, 0x87, 0x58, 0xb9, 0xd1, 0x21, 0xbe, 0x03, 0xd5, 0x6b, 0x8f, 0xc9, 0x29, 0x13,
+	0xd2, 0x04, 0x40, 0x4a, 0xab, 0x92, 0xbe, 0x85, 0x67, 0xab, 0x7b, 0xaa, 0xe3, 0x88, 0xcf, 0xb9,
+	0x58, 0x69, 0xb1, 0xd5, 0x8d, 0xdb, 0xcf, 0xba, 0x2d, 0x2c, 0x77, 0x61, 0xe7, 0x0f, 0x25, 0x68,
+	0x4c, 0xb8, 0x4a, 0x61, 0xb7, 0x1f, 0xcf, 0xff, 0x49, 0xb9, 0x87, 0xd0, 0xa0, 0x53, 0x6f, 0xae,
+	0xaa, 0xa7, 0xca, 0x2d, 0x5a, 0xc3, 0x32, 0xa9, 0x1b, 0x6c, 0xcc, 0x66, 0xfa, 0x4e, 0x73, 0xc5,
+	0x25, 0x15, 0x6e, 0x44, 0x7d, 0xca, 0xae, 0x68, 0xa0, 0x43, 0xad, 0x49, 0x9a, 0x1a, 0x25, 0x06,
+	0x54, 0xc1, 0x86, 0x6c, 0x92, 0x4b, 0x6f, 0xaa, 0x83, 0xad, 0x49, 0x40, 0x43, 0x63, 0x85, 0xa8,
+	0xd3, 0x3a, 0x63, 0x21, 0x13, 0x17, 0x14, 0x1f, 0x68, 0xaa, 0x24, 0xa5, 0x9d, 0x57, 0xd0, 0xc2,
+	0x73, 0xea, 0xe9, 0x3e, 0xf8, 0xa7, 0x9f, 0x93, 0x33, 0x84, 0x36, 0x4a, 0xda, 0x67, 0xc2, 0xf7,
+	0xa2, 0xe0, 0x67, 0x88, 0xda, 0x83, 0xd2, 0xd1, 0x65, 0xfa, 0x66, 0x67, 0xe5, 0xde, 0xec, 0x54,
+	0x27, 0x89, 0x0f, 0x74, 0xdd, 0x92, 0xe9, 0x24, 0x91, 0x74, 0x9e, 0x42, 0x45, 0x77, 0xd2, 0x4b,
+	0xa7, 0xa9, 0xe2, 0x9d, 0x76, 0xdf, 0x15, 0x82, 0x84, 0xf3, 0x31, 0x54, 0xc7, 0x2c, 0x6b, 0x84,
+	0xf0, 0x55, 0x43, 0xe2, 0x71, 0x58, 0x26, 0xa9, 0x21, 0xa6, 0xd8, 0x9c, 0x47, 0x50, 0x23, 0xd4,
+	0x0b, 0x6e, 0x4b, 0xeb, 0xce, 0x01, 0x76, 0x31, 0x9a, 0x4f, 0xa8, 0x2c, 0xe5, 0xc7, 0x91, 0xe0,
+	0x49, 0x1b, 0x63, 0x28, 0x55, 0x8c, 0xf0, 0xcb, 0xbd, 0x91, 0xdf, 0x9a, 0x08, 0x0f, 0x8c, 0xb4,
+	0x1f, 0x2c, 0xe8, 0xe8, 0xef, 0x03, 0x26, 0xa4, 0xa9, 0xee, 0x4a, 0x5b, 0x74, 0xbb, 0x6b, 0x16,
+	0x06, 0xfc, 0x3a, 0xd1, 0x56, 0x63, 0x5f, 0x6b, 0x48, 0x79, 0xa6, 0x72, 0x3d, 0xc3, 0x60, 0x8a,
+	0x03, 0x0d, 0x03, 0x33, 0xfc, 0x0c, 0x3a, 0xba, 0xc3, 0xcc, 0xdf, 0x6b, 0xca, 0x4b, 0xef, 0x35,
+	0x6d, 0xc5, 0x97, 0xbf, 0xd5, 0x2c, 0xb9, 0x95, 0x98, 0x32, 0x5a, 0xbc, 0x95, 0x9c, 0x82, 0x6d,
+	0x8a, 0x58, 0x5e, 0xf5, 0xd5, 0x3e, 0x71, 0x4a, 0xcf, 0x78, 0x44, 0x33, 0x63, 0x54, 0x11, 0x18,
+	0xea, 0x7b, 0xc8, 0x94, 0xcd, 0x58, 0xfa, 0x74, 0xa4, 0x09, 0x87, 0x03, 0xa0, 0x9d, 0xd5, 0x12,
+	0x59, 0xe6, 0xb7, 0xf2, 0xad, 0x61, 0x3e, 0xf3, 0xab, 0x37, 0xcf, 0x9c, 0x95, 0x92, 0x57, 0xc6,
+	0xbc, 0xe1, 0x7e, 0x09, 0x99, 0x99, 0x92, 0x88, 0x4e, 0x01, 0xe7, 0x1f, 0x69, 0x7d, 0xd6, 0xce,
+	0x2a, 0x54, 0x3f, 0x6a, 0x3a, 0x81, 0x25, 0x8b, 0x9a, 0xa1, 0xec, 0x75, 0x22, 0x6d, 0x1b, 0xf0,
+	0x1d, 0xc7, 0xdc, 0x7e, 0x83, 0x37, 0x7c, 0xa5, 0x5c, 0xf0, 0x95, 0x7b, 0x50, 0xbd, 0xf0, 0x84,
+	0x3b, 0xe3, 0x11, 0xa6, 0x91, 0x2a, 0xd9, 0xb8, 0xf0, 0xc4, 0x21, 0x8f, 0xe8, 0x32, 0x37, 0xaa,
+	0x2c, 0x73, 0xa3, 0xef, 0xa0, 0x61, 0x0e, 0x03, 0x4d, 0xb5, 0xe2, 0x18, 0xf6, 0xa0, 0x6a, 0x9a,
+	0x88, 0xac, 0x0c, 0x15, 0x1f, 0x37, 0x8c, 0x24, 0x92, 0xf2, 0x15, 0x34, 0x2c, 0x17, 0x34, 0x74,
+	0x3e, 0x83, 0x3a, 0xb6, 0xa6, 0xb8, 0xf0, 0x23, 0xd8, 0xc0, 0x3e, 0x38, 0x31, 0x58, 0x03, 0x0d,
+	0x86, 0x3c, 0x24, 0x19, 0x74, 0x9e, 0x02, 0xe0, 0x3d, 0x40, 0xcf, 0xca, 0xba, 0x7e, 0xeb, 0x47,
+	0xbb, 0x7e, 0xe7, 0x2b, 0xb0, 0x0b, 0x4d, 0x30, 0x4e, 0xfd, 0x02, 0x5a, 0x67, 0x05, 0xd4, 0x88,
+	0xd8, 0x2a, 0xac, 0x8b, 0x63, 0xe4, 0x06, 0xab, 0x2a, 0x66, 0xeb, 0xff, 0x43, 0x83, 0x5c, 0x78,
+	0xad, 0x2f, 0xdd, 0x78, 0xad, 0x7f, 0x0c, 0xed, 0x90, 0xca, 0x6b, 0x1e, 0x5d, 0xa6, 0x8f, 0xf5,
+	0xe8, 0xc3, 0x2d, 0x03, 0x27, 0x6f, 0xf5, 0xf7, 0xa1, 0x36, 0xf5, 0x84, 0x74, 0x05, 0x35, 0x37,
+	0xd1, 0xb2, 0x7a, 0xac, 0x17, 0xf2, 0x84, 0xe2, 0x35, 0xcc, 0x24, 0x23, 0xf3, 0x23, 0x22, 0x21,
+	0x9d, 0x67, 0x50, 0x47, 0x15, 0x71, 0xbf, 0x4f, 0x60, 0x03, 0xd5, 0x4a, 0x36, 0xda, 0xc9, 0x4e,
+	0x0f, 0xf9, 0x48, 0xc2, 0xf0, 0xe4, 0x4b, 0xd8, 0x5a, 0xf2, 0x9a, 0xab, 0x2e, 0x0d, 0xc4, 0x1d,
+	0x1d, 0x8d, 0x06, 0x78, 0x69, 0x20, 0xee, 0x7e, 0x6f, 0x78, 0xf0, 0x4d, 0xc7, 0xb2, 0x1b, 0x50,
+	0x25, 0xee, 0xd7, 0x83, 0xc1, 0x97, 0x07, 0xdf, 0x74, 0x4a, 0x76, 0x13, 0x6a, 0xc4, 0x3d, 0x3c,
+	0x1a, 0x8d, 0x5f, 0x1d, 0x7c, 0xd3, 0x29, 0x3f, 0xf9, 0x0d, 0xb4, 0x6f, 0x3c, 0x7d, 0xd9, 0x6d,
+	0xa8, 0x4f, 0xdc, 0xd7, 0xa3, 0xfe, 0xab, 0xde, 0xe8, 0xe5, 0x60, 0xbf, 0xf3, 0x96, 0x9a, 0x32,
+	0x71, 0x8f, 0xc9, 0x70, 0xd2, 0x1b, 0x0f, 0x50, 0xde, 0xc4, 0x3d, 0x7e, 0xfd, 0xfc, 0x60, 0xd8,
+	0xef, 0x94, 0x9e, 0xec, 0x40, 0x35, 0xb9, 0x16, 0xab, 0x91, 0x9e, 0x3b, 0xea, 0x8d, 0x87, 0x13,
+	0xa5, 0x44, 0x0b, 0xa0, 0xe7, 0xbe, 0xe8, 0xf5, 0x07, 0xcf, 0x8f, 0x8e, 0xbe, 0xec, 0x58, 0x4f,
+	0xfe, 0x69, 0x15, 0xef, 0x23, 0xc9, 0x95, 0xc2, 0x7e, 0x1b, 0xec, 0x91, 0x3b, 0x98, 0x0c, 0x46,
+	0x63, 0x77, 0x38, 0x9a, 0x0c, 0xc7, 0xbd, 0xf1, 0xf0, 0x68, 0xd4, 0x79, 0xcb, 0xbe, 0x0b, 0x9b,
+	0x09, 0x8e, 0xd7, 0xa1, 0x83, 0xc1, 0x7e, 0xc7, 0xb2, 0xef, 0x40, 0x27, 0x81, 0xc9, 0xe0, 0xe4,
+	0xf8, 0x68, 0x74, 0x32, 0xe8, 0x94, 0x6c, 0x1b, 0x5a, 0x29, 0xb3, 0xd6, 0xbc, 0x53, 0x46, 0xce,
+	0x17, 0x64, 0x38, 0x18, 0xed, 0xbb, 0x64, 0xf0, 0xd5, 0xeb, 0xc1, 0xc9, 0xb8, 0xb3, 0x66, 0x77,
+	0xa0, 0x31, 0x72, 0x47, 0x83, 0xaf, 0xcd, 0x48, 0xa7, 0x52, 0x94, 0x78, 0x38, 0x1c, 0xed, 0x0f,
+	0x48, 0x67, 0xdd, 0xde, 0x82, 0x76, 0x2a, 0xf1, 0xe8, 0xf0, 0x70, 0x30, 0x1a, 0x77, 0x36, 0x9e,
+	0x3f, 0x82, 0x77, 0xa9, 0xd8, 0x9d, 0x53, 0x3a, 0x9f, 0xd2, 0x5d, 0x2f, 0xa2, 0x0b, 0x1e, 0xb3,
+	0x70, 0x57, 0x04, 0x97, 0xbb, 0xc6, 0x2f, 0xfe, 0x52, 0x2a, 0xf7, 0x8e, 0x9f, 0x9f, 0xae, 0xeb,
+	0xe3, 0xfb, 0xd5, 0x7f, 0x07, 0x00, 0x50, 0x4a, 0xe5, 0x64, 0x2a, 0x1b, 0x00, 0x00,
 }
@@ -0,0 +1,126 @@
+package rest_server
+
+import (
+	"github.com/d3ce1t/areyouin-server/model"
+	"github.com/d3ce1t/areyouin-server/protocol/core"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// Conversions from model objects to core messages. They must produce the same
+// output as the ones used by the binary protocol server.
+
+func convEvent2Net(event *model.Event) *core.Event {
+
+	netEvent := &core.Event{
+		EventId:       event.Id(),
+		AuthorId:      event.AuthorID(),
+		AuthorName:    event.AuthorName(),
+		StartDate:     utils.TimeToMillis(event.StartDate()),
+		EndDate:       utils.TimeToMillis(event.EndDate()),
+		Message:       event.Description(),
+		NumAttendees:  int32(event.NumAttendees()),
+		NumGuests:     int32(event.NumGuests()),
+		CreatedDate:   utils.TimeToMillis(event.CreatedDate()),
+		InboxPosition: utils.TimeToMillis(event.InboxPosition()),
+		PictureDigest: event.PictureDigest(),
+		State:         core.EventState(event.Status()),
+		IsPublic:      event.IsPublic(),
+		SeriesId:      event.SeriesID(),
+		Capacity:      int32(event.Capacity()),
+		Waitlist:      event.Participants.Waitlist(),
+		Quorum:        int32(event.Quorum()),
+		Confirmed:     event.IsConfirmed(),
+		Participants:  make(map[int64]*core.EventParticipant),
+	}
+
+	if !event.QuorumDeadline().IsZero() {
+		netEvent.QuorumDeadline = utils.TimeToMillis(event.QuorumDeadline())
+	}
+
+	if location := event.Location(); location != nil {
+		netEvent.Geolocation = convLocation2Net(location)
+	}
+
+	for _, p := range event.Participants.AsSlice() {
+		netEvent.Participants[p.Id()] = convParticipant2Net(p)
+	}
+
+	return netEvent
+}
+
+func convLocation2Net(location *model.Location) *core.Location {
+	return &core.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
+
+func convNetLocation(location *core.Location) *model.Location {
+	return &model.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
+
+func convEventList2Net(eventList []*model.Event) []*core.Event {
+	netEvents := make([]*core.Event, 0, len(eventList))
+	for _, event := range eventList {
+		netEvents = append(netEvents, convEvent2Net(event))
+	}
+	return netEvents
+}
+
+func convParticipant2Net(participant *model.Participant) *core.EventParticipant {
+	return &core.EventParticipant{
+		UserId:    participant.Id(),
+		Name:      participant.Name(),
+		Response:  core.AttendanceResponse(participant.Response()),
+		Delivered: core.InvitationStatus(participant.InvitationStatus()),
+	}
+}
+
+func convParticipantList2Net(pl map[int64]*model.Participant) []*core.EventParticipant {
+	result := make([]*core.EventParticipant, 0, len(pl))
+	for _, p := range pl {
+		result = append(result, convParticipant2Net(p))
+	}
+	return result
+}
+
+func convFriendList2Net(friendList []*model.Friend) []*core.Friend {
+	result := make([]*core.Friend, 0, len(friendList))
+	for _, f := range friendList {
+		result = append(result, &core.Friend{
+			UserId:        f.Id(),
+			Name:          f.Name(),
+			PictureDigest: f.PictureDigest(),
+		})
+	}
+	return result
+}
+
+func convFriendRequestList2Net(friendRequestList []*model.FriendRequest) []*core.FriendRequest {
+	result := make([]*core.FriendRequest, 0, len(friendRequestList))
+	for _, r := range friendRequestList {
+		result = append(result, &core.FriendRequest{
+			FriendId:    r.FromUser(),
+			Name:        r.FromUserName(),
+			Email:       r.FromUserEmail(),
+			CreatedDate: r.CreatedDate(),
+		})
+	}
+	return result
+}
+
+func convGroupList2Net(groups []*model.Group) []*core.Group {
+	result := make([]*core.Group, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, &core.Group{
+			Id:      g.Id(),
+			Name:    g.Name(),
+			Size:    int32(g.Size()),
+			Members: g.Members(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,484 @@
+package rest_server
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/model"
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// Events resource:
+//
+//	GET    /v1/events                          -> EventsList
+//	POST   /v1/events                          CreateEvent -> Event
+//	GET    /v1/events/{id}                     -> Event
+//	PATCH  /v1/events/{id}                     ModifyEvent -> Event
+//	DELETE /v1/events/{id}                     -> Event
+//	GET    /v1/events/{id}/participants        -> AttendanceStatus
+//	POST   /v1/events/{id}/participants        InviteUsers -> AttendanceStatus
+//	DELETE /v1/events/{id}/participants/{uid}  -> AttendanceStatus
+//	PUT    /v1/events/{id}/response            ConfirmAttendance -> EventParticipant
+func (s *RestServer) handleEvents(w http.ResponseWriter, r *restRequest) {
+
+	switch {
+	case len(r.Path) == 0 && r.Method == http.MethodGet:
+		s.listEvents(w, r)
+	case len(r.Path) == 0 && r.Method == http.MethodPost:
+		s.createEvent(w, r)
+	case len(r.Path) == 1 && r.Method == http.MethodGet:
+		s.getEvent(w, r, parseID(r.Path[0]))
+	case len(r.Path) == 1 && r.Method == http.MethodPatch:
+		s.modifyEvent(w, r, parseID(r.Path[0]))
+	case len(r.Path) == 1 && r.Method == http.MethodDelete:
+		s.cancelEvent(w, r, parseID(r.Path[0]))
+	case len(r.Path) == 2 && r.Path[1] == "participants" && r.Method == http.MethodGet:
+		s.listParticipants(w, r, parseID(r.Path[0]))
+	case len(r.Path) == 2 && r.Path[1] == "participants" && r.Method == http.MethodPost:
+		s.inviteParticipants(w, r, parseID(r.Path[0]))
+	case len(r.Path) == 3 && r.Path[1] == "participants" && r.Method == http.MethodDelete:
+		s.removeParticipant(w, r, parseID(r.Path[0]), parseID(r.Path[2]))
+	case len(r.Path) == 2 && r.Path[1] == "response" && r.Method == http.MethodPut:
+		s.confirmAttendance(w, r, parseID(r.Path[0]))
+	default:
+		panic(routeError(isEventPath(r.Path)))
+	}
+}
+
+func (s *RestServer) listEvents(w http.ResponseWriter, r *restRequest) {
+
+	events, err := s.Model.Events.GetRecentEvents(r.UserID)
+	if err == model.ErrEmptyInbox {
+		events = nil
+	} else {
+		checkNoErrorOrPanic(err)
+	}
+
+	writeJSON(w, http.StatusOK, &proto.EventsList{Event: convEventList2Net(events)})
+	log.Printf("< (%v) REST SEND PRIVATE EVENTS (num.events: %v)\n", r.UserID, len(events))
+}
+
+func (s *RestServer) createEvent(w http.ResponseWriter, r *restRequest) {
+
+	msg := &proto.CreateEvent{}
+	readJSON(r, msg)
+
+	author, err := s.Model.Accounts.GetUserAccount(r.UserID)
+	checkNoErrorOrPanic(err)
+
+	createdDate := utils.GetCurrentTimeUTC()
+	if msg.CreatedDate != 0 {
+		createdDate = utils.MillisToTimeUTC(msg.CreatedDate)
+	}
+
+	b := s.Model.Events.NewEventBuilder().
+		SetAuthor(author).
+		SetCreatedDate(createdDate).
+		SetStartDate(utils.MillisToTimeUTC(msg.StartDate)).
+		SetEndDate(utils.MillisToTimeUTC(msg.EndDate)).
+		SetDescription(msg.Message).
+		SetPublic(msg.IsPublic).
+		SetCapacity(int(msg.Capacity))
+
+	if msg.Geolocation != nil {
+		b.SetLocation(convNetLocation(msg.Geolocation))
+	}
+
+	if msg.Quorum != 0 || msg.QuorumDeadline != 0 {
+		b.SetQuorum(int(msg.Quorum), utils.MillisToTimeUTC(msg.QuorumDeadline))
+	}
+
+	for _, pID := range msg.Participants {
+		b.ParticipantAdder().AddUserID(pID)
+	}
+
+	event, err := b.Build()
+	checkNoErrorOrPanic(err)
+
+	err = s.Model.Events.SaveEvent(event)
+	checkNoErrorOrPanic(err)
+
+	if len(msg.Picture) != 0 {
+		if err = s.Model.Events.ChangeEventPicture(event, msg.Picture); err != nil {
+			// Only log error but do nothing. Event has already been published.
+			log.Printf("* (%v) REST Error saving picture for event %v (%v)\n", r.UserID, event.Id(), err)
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, convEvent2Net(event))
+	log.Printf("< (%v) REST CREATE EVENT OK (eventId: %v, Num.Participants: %v)\n",
+		r.UserID, event.Id(), event.NumGuests())
+}
+
+func (s *RestServer) getEvent(w http.ResponseWriter, r *restRequest, eventID int64) {
+
+	event, err := s.Model.Events.GetEventForUser(r.UserID, eventID)
+	checkNoErrorOrPanic(err)
+
+	writeJSON(w, http.StatusOK, convEvent2Net(event))
+	log.Printf("< (%v) REST SEND EVENT %v\n", r.UserID, event.Id())
+}
+
+// Modify existing event. As in the binary protocol, unset fields are not
+// modified.
+func (s *RestServer) modifyEvent(w http.ResponseWriter, r *restRequest, eventID int64) {
+
+	msg := &proto.ModifyEvent{}
+	readJSON(r, msg)
+
+	event, err := s.Model.Events.LoadEvent(eventID)
+	checkNoErrorOrPanic(err)
+
+	b := s.Model.Events.NewEventModifier(event, r.UserID)
+
+	if msg.ModifyDate != 0 {
+		b.SetModifiedDate(utils.MillisToTimeUTC(msg.ModifyDate))
+	} else {
+		b.SetModifiedDate(utils.GetCurrentTimeUTC())
+	}
+
+	if msg.Message != "" && msg.Message != event.Description() {
+		b.SetDescription(msg.Message)
+	}
+
+	if startDate := utils.MillisToTimeUTC(msg.StartDate); msg.StartDate != 0 && !startDate.Equal(event.StartDate()) {
+		b.SetStartDate(startDate)
+	}
+
+	if endDate := utils.MillisToTimeUTC(msg.EndDate); msg.EndDate != 0 && !endDate.Equal(event.EndDate()) {
+		b.SetEndDate(endDate)
+	}
+
+	if msg.Visibility != proto.EventVisibility_V_UNCHANGED {
+		if isPublic := msg.Visibility == proto.EventVisibility_V_PUBLIC; isPublic != event.IsPublic() {
+			b.SetPublic(isPublic)
+		}
+	}
+
+	if msg.RemoveGeolocation {
+		if event.Location() != nil {
+			b.SetLocation(nil)
+		}
+	} else if msg.Geolocation != nil {
+		if location := convNetLocation(msg.Geolocation); !location.Equal(event.Location()) {
+			b.SetLocation(location)
+		}
+	}
+
+	if msg.RemoveCapacity {
+		if event.Capacity() != 0 {
+			b.SetCapacity(0)
+		}
+	} else if msg.Capacity != 0 && int(msg.Capacity) != event.Capacity() {
+		b.SetCapacity(int(msg.Capacity))
+	}
+
+	if msg.RemoveQuorum {
+		if event.Quorum() != 0 {
+			b.SetQuorum(0, time.Time{})
+		}
+	} else if msg.Quorum != 0 || msg.QuorumDeadline != 0 {
+		quorum := event.Quorum()
+		if msg.Quorum != 0 {
+			quorum = int(msg.Quorum)
+		}
+		deadline := event.QuorumDeadline()
+		if msg.QuorumDeadline != 0 {
+			deadline = utils.MillisToTimeUTC(msg.QuorumDeadline)
+		}
+		b.SetQuorum(quorum, deadline)
+	}
+
+	for _, pID := range msg.Participants {
+		b.ParticipantAdder().AddUserID(pID)
+	}
+
+	modifiedEvent, err := b.Build()
+	checkNoErrorOrPanic(err)
+
+	err = s.Model.Events.SaveEvent(modifiedEvent)
+	checkNoErrorOrPanic(err)
+
+	if len(msg.Picture) > 0 || msg.RemovePicture {
+		if err := s.Model.Events.ChangeEventPicture(modifiedEvent, msg.Picture); err != nil {
+			log.Printf("* (%v) REST Error saving picture for event %v (%v)\n", r.UserID, modifiedEvent.Id(), err)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, convEvent2Net(modifiedEvent))
+	log.Printf("< (%v) REST MODIFY EVENT OK (eventId: %v)\n", r.UserID, modifiedEvent.Id())
+}
+
+func (s *RestServer) cancelEvent(w http.ResponseWriter, r *restRequest, eventID int64) {
+
+	event, err := s.Model.Events.LoadEvent(eventID)
+	checkNoErrorOrPanic(err)
+
+	cancelledEvent, err :=
+		s.Model.Events.NewEventModifier(event, r.UserID).
+			SetCancelled(true).Build()
+	checkNoErrorOrPanic(err)
+
+	err = s.Model.Events.SaveEvent(cancelledEvent)
+	checkNoErrorOrPanic(err)
+
+	writeJSON(w, http.StatusOK, convEvent2Net(cancelledEvent))
+	log.Printf("< (%v) REST CANCEL EVENT OK (eventId: %v)\n", r.UserID, cancelledEvent.Id())
+}
+
+func (s *RestServer) listParticipants(w http.ResponseWriter, r *restRequest, eventID int64) {
+
+	event, err := s.Model.Events.GetEventForUser(r.UserID, eventID)
+	checkNoErrorOrPanic(err)
+
+	participants := make(map[int64]*model.Participant)
+	for _, p := range event.Participants.AsSlice() {
+		participants[p.Id()] = p
+	}
+
+	writeJSON(w, http.StatusOK, &proto.AttendanceStatus{
+		EventId:          event.Id(),
+		AttendanceStatus: convParticipantList2Net(participants),
+		NumGuests:        int32(event.NumGuests()),
+	})
+	log.Printf("< (%v) REST SEND PARTICIPANTS (eventId: %v, num.participants: %v)\n",
+		r.UserID, event.Id(), len(participants))
+}
+
+func (s *RestServer) inviteParticipants(w http.ResponseWriter, r *restRequest, eventID int64) {
+
+	msg := &proto.InviteUsers{}
+	readJSON(r, msg)
+
+	if len(msg.Participants) == 0 {
+		panic(model.ErrParticipantsRequired)
+	}
+
+	event, err := s.Model.Events.LoadEvent(eventID)
+	checkNoErrorOrPanic(err)
+
+	b := s.Model.Events.NewEventModifier(event, r.UserID)
+	for _, pID := range msg.Participants {
+		b.ParticipantAdder().AddUserID(pID)
+	}
+
+	modifiedEvent, err := b.Build()
+	checkNoErrorOrPanic(err)
+
+	err = s.Model.Events.SaveEvent(modifiedEvent)
+	checkNoErrorOrPanic(err)
+
+	newParticipants := s.Model.Events.ExtractNewParticipants(modifiedEvent, event)
+
+	writeJSON(w, http.StatusOK, &proto.AttendanceStatus{
+		EventId:          modifiedEvent.Id(),
+		AttendanceStatus: convParticipantList2Net(newParticipants),
+		NumGuests:        int32(modifiedEvent.NumGuests()),
+	})
+	log.Printf("< (%v) REST INVITE USERS OK (eventID: %v, newParticipants: %v/%v, total: %v)\n",
+		r.UserID, modifiedEvent.Id(), len(newParticipants), len(msg.Participants), modifiedEvent.NumGuests())
+}
+
+func (s *RestServer) removeParticipant(w http.ResponseWriter, r *restRequest, eventID int64, participantID int64) {
+
+	event, err := s.Model.Events.LoadEvent(eventID)
+	checkNoErrorOrPanic(err)
+
+	modifiedEvent, err := s.Model.Events.NewEventModifier(event, r.UserID).
+		RemoveParticipant(participantID).Build()
+	checkNoErrorOrPanic(err)
+
+	err = s.Model.Events.SaveEvent(modifiedEvent)
+	checkNoErrorOrPanic(err)
+
+	writeJSON(w, http.StatusOK, &proto.AttendanceStatus{
+		EventId:             modifiedEvent.Id(),
+		NumGuests:           int32(modifiedEvent.NumGuests()),
+		RemovedParticipants: []int64{participantID},
+	})
+	log.Printf("< (%v) REST CANCEL USERS INVITATION OK (eventID: %v, removed: %v, total: %v)\n",
+		r.UserID, modifiedEvent.Id(), participantID, modifiedEvent.NumGuests())
+}
+
+func (s *RestServer) confirmAttendance(w http.ResponseWriter, r *restRequest, eventID int64) {
+
+	msg := &proto.ConfirmAttendance{}
+	readJSON(r, msg)
+
+	participant, err := s.Model.Events.ChangeParticipantResponse(eventID,
+		r.UserID, api.AttendanceResponse(msg.ActionCode))
+	checkNoErrorOrPanic(err)
+
+	writeJSON(w, http.StatusOK, convParticipant2Net(participant))
+	log.Printf("< (%v) REST CONFIRM ATTENDANCE %v OK\n", r.UserID, eventID)
+}
+
+// Friends resource:
+//
+//	GET /v1/friends  -> FriendsList
+func (s *RestServer) handleFriends(w http.ResponseWriter, r *restRequest) {
+
+	if len(r.Path) != 0 || r.Method != http.MethodGet {
+		panic(routeError(len(r.Path) == 0))
+	}
+
+	friends, err := s.Model.Friends.GetAllFriends(r.UserID)
+	checkNoErrorOrPanic(err)
+
+	writeJSON(w, http.StatusOK, &proto.FriendsList{Friends: convFriendList2Net(friends)})
+	log.Printf("< (%v) REST SEND USER FRIENDS (num.friends: %v)\n", r.UserID, len(friends))
+}
+
+// Groups resource:
+//
+//	GET /v1/groups  -> GroupsList
+//	PUT /v1/groups  SyncGroups -> GroupsList
+func (s *RestServer) handleGroups(w http.ResponseWriter, r *restRequest) {
+
+	switch {
+	case len(r.Path) == 0 && r.Method == http.MethodGet:
+		// Just send groups list
+	case len(r.Path) == 0 && r.Method == http.MethodPut:
+		s.syncGroups(r)
+	default:
+		panic(routeError(len(r.Path) == 0))
+	}
+
+	groups, err := s.Model.Friends.GetAllGroups(r.UserID)
+	checkNoErrorOrPanic(err)
+
+	writeJSON(w, http.StatusOK, &proto.GroupsList{Groups: convGroupList2Net(groups)})
+	log.Printf("< (%v) REST GROUPS LIST (num.groups: %v)\n", r.UserID, len(groups))
+}
+
+// Groups are synchronised with the same rules as in the binary protocol. A
+// group with size -1 and no members is removed if it has no name, or renamed
+// otherwise.
+func (s *RestServer) syncGroups(r *restRequest) {
+
+	msg := &proto.SyncGroups{}
+	readJSON(r, msg)
+
+	clientGroups := make([]*model.Group, 0, len(msg.Groups))
+	builder := model.NewGroupBuilder()
+
+	for _, g := range msg.Groups {
+		if g.Size == -1 && len(g.Members) == 0 {
+			if g.Name == "" {
+				s.Model.Friends.DeleteGroup(r.UserID, g.Id)
+			} else {
+				s.Model.Friends.RenameGroup(r.UserID, g.Id, g.Name)
+			}
+		} else {
+			builder.SetId(g.Id)
+			builder.SetName(g.Name)
+			for _, friendID := range g.Members {
+				builder.AddMember(friendID)
+			}
+			clientGroups = append(clientGroups, builder.Build())
+		}
+	}
+
+	if len(clientGroups) > 0 {
+		err := s.Model.Friends.AddGroups(r.UserID, clientGroups)
+		checkNoErrorOrPanic(err)
+	}
+
+	log.Printf("< (%v) REST SYNC GROUPS OK\n", r.UserID)
+}
+
+// Friend requests resource:
+//
+//	GET  /v1/friend-requests             -> FriendRequestsList
+//	POST /v1/friend-requests             CreateFriendRequest -> (empty)
+//	PUT  /v1/friend-requests/{friend_id} ConfirmFriendRequest -> (empty)
+func (s *RestServer) handleFriendRequests(w http.ResponseWriter, r *restRequest) {
+
+	switch {
+	case len(r.Path) == 0 && r.Method == http.MethodGet:
+		s.listFriendRequests(w, r)
+	case len(r.Path) == 0 && r.Method == http.MethodPost:
+		s.createFriendRequest(w, r)
+	case len(r.Path) == 1 && r.Method == http.MethodPut:
+		s.confirmFriendRequest(w, r, parseID(r.Path[0]))
+	default:
+		panic(routeError(len(r.Path) <= 1))
+	}
+}
+
+func (s *RestServer) listFriendRequests(w http.ResponseWriter, r *restRequest) {
+
+	requests, err := s.Model.Friends.GetAllFriendRequests(r.UserID)
+	checkNoErrorOrPanic(err)
+
+	writeJSON(w, http.StatusOK, &proto.FriendRequestsList{FriendRequests: convFriendRequestList2Net(requests)})
+	log.Printf("< (%v) REST SEND FRIEND REQUESTS (num.requests: %v)\n", r.UserID, len(requests))
+}
+
+func (s *RestServer) createFriendRequest(w http.ResponseWriter, r *restRequest) {
+
+	msg := &proto.CreateFriendRequest{}
+	readJSON(r, msg)
+
+	userAccount, err := s.Model.Accounts.GetUserAccount(r.UserID)
+	checkNoErrorOrPanic(err)
+
+	friendAccount, err := s.Model.Accounts.GetUserAccountByEmail(msg.Email)
+	if err == model.ErrNotFound {
+		panic(ErrFriendNotFound)
+	}
+	checkNoErrorOrPanic(err)
+
+	_, err = s.Model.Friends.CreateFriendRequest(userAccount, friendAccount)
+	checkNoErrorOrPanic(err)
+
+	w.WriteHeader(http.StatusCreated)
+	log.Printf("< (%v) REST CREATE FRIEND REQUEST OK\n", r.UserID)
+}
+
+func (s *RestServer) confirmFriendRequest(w http.ResponseWriter, r *restRequest, friendID int64) {
+
+	msg := &proto.ConfirmFriendRequest{}
+	readJSON(r, msg)
+
+	currentUser, err := s.Model.Accounts.GetUserAccount(r.UserID)
+	checkNoErrorOrPanic(err)
+
+	friend, err := s.Model.Accounts.GetUserAccount(friendID)
+	if err == model.ErrNotFound {
+		panic(ErrFriendNotFound)
+	}
+	checkNoErrorOrPanic(err)
+
+	accept := msg.Response == proto.ConfirmFriendRequest_CONFIRM
+
+	err = s.Model.Friends.ConfirmFriendRequest(friend, currentUser, accept)
+	checkNoErrorOrPanic(err)
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Printf("< (%v) REST CONFIRM FRIEND REQUEST OK (accepted: %v)\n", r.UserID, accept)
+}
+
+func isEventPath(path []string) bool {
+	switch len(path) {
+	case 0, 1:
+		return true
+	case 2:
+		return path[1] == "participants" || path[1] == "response"
+	case 3:
+		return path[1] == "participants"
+	}
+	return false
+}
+
+// Returns the error for a request that does not match any route. If path is
+// known, then only the method is wrong.
+func routeError(knownPath bool) error {
+	if knownPath {
+		return ErrMethodNotAllowed
+	}
+	return ErrResourceNotFound
+}
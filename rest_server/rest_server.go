@@ -0,0 +1,238 @@
+package rest_server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/cqldao"
+	"github.com/d3ce1t/areyouin-server/model"
+	"github.com/d3ce1t/areyouin-server/utils"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+const apiPrefix = "/v1/"
+
+var (
+	ErrInvalidRequest   = errors.New("invalid request")
+	ErrUnauthorized     = errors.New("authentication required")
+	ErrForbidden        = errors.New("forbidden")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	ErrResourceNotFound = errors.New("resource not found")
+	ErrFriendNotFound   = errors.New("friend not found")
+)
+
+// JSON output mirrors core protobuf messages using the canonical proto3 JSON
+// mapping, so int64 fields are encoded as strings and bytes as base64.
+var (
+	jsonMarshaler   = &jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	jsonUnmarshaler = &jsonpb.Unmarshaler{AllowUnknownFields: true}
+)
+
+func NewServer(session api.DbSession, model *model.AyiModel, config api.Config) *RestServer {
+	server := &RestServer{
+		DbSession: session,
+		Model:     model,
+		Config:    config,
+	}
+	server.init()
+	return server
+}
+
+// RestServer exposes a subset of the model through an HTTP/JSON API. It is
+// meant for scripting and third-party integrations. Clients are authenticated
+// with the same user id and access token used to download images.
+type RestServer struct {
+	DbSession api.DbSession
+	Model     *model.AyiModel
+	Config    api.Config
+	mux       *http.ServeMux
+}
+
+// A request to a resource. Path contains the segments of the URL that follow
+// the resource name.
+type restRequest struct {
+	UserID int64
+	Path   []string
+	*http.Request
+}
+
+type restHandler func(w http.ResponseWriter, r *restRequest)
+
+func (s *RestServer) init() {
+	s.mux = http.NewServeMux()
+	s.handle("events", s.handleEvents)
+	s.handle("friends", s.handleFriends)
+	s.handle("groups", s.handleGroups)
+	s.handle("friend-requests", s.handleFriendRequests)
+}
+
+// Register handler for a resource and all of its sub-resources
+func (s *RestServer) handle(resource string, handler restHandler) {
+
+	prefix := apiPrefix + resource
+
+	f := func(w http.ResponseWriter, r *http.Request) {
+
+		var userID int64
+
+		defer func() {
+			if r := recover(); r != nil {
+				err, ok := r.(error)
+				if !ok {
+					err = fmt.Errorf("%v", r)
+				}
+				writeError(w, err)
+				log.Printf("< (%v) REST ERROR: %v\n", userID, err)
+			}
+		}()
+
+		log.Printf("> (%v) REST %v %v\n", r.Header.Get("userid"), r.Method, r.URL.Path)
+
+		userID, err := s.checkAccess(r.Header)
+		if err != nil || userID == 0 {
+			writeError(w, ErrUnauthorized)
+			log.Printf("< (%v) REST ERROR: ACCESS DENIED\n", r.Header.Get("userid"))
+			return
+		}
+
+		path := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		req := &restRequest{UserID: userID, Request: r}
+		if path != "" {
+			req.Path = strings.Split(path, "/")
+		}
+
+		handler(w, req)
+	}
+
+	s.mux.HandleFunc(prefix, f)
+	s.mux.HandleFunc(prefix+"/", f)
+}
+
+// Check access and returns user_id if access is granted or
+// 0 otherwise.
+func (s *RestServer) checkAccess(header http.Header) (int64, error) {
+
+	user_id_str := header.Get("userid")
+	token := header.Get("token")
+
+	if user_id_str == "" || token == "" {
+		return 0, nil
+	}
+
+	user_id, err := strconv.ParseInt(user_id_str, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	access_dao := cqldao.NewAccessTokenDAO(s.DbSession)
+
+	accessToken, err := access_dao.Load(user_id)
+	if err != nil {
+		return 0, err
+	}
+
+	if accessToken.Token != token {
+		return 0, nil
+	}
+
+	access_dao.SetLastUsed(user_id, utils.GetCurrentTimeMillis()) // ignore possible errors
+	return user_id, nil
+}
+
+// Run an HTTP server and starts to serve the REST API from it
+func (s *RestServer) Run() {
+
+	addr := fmt.Sprintf("%v:%v", s.Config.ListenAddress(), s.Config.RestListenPort())
+	log.Printf("REST API listening on %v (https: %v)\n", addr, s.Config.RestEnableHTTPS())
+
+	if !s.Config.RestEnableHTTPS() {
+
+		// HTTP Server
+
+		err := http.ListenAndServe(addr, s.mux)
+		if err != nil {
+			log.Fatal("ListenAndServe: ", err)
+		}
+
+	} else {
+
+		// HTTPS Server
+
+		err := http.ListenAndServeTLS(addr, s.Config.CertFile(), s.Config.CertKey(), s.mux)
+		if err != nil {
+			log.Fatal("ListenAndServe: ", err)
+		}
+	}
+}
+
+func readJSON(r *restRequest, message proto.Message) {
+	if err := jsonUnmarshaler.Unmarshal(r.Body, message); err != nil {
+		panic(ErrInvalidRequest)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, message proto.Message) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := jsonMarshaler.Marshal(w, message); err != nil {
+		log.Printf("* REST ERROR: writing response: %v\n", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(getHTTPStatus(err))
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func getHTTPStatus(err error) int {
+
+	switch err {
+
+	case ErrInvalidRequest, model.ErrInvalidEmail, model.ErrInvalidName,
+		model.ErrInvalidDescription, model.ErrInvalidStartDate, model.ErrInvalidEndDate,
+		model.ErrInvalidParticipant, model.ErrParticipantsRequired, model.ErrInvalidLocation,
+		model.ErrLocationRequired, model.ErrEventOutOfCreationWindow, model.ErrIllegalArgument,
+		model.ErrMissingArgument, model.ErrInvalidCapacity, model.ErrInvalidQuorum:
+		return http.StatusBadRequest
+
+	case ErrUnauthorized:
+		return http.StatusUnauthorized
+
+	case ErrForbidden, model.ErrInvalidAuthor, model.ErrInvalidOwner:
+		return http.StatusForbidden
+
+	case ErrResourceNotFound, ErrFriendNotFound, model.ErrNotFound, model.ErrParticipantNotFound:
+		return http.StatusNotFound
+
+	case ErrMethodNotAllowed:
+		return http.StatusMethodNotAllowed
+
+	case model.ErrEventNotWritable, model.ErrAlreadyFriends, model.ErrFriendRequestAlreadyExist:
+		return http.StatusConflict
+
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseID(s string) int64 {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		panic(ErrInvalidRequest)
+	}
+	return id
+}
+
+func checkNoErrorOrPanic(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+package rest_server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/d3ce1t/areyouin-server/protocol/core"
+)
+
+func TestRouteError(t *testing.T) {
+
+	tests := []struct {
+		path []string
+		err  error
+	}{
+		{nil, ErrMethodNotAllowed},
+		{[]string{"1"}, ErrMethodNotAllowed},
+		{[]string{"1", "participants"}, ErrMethodNotAllowed},
+		{[]string{"1", "participants", "2"}, ErrMethodNotAllowed},
+		{[]string{"1", "response"}, ErrMethodNotAllowed},
+		{[]string{"1", "comments"}, ErrResourceNotFound},
+		{[]string{"1", "response", "2"}, ErrResourceNotFound},
+		{[]string{"1", "participants", "2", "3"}, ErrResourceNotFound},
+	}
+
+	for _, test := range tests {
+		if err := routeError(isEventPath(test.path)); err != test.err {
+			t.Errorf("path %v: expected %v, got %v", test.path, test.err, err)
+		}
+	}
+}
+
+func TestWriteJSONMirrorsCoreMessages(t *testing.T) {
+
+	event := &core.Event{
+		EventId:  1234,
+		AuthorId: 5678,
+		Message:  "Test",
+		State:    core.EventState_ONGOING,
+		Participants: map[int64]*core.EventParticipant{
+			5678: {UserId: 5678, Response: core.AttendanceResponse_ASSIST},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	writeJSON(w, http.StatusOK, event)
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("unexpected content type %v", ct)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	// Field names are the ones of the proto file and int64 are strings
+	if result["event_id"] != "1234" || result["author_id"] != "5678" || result["state"] != "ONGOING" {
+		t.Fatalf("unexpected JSON output: %v", w.Body.String())
+	}
+
+	// Unset fields are emitted too
+	if _, ok := result["is_public"]; !ok {
+		t.Fatalf("default values not emitted: %v", w.Body.String())
+	}
+
+	// Roundtrip
+	decoded := &core.Event{}
+	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(w.Body.Bytes()), decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.EventId != event.EventId || decoded.Participants[5678].Response != core.AttendanceResponse_ASSIST {
+		t.Fatalf("roundtrip failed: %v", decoded)
+	}
+}
+
+func TestGetHTTPStatus(t *testing.T) {
+	if s := getHTTPStatus(ErrUnauthorized); s != http.StatusUnauthorized {
+		t.Errorf("expected %v, got %v", http.StatusUnauthorized, s)
+	}
+	if s := getHTTPStatus(ErrInvalidRequest); s != http.StatusBadRequest {
+		t.Errorf("expected %v, got %v", http.StatusBadRequest, s)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+// ackMetrics tracks writes that required an ACK from the client
+type ackMetrics struct {
+	mutex        sync.Mutex
+	acked        uint64
+	retries      uint64
+	timeouts     uint64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+// Acked records a packet acknowledged latency time after its last write
+func (m *ackMetrics) Acked(latency time.Duration) {
+
+	defer m.mutex.Unlock()
+	m.mutex.Lock()
+
+	m.acked++
+	m.totalLatency += latency
+	if latency > m.maxLatency {
+		m.maxLatency = latency
+	}
+}
+
+// Retried records a packet written again because its ACK wasn't received
+func (m *ackMetrics) Retried() {
+	defer m.mutex.Unlock()
+	m.mutex.Lock()
+	m.retries++
+}
+
+// TimedOut records a packet that wasn't acknowledged after all retries
+func (m *ackMetrics) TimedOut() {
+	defer m.mutex.Unlock()
+	m.mutex.Lock()
+	m.timeouts++
+}
+
+func (m *ackMetrics) Stats() api.AckStats {
+
+	defer m.mutex.Unlock()
+	m.mutex.Lock()
+
+	stats := api.AckStats{
+		Acked:      m.acked,
+		Retries:    m.retries,
+		Timeouts:   m.timeouts,
+		MaxLatency: m.maxLatency,
+	}
+
+	if m.acked > 0 {
+		stats.AvgLatency = m.totalLatency / time.Duration(m.acked)
+	}
+
+	return stats
+}
+
+func (s *Server) AckStats() api.AckStats {
+	return s.ackMetrics.Stats()
+}
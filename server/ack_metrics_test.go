@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAckMetrics(t *testing.T) {
+
+	m := &ackMetrics{}
+
+	if stats := m.Stats(); stats.Acked != 0 || stats.AvgLatency != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	m.Acked(10 * time.Millisecond)
+	m.Acked(30 * time.Millisecond)
+	m.Retried()
+	m.TimedOut()
+
+	stats := m.Stats()
+	if stats.Acked != 2 || stats.Retries != 1 || stats.Timeouts != 1 {
+		t.Fatalf("unexpected counters %+v", stats)
+	}
+
+	if stats.AvgLatency != 20*time.Millisecond || stats.MaxLatency != 30*time.Millisecond {
+		t.Fatalf("unexpected latencies %+v", stats)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// A Capability is a feature not supported by every client version. Callbacks
+// and ModelObserver query them through AyiSession.Supports instead of checking
+// platform and versions by themselves.
+type Capability string
+
+const (
+	// EVENT_MODIFIED may convey participants (crashes iOS 1.0.10 and lower)
+	CAP_FULL_EVENT_MODIFIED Capability = "full_event_modified"
+
+	// Client acknowledges responses that deliver events, so delivery state is
+	// only changed once they have been received. Declared by client in HELLO.
+	CAP_ACK_RESPONSES Capability = proto.CAPABILITY_ACK_RESPONSES
+)
+
+// Any platform not explicitly registered
+const anyPlatform = ""
+
+// Range of client versions, both inclusive. Empty bounds are unbounded.
+type versionRange struct {
+	min string
+	max string
+}
+
+func (r versionRange) Contains(version string) bool {
+	if r.min != "" && utils.CompareVersions(version, r.min) < 0 {
+		return false
+	}
+	if r.max != "" && utils.CompareVersions(version, r.max) > 0 {
+		return false
+	}
+	return true
+}
+
+// CapabilityRegistry knows which client versions of each platform support a
+// capability. If a platform has no ranges registered for a capability, the
+// ranges of anyPlatform are used. A capability with no ranges at all is not
+// supported.
+type CapabilityRegistry struct {
+	ranges map[Capability]map[string][]versionRange
+}
+
+func NewCapabilityRegistry() *CapabilityRegistry {
+	return &CapabilityRegistry{
+		ranges: make(map[Capability]map[string][]versionRange),
+	}
+}
+
+// Register adds a version range of platform that supports capability. Use
+// anyPlatform for platforms not registered, and empty versions for unbounded
+// ranges.
+func (r *CapabilityRegistry) Register(capability Capability, platform string, minVersion string, maxVersion string) {
+
+	platforms, ok := r.ranges[capability]
+	if !ok {
+		platforms = make(map[string][]versionRange)
+		r.ranges[capability] = platforms
+	}
+
+	platforms[platform] = append(platforms[platform], versionRange{min: minVersion, max: maxVersion})
+}
+
+func (r *CapabilityRegistry) Supports(capability Capability, platform string, version string) bool {
+
+	platforms, ok := r.ranges[capability]
+	if !ok {
+		return false
+	}
+
+	ranges, ok := platforms[platform]
+	if !ok {
+		ranges = platforms[anyPlatform]
+	}
+
+	for _, versions := range ranges {
+		if versions.Contains(version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Capabilities of released clients. Unknown platforms, including clients
+// that didn't send HELLO, support none of them.
+func newDefaultCapabilityRegistry() *CapabilityRegistry {
+	r := NewCapabilityRegistry()
+	r.Register(CAP_FULL_EVENT_MODIFIED, PLATFORM_ANDROID, "", "")
+	r.Register(CAP_FULL_EVENT_MODIFIED, PLATFORM_IOS, "1.0.11", "")
+	return r
+}
+
+// Returns true if the client of this session declared capability in HELLO or
+// its version supports it
+func (s *AyiSession) Supports(capability Capability) bool {
+
+	defer s.helloMutex.RUnlock()
+	s.helloMutex.RLock()
+
+	for _, declared := range s.Capabilities {
+		if Capability(declared) == capability {
+			return true
+		}
+	}
+	return s.Server.capabilities.Supports(capability, s.Platform, s.ClientVersion)
+}
+
+// Returns false if client version of this session is lower than the minimum
+// supported version configured for its platform
+func (s *AyiSession) isClientVersionSupported() bool {
+	minVersion, ok := s.Server.Config.MinClientVersions()[s.Platform]
+	return !ok || utils.CompareVersions(s.ClientVersion, minVersion) >= 0
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+)
+
+func TestCapabilityRegistry(t *testing.T) {
+
+	r := newDefaultCapabilityRegistry()
+
+	tests := []struct {
+		platform string
+		version  string
+		want     bool
+	}{
+		{PLATFORM_IOS, "1.0.10", false},
+		{PLATFORM_IOS, "1.0.11", true},
+		{PLATFORM_IOS, "1.2", true},
+		{PLATFORM_ANDROID, "1.0.8", true},
+		{"", "", false},
+		{"Windows", "1.0.8", false},
+	}
+
+	for _, test := range tests {
+		if got := r.Supports(CAP_FULL_EVENT_MODIFIED, test.platform, test.version); got != test.want {
+			t.Errorf("Supports(%v %v) = %v, want %v", test.platform, test.version, got, test.want)
+		}
+	}
+
+	if r.Supports(Capability("unknown"), PLATFORM_ANDROID, "1.0.8") {
+		t.Error("unknown capability supported")
+	}
+}
+
+func TestCapabilityVersionRanges(t *testing.T) {
+
+	r := NewCapabilityRegistry()
+	r.Register(CAP_FULL_EVENT_MODIFIED, PLATFORM_ANDROID, "1.0.5", "1.0.9")
+	r.Register(CAP_FULL_EVENT_MODIFIED, PLATFORM_ANDROID, "1.2", "")
+
+	tests := map[string]bool{
+		"1.0.4":  false,
+		"1.0.5":  true,
+		"1.0.9":  true,
+		"1.1.0":  false,
+		"1.2.0":  true,
+		"2.0.0":  true,
+		"1.0.10": false,
+	}
+
+	for version, want := range tests {
+		if got := r.Supports(CAP_FULL_EVENT_MODIFIED, PLATFORM_ANDROID, version); got != want {
+			t.Errorf("Supports(%v) = %v, want %v", version, got, want)
+		}
+	}
+
+	// No ranges for any platform
+	if r.Supports(CAP_FULL_EVENT_MODIFIED, PLATFORM_IOS, "2.0.0") {
+		t.Error("capability supported on unregistered platform")
+	}
+}
+
+func TestDeclaredCapabilities(t *testing.T) {
+
+	session := &AyiSession{
+		Server:        &Server{capabilities: newDefaultCapabilityRegistry()},
+		Platform:      PLATFORM_ANDROID,
+		ClientVersion: "1.0.8",
+	}
+
+	if session.Supports(CAP_ACK_RESPONSES) {
+		t.Fatal("capability not declared but supported")
+	}
+
+	session.Capabilities = []string{"other", string(CAP_ACK_RESPONSES)}
+	if !session.Supports(CAP_ACK_RESPONSES) {
+		t.Fatal("declared capability not supported")
+	}
+}
+
+func TestClientInfoSetWhileWriting(t *testing.T) {
+
+	session := &AyiSession{Server: &Server{capabilities: newDefaultCapabilityRegistry()}}
+	packet := proto.NewPacket(proto.VERSION_3).EventExpired(1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// Writer may marshal packets while HELLO is being handled
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			session.marshalPacket(packet)
+			session.Supports(CAP_ACK_RESPONSES)
+		}
+	}()
+
+	session.setClientInfo(&proto.Hello{
+		Platform:      PLATFORM_ANDROID,
+		ClientVersion: "1.0.8",
+		Compression:   []string{proto.COMPRESSION_SNAPPY},
+		Capabilities:  []string{string(CAP_ACK_RESPONSES)},
+	})
+
+	wg.Wait()
+
+	if !session.CompressionEnabled || !session.Supports(CAP_ACK_RESPONSES) {
+		t.Fatal("client info not set")
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/capture"
+)
+
+// Users whose packets are being captured. Capture is enabled per user so that
+// it survives reconnections, but each session writes its own capture file.
+type captureSet struct {
+	mutex sync.RWMutex
+	users map[int64]bool
+}
+
+func newCaptureSet() *captureSet {
+	return &captureSet{users: make(map[int64]bool)}
+}
+
+func (cs *captureSet) Add(userID int64) {
+	defer cs.mutex.Unlock()
+	cs.mutex.Lock()
+	cs.users[userID] = true
+}
+
+func (cs *captureSet) Remove(userID int64) bool {
+	defer cs.mutex.Unlock()
+	cs.mutex.Lock()
+	ok := cs.users[userID]
+	delete(cs.users, userID)
+	return ok
+}
+
+func (cs *captureSet) Contains(userID int64) bool {
+	defer cs.mutex.RUnlock()
+	cs.mutex.RLock()
+	return cs.users[userID]
+}
+
+func (cs *captureSet) Keys() []int64 {
+	defer cs.mutex.RUnlock()
+	cs.mutex.RLock()
+	keys := make([]int64, 0, len(cs.users))
+	for k := range cs.users {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// StartCapture enables packet capture for userID. If the user is connected,
+// the capture of its current sessions starts right away and the files where
+// packets are written are returned. Otherwise, it starts the next time the
+// user is authenticated.
+func (s *Server) StartCapture(userID int64) ([]string, error) {
+
+	s.captures.Add(userID)
+
+	var files []string
+	for _, session := range s.getSessions(userID) {
+		file, err := session.startCapture(s.Config.CaptureDir())
+		if err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// StopCapture disables packet capture for userID and closes the capture of
+// its current sessions, if any
+func (s *Server) StopCapture(userID int64) error {
+
+	if !s.captures.Remove(userID) {
+		return ErrCaptureNotStarted
+	}
+
+	for _, session := range s.getSessions(userID) {
+		session.stopCapture()
+	}
+
+	return nil
+}
+
+func (s *Server) CapturedUsers() []int64 {
+	users := s.captures.Keys()
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+	return users
+}
+
+// Starts capturing packets of the session into a new file in dir. If capture
+// is already started, current file is returned.
+func (s *AyiSession) startCapture(dir string) (string, error) {
+
+	defer s.captureMutex.Unlock()
+	s.captureMutex.Lock()
+
+	if s.capture != nil {
+		return s.captureFile, nil
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(dir, fmt.Sprintf("%v-%v%v", s.UserId,
+		time.Now().UTC().Format("20060102-150405.000000"), capture.FileExtension))
+
+	w, err := capture.Create(file)
+	if err != nil {
+		return "", err
+	}
+
+	s.capture = w
+	s.captureFile = file
+	log.Printf("* (%v) Capturing packets to %v\n", s, file)
+
+	return file, nil
+}
+
+func (s *AyiSession) stopCapture() {
+
+	defer s.captureMutex.Unlock()
+	s.captureMutex.Lock()
+
+	if s.capture == nil {
+		return
+	}
+
+	if err := s.capture.Close(); err != nil {
+		log.Printf("* (%v) Close capture error: %v\n", s, err)
+	}
+
+	log.Printf("* (%v) Capture %v finished\n", s, s.captureFile)
+	s.capture = nil
+	s.captureFile = ""
+}
+
+func (s *AyiSession) capturePacket(direction capture.Direction, packet *proto.AyiPacket) {
+
+	s.captureMutex.Lock()
+	w := s.capture
+	s.captureMutex.Unlock()
+
+	if w == nil {
+		return
+	}
+
+	if err := w.Write(direction, s.UserId, packet); err != nil {
+		log.Printf("* (%v) Capture packet error: %v\n", s, err)
+	}
+}
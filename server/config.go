@@ -2,6 +2,7 @@ package main
 
 import (
 	"io/ioutil"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -78,6 +79,91 @@ func (c *Config) FirebaseAPIKey() string {
 	return c.data.FirebaseAPIKey
 }
 
+// PushProvider returns the push provider used by default
+func (c *Config) PushProvider() string {
+	return c.data.PushProvider
+}
+
+// PushPlatformProviders returns platforms that use a push provider other
+// than the default one
+func (c *Config) PushPlatformProviders() map[string]string {
+	return c.data.PushPlatformProviders
+}
+
+func (c *Config) FCMCredentialsFile() string {
+	return c.data.FCMCredentialsFile
+}
+
+func (c *Config) APNSKeyFile() string {
+	return c.data.APNSKeyFile
+}
+
+func (c *Config) APNSKeyID() string {
+	return c.data.APNSKeyID
+}
+
+func (c *Config) APNSTeamID() string {
+	return c.data.APNSTeamID
+}
+
+func (c *Config) APNSTopic() string {
+	return c.data.APNSTopic
+}
+
+func (c *Config) APNSSandbox() bool {
+	return c.data.APNSSandbox
+}
+
+func (c *Config) WebSocketEnabled() bool {
+	return c.data.WebSocketEnabled
+}
+
+func (c *Config) WebSocketListenPort() int {
+	return c.data.WebSocketListenPort
+}
+
+func (c *Config) WebSocketEnableTLS() bool {
+	return c.data.WebSocketEnableTLS
+}
+
+func (c *Config) WebSocketAllowedOrigins() []string {
+	return c.data.WebSocketAllowedOrigins
+}
+
+func (c *Config) RestEnabled() bool {
+	return c.data.RestEnabled
+}
+
+func (c *Config) RestListenPort() int {
+	return c.data.RestListenPort
+}
+
+func (c *Config) RestEnableHTTPS() bool {
+	return c.data.RestEnableHTTPS
+}
+
+func (c *Config) CaptureDir() string {
+	return c.data.CaptureDir
+}
+
+// MinClientVersions returns the minimum client version supported for each
+// platform. Platforms not included have no minimum.
+func (c *Config) MinClientVersions() map[string]string {
+	return c.data.MinClientVersions
+}
+
+// EventReminders returns how long before start participants who will or may
+// attend an event are reminded of it
+func (c *Config) EventReminders() []time.Duration {
+	return c.data.EventReminders
+}
+
+// EventNudges returns how long before start participants who haven't
+// answered to an event are nudged
+func (c *Config) EventNudges() []time.Duration {
+	return c.data.EventNudges
+}
+
 type ConfigDTO struct {
 	MaintenanceMode     bool     `yaml:"maintenance_mode,omitempty"`
 	ShowTestModeWarning bool     `yaml:"test_mode_warning,omitempty"`
@@ -96,6 +182,31 @@ type ConfigDTO struct {
 	FBWebHookEnabled    bool     `yaml:"fb_webhoook_enable"`
 	FBWebHookListenPort int      `yaml:"fb_webhook_listen_port,omitempty"`
 	FirebaseAPIKey      string   `yaml:"firebase_api_key"`
+
+	PushProvider          string            `yaml:"push_provider,omitempty"`
+	PushPlatformProviders map[string]string `yaml:"push_platform_providers,omitempty"`
+	FCMCredentialsFile    string            `yaml:"fcm_credentials_file,omitempty"`
+	APNSKeyFile           string            `yaml:"apns_key_file,omitempty"`
+	APNSKeyID             string            `yaml:"apns_key_id,omitempty"`
+	APNSTeamID            string            `yaml:"apns_team_id,omitempty"`
+	APNSTopic             string            `yaml:"apns_topic,omitempty"`
+	APNSSandbox           bool              `yaml:"apns_sandbox,omitempty"`
+
+	WebSocketEnabled        bool     `yaml:"websocket_enable,omitempty"`
+	WebSocketListenPort     int      `yaml:"websocket_listen_port,omitempty"`
+	WebSocketEnableTLS      bool     `yaml:"websocket_enable_tls,omitempty"`
+	WebSocketAllowedOrigins []string `yaml:"websocket_allowed_origins,flow,omitempty"`
+
+	RestEnabled     bool `yaml:"rest_enable,omitempty"`
+	RestListenPort  int  `yaml:"rest_listen_port,omitempty"`
+	RestEnableHTTPS bool `yaml:"rest_enable_https,omitempty"`
+
+	CaptureDir string `yaml:"capture_dir,omitempty"`
+
+	MinClientVersions map[string]string `yaml:"min_client_version,omitempty"`
+
+	EventReminders []time.Duration `yaml:"event_reminders,flow,omitempty"`
+	EventNudges    []time.Duration `yaml:"event_nudges,flow,omitempty"`
 }
 
 func loadConfigFromFile(file string) (*Config, error) {
@@ -134,5 +245,25 @@ func loadConfigFromFile(file string) (*Config, error) {
 		config.data.FBWebHookListenPort = 40186
 	}
 
+	if config.data.WebSocketListenPort == 0 {
+		config.data.WebSocketListenPort = 1823
+	}
+
+	if config.data.RestListenPort == 0 {
+		config.data.RestListenPort = 40188
+	}
+
+	if config.data.CaptureDir == "" {
+		config.data.CaptureDir = "captures"
+	}
+
+	if config.data.EventReminders == nil {
+		config.data.EventReminders = []time.Duration{24 * time.Hour, 1 * time.Hour}
+	}
+
+	if config.data.EventNudges == nil {
+		config.data.EventNudges = []time.Duration{24 * time.Hour}
+	}
+
 	return config, nil
 }
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
 	"github.com/d3ce1t/areyouin-server/model"
+	proto "github.com/d3ce1t/areyouin-server/protocol"
 	"github.com/d3ce1t/areyouin-server/protocol/core"
 	"github.com/d3ce1t/areyouin-server/utils"
 )
@@ -30,9 +34,23 @@ func convEvent2Net(event *model.Event) *core.Event {
 		InboxPosition: utils.TimeToMillis(event.InboxPosition()),
 		PictureDigest: event.PictureDigest(),
 		State:         core.EventState(event.Status()),
+		IsPublic:      event.IsPublic(),
+		SeriesId:      event.SeriesID(),
+		Capacity:      int32(event.Capacity()),
+		Waitlist:      event.Participants.Waitlist(),
+		Quorum:        int32(event.Quorum()),
+		Confirmed:     event.IsConfirmed(),
 		Participants:  make(map[int64]*core.EventParticipant),
 	}
 
+	if !event.QuorumDeadline().IsZero() {
+		netEvent.QuorumDeadline = utils.TimeToMillis(event.QuorumDeadline())
+	}
+
+	if location := event.Location(); location != nil {
+		netEvent.Geolocation = convLocation2Net(location)
+	}
+
 	for _, p := range event.Participants.AsSlice() {
 
 		netEvent.Participants[p.Id()] = &core.EventParticipant{
@@ -46,6 +64,36 @@ func convEvent2Net(event *model.Event) *core.Event {
 	return netEvent
 }
 
+func convLocation2Net(location *model.Location) *core.Location {
+	return &core.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
+
+func convNetLocation(location *core.Location) *model.Location {
+	return &model.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
+
+func convNetRecurrence(recurrence *proto.Recurrence) *model.Recurrence {
+
+	result := &model.Recurrence{
+		Frequency: api.RecurrenceFrequency(recurrence.Frequency),
+		Interval:  int(recurrence.Interval),
+		Count:     int(recurrence.Count),
+		TimeZone:  recurrence.TimeZone,
+	}
+
+	if recurrence.Until != 0 {
+		result.Until = utils.MillisToTimeUTC(recurrence.Until)
+	}
+
+	return result
+}
+
 func convEventList2Net(eventList []*model.Event) []*core.Event {
 	netEvents := make([]*core.Event, 0, len(eventList))
 	for _, event := range eventList {
@@ -128,3 +176,110 @@ func convGroupList2Net(groups []*model.Group) []*core.Group {
 	}
 	return result
 }
+
+func convChangeProposal2Net(proposal *model.ChangeProposal) *proto.EventChangeProposed {
+
+	netProposal := &proto.EventChangeProposed{
+		EventId:  proposal.EventID(),
+		ChangeId: proposal.Id(),
+		Message:  proposal.Description(),
+	}
+
+	if proposal.IsDateChange() {
+		netProposal.StartDate = utils.TimeToMillis(proposal.StartDate())
+		netProposal.EndDate = utils.TimeToMillis(proposal.EndDate())
+	}
+
+	return netProposal
+}
+
+// Voting status start and end dates are the voting window
+func convVotingStatus2Net(proposal *model.ChangeProposal) *proto.VotingStatus {
+
+	elapsedTime := utils.GetCurrentTimeUTC().Sub(proposal.CreatedDate())
+	if votingTime := proposal.Deadline().Sub(proposal.CreatedDate()); elapsedTime > votingTime {
+		elapsedTime = votingTime
+	}
+
+	return &proto.VotingStatus{
+		EventId:       proposal.EventID(),
+		ChangeId:      proposal.Id(),
+		StartDate:     utils.TimeToMillis(proposal.CreatedDate()),
+		EndDate:       utils.TimeToMillis(proposal.Deadline()),
+		ElapsedTime:   int64(elapsedTime / time.Millisecond),
+		VotesReceived: uint32(proposal.VotesReceived()),
+		VotesTotal:    uint32(proposal.VotesTotal()),
+		Finished:      proposal.IsFinished(),
+	}
+}
+
+func convPushToken2Net(token *model.IIDToken, currentDeviceID string) *proto.Device {
+	return &proto.Device{
+		DeviceId:       token.DeviceID(),
+		Platform:       token.Platform(),
+		NetworkVersion: uint32(token.Version()),
+		LastSeen:       utils.TimeToMillis(token.LastSeen()),
+		Current:        token.DeviceID() == currentDeviceID,
+	}
+}
+
+func convNotificationSettings2Net(settings *model.NotificationSettings) *proto.NotificationSettings {
+
+	netSettings := &proto.NotificationSettings{
+		QuietHoursEnabled: settings.QuietHoursEnabled,
+		QuietHoursStart:   uint32(settings.QuietHoursStart),
+		QuietHoursEnd:     uint32(settings.QuietHoursEnd),
+		TimeZone:          settings.TimeZone,
+		MutedEvents:       settings.MutedEvents,
+	}
+
+	for _, category := range settings.DisabledCategories {
+		netSettings.DisabledCategories = append(netSettings.DisabledCategories,
+			proto.NotificationCategory(category))
+	}
+
+	return netSettings
+}
+
+func convNetNotificationSettings(netSettings *proto.NotificationSettings) *model.NotificationSettings {
+
+	settings := &model.NotificationSettings{
+		QuietHoursEnabled: netSettings.QuietHoursEnabled,
+		QuietHoursStart:   int(netSettings.QuietHoursStart),
+		QuietHoursEnd:     int(netSettings.QuietHoursEnd),
+		TimeZone:          netSettings.TimeZone,
+	}
+
+	for _, category := range netSettings.DisabledCategories {
+		settings.DisabledCategories = append(settings.DisabledCategories,
+			api.NotificationCategory(category))
+	}
+
+	return settings
+}
+
+func convComment2Net(comment *model.Comment) *proto.EventComment {
+
+	netComment := &proto.EventComment{
+		EventId:     comment.EventID(),
+		CommentId:   comment.Id(),
+		AuthorId:    comment.AuthorID(),
+		AuthorName:  comment.AuthorName(),
+		Message:     comment.Message(),
+		CreatedDate: utils.TimeToMillis(comment.CreatedDate()),
+	}
+
+	if comment.IsEdited() {
+		netComment.EditedDate = utils.TimeToMillis(comment.EditedDate())
+	}
+
+	return netComment
+}
+
+func convCommentList2Net(comments []*model.Comment) []*proto.EventComment {
+	result := make([]*proto.EventComment, 0, len(comments))
+	for _, c := range comments {
+		result = append(result, convComment2Net(c))
+	}
+	return result
+}
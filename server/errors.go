@@ -18,6 +18,9 @@ var (
 	ErrAuthorMismatch             = errors.New("author mismatch")
 	ErrOperationFailed            = errors.New("operation failed")
 	ErrFriendNotFound             = errors.New("friend not found")
+	ErrUnknownPosition            = errors.New("user position is unknown")
+	ErrCaptureNotStarted          = errors.New("capture not started")
+	ErrDeviceNotFound             = errors.New("device not found")
 )
 
 func getNetErrorCode(err error, default_code int32) int32 {
@@ -41,6 +44,12 @@ func getNetErrorCode(err error, default_code int32) int32 {
 	case ErrFriendNotFound:
 		err_code = proto.E_FRIEND_NOT_FOUND
 
+	case ErrUnknownPosition:
+		err_code = proto.E_UNKNOWN_USER_POSITION
+
+	case ErrDeviceNotFound:
+		err_code = proto.E_DEVICE_NOT_FOUND
+
 	case model.ErrInvalidEmail:
 		err_code = proto.E_INPUT_INVALID_EMAIL_ADDRESS
 
@@ -85,6 +94,35 @@ func getNetErrorCode(err error, default_code int32) int32 {
 	case model.ErrAlreadyFriends:
 		err_code = proto.E_ALREADY_FRIENDS
 
+	case model.ErrInvalidChangeProposal:
+		err_code = proto.E_INVALID_INPUT
+
+	case model.ErrChangeAlreadyProposed:
+		err_code = proto.E_CHANGE_ALREADY_PROPOSED
+
+	case model.ErrVotingFinished:
+		err_code = proto.E_VOTING_FINISHED
+
+	case model.ErrAlreadyVoted:
+		err_code = proto.E_ALREADY_VOTED
+
+	case model.ErrSyncCursorExpired, model.ErrInvalidSyncCursor:
+		// Either way, client must download its inbox again
+		err_code = proto.E_SYNC_CURSOR_EXPIRED
+
+	case model.ErrInvalidLocation, model.ErrLocationRequired:
+		err_code = proto.E_INVALID_LOCATION
+
+	case model.ErrInvalidRecurrence, model.ErrEventNotRecurring, model.ErrInvalidCapacity,
+		model.ErrInvalidQuorum:
+		err_code = proto.E_INVALID_INPUT
+
+	case model.ErrInvalidComment:
+		err_code = proto.E_INVALID_INPUT
+
+	case model.ErrCommentNotWritable:
+		err_code = proto.E_ACCESS_DENIED
+
 	case api.ErrEmailAlreadyExists:
 		err_code = proto.E_EMAIL_EXISTS
 
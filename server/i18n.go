@@ -1,13 +1,17 @@
 package main
 
+import (
+	"fmt"
+	"strings"
+)
+
 type i18nKey int
 
 // i18n string keys
 const (
 	NotificationFriendJoinedTitle i18nKey = iota
 	NotificationFriendJoinedBody
-
-/*	NotificationNewEventTitle i18nKey = iota
+	NotificationNewEventTitle
 	NotificationNewEventBody
 	NotificationEventCancelledTitle
 	NotificationEventCancelledBody
@@ -17,10 +21,30 @@ const (
 	NotificationEventResponseMaybeBody
 	NotificationEventResponseNoAssistTitle
 	NotificationEventResponseNoAssistBody
+	NotificationChangeProposedTitle
+	NotificationChangeProposedBody
+	NotificationChangeDateProposedBody
+	NotificationChangeMessageProposedBody
+	NotificationVotingFinishedTitle
+	NotificationChangeAcceptedBody
+	NotificationChangeDiscardedBody
 	NotificationNewFriendRequestTitle
 	NotificationNewFriendRequestBody
 	NotificationNewFriendTitle
-	NotificationNewFriendBody*/
+	NotificationNewFriendBody
+	NotificationEventReminderTitle
+	NotificationEventStartsInDaysBody
+	NotificationEventStartsInHoursBody
+	NotificationEventStartsInMinutesBody
+	NotificationEventNudgeBody
+	NotificationEventCommentTitle
+	NotificationEventCommentBody
+	NotificationWaitlistPromotedTitle
+	NotificationWaitlistPromotedBody
+	NotificationEventConfirmedTitle
+	NotificationEventConfirmedBody
+	NotificationQuorumFailedTitle
+	NotificationQuorumFailedBody
 )
 
 type i18nLang int
@@ -31,68 +55,237 @@ const (
 	ES                 // Spanish
 )
 
-var (
-	defaultLang = ES
-
-	language = map[i18nLang]map[i18nKey]string{
-		ES: {
-			NotificationFriendJoinedTitle: "Nuevo amigo",
-			NotificationFriendJoinedBody:  "%v se ha unido a AreYouIN",
-			/*
-				// New event notification
-				NotificationNewEventTitle: "Nuevo evento",
-				NotificationNewEventBody:  "%v te ha invitado a un evento",
-				// Event cancelled notification
-				NotificationEventCancelledTitle: "%v",
-				NotificationEventCancelledBody:  "%v ha cancelado el evento",
-				// Participant will assist to event
-				NotificationEventResponseAssistTitle: "%v",
-				NotificationEventResponseAssistBody:  "%v asistirá al evento",
-				// Participant may assist to event
-				NotificationEventResponseMaybeTitle: "%v",
-				NotificationEventResponseMaybeBody:  "%v quizá asista al evento",
-				// Participant will assist to event
-				NotificationEventResponseNoAssistTitle: "%v",
-				NotificationEventResponseNoAssistBody:  "%v no asistirá al evento",
-				// Friend request notification
-				NotificationNewFriendRequestTitle: "Solicitud de amistad",
-				NotificationNewFriendRequestBody:  "%v quiere ser tu amigo",
-				// New friend notification
-				NotificationNewFriendTitle: "Nuevo amigo",
-				NotificationNewFriendBody:  "%v y tú ahora sois amigos",
-			*/
-		},
-		EN: {
-			NotificationFriendJoinedTitle: "New friend",
-			NotificationFriendJoinedBody:  "%v has joined AreYouIN",
-			/*
-				// New event notification
-				NotificationNewEventTitle: "New event",
-				NotificationNewEventBody:  "%v has invited you to an event",
-				// Event cancelled notification
-				NotificationEventCancelledTitle: "%v",
-				NotificationEventCancelledBody:  "%v has cancelled the event",
-				// Participant will assist to event
-				NotificationEventResponseAssistTitle: "%v",
-				NotificationEventResponseAssistBody:  "%v will assist to event",
-				// Participant may assist to event
-				NotificationEventResponseMaybeTitle: "%v",
-				NotificationEventResponseMaybeBody:  "%v may assist to event",
-				// Participant will assist to event
-				NotificationEventResponseNoAssistTitle: "%v",
-				NotificationEventResponseNoAssistBody:  "%v will not assist to event",
-				// Friend request notification
-				NotificationNewFriendRequestTitle: "Friend request",
-				NotificationNewFriendRequestBody:  "%v wants to be your friend",
-				// New friend notification
-				NotificationNewFriendTitle: "New friend",
-				NotificationNewFriendBody:  "%v and you are friends now",
-			*/
-		},
+// Language used when user language is unknown or isn't supported
+const defaultLang = ES
+
+// Primary subtag of supported languages
+var languageTags = map[string]i18nLang{
+	"en": EN,
+	"es": ES,
+}
+
+// A string of the catalog. Strings that depend on a count may have a zero
+// and a singular form. Both fall back to other if they are empty.
+type i18nString struct {
+	zero  string
+	one   string
+	other string
+}
+
+func str(s string) i18nString {
+	return i18nString{other: s}
+}
+
+func plural(zero string, one string, other string) i18nString {
+	return i18nString{zero: zero, one: one, other: other}
+}
+
+// Strings are fmt formats. Arguments may be reordered with explicit indexes
+// (%[2]v) when a language needs it. Plural strings get the count as first
+// argument.
+var language = map[i18nLang]map[i18nKey]i18nString{
+	ES: {
+		NotificationFriendJoinedTitle: str("Nuevo amigo"),
+		NotificationFriendJoinedBody:  str("%v se ha unido a AreYouIN"),
+		// New event notification
+		NotificationNewEventTitle: str("Nuevo evento"),
+		NotificationNewEventBody: plural(
+			"%[2]v te ha invitado a un evento",
+			"%[2]v te ha invitado a un evento junto a otro amigo",
+			"%[2]v te ha invitado a un evento junto a otros %[1]d amigos"),
+		// Event cancelled notification
+		NotificationEventCancelledTitle: str("%v"),
+		NotificationEventCancelledBody:  str("%v ha cancelado el evento"),
+		// Participant will assist to event
+		NotificationEventResponseAssistTitle: str("%v"),
+		NotificationEventResponseAssistBody: plural(
+			"%[2]v asistirá al evento",
+			"%[2]v asistirá al evento",
+			"%[2]v asistirá al evento (%[1]d asistentes)"),
+		// Participant may assist to event
+		NotificationEventResponseMaybeTitle: str("%v"),
+		NotificationEventResponseMaybeBody:  str("%v quizá asista al evento"),
+		// Participant won't assist to event
+		NotificationEventResponseNoAssistTitle: str("%v"),
+		NotificationEventResponseNoAssistBody:  str("%v no asistirá al evento"),
+		// Change proposed notification
+		NotificationChangeProposedTitle:       str("%v"),
+		NotificationChangeProposedBody:        str("%v ha propuesto cambiar la fecha y la descripción"),
+		NotificationChangeDateProposedBody:    str("%v ha propuesto una nueva fecha"),
+		NotificationChangeMessageProposedBody: str("%v ha propuesto una nueva descripción"),
+		// Voting finished notification
+		NotificationVotingFinishedTitle: str("%v"),
+		NotificationChangeAcceptedBody:  str("El cambio propuesto ha sido aceptado"),
+		NotificationChangeDiscardedBody: str("El cambio propuesto ha sido descartado"),
+		// Friend request notification
+		NotificationNewFriendRequestTitle: str("Solicitud de amistad"),
+		NotificationNewFriendRequestBody:  str("%v quiere ser tu amigo"),
+		// New friend notification
+		NotificationNewFriendTitle: str("Nuevo amigo"),
+		NotificationNewFriendBody:  str("%v y tú ahora sois amigos"),
+		// Event reminder notification
+		NotificationEventReminderTitle: str("%v"),
+		NotificationEventStartsInDaysBody: plural("",
+			"El evento empieza en un día",
+			"El evento empieza en %[1]d días"),
+		NotificationEventStartsInHoursBody: plural("",
+			"El evento empieza en una hora",
+			"El evento empieza en %[1]d horas"),
+		NotificationEventStartsInMinutesBody: plural("",
+			"El evento empieza en un minuto",
+			"El evento empieza en %[1]d minutos"),
+		NotificationEventNudgeBody: str("%v espera tu respuesta"),
+		// Event comment notification
+		NotificationEventCommentTitle: str("%v"),
+		NotificationEventCommentBody:  str("%v: %v"),
+		// Waitlist notification
+		NotificationWaitlistPromotedTitle: str("%v"),
+		NotificationWaitlistPromotedBody:  str("Se ha liberado una plaza. ¡Ya estás dentro!"),
+		// Quorum notifications
+		NotificationEventConfirmedTitle: str("%v"),
+		NotificationEventConfirmedBody: plural(
+			"",
+			"¡Evento confirmado! Ya hay 1 asistente",
+			"¡Evento confirmado! Ya hay %v asistentes"),
+		NotificationQuorumFailedTitle: str("%v"),
+		NotificationQuorumFailedBody: plural(
+			"",
+			"Evento cancelado: no se alcanzó el mínimo de 1 asistente",
+			"Evento cancelado: no se alcanzó el mínimo de %v asistentes"),
+	},
+	EN: {
+		NotificationFriendJoinedTitle: str("New friend"),
+		NotificationFriendJoinedBody:  str("%v has joined AreYouIN"),
+		// New event notification
+		NotificationNewEventTitle: str("New event"),
+		NotificationNewEventBody: plural(
+			"%[2]v has invited you to an event",
+			"%[2]v has invited you and another friend to an event",
+			"%[2]v has invited you and %[1]d other friends to an event"),
+		// Event cancelled notification
+		NotificationEventCancelledTitle: str("%v"),
+		NotificationEventCancelledBody:  str("%v has cancelled the event"),
+		// Participant will assist to event
+		NotificationEventResponseAssistTitle: str("%v"),
+		NotificationEventResponseAssistBody: plural(
+			"%[2]v will attend the event",
+			"%[2]v will attend the event",
+			"%[2]v will attend the event (%[1]d attendees)"),
+		// Participant may assist to event
+		NotificationEventResponseMaybeTitle: str("%v"),
+		NotificationEventResponseMaybeBody:  str("%v may attend the event"),
+		// Participant won't assist to event
+		NotificationEventResponseNoAssistTitle: str("%v"),
+		NotificationEventResponseNoAssistBody:  str("%v will not attend the event"),
+		// Change proposed notification
+		NotificationChangeProposedTitle:       str("%v"),
+		NotificationChangeProposedBody:        str("%v has proposed to change date and description"),
+		NotificationChangeDateProposedBody:    str("%v has proposed a new date"),
+		NotificationChangeMessageProposedBody: str("%v has proposed a new description"),
+		// Voting finished notification
+		NotificationVotingFinishedTitle: str("%v"),
+		NotificationChangeAcceptedBody:  str("The proposed change has been accepted"),
+		NotificationChangeDiscardedBody: str("The proposed change has been discarded"),
+		// Friend request notification
+		NotificationNewFriendRequestTitle: str("Friend request"),
+		NotificationNewFriendRequestBody:  str("%v wants to be your friend"),
+		// New friend notification
+		NotificationNewFriendTitle: str("New friend"),
+		NotificationNewFriendBody:  str("%v and you are friends now"),
+		// Event reminder notification
+		NotificationEventReminderTitle: str("%v"),
+		NotificationEventStartsInDaysBody: plural("",
+			"The event starts in a day",
+			"The event starts in %[1]d days"),
+		NotificationEventStartsInHoursBody: plural("",
+			"The event starts in an hour",
+			"The event starts in %[1]d hours"),
+		NotificationEventStartsInMinutesBody: plural("",
+			"The event starts in a minute",
+			"The event starts in %[1]d minutes"),
+		NotificationEventNudgeBody: str("%v is waiting for your answer"),
+		// Event comment notification
+		NotificationEventCommentTitle: str("%v"),
+		NotificationEventCommentBody:  str("%v: %v"),
+		// Waitlist notification
+		NotificationWaitlistPromotedTitle: str("%v"),
+		NotificationWaitlistPromotedBody:  str("A spot opened up. You're in!"),
+		// Quorum notifications
+		NotificationEventConfirmedTitle: str("%v"),
+		NotificationEventConfirmedBody: plural(
+			"",
+			"Event confirmed! 1 person is going",
+			"Event confirmed! %v people are going"),
+		NotificationQuorumFailedTitle: str("%v"),
+		NotificationQuorumFailedBody: plural(
+			"",
+			"Event cancelled: the minimum of 1 attendee wasn't reached",
+			"Event cancelled: the minimum of %v attendees wasn't reached"),
+	},
+}
+
+// Returns string of key in lang, or in defaultLang if lang doesn't have it
+func lookup(lang i18nLang, key i18nKey) (i18nString, bool) {
+	if s, ok := language[lang][key]; ok {
+		return s, true
+	}
+	s, ok := language[defaultLang][key]
+	return s, ok
+}
+
+// T Retrieves a string by key for the given lang formatted with args. Returns
+// an empty string if key isn't in the catalog.
+func T(lang i18nLang, key i18nKey, args ...interface{}) string {
+
+	s, ok := lookup(lang, key)
+	if !ok {
+		return ""
+	}
+
+	if len(args) == 0 {
+		return s.other
+	}
+
+	return fmt.Sprintf(s.other, args...)
+}
+
+// TN Retrieves the plural form of a string by key for count in the given lang.
+// String is formatted with count followed by args, unless it has no verbs.
+// Both supported languages use the singular form only for one.
+func TN(lang i18nLang, key i18nKey, count int, args ...interface{}) string {
+
+	s, ok := lookup(lang, key)
+	if !ok {
+		return ""
+	}
+
+	format := s.other
+	if count == 0 && s.zero != "" {
+		format = s.zero
+	} else if count == 1 && s.one != "" {
+		format = s.one
+	}
+
+	// Forms like "in an hour" don't need formatting
+	if !strings.Contains(format, "%") {
+		return format
+	}
+
+	return fmt.Sprintf(format, append([]interface{}{count}, args...)...)
+}
+
+// Returns the supported language of a language tag sent by a client (i.e.
+// "es", "es-ES" or "en_GB"), or defaultLang if it isn't supported.
+func parseLang(tag string) i18nLang {
+
+	primary := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(primary, "-_"); i != -1 {
+		primary = primary[:i]
+	}
+
+	if lang, ok := languageTags[primary]; ok {
+		return lang
 	}
-)
 
-// T Retrieves a string by key for the given lang
-func T(lang i18nLang, key i18nKey) string {
-	return language[lang][key]
+	return defaultLang
 }
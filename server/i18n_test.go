@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/model"
+)
+
+func TestCatalogIsComplete(t *testing.T) {
+	for lang, strings := range language {
+		for key := NotificationFriendJoinedTitle; key <= NotificationQuorumFailedBody; key++ {
+			if s, ok := strings[key]; !ok || s.other == "" {
+				t.Fatalf("language %v has no string for key %v", lang, key)
+			}
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{T(EN, NotificationNewFriendTitle), "New friend"},
+		{T(ES, NotificationNewFriendRequestBody, "Ana"), "Ana quiere ser tu amigo"},
+		{T(EN, NotificationEventCancelledTitle, "Party"), "Party"},
+		{TN(EN, NotificationNewEventBody, 0, "Ana"), "Ana has invited you to an event"},
+		{TN(EN, NotificationNewEventBody, 1, "Ana"), "Ana has invited you and another friend to an event"},
+		{TN(EN, NotificationNewEventBody, 5, "Ana"), "Ana has invited you and 5 other friends to an event"},
+		{TN(ES, NotificationNewEventBody, 5, "Ana"), "Ana te ha invitado a un evento junto a otros 5 amigos"},
+		{TN(ES, NotificationEventResponseAssistBody, 1, "Ana"), "Ana asistirá al evento"},
+		{TN(ES, NotificationEventResponseAssistBody, 3, "Ana"), "Ana asistirá al evento (3 asistentes)"},
+		{TN(EN, NotificationEventStartsInHoursBody, 1), "The event starts in an hour"},
+		{TN(ES, NotificationEventStartsInDaysBody, 2), "El evento empieza en 2 días"},
+		{T(EN, NotificationEventCommentBody, "Ana", "Running late"), "Ana: Running late"},
+		{TN(EN, NotificationEventConfirmedBody, 1), "Event confirmed! 1 person is going"},
+		{TN(ES, NotificationQuorumFailedBody, 4), "Evento cancelado: no se alcanzó el mínimo de 4 asistentes"},
+		{T(EN, i18nKey(-1)), ""},
+	}
+
+	for i, test := range tests {
+		if test.text != test.expected {
+			t.Fatalf("test %v: expected %q, got %q", i, test.expected, test.text)
+		}
+	}
+}
+
+func TestParseLang(t *testing.T) {
+
+	tests := []struct {
+		tag      string
+		expected i18nLang
+	}{
+		{"en", EN},
+		{"en-GB", EN},
+		{"EN_us", EN},
+		{"es", ES},
+		{"es-419", ES},
+		{"fr-FR", defaultLang},
+		{"", defaultLang},
+	}
+
+	for _, test := range tests {
+		if lang := parseLang(test.tag); lang != test.expected {
+			t.Fatalf("tag %q: expected %v, got %v", test.tag, test.expected, lang)
+		}
+	}
+}
+
+func TestLocKeysOnlyForSupportedTokens(t *testing.T) {
+
+	provider := NewMemoryPushProvider()
+	s := &Server{pushDispatcher: NewPushDispatcher(provider, 1, 10)}
+	s.pushDispatcher.Start()
+	defer s.pushDispatcher.Stop()
+
+	notification := &PushNotification{
+		Title:       T(EN, NotificationNewFriendTitle),
+		Body:        T(EN, NotificationNewFriendBody, "Ana"),
+		TitleLocKey: "notification.friend.new.title",
+		BodyLocKey:  "notification.friend.new.body",
+		BodyLocArgs: []string{"Ana"},
+	}
+
+	s.sendPush(1, model.NewIIDToken("new", "new-token", PUSH_LOC_KEYS_MIN_VERSION, PLATFORM_ANDROID),
+		&PushMessage{Notification: notification})
+	s.sendPush(1, model.NewIIDToken("old", "old-token", 2, PLATFORM_ANDROID),
+		&PushMessage{Notification: notification})
+
+	// Old tokens aren't sent notifications that only have loc keys
+	s.sendPush(1, model.NewIIDToken("old", "old-token", 2, PLATFORM_ANDROID),
+		&PushMessage{Notification: &PushNotification{TitleLocKey: "key"}})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(provider.Messages()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	messages := provider.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %v", len(messages))
+	}
+
+	for _, message := range messages {
+		n := message.Notification
+		if n.Title != "New friend" || n.Body != "Ana and you are friends now" {
+			t.Fatalf("unexpected text %+v", n)
+		}
+		switch message.Token {
+		case "new-token":
+			if n.BodyLocKey == "" || len(n.BodyLocArgs) != 1 {
+				t.Fatalf("loc keys removed %+v", n)
+			}
+		case "old-token":
+			if n.TitleLocKey != "" || n.BodyLocKey != "" || n.BodyLocArgs != nil {
+				t.Fatalf("loc keys sent to old token %+v", n)
+			}
+		}
+	}
+
+	if notification.BodyLocKey == "" {
+		t.Fatal("original notification modified")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	imgserv "github.com/d3ce1t/areyouin-server/images_server"
 	"github.com/d3ce1t/areyouin-server/model"
 	proto "github.com/d3ce1t/areyouin-server/protocol"
+	restserv "github.com/d3ce1t/areyouin-server/rest_server"
 )
 
 // Server global configuration
@@ -78,18 +79,36 @@ func main() {
 		server.registerCallback(proto.M_MODIFY_EVENT, onModifyEvent)
 		server.registerCallback(proto.M_CANCEL_EVENT, onCancelEvent)
 		server.registerCallback(proto.M_INVITE_USERS, onInviteUsers)
+		server.registerCallback(proto.M_CANCEL_USERS_INVITATION, onCancelUsersInvitation)
 		server.registerCallback(proto.M_CONFIRM_ATTENDANCE, onConfirmAttendance)
+		server.registerCallback(proto.M_PROPOSE_EVENT_CHANGE, onProposeEventChange)
+		server.registerCallback(proto.M_VOTE_CHANGE, onVoteChange)
 		server.registerCallback(proto.M_GET_USER_FRIENDS, onGetUserFriends)
 		server.registerCallback(proto.M_GET_USER_ACCOUNT, onGetUserAccount)
 		server.registerCallback(proto.M_CHANGE_PROFILE_PICTURE, onChangeProfilePicture)
 		server.registerCallback(proto.M_CLOCK_REQUEST, onClockRequest)
 		server.registerCallback(proto.M_IID_TOKEN, onIIDTokenReceived)
+		server.registerCallback(proto.M_LIST_DEVICES, onListDevices)
+		server.registerCallback(proto.M_REVOKE_DEVICE, onRevokeDevice)
+		server.registerCallback(proto.M_GET_NOTIFICATION_SETTINGS, onGetNotificationSettings)
+		server.registerCallback(proto.M_SET_NOTIFICATION_SETTINGS, onSetNotificationSettings)
+		server.registerCallback(proto.M_MUTE_EVENT, onMuteEvent)
+		server.registerCallback(proto.M_POST_COMMENT, onPostComment)
+		server.registerCallback(proto.M_EDIT_COMMENT, onEditComment)
+		server.registerCallback(proto.M_DELETE_COMMENT, onDeleteComment)
+		server.registerCallback(proto.M_GET_COMMENTS, onGetComments)
 		server.registerCallback(proto.M_CHANGE_EVENT_PICTURE, onChangeEventPicture)
 		server.registerCallback(proto.M_SYNC_GROUPS, onSyncGroups)
 		server.registerCallback(proto.M_GET_GROUPS, onGetGroups)
 		server.registerCallback(proto.M_READ_EVENT, onReadEvent)
 		server.registerCallback(proto.M_LIST_PRIVATE_EVENTS, onListPrivateEvents)
 		server.registerCallback(proto.M_HISTORY_PRIVATE_EVENTS, onListEventsHistory)
+		server.registerCallback(proto.M_LIST_AUTHORED_EVENTS, onListAuthoredEvents)
+		server.registerCallback(proto.M_HISTORY_AUTHORED_EVENTS, onListAuthoredEventsHistory)
+		server.registerCallback(proto.M_SYNC_EVENTS, onSyncEvents)
+		server.registerCallback(proto.M_LIST_PUBLIC_EVENTS, onListPublicEvents)
+		server.registerCallback(proto.M_USER_POSITION, onUserPosition)
+		server.registerCallback(proto.M_USER_POSITION_RANGE, onUserPositionRange)
 		server.registerCallback(proto.M_CREATE_FRIEND_REQUEST, onFriendRequest)
 		server.registerCallback(proto.M_GET_FRIEND_REQUESTS, onListFriendRequests)
 		server.registerCallback(proto.M_CONFIRM_FRIEND_REQUEST, onConfirmFriendRequest)
@@ -102,6 +121,12 @@ func main() {
 		imagesServer := imgserv.NewServer(session, model, cfg)
 		go imagesServer.Run()
 
+		// Create REST API HTTP server and start
+		if cfg.RestEnabled() {
+			restServer := restserv.NewServer(session, model, cfg)
+			go restServer.Run()
+		}
+
 	}
 
 	// Create shell and start listening
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/d3ce1t/areyouin-server/model"
+	proto "github.com/d3ce1t/areyouin-server/protocol"
 	"github.com/d3ce1t/areyouin-server/utils"
 
 	"github.com/imkira/go-observer"
@@ -92,6 +93,26 @@ func (m *ModelObserver) processSignal(signal *model.Signal) {
 		collapseKey := fmt.Sprintf("event#%v#%v", signal.Data["EventID"], signal.Data["UserID"])
 		m.signalsQueue.AddWithKey(collapseKey, signal)
 
+	case model.SignalEventChangeProposed:
+		collapseKey := fmt.Sprintf("event-proposal#%v", signal.Data["EventID"])
+		m.signalsQueue.AddWithKey(collapseKey, signal)
+
+	case model.SignalVotingStatusChanged:
+		fallthrough
+	case model.SignalVotingFinished:
+		// Only last voting status is sent. Finished status replaces any
+		// pending status.
+		collapseKey := fmt.Sprintf("event-voting#%v", signal.Data["EventID"])
+		m.signalsQueue.AddWithKey(collapseKey, signal)
+
+	case model.SignalCommentPosted:
+		fallthrough
+	case model.SignalCommentEdited:
+		fallthrough
+	case model.SignalCommentDeleted:
+		// Comments are a conversation, so they aren't delayed
+		m.processCommentSignal(signal)
+
 	case model.SignalFriendRequestAccepted:
 		m.processFriendRequestAcceptedSignal(signal)
 
@@ -121,12 +142,33 @@ func (m *ModelObserver) processDelayedChanges() {
 		case model.SignalEventCancelled:
 			m.processEventCancelledSignal(signal)
 
+		case model.SignalEventParticipantsRemoved:
+			m.processParticipantsRemovedSignal(signal)
+
 		case model.SignalEventInfoChanged:
 			m.processEventChangedSignal(signal)
 
 		case model.SignalParticipantChanged:
 			m.processParticipantChangeSignal(signal)
 
+		case model.SignalEventChangeProposed:
+			m.processEventChangeProposedSignal(signal)
+
+		case model.SignalVotingStatusChanged:
+			m.processVotingStatusSignal(signal)
+
+		case model.SignalVotingFinished:
+			m.processVotingFinishedSignal(signal)
+
+		case model.SignalEventReminder:
+			m.processEventReminderSignal(signal)
+
+		case model.SignalParticipantsPromoted:
+			m.processParticipantsPromotedSignal(signal)
+
+		case model.SignalEventConfirmed:
+			m.processEventConfirmedSignal(signal)
+
 		case model.SignalNewFriendRequest:
 			m.processNewFriendRequestSignal(signal)
 
@@ -152,7 +194,7 @@ func (m *ModelObserver) processNewEventSignal(signal *model.Signal) {
 
 		go func(participantID int64) {
 			// Notification
-			sendNewEventNotification(event, participantID)
+			m.server.sendNewEventNotification(event, participantID)
 		}(pID)
 	}
 
@@ -169,20 +211,12 @@ func (m *ModelObserver) processNewEventSignal(signal *model.Signal) {
 		netParticipants := convParticipantList2Net(participantList)
 
 		for _, pID := range oldParticipants {
-
-			session := m.server.getSession(pID)
-			if session == nil {
-				continue
-			}
-
-			go func(session *AyiSession) {
-				message := session.NewMessage().AttendanceStatusWithNumGuests(event.Id(), netParticipants, event.NumGuests())
-				if ok := session.Write(message); ok {
-					log.Printf("< (%v) EVENT %v ATTENDANCE STATUS CHANGED (%v participants changed)\n", session.UserId, event.Id(), len(netParticipants))
-				} else {
-					log.Println("* processNewEventSignal: Coudn't send message to", session.UserId)
+			go func(userID int64) {
+				message := m.server.NewMessage().AttendanceStatusWithNumGuests(event.Id(), netParticipants, event.NumGuests())
+				if m.server.writeToUser(userID, message) {
+					log.Printf("< (%v) EVENT %v ATTENDANCE STATUS CHANGED (%v participants changed)\n", userID, event.Id(), len(netParticipants))
 				}
-			}(session)
+			}(pID)
 		}
 	}
 }
@@ -194,13 +228,64 @@ func (m *ModelObserver) processEventCancelledSignal(signal *model.Signal) {
 
 	for _, pID := range event.Participants.Ids() {
 
-		if pID == cancelledBy {
+		// Author didn't cancel it when quorum wasn't reached, so author is
+		// notified as well
+		if pID == cancelledBy && !event.IsQuorumFailed() {
 			continue
 		}
 
 		go func(participantID int64) {
 			// Notification
-			sendEventCancelledNotification(event, participantID)
+			m.server.sendEventCancelledNotification(event, participantID)
+		}(pID)
+	}
+}
+
+func (m *ModelObserver) processEventConfirmedSignal(signal *model.Signal) {
+
+	event := signal.Data["Event"].(*model.Event)
+
+	for _, pID := range event.Participants.Ids() {
+		go func(userID int64) {
+
+			// Notification
+			m.server.sendEventConfirmedNotification(event, userID)
+
+			if m.writeEventModified(userID, event) {
+				log.Printf("< (%v) EVENT %v CONFIRMED\n", userID, event.Id())
+			}
+
+		}(pID)
+	}
+}
+
+func (m *ModelObserver) processParticipantsRemovedSignal(signal *model.Signal) {
+
+	event := signal.Data["Event"].(*model.Event)
+	removedParticipants := signal.Data["RemovedParticipants"].([]int64)
+
+	// Send invitation cancelled to removed participants
+	for _, pID := range removedParticipants {
+		go func(userID int64) {
+			if m.server.writeToUser(userID, m.server.NewMessage().InvitationCancelled(event.Id())) {
+				log.Printf("< (%v) EVENT %v INVITATION CANCELLED\n", userID, event.Id())
+			}
+		}(pID)
+	}
+
+	// Send participants change to remaining participants
+	for _, pID := range event.Participants.Ids() {
+
+		// Author already received the change as part of the request
+		if pID == event.AuthorID() {
+			continue
+		}
+
+		go func(userID int64) {
+			message := m.server.NewMessage().ParticipantsRemoved(event.Id(), removedParticipants, event.NumGuests())
+			if m.server.writeToUser(userID, message) {
+				log.Printf("< (%v) EVENT %v ATTENDANCE STATUS CHANGED (%v participants removed)\n", userID, event.Id(), len(removedParticipants))
+			}
 		}(pID)
 	}
 }
@@ -227,44 +312,204 @@ func (m *ModelObserver) processParticipantChangeSignal(signal *model.Signal) {
 
 		go func(userID int64) {
 
-			// Notification
-			if participant.Id() != userID && oldParticipant.Response() != participant.Response() {
-				sendEventResponseNotification(event, participant.Id(), userID)
+			// Notification. Nobody is told that a participant on the waitlist will attend.
+			if participant.Id() != userID && oldParticipant.Response() != participant.Response() &&
+				!event.Participants.IsWaitlisted(participant.Id()) {
+				m.server.sendEventResponseNotification(event, participant.Id(), userID)
 			}
 
-			if session := m.server.getSession(userID); session != nil {
-				message := session.NewMessage().AttendanceStatus(event.Id(), netParticipant)
-				if ok := session.Write(message); ok {
-					log.Printf("< (%v) EVENT %v ATTENDANCE STATUS (%v participants changed)\n", session.UserId, event.Id(), len(netParticipant))
-				} else {
-					log.Println("* processParticipantChangeSignal: Coudn't send message to", session.UserId)
-				}
+			message := m.server.NewMessage().AttendanceStatusWithWaitlist(event.Id(), netParticipant,
+				event.Capacity(), event.Participants.Waitlist())
+			if m.server.writeToUser(userID, message) {
+				log.Printf("< (%v) EVENT %v ATTENDANCE STATUS (%v participants changed)\n", userID, event.Id(), len(netParticipant))
 			}
 
 		}(pID)
 	}
 }
 
+// Promoted participants are notified that they are in. Everyone gets the new
+// waitlist.
+func (m *ModelObserver) processParticipantsPromotedSignal(signal *model.Signal) {
+
+	event := signal.Data["Event"].(*model.Event)
+	promoted := signal.Data["Promoted"].([]int64)
+
+	participantList := make(map[int64]*model.Participant)
+	for _, pID := range promoted {
+		participantList[pID], _ = event.Participants.Get(pID)
+	}
+	netParticipants := convParticipantList2Net(participantList)
+
+	for _, pID := range promoted {
+		go m.server.sendWaitlistPromotedNotification(event, pID)
+	}
+
+	for _, pID := range event.Participants.Ids() {
+		go func(userID int64) {
+			message := m.server.NewMessage().AttendanceStatusWithWaitlist(event.Id(), netParticipants,
+				event.Capacity(), event.Participants.Waitlist())
+			if m.server.writeToUser(userID, message) {
+				log.Printf("< (%v) EVENT %v ATTENDANCE STATUS (%v participants promoted)\n", userID, event.Id(), len(promoted))
+			}
+		}(pID)
+	}
+}
+
 func (m *ModelObserver) processEventChangedSignal(signal *model.Signal) {
 
 	event := signal.Data["Event"].(*model.Event)
-	liteEvent := convEvent2Net(event.CloneWithEmptyParticipants())
 
 	for _, pID := range event.Participants.Ids() {
+		go func(userID int64) {
+			if m.writeEventModified(userID, event) {
+				log.Printf("< (%v) EVENT %v CHANGED\n", userID, event.Id())
+			}
+		}(pID)
+	}
+}
+
+// Writes EVENT_MODIFIED to userID. Participants are only included for
+// sessions that support CAP_FULL_EVENT_MODIFIED. Outboxes keep the event
+// without them, because the client that will resume is unknown.
+func (m *ModelObserver) writeEventModified(userID int64, event *model.Event) bool {
 
-		session := m.server.getSession(pID)
-		if session == nil {
+	liteMessage := m.server.NewMessage().EventModified(convEvent2Net(event.CloneWithEmptyParticipants()))
+	fullMessage := m.server.NewMessage().EventModified(convEvent2Net(event))
+
+	return m.server.writeToUserSessions(userID, liteMessage, func(session *AyiSession) *proto.AyiPacket {
+		if session.Supports(CAP_FULL_EVENT_MODIFIED) {
+			return fullMessage
+		}
+		return liteMessage
+	})
+}
+
+func (m *ModelObserver) processEventChangeProposedSignal(signal *model.Signal) {
+
+	event := signal.Data["Event"].(*model.Event)
+	proposal := signal.Data["Proposal"].(*model.ChangeProposal)
+	netProposal := convChangeProposal2Net(proposal)
+
+	for _, pID := range event.Participants.Ids() {
+
+		// Author already received the proposal as part of the request
+		if pID == proposal.AuthorID() {
 			continue
 		}
 
-		go func(session *AyiSession) {
-			msg := session.NewMessage().EventModified(liteEvent)
-			if session.Write(msg) {
-				log.Printf("< (%v) EVENT %v CHANGED\n", session.UserId, event.Id())
+		go func(userID int64) {
+
+			// Notification
+			m.server.sendChangeProposedNotification(event, proposal, userID)
+
+			if m.server.writeToUser(userID, m.server.NewMessage().EventChangeProposed(netProposal)) {
+				log.Printf("< (%v) EVENT %v CHANGE PROPOSED (changeId: %v)\n", userID, event.Id(), proposal.Id())
+			}
+
+		}(pID)
+	}
+}
+
+func (m *ModelObserver) processVotingStatusSignal(signal *model.Signal) {
+
+	event := signal.Data["Event"].(*model.Event)
+	proposal := signal.Data["Proposal"].(*model.ChangeProposal)
+	netStatus := convVotingStatus2Net(proposal)
+
+	for _, pID := range event.Participants.Ids() {
+		go func(userID int64) {
+			if m.server.writeToUser(userID, m.server.NewMessage().VotingStatus(netStatus)) {
+				log.Printf("< (%v) EVENT %v VOTING STATUS (changeId: %v, votes: %v/%v)\n", userID,
+					event.Id(), proposal.Id(), proposal.VotesReceived(), proposal.VotesTotal())
+			}
+		}(pID)
+	}
+}
+
+func (m *ModelObserver) processVotingFinishedSignal(signal *model.Signal) {
+
+	event := signal.Data["Event"].(*model.Event)
+	proposal := signal.Data["Proposal"].(*model.ChangeProposal)
+	netStatus := convVotingStatus2Net(proposal)
+
+	for _, pID := range event.Participants.Ids() {
+
+		go func(userID int64) {
+
+			// Notification
+			m.server.sendVotingFinishedNotification(event, proposal, userID)
+
+			m.server.writeToUser(userID, m.server.NewMessage().VotingFinished(netStatus))
+
+			if proposal.IsAccepted() {
+				if m.server.writeToUser(userID, m.server.NewMessage().ChangeAccepted(event.Id(), proposal.Id())) {
+					log.Printf("< (%v) EVENT %v CHANGE ACCEPTED (changeId: %v)\n", userID, event.Id(), proposal.Id())
+				}
 			} else {
-				log.Println("processEventChangedSignal: Coudn't send message to", session.UserId)
+				if m.server.writeToUser(userID, m.server.NewMessage().ChangeDiscarded(event.Id(), proposal.Id())) {
+					log.Printf("< (%v) EVENT %v CHANGE DISCARDED (changeId: %v)\n", userID, event.Id(), proposal.Id())
+				}
+			}
+
+		}(pID)
+	}
+}
+
+func (m *ModelObserver) processEventReminderSignal(signal *model.Signal) {
+
+	event := signal.Data["Event"].(*model.Event)
+	reminder := signal.Data["Reminder"].(*model.EventReminder)
+
+	for _, pID := range event.Participants.Ids() {
+
+		participant, _ := event.Participants.Get(pID)
+		if !reminder.IsRecipient(participant) {
+			continue
+		}
+
+		go m.server.sendEventReminderNotification(event, reminder, pID)
+	}
+}
+
+// Comment changes are sent to online participants. Offline participants are
+// only notified of new comments.
+func (m *ModelObserver) processCommentSignal(signal *model.Signal) {
+
+	event := signal.Data["Event"].(*model.Event)
+	comment := signal.Data["Comment"].(*model.Comment)
+	userID := signal.Data["UserID"].(int64)
+	netComment := convComment2Net(comment)
+
+	for _, pID := range event.Participants.Ids() {
+
+		// User already received the change as part of the request
+		if pID == userID {
+			continue
+		}
+
+		go func(participantID int64) {
+
+			var message *proto.AyiPacket
+			var action string
+
+			switch signal.Type {
+			case model.SignalCommentPosted:
+				message, action = m.server.NewMessage().CommentPosted(netComment), "POSTED"
+			case model.SignalCommentEdited:
+				message, action = m.server.NewMessage().CommentEdited(netComment), "EDITED"
+			case model.SignalCommentDeleted:
+				message, action = m.server.NewMessage().CommentDeleted(event.Id(), comment.Id()), "DELETED"
 			}
-		}(session)
+
+			if m.server.writeToUser(participantID, message) {
+				log.Printf("< (%v) EVENT %v COMMENT %v (commentId: %v)\n", participantID, event.Id(), action, comment.Id())
+			} else if signal.Type == model.SignalCommentPosted {
+				// Notification
+				m.server.sendEventCommentNotification(event, comment, participantID)
+			}
+
+		}(pID)
 	}
 }
 
@@ -275,11 +520,11 @@ func (m *ModelObserver) processNewFriendRequestSignal(signal *model.Signal) {
 	friendRequest := signal.Data["FriendRequest"].(*model.FriendRequest)
 
 	// Notification
-	sendFriendRequestNotification(fromUser.Name(), toUser.Id())
+	m.server.sendFriendRequestNotification(fromUser.Name(), toUser.Id())
 
-	if session := m.server.getSession(toUser.Id()); session != nil {
-		session.Write(session.NewMessage().FriendRequestReceived(convFriendRequest2Net(friendRequest)))
-		log.Printf("< (%v) SEND FRIEND REQUEST: %v\n", session, friendRequest)
+	message := m.server.NewMessage().FriendRequestReceived(convFriendRequest2Net(friendRequest))
+	if m.server.writeToUser(toUser.Id(), message) {
+		log.Printf("< (%v) SEND FRIEND REQUEST: %v\n", toUser.Id(), friendRequest)
 	}
 }
 
@@ -291,7 +536,7 @@ func (m *ModelObserver) processFriendRequestAcceptedSignal(signal *model.Signal)
 	// Send Friend list to userID and notify with "you and friendName
 	// are now friends"
 	notifyFriend := func(userID int64, friendName string) {
-		sendNewFriendNotification(friendName, userID)
+		m.server.sendNewFriendNotification(friendName, userID)
 		m.sendFriends(userID)
 	}
 
@@ -317,7 +562,7 @@ func (m *ModelObserver) processFriendsImported(signal *model.Signal) {
 		if initialImport {
 			// Do another notification
 		} else {
-			sendNewFriendNotification(friendName, userID)
+			m.server.sendNewFriendNotification(friendName, userID)
 		}
 
 		m.sendFriends(userID)
@@ -343,17 +588,15 @@ func (m *ModelObserver) sendFriends(userID int64) {
 		}
 	}()
 
-	if session := m.server.getSession(userID); session != nil {
-
-		// May panic so defer was added above
-		friends, err := m.model.Friends.GetAllFriends(userID)
-		if err != nil {
-			log.Println("SendUserFriends Error:", err)
-			return
-		}
+	// May panic so defer was added above
+	friends, err := m.model.Friends.GetAllFriends(userID)
+	if err != nil {
+		log.Println("SendUserFriends Error:", err)
+		return
+	}
 
-		if len(friends) > 0 {
-			session.Write(session.NewMessage().FriendsList(convFriendList2Net(friends)))
+	if len(friends) > 0 {
+		if m.server.writeToUser(userID, m.server.NewMessage().FriendsList(convFriendList2Net(friends))) {
 			log.Printf("< (%v) SEND USER FRIENDS (num.friends: %v)\n", userID, len(friends))
 		}
 	}
@@ -1,23 +1,34 @@
 package main
 
 import (
-	"encoding/json"
+	"fmt"
 	"log"
+	"strconv"
+	"time"
 
 	"github.com/d3ce1t/areyouin-server/api"
 	"github.com/d3ce1t/areyouin-server/model"
-
-	gcm "github.com/google/go-gcm"
 )
 
-func createNewEventNotification(event *model.Event) *gcm.Notification {
+// Notifications carry their text rendered in recipient language and the loc
+// keys that clients use instead when they support them.
+
+func createNewEventNotification(event *model.Event, lang i18nLang) *PushNotification {
+
+	bodyArgs := []string{event.AuthorName()}
 
-	bodyArgs, _ := json.Marshal([]string{event.AuthorName()})
+	// Guests other than author and recipient
+	otherGuests := event.NumGuests() - 2
+	if otherGuests < 0 {
+		otherGuests = 0
+	}
 
-	notification := &gcm.Notification{
+	notification := &PushNotification{
+		Title:       T(lang, NotificationNewEventTitle),
+		Body:        TN(lang, NotificationNewEventBody, otherGuests, event.AuthorName()),
 		TitleLocKey: "notification.event.new.title",
 		BodyLocKey:  "notification.event.new.body",
-		BodyLocArgs: string(bodyArgs),
+		BodyLocArgs: bodyArgs,
 		Icon:        "icon_notification_25dp", // Android only (drawable name)
 		Sound:       "default",
 		Color:       "#009688", // Android only
@@ -26,16 +37,18 @@ func createNewEventNotification(event *model.Event) *gcm.Notification {
 	return notification
 }
 
-func createEventCancelledNotification(event *model.Event) *gcm.Notification {
+func createEventCancelledNotification(event *model.Event, lang i18nLang) *PushNotification {
 
-	titleArgs, _ := json.Marshal([]string{event.Title()})
-	bodyArgs, _ := json.Marshal([]string{event.AuthorName()})
+	titleArgs := []string{event.Title()}
+	bodyArgs := []string{event.AuthorName()}
 
-	notification := &gcm.Notification{
+	notification := &PushNotification{
+		Title:        T(lang, NotificationEventCancelledTitle, event.Title()),
+		Body:         T(lang, NotificationEventCancelledBody, event.AuthorName()),
 		TitleLocKey:  "notification.event.cancelled.title",
-		TitleLocArgs: string(titleArgs),
+		TitleLocArgs: titleArgs,
 		BodyLocKey:   "notification.event.cancelled.body",
-		BodyLocArgs:  string(bodyArgs),
+		BodyLocArgs:  bodyArgs,
 		Icon:         "icon_notification_25dp", // Android only (drawable name)
 		Sound:        "default",
 		Color:        "#009688", // Android only
@@ -44,34 +57,141 @@ func createEventCancelledNotification(event *model.Event) *gcm.Notification {
 	return notification
 }
 
-func createEventResponseNotification(event *model.Event, participantID int64) *gcm.Notification {
+func createQuorumFailedNotification(event *model.Event, lang i18nLang) *PushNotification {
+
+	titleArgs := []string{event.Title()}
+	bodyArgs := []string{strconv.Itoa(event.Quorum())}
+
+	notification := &PushNotification{
+		Title:        T(lang, NotificationQuorumFailedTitle, event.Title()),
+		Body:         TN(lang, NotificationQuorumFailedBody, event.Quorum()),
+		TitleLocKey:  "notification.event.quorum.failed.title",
+		TitleLocArgs: titleArgs,
+		BodyLocKey:   "notification.event.quorum.failed.body",
+		BodyLocArgs:  bodyArgs,
+		Icon:         "icon_notification_25dp", // Android only (drawable name)
+		Sound:        "default",
+		Color:        "#009688", // Android only
+	}
+
+	return notification
+}
+
+func createEventConfirmedNotification(event *model.Event, lang i18nLang) *PushNotification {
+
+	titleArgs := []string{event.Title()}
+	bodyArgs := []string{strconv.Itoa(event.NumAttendees())}
+
+	notification := &PushNotification{
+		Title:        T(lang, NotificationEventConfirmedTitle, event.Title()),
+		Body:         TN(lang, NotificationEventConfirmedBody, event.NumAttendees()),
+		TitleLocKey:  "notification.event.confirmed.title",
+		TitleLocArgs: titleArgs,
+		BodyLocKey:   "notification.event.confirmed.body",
+		BodyLocArgs:  bodyArgs,
+		Icon:         "icon_notification_25dp", // Android only (drawable name)
+		Sound:        "default",
+		Color:        "#009688", // Android only
+	}
+
+	return notification
+}
+
+func createEventResponseNotification(event *model.Event, participantID int64, lang i18nLang) *PushNotification {
 
 	participant, _ := event.Participants.Get(participantID)
-	titleArgs, _ := json.Marshal([]string{event.Title()})
-	bodyArgs, _ := json.Marshal([]string{participant.Name()})
+	titleArgs := []string{event.Title()}
+	bodyArgs := []string{participant.Name()}
 
 	var titleKey, bodyKey string
+	var title, body string
 
 	switch participant.Response() {
 	case api.AttendanceResponse_ASSIST:
 		titleKey = "notification.event.response.assist.title"
 		bodyKey = "notification.event.response.assist.body"
+		title = T(lang, NotificationEventResponseAssistTitle, event.Title())
+		body = TN(lang, NotificationEventResponseAssistBody, event.NumAttendees(), participant.Name())
 	case api.AttendanceResponse_MAYBE:
 		titleKey = "notification.event.response.maybe.title"
 		bodyKey = "notification.event.response.maybe.body"
+		title = T(lang, NotificationEventResponseMaybeTitle, event.Title())
+		body = T(lang, NotificationEventResponseMaybeBody, participant.Name())
 	case api.AttendanceResponse_NO_ASSIST:
 		titleKey = "notification.event.response.no_assist.title"
 		bodyKey = "notification.event.response.no_assist.body"
+		title = T(lang, NotificationEventResponseNoAssistTitle, event.Title())
+		body = T(lang, NotificationEventResponseNoAssistBody, participant.Name())
 	case api.AttendanceResponse_NO_RESPONSE:
 		log.Println("* WARNING: createEventResponseNotification with NO_RESPONSE value")
 		return nil
 	}
 
-	notification := &gcm.Notification{
+	notification := &PushNotification{
+		Title:        title,
+		Body:         body,
 		TitleLocKey:  titleKey,
-		TitleLocArgs: string(titleArgs),
+		TitleLocArgs: titleArgs,
+		BodyLocKey:   bodyKey,
+		BodyLocArgs:  bodyArgs,
+		Icon:         "icon_notification_25dp", // Android only (drawable name)
+		Sound:        "default",
+		Color:        "#009688", // Android only
+	}
+
+	return notification
+}
+
+func createWaitlistPromotedNotification(event *model.Event, lang i18nLang) *PushNotification {
+
+	titleArgs := []string{event.Title()}
+
+	notification := &PushNotification{
+		Title:        T(lang, NotificationWaitlistPromotedTitle, event.Title()),
+		Body:         T(lang, NotificationWaitlistPromotedBody),
+		TitleLocKey:  "notification.event.waitlist.promoted.title",
+		TitleLocArgs: titleArgs,
+		BodyLocKey:   "notification.event.waitlist.promoted.body",
+		Icon:         "icon_notification_25dp", // Android only (drawable name)
+		Sound:        "default",
+		Color:        "#009688", // Android only
+	}
+
+	return notification
+}
+
+func createChangeProposedNotification(event *model.Event, proposal *model.ChangeProposal, lang i18nLang) *PushNotification {
+
+	var authorName string
+	if participant, ok := event.Participants.Get(proposal.AuthorID()); ok {
+		authorName = participant.Name()
+	}
+
+	titleArgs := []string{event.Title()}
+	bodyArgs := []string{authorName}
+
+	var bodyKey string
+	var body i18nKey
+
+	switch {
+	case proposal.IsDateChange() && proposal.IsDescriptionChange():
+		bodyKey = "notification.event.change_proposed.body"
+		body = NotificationChangeProposedBody
+	case proposal.IsDateChange():
+		bodyKey = "notification.event.change_date_proposed.body"
+		body = NotificationChangeDateProposedBody
+	default:
+		bodyKey = "notification.event.change_message_proposed.body"
+		body = NotificationChangeMessageProposedBody
+	}
+
+	notification := &PushNotification{
+		Title:        T(lang, NotificationChangeProposedTitle, event.Title()),
+		Body:         T(lang, body, authorName),
+		TitleLocKey:  "notification.event.change_proposed.title",
+		TitleLocArgs: titleArgs,
 		BodyLocKey:   bodyKey,
-		BodyLocArgs:  string(bodyArgs),
+		BodyLocArgs:  bodyArgs,
 		Icon:         "icon_notification_25dp", // Android only (drawable name)
 		Sound:        "default",
 		Color:        "#009688", // Android only
@@ -80,14 +200,45 @@ func createEventResponseNotification(event *model.Event, participantID int64) *g
 	return notification
 }
 
-func createFriendRequestdNotification(friendName string) *gcm.Notification {
+func createVotingFinishedNotification(event *model.Event, proposal *model.ChangeProposal, lang i18nLang) *PushNotification {
+
+	titleArgs := []string{event.Title()}
 
-	bodyArgs, _ := json.Marshal([]string{friendName})
+	var bodyKey string
+	var body i18nKey
 
-	notification := &gcm.Notification{
+	if proposal.IsAccepted() {
+		bodyKey = "notification.event.change_accepted.body"
+		body = NotificationChangeAcceptedBody
+	} else {
+		bodyKey = "notification.event.change_discarded.body"
+		body = NotificationChangeDiscardedBody
+	}
+
+	notification := &PushNotification{
+		Title:        T(lang, NotificationVotingFinishedTitle, event.Title()),
+		Body:         T(lang, body),
+		TitleLocKey:  "notification.event.voting_finished.title",
+		TitleLocArgs: titleArgs,
+		BodyLocKey:   bodyKey,
+		Icon:         "icon_notification_25dp", // Android only (drawable name)
+		Sound:        "default",
+		Color:        "#009688", // Android only
+	}
+
+	return notification
+}
+
+func createFriendRequestdNotification(friendName string, lang i18nLang) *PushNotification {
+
+	bodyArgs := []string{friendName}
+
+	notification := &PushNotification{
+		Title:       T(lang, NotificationNewFriendRequestTitle),
+		Body:        T(lang, NotificationNewFriendRequestBody, friendName),
 		TitleLocKey: "notification.friend_request.new.title",
 		BodyLocKey:  "notification.friend_request.new.body",
-		BodyLocArgs: string(bodyArgs),
+		BodyLocArgs: bodyArgs,
 		Icon:        "icon_notification_25dp", // Android only (drawable name)
 		Sound:       "default",
 		Color:       "#009688", // Android only
@@ -96,14 +247,16 @@ func createFriendRequestdNotification(friendName string) *gcm.Notification {
 	return notification
 }
 
-func createNewFriendNotification(friendName string) *gcm.Notification {
+func createNewFriendNotification(friendName string, lang i18nLang) *PushNotification {
 
-	bodyArgs, _ := json.Marshal([]string{friendName})
+	bodyArgs := []string{friendName}
 
-	notification := &gcm.Notification{
+	notification := &PushNotification{
+		Title:       T(lang, NotificationNewFriendTitle),
+		Body:        T(lang, NotificationNewFriendBody, friendName),
 		TitleLocKey: "notification.friend.new.title",
 		BodyLocKey:  "notification.friend.new.body",
-		BodyLocArgs: string(bodyArgs),
+		BodyLocArgs: bodyArgs,
 		Icon:        "icon_notification_25dp", // Android only (drawable name)
 		Sound:       "default",
 		Color:       "#009688", // Android only
@@ -112,6 +265,75 @@ func createNewFriendNotification(friendName string) *gcm.Notification {
 	return notification
 }
 
-/*func createFriendJoinedNotification(friendName string) *gcm.Notification {
+func createEventReminderNotification(event *model.Event, reminder *model.EventReminder, lang i18nLang) *PushNotification {
+
+	titleArgs := []string{event.Title()}
+
+	var bodyKey, body string
+	var bodyArgs []string
+
+	if reminder.Type() == api.ReminderType_NUDGE {
+		bodyKey = "notification.event.nudge.body"
+		body = T(lang, NotificationEventNudgeBody, event.AuthorName())
+		bodyArgs = []string{event.AuthorName()}
+	} else {
+		var unit string
+		var key i18nKey
+		var count int
+		offset := reminder.Offset()
+
+		switch {
+		case offset >= 24*time.Hour && offset%(24*time.Hour) == 0:
+			unit, key, count = "days", NotificationEventStartsInDaysBody, int(offset/(24*time.Hour))
+		case offset >= time.Hour:
+			unit, key, count = "hours", NotificationEventStartsInHoursBody, int(offset.Round(time.Hour)/time.Hour)
+		default:
+			unit, key, count = "minutes", NotificationEventStartsInMinutesBody, int(offset/time.Minute)
+		}
+
+		bodyKey = "notification.event.reminder." + unit + ".body"
+		body = TN(lang, key, count)
+		bodyArgs = []string{strconv.Itoa(count)}
+	}
+
+	notification := &PushNotification{
+		Title:        T(lang, NotificationEventReminderTitle, event.Title()),
+		Body:         body,
+		TitleLocKey:  "notification.event.reminder.title",
+		TitleLocArgs: titleArgs,
+		BodyLocKey:   bodyKey,
+		BodyLocArgs:  bodyArgs,
+		Icon:         "icon_notification_25dp", // Android only (drawable name)
+		Sound:        "default",
+		Color:        "#009688", // Android only
+	}
+
+	return notification
+}
+
+// Comments of the same event share a tag, so that the last one replaces any
+// previous one still shown
+func createEventCommentNotification(event *model.Event, comment *model.Comment, lang i18nLang) *PushNotification {
+
+	titleArgs := []string{event.Title()}
+	bodyArgs := []string{comment.AuthorName(), comment.Message()}
+
+	notification := &PushNotification{
+		Title:        T(lang, NotificationEventCommentTitle, event.Title()),
+		Body:         T(lang, NotificationEventCommentBody, comment.AuthorName(), comment.Message()),
+		TitleLocKey:  "notification.event.comment.title",
+		TitleLocArgs: titleArgs,
+		BodyLocKey:   "notification.event.comment.body",
+		BodyLocArgs:  bodyArgs,
+		Icon:         "icon_notification_25dp", // Android only (drawable name)
+		Sound:        "default",
+		Color:        "#009688", // Android only
+		Tag:          fmt.Sprintf("comments#%v", event.Id()),
+	}
+
+	return notification
+}
+
+/*func createFriendJoinedNotification(friendName string) *PushNotification {
 
 }*/
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/twinj/uuid"
+)
+
+const (
+	OUTBOX_MAX_PACKETS    = 100
+	OUTBOX_MAX_AGE        = 24 * time.Hour
+	OUTBOX_SWEEP_INTERVAL = time.Hour
+)
+
+// An outbox keeps server initiated packets (EventModified, AttendanceStatus,
+// FriendRequestReceived...) that couldn't be written to a user because it was
+// disconnected. They are replayed in order when the user authenticates again
+// presenting the resume token issued to its previous session. Each device of
+// a user has its own outbox.
+//
+// Outboxes are kept in memory only. They are lost when the server restarts, so
+// resume tokens issued before that are rejected and clients do a full sync.
+type outbox struct {
+	resumeToken string
+	packets     []*proto.AyiPacket
+	overflow    bool      // Packets were discarded, so outbox cannot be resumed
+	replaying   bool      // Packets are being replayed, so new ones must wait
+	since       time.Time // When first packet was kept
+	lastSeen    time.Time // When device was last seen connected
+}
+
+func (o *outbox) expired() bool {
+	return len(o.packets) > 0 && time.Since(o.since) > OUTBOX_MAX_AGE
+}
+
+// OutboxMap holds outboxes of devices authenticated since server started
+type OutboxMap struct {
+	mutex sync.Mutex
+	m     map[int64]map[string]*outbox
+}
+
+func NewOutboxMap() *OutboxMap {
+	return &OutboxMap{
+		m: make(map[int64]map[string]*outbox),
+	}
+}
+
+// Reset issues a new resume token for deviceID of userID. If resumeToken is
+// the current one and no packet was discarded, kept packets are preserved to
+// be taken with Take and its number is returned. Otherwise they are dropped
+// and the client must do a full sync. In both cases, packets for the device
+// are kept with AddIfReplaying until Take finds the outbox empty, so that they
+// aren't written ahead of the replayed ones.
+func (om *OutboxMap) Reset(userID int64, deviceID string, resumeToken string) (newToken string, missed int, resumed bool) {
+
+	defer om.mutex.Unlock()
+	om.mutex.Lock()
+
+	devices, ok := om.m[userID]
+	if !ok {
+		devices = make(map[string]*outbox)
+		om.m[userID] = devices
+	}
+
+	box, ok := devices[deviceID]
+
+	resumed = ok && resumeToken != "" && resumeToken == box.resumeToken &&
+		!box.overflow && !box.expired()
+
+	if !resumed {
+		box = &outbox{}
+		devices[deviceID] = box
+	}
+
+	box.resumeToken = uuid.NewV4().String()
+	box.replaying = true
+	box.lastSeen = time.Now()
+	return box.resumeToken, len(box.packets), resumed
+}
+
+// Devices returns devices of userID that have an outbox
+func (om *OutboxMap) Devices(userID int64) []string {
+
+	defer om.mutex.Unlock()
+	om.mutex.Lock()
+
+	devices := make([]string, 0, len(om.m[userID]))
+	for deviceID := range om.m[userID] {
+		devices = append(devices, deviceID)
+	}
+
+	return devices
+}
+
+// Add keeps packet in the outbox of deviceID of userID. Returns false if the
+// device has no outbox or it cannot keep more packets.
+func (om *OutboxMap) Add(userID int64, deviceID string, packet *proto.AyiPacket) bool {
+
+	defer om.mutex.Unlock()
+	om.mutex.Lock()
+
+	return om.add(userID, deviceID, packet)
+}
+
+// AddIfReplaying keeps packet in the outbox of deviceID of userID only if its
+// packets are being replayed. Returns false if packet can be written to the
+// session of the device instead.
+func (om *OutboxMap) AddIfReplaying(userID int64, deviceID string, packet *proto.AyiPacket) bool {
+
+	defer om.mutex.Unlock()
+	om.mutex.Lock()
+
+	box, ok := om.m[userID][deviceID]
+	if !ok || !box.replaying {
+		return false
+	}
+
+	return om.add(userID, deviceID, packet)
+}
+
+func (om *OutboxMap) add(userID int64, deviceID string, packet *proto.AyiPacket) bool {
+
+	box, ok := om.m[userID][deviceID]
+	if !ok || box.overflow {
+		return false
+	}
+
+	if box.expired() {
+		delete(om.m[userID], deviceID)
+		if len(om.m[userID]) == 0 {
+			delete(om.m, userID)
+		}
+		return false
+	}
+
+	if len(box.packets) >= OUTBOX_MAX_PACKETS {
+		box.packets = nil
+		box.overflow = true
+		return false
+	}
+
+	if len(box.packets) == 0 {
+		box.since = time.Now()
+	}
+
+	box.packets = append(box.packets, packet)
+	return true
+}
+
+// Take removes and returns packets kept for deviceID of userID in the order
+// they were added. If there are no packets, replay is finished.
+func (om *OutboxMap) Take(userID int64, deviceID string) []*proto.AyiPacket {
+
+	defer om.mutex.Unlock()
+	om.mutex.Lock()
+
+	box, ok := om.m[userID][deviceID]
+	if !ok {
+		return nil
+	}
+
+	if len(box.packets) == 0 {
+		box.replaying = false
+	}
+
+	packets := box.packets
+	box.packets = nil
+	return packets
+}
+
+// PutBack returns packets taken with Take that couldn't be written to the
+// outbox of deviceID of userID. They are kept ahead of packets added meanwhile
+// and replay is finished.
+func (om *OutboxMap) PutBack(userID int64, deviceID string, packets []*proto.AyiPacket) {
+
+	defer om.mutex.Unlock()
+	om.mutex.Lock()
+
+	box, ok := om.m[userID][deviceID]
+	if !ok {
+		return
+	}
+
+	box.replaying = false
+
+	if box.overflow || len(packets) == 0 {
+		return
+	}
+
+	if len(packets)+len(box.packets) > OUTBOX_MAX_PACKETS {
+		box.packets = nil
+		box.overflow = true
+		return
+	}
+
+	box.packets = append(append([]*proto.AyiPacket{}, packets...), box.packets...)
+}
+
+// Sweep removes outboxes whose packets are older than OUTBOX_MAX_AGE, and
+// outboxes of disconnected devices that cannot be resumed or have been empty
+// since the device was last seen OUTBOX_MAX_AGE ago. Returns the number of
+// outboxes removed.
+func (om *OutboxMap) Sweep(connected func(userID int64, deviceID string) bool) int {
+
+	defer om.mutex.Unlock()
+	om.mutex.Lock()
+
+	removed := 0
+
+	for userID, devices := range om.m {
+		for deviceID, box := range devices {
+
+			isConnected := connected(userID, deviceID)
+			if isConnected {
+				box.lastSeen = time.Now()
+			}
+
+			unused := !isConnected && (box.overflow ||
+				len(box.packets) == 0 && time.Since(box.lastSeen) > OUTBOX_MAX_AGE)
+
+			if box.expired() || unused {
+				delete(devices, deviceID)
+				removed++
+			}
+		}
+
+		if len(devices) == 0 {
+			delete(om.m, userID)
+		}
+	}
+
+	return removed
+}
+
+// Removes outboxes that aren't useful anymore every OUTBOX_SWEEP_INTERVAL
+func (s *Server) sweepOutboxes() {
+	for range time.Tick(OUTBOX_SWEEP_INTERVAL) {
+		removed := s.outboxes.Sweep(func(userID int64, deviceID string) bool {
+			_, ok := s.sessions.Get(userID, deviceID)
+			return ok
+		})
+		if removed > 0 {
+			log.Printf("* Removed %v unused outboxes\n", removed)
+		}
+	}
+}
+
+// Server initiated message. Header version is set when it's written to a
+// session.
+func (s *Server) NewMessage() proto.MessageBuilder {
+	return proto.NewPacket(proto.VERSION_3)
+}
+
+// Writes a server initiated packet to every session of userID. Devices of
+// the user that aren't connected, or whose write fails, keep the packet in
+// their outbox so that it is replayed when their session is resumed. Returns
+// true if the packet was written to at least one session.
+func (s *Server) writeToUser(userID int64, packet *proto.AyiPacket) bool {
+	return s.writeToUserSessions(userID, packet, func(*AyiSession) *proto.AyiPacket {
+		return packet
+	})
+}
+
+// Like writeToUser, but the packet written to each session is built by
+// buildPacket, so that it may depend on what the session supports. Outboxes
+// of devices that aren't connected keep packet.
+func (s *Server) writeToUserSessions(userID int64, packet *proto.AyiPacket,
+	buildPacket func(session *AyiSession) *proto.AyiPacket) bool {
+
+	written := make(map[string]bool)
+
+	for _, session := range s.getSessions(userID) {
+		// Packets missed by the device are being replayed, so this one goes
+		// after them
+		if s.outboxes.AddIfReplaying(userID, session.DeviceID, buildPacket(session).Copy()) {
+			written[session.DeviceID] = true
+			continue
+		}
+		// Each session sets its own version and token
+		sessionPacket := buildPacket(session).Copy()
+		sessionPacket.Header.SetVersion(uint32(session.ProtocolVersion))
+		if session.Write(sessionPacket) {
+			written[session.DeviceID] = true
+		} else {
+			log.Printf("* (%v) Coudn't send %v (device: %v)\n", userID, packet.Type(), session.DeviceID)
+		}
+	}
+
+	for _, deviceID := range s.outboxes.Devices(userID) {
+		if !written[deviceID] && s.outboxes.Add(userID, deviceID, packet.Copy()) {
+			log.Printf("* (%v) %v kept in outbox (device: %v)\n", userID, packet.Type(), deviceID)
+		}
+	}
+
+	return len(written) > 0
+}
+
+// Replays packets missed by a session while its user was disconnected.
+// Packets sent to the user meanwhile are kept in the outbox and replayed
+// too, so that order is preserved.
+func (s *Server) replayOutbox(session *AyiSession) {
+
+	replayed := 0
+	packets := s.outboxes.Take(session.UserId, session.DeviceID)
+
+	for len(packets) > 0 {
+		for i, packet := range packets {
+			packet.Header.SetVersion(uint32(session.ProtocolVersion))
+			if !session.Write(packet) {
+				// Keep unsent packets for the next session
+				s.outboxes.PutBack(session.UserId, session.DeviceID, packets[i:])
+				log.Printf("* (%v) Outbox replay interrupted (unsent: %v)\n", session, len(packets)-i)
+				return
+			}
+		}
+		replayed += len(packets)
+		packets = s.outboxes.Take(session.UserId, session.DeviceID)
+	}
+
+	if replayed > 0 {
+		log.Printf("< (%v) REPLAYED %v MISSED PACKETS\n", session, replayed)
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+)
+
+func TestOutboxResume(t *testing.T) {
+
+	om := NewOutboxMap()
+	packet := proto.NewPacket(proto.VERSION_3).EventExpired(1)
+
+	// No outbox until user authenticates
+	if om.Add(100, "phone", packet) {
+		t.Fatal("packet kept for unknown user")
+	}
+
+	token, missed, resumed := om.Reset(100, "phone", "")
+	if token == "" || missed != 0 || resumed {
+		t.Fatalf("unexpected reset (%v, %v, %v)", token, missed, resumed)
+	}
+
+	for i := int64(1); i <= 3; i++ {
+		if !om.Add(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(i)) {
+			t.Fatal("packet not kept")
+		}
+	}
+
+	newToken, missed, resumed := om.Reset(100, "phone", token)
+	if newToken == token || missed != 3 || !resumed {
+		t.Fatalf("unexpected resume (%v, %v, %v)", newToken, missed, resumed)
+	}
+
+	packets := om.Take(100, "phone")
+	if len(packets) != 3 {
+		t.Fatalf("expected 3 packets, got %v", len(packets))
+	}
+
+	for i, p := range packets {
+		msg, _ := p.DecodeMessage()
+		if msg.(*proto.EventExpired).EventId != int64(i+1) {
+			t.Fatalf("packets out of order: %v", msg)
+		}
+	}
+
+	if len(om.Take(100, "phone")) != 0 {
+		t.Fatal("packets not removed")
+	}
+}
+
+func TestOutboxInvalidToken(t *testing.T) {
+
+	om := NewOutboxMap()
+	om.Reset(100, "phone", "")
+	om.Add(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(1))
+
+	if _, missed, resumed := om.Reset(100, "phone", "invalid"); resumed || missed != 0 {
+		t.Fatal("session resumed with invalid token")
+	}
+
+	if len(om.Take(100, "phone")) != 0 {
+		t.Fatal("packets not dropped")
+	}
+}
+
+func TestOutboxOverflow(t *testing.T) {
+
+	om := NewOutboxMap()
+	token, _, _ := om.Reset(100, "phone", "")
+
+	for i := 0; i < OUTBOX_MAX_PACKETS; i++ {
+		om.Add(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(int64(i)))
+	}
+
+	if om.Add(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(0)) {
+		t.Fatal("packet kept in a full outbox")
+	}
+
+	if _, _, resumed := om.Reset(100, "phone", token); resumed {
+		t.Fatal("overflowed outbox resumed")
+	}
+}
+
+func TestOutboxPutBack(t *testing.T) {
+
+	om := NewOutboxMap()
+	om.Reset(100, "phone", "")
+
+	for i := int64(1); i <= 3; i++ {
+		om.Add(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(i))
+	}
+
+	// First packet was written, the others weren't
+	packets := om.Take(100, "phone")
+	om.Add(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(4))
+	om.PutBack(100, "phone", packets[1:])
+
+	packets = om.Take(100, "phone")
+	if len(packets) != 3 {
+		t.Fatalf("expected 3 packets, got %v", len(packets))
+	}
+
+	for i, p := range packets {
+		msg, _ := p.DecodeMessage()
+		if msg.(*proto.EventExpired).EventId != int64(i+2) {
+			t.Fatalf("packets out of order: %v", msg)
+		}
+	}
+}
+
+func TestOutboxAddIfReplaying(t *testing.T) {
+
+	om := NewOutboxMap()
+
+	if om.AddIfReplaying(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(1)) {
+		t.Fatal("packet kept for unknown user")
+	}
+
+	token, _, _ := om.Reset(100, "phone", "")
+	om.Add(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(1))
+	om.Reset(100, "phone", token)
+
+	// Replay in progress
+	packets := om.Take(100, "phone")
+	if !om.AddIfReplaying(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(2)) {
+		t.Fatal("packet not kept while replaying")
+	}
+
+	packets = append(packets, om.Take(100, "phone")...)
+	if len(packets) != 2 {
+		t.Fatalf("expected 2 packets, got %v", len(packets))
+	}
+
+	// Empty outbox finishes replay
+	if len(om.Take(100, "phone")) != 0 {
+		t.Fatal("packets not removed")
+	}
+
+	if om.AddIfReplaying(100, "phone", proto.NewPacket(proto.VERSION_3).EventExpired(3)) {
+		t.Fatal("packet kept after replay finished")
+	}
+}
+
+func TestOutboxSweep(t *testing.T) {
+
+	om := NewOutboxMap()
+	connected := map[string]bool{"online": true}
+	isConnected := func(userID int64, deviceID string) bool {
+		return connected[deviceID]
+	}
+
+	for _, deviceID := range []string{"online", "recent", "idle", "expired", "pending", "overflow"} {
+		om.Reset(100, deviceID, "")
+		om.Take(100, deviceID) // Finish replay
+	}
+
+	longAgo := time.Now().Add(-OUTBOX_MAX_AGE - time.Minute)
+
+	om.m[100]["online"].lastSeen = longAgo
+	om.m[100]["idle"].lastSeen = longAgo
+
+	om.Add(100, "expired", proto.NewPacket(proto.VERSION_3).EventExpired(1))
+	om.m[100]["expired"].since = longAgo
+
+	om.Add(100, "pending", proto.NewPacket(proto.VERSION_3).EventExpired(1))
+	om.m[100]["pending"].lastSeen = longAgo
+
+	om.m[100]["overflow"].overflow = true
+
+	if removed := om.Sweep(isConnected); removed != 3 {
+		t.Fatalf("expected 3 outboxes removed, got %v", removed)
+	}
+
+	for _, deviceID := range []string{"idle", "expired", "overflow"} {
+		if _, ok := om.m[100][deviceID]; ok {
+			t.Fatalf("outbox of %v not removed", deviceID)
+		}
+	}
+
+	// Connected device is seen again, so it's kept once disconnected
+	connected["online"] = false
+	if removed := om.Sweep(isConnected); removed != 0 {
+		t.Fatalf("expected no outbox removed, got %v", removed)
+	}
+
+	if len(om.Devices(100)) != 3 {
+		t.Fatalf("expected 3 outboxes, got %v", om.Devices(100))
+	}
+
+	// Removing last outbox removes user
+	om.m[100]["online"].lastSeen = longAgo
+	om.m[100]["recent"].lastSeen = longAgo
+	om.Take(100, "pending")
+	om.Sweep(isConnected)
+
+	if _, ok := om.m[100]; ok {
+		t.Fatal("user without outboxes not removed")
+	}
+}
+
+func TestOutboxKeepsUnacknowledged(t *testing.T) {
+
+	session := NewSession(nil, &Server{outboxes: NewOutboxMap()})
+	packet := proto.NewPacket(proto.VERSION_3).EventExpired(1)
+
+	// Not authenticated yet
+	if session.keepUnacknowledged(100, "phone", packet) {
+		t.Fatal("packet kept for unknown device")
+	}
+
+	session.Server.outboxes.Reset(100, "phone", "")
+
+	if !session.keepUnacknowledged(100, "phone", packet) {
+		t.Fatal("packet not kept")
+	}
+
+	if packets := session.Server.outboxes.Take(100, "phone"); len(packets) != 1 || packets[0].Type() != packet.Type() {
+		t.Fatalf("expected unacknowledged packet in outbox, got %v packets", len(packets))
+	}
+
+	if session.IsClosed() {
+		t.Fatal("session closed")
+	}
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+	"github.com/d3ce1t/areyouin-server/model"
+	"github.com/d3ce1t/areyouin-server/utils"
+)
+
+// Push Messages
+const (
+	PushNewDataAvailable = 4
+)
+
+// Tokens of older clients don't support loc keys, so they are sent only the
+// rendered text of notifications, and always a send-to-sync message.
+const PUSH_LOC_KEYS_MIN_VERSION = 3
+
+// A notification sent to every device of a user
+type userNotification struct {
+	category api.NotificationCategory
+	eventID  int64         // Zero if notification isn't about an event
+	ttl      time.Duration // Notification is dropped if it isn't positive
+	build    func(lang i18nLang) *PushNotification
+
+	// Wake up the app, so that it syncs when notification is received
+	contentAvailable bool
+
+	// Only the last notification with the same key is delivered to devices
+	// that were offline
+	collapseKey string
+
+	// Notification carries no new data, so nothing is sent when muted instead
+	// of send-to-sync messages
+	silentWhenMuted bool
+}
+
+// Sends n to every device of userID. If user has muted its category, devices
+// are only sent send-to-sync messages, unless n is silent when muted.
+func (s *Server) notifyUser(userID int64, n *userNotification) {
+
+	if n.ttl <= 0 {
+		log.Printf("* (%v) Notification expired before being sent (category: %v, event: %v)\n", userID, n.category, n.eventID)
+		return
+	}
+
+	tokens, err := s.Model.Accounts.GetPushTokens(userID)
+	if err != nil {
+		log.Printf("* (%v) Couldn't load push tokens: %v\n", userID, err)
+		return
+	}
+
+	if !s.notificationAllowed(userID, n.category, n.eventID) {
+		if !n.silentWhenMuted {
+			s.sendToSyncAll(userID, tokens, n.ttl)
+		}
+		return
+	}
+
+	notification := n.build(s.userLang(userID))
+
+	for _, token := range tokens {
+
+		if !n.contentAvailable && n.collapseKey == "" {
+			s.sendNotification(userID, token, notification, n.ttl)
+			continue
+		}
+
+		s.sendPush(userID, token, &PushMessage{
+			TTL:              n.ttl,
+			CollapseKey:      n.collapseKey,
+			Notification:     notification,
+			ContentAvailable: n.contentAvailable, // For iOS
+		})
+
+		if n.contentAvailable && (token.Version() < PUSH_LOC_KEYS_MIN_VERSION || token.Platform() == PLATFORM_ANDROID) {
+			// Android push composed of notification + data isn't received directly by
+			// app. So send a second push with send-to-sync data.
+			s.sendToSync(userID, token, n.ttl)
+		}
+	}
+}
+
+func (s *Server) sendNewEventNotification(event *model.Event, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_INVITATION,
+		eventID:  event.Id(),
+		ttl:      event.StartDate().Sub(utils.GetCurrentTimeUTC()),
+		build: func(lang i18nLang) *PushNotification {
+			return createNewEventNotification(event, lang)
+		},
+		contentAvailable: true,
+	})
+}
+
+func (s *Server) sendEventCancelledNotification(event *model.Event, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_CANCELLED,
+		eventID:  event.Id(),
+		ttl:      event.EndDate().Sub(utils.GetCurrentTimeUTC()),
+		build: func(lang i18nLang) *PushNotification {
+			if event.IsQuorumFailed() {
+				return createQuorumFailedNotification(event, lang)
+			}
+			return createEventCancelledNotification(event, lang)
+		},
+		contentAvailable: true,
+	})
+}
+
+func (s *Server) sendEventResponseNotification(event *model.Event, participantID int64, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_RESPONSE,
+		eventID:  event.Id(),
+		ttl:      event.EndDate().Sub(utils.GetCurrentTimeUTC()),
+		build: func(lang i18nLang) *PushNotification {
+			return createEventResponseNotification(event, participantID, lang)
+		},
+	})
+}
+
+func (s *Server) sendEventConfirmedNotification(event *model.Event, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_CHANGE,
+		eventID:  event.Id(),
+		ttl:      event.StartDate().Sub(utils.GetCurrentTimeUTC()),
+		build: func(lang i18nLang) *PushNotification {
+			return createEventConfirmedNotification(event, lang)
+		},
+	})
+}
+
+func (s *Server) sendWaitlistPromotedNotification(event *model.Event, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_RESPONSE,
+		eventID:  event.Id(),
+		ttl:      event.StartDate().Sub(utils.GetCurrentTimeUTC()),
+		build: func(lang i18nLang) *PushNotification {
+			return createWaitlistPromotedNotification(event, lang)
+		},
+	})
+}
+
+func (s *Server) sendChangeProposedNotification(event *model.Event, proposal *model.ChangeProposal, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_CHANGE,
+		eventID:  event.Id(),
+		ttl:      proposal.Deadline().Sub(utils.GetCurrentTimeUTC()),
+		build: func(lang i18nLang) *PushNotification {
+			return createChangeProposedNotification(event, proposal, lang)
+		},
+	})
+}
+
+func (s *Server) sendVotingFinishedNotification(event *model.Event, proposal *model.ChangeProposal, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_CHANGE,
+		eventID:  event.Id(),
+		ttl:      event.EndDate().Sub(utils.GetCurrentTimeUTC()),
+		build: func(lang i18nLang) *PushNotification {
+			return createVotingFinishedNotification(event, proposal, lang)
+		},
+	})
+}
+
+func (s *Server) sendEventReminderNotification(event *model.Event, reminder *model.EventReminder, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_REMINDER,
+		eventID:  event.Id(),
+		ttl:      event.StartDate().Sub(utils.GetCurrentTimeUTC()),
+		build: func(lang i18nLang) *PushNotification {
+			return createEventReminderNotification(event, reminder, lang)
+		},
+		silentWhenMuted: true,
+	})
+}
+
+// Comments of an event are collapsed, so that only the last one is delivered
+// to devices that were offline. Nothing is sent when muted, as comments are
+// fetched on demand.
+func (s *Server) sendEventCommentNotification(event *model.Event, comment *model.Comment, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_EVENT_COMMENT,
+		eventID:  event.Id(),
+		ttl:      PUSH_MAX_TTL,
+		build: func(lang i18nLang) *PushNotification {
+			return createEventCommentNotification(event, comment, lang)
+		},
+		collapseKey:     fmt.Sprintf("comments#%v", event.Id()),
+		silentWhenMuted: true,
+	})
+}
+
+func (s *Server) sendFriendRequestNotification(friendName string, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_FRIEND_REQUEST,
+		ttl:      PUSH_MAX_TTL,
+		build: func(lang i18nLang) *PushNotification {
+			return createFriendRequestdNotification(friendName, lang)
+		},
+	})
+}
+
+func (s *Server) sendNewFriendNotification(friendName string, userID int64) {
+	s.notifyUser(userID, &userNotification{
+		category: api.NotificationCategory_NEW_FRIEND,
+		ttl:      PUSH_MAX_TTL,
+		build: func(lang i18nLang) *PushNotification {
+			return createNewFriendNotification(friendName, lang)
+		},
+	})
+}
+
+// Sends notification to token. Older clients are also sent a send-to-sync
+// message.
+func (s *Server) sendNotification(userID int64, token *model.IIDToken, notification *PushNotification, ttl time.Duration) {
+
+	if token.Version() < PUSH_LOC_KEYS_MIN_VERSION {
+		s.sendToSync(userID, token, ttl)
+	}
+
+	if notification == nil {
+		log.Println("* WARNING: sendNotification -> Skip notification because nil")
+		return
+	}
+
+	s.sendPush(userID, token, &PushMessage{
+		TTL:          ttl,
+		Notification: notification,
+	})
+}
+
+// Returns true if userID wants to receive a notification of category about
+// eventID now. Notifications are allowed if settings can't be read.
+func (s *Server) notificationAllowed(userID int64, category api.NotificationCategory, eventID int64) bool {
+
+	settings, err := s.Model.Accounts.GetNotificationSettings(userID)
+	if err != nil {
+		log.Printf("* (%v) Couldn't load notification settings: %v\n", userID, err)
+		return true
+	}
+
+	if !settings.Allows(category, eventID, utils.GetCurrentTimeUTC()) {
+		log.Printf("* (%v) Notification muted by user settings (category: %v, event: %v)\n", userID, category, eventID)
+		return false
+	}
+
+	return true
+}
+
+// Sends only send-to-sync messages to tokens of a notification that user has
+// muted, so that clients still get the new data.
+func (s *Server) sendToSyncAll(userID int64, tokens []*model.IIDToken, ttl time.Duration) {
+	for _, token := range tokens {
+		s.sendToSync(userID, token, ttl)
+	}
+}
+
+// Send-to-Sync PUSH Message
+func (s *Server) sendToSync(userID int64, token *model.IIDToken, ttl time.Duration) {
+
+	if token.Token() == "" {
+		return
+	}
+
+	s.sendPush(userID, token, &PushMessage{
+		TTL:              ttl,
+		CollapseKey:      "send-to-sync",
+		ContentAvailable: true, // For iOS
+		Data: map[string]interface{}{
+			"msg_type":     "notification",
+			"notify_type":  PushNewDataAvailable,
+			"created_date": utils.GetCurrentTimeMillis(),
+		},
+	})
+}
+
+// Queues message to be sent to token. Delivery errors are handled by the push
+// dispatcher.
+func (s *Server) sendPush(userID int64, token *model.IIDToken, message *PushMessage) {
+
+	message.Token = token.Token()
+	message.Platform = token.Platform()
+
+	if message.Notification != nil && token.Version() < PUSH_LOC_KEYS_MIN_VERSION {
+		if message.Notification.Title == "" && message.Notification.Body == "" {
+			log.Printf("* (%v) Notification without text skipped for version %v token\n", userID, token.Version())
+			return
+		}
+		message.Notification = message.Notification.withoutLocKeys()
+	}
+
+	if s.pushDispatcher.Dispatch(userID, token.DeviceID(), message) {
+		log.Printf("< (%v) Send push notification\n", userID)
+	}
+}
+
+// Returns language of userID for notifications
+func (s *Server) userLang(userID int64) i18nLang {
+
+	tag, err := s.Model.Accounts.GetUserLanguage(userID)
+	if err != nil {
+		log.Printf("* (%v) Couldn't load user language: %v\n", userID, err)
+	}
+
+	return parseLang(tag)
+}
+
+// Removes a token that push service reported as invalid, unless its device
+// has registered a new one in the meantime
+func (s *Server) removeInvalidPushToken(userID int64, deviceID string, token string) {
+
+	current, err := s.Model.Accounts.GetPushToken(userID, deviceID)
+	if err != nil || current.Token() != token {
+		return
+	}
+
+	removed := model.NewIIDToken(deviceID, "", current.Version(), current.Platform())
+	if err := s.Model.Accounts.SetPushToken(userID, removed); err != nil {
+		log.Printf("* (%v) Couldn't remove invalid push token: %v\n", userID, err)
+		return
+	}
+
+	log.Printf("* (%v) Invalid push token of device %v removed\n", userID, deviceID)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	APNS_PRODUCTION_URL = "https://api.push.apple.com"
+	APNS_SANDBOX_URL    = "https://api.sandbox.push.apple.com"
+
+	// Apple rejects tokens older than one hour and throttles tokens renewed
+	// more than once every 20 minutes
+	APNS_TOKEN_LIFETIME = 50 * time.Minute
+)
+
+// APNSProvider sends messages to the Apple Push Notification service using
+// token based authentication (.p8 auth key).
+type APNSProvider struct {
+	endpoint string
+	keyID    string
+	teamID   string
+	topic    string // App bundle id
+	key      *ecdsa.PrivateKey
+	client   *http.Client
+
+	mutex       sync.Mutex
+	token       string
+	tokenIssued time.Time
+}
+
+func NewAPNSProvider(keyFile string, keyID string, teamID string, topic string, sandbox bool) (*APNSProvider, error) {
+
+	if keyID == "" || teamID == "" || topic == "" {
+		return nil, errors.New("apns key id, team id and topic are required")
+	}
+
+	data, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	endpoint := APNS_PRODUCTION_URL
+	if sandbox {
+		endpoint = APNS_SANDBOX_URL
+	}
+
+	return &APNSProvider{
+		endpoint: endpoint,
+		keyID:    keyID,
+		teamID:   teamID,
+		topic:    topic,
+		key:      ecKey,
+		client:   &http.Client{Timeout: 30 * time.Second}, // Default transport negotiates HTTP/2
+	}, nil
+}
+
+func (p *APNSProvider) Name() string {
+	return PUSH_PROVIDER_APNS
+}
+
+func (p *APNSProvider) Send(message *PushMessage) error {
+
+	token, err := p.authToken()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(apnsPayload(message))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", p.endpoint+"/3/device/"+message.Token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("apns-topic", p.topic)
+	for name, value := range apnsHeaders(message) {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var apnsError struct {
+		Reason string `json:"reason"`
+	}
+
+	data, _ := ioutil.ReadAll(resp.Body)
+	if err := json.Unmarshal(data, &apnsError); err != nil || apnsError.Reason == "" {
+		apnsError.Reason = http.StatusText(resp.StatusCode)
+	}
+
+	return &PushError{Provider: p.Name(), Status: resp.StatusCode, Reason: apnsError.Reason}
+}
+
+// Returns provider authentication token, signing a new one if current one is
+// about to expire
+func (p *APNSProvider) authToken() (string, error) {
+
+	defer p.mutex.Unlock()
+	p.mutex.Lock()
+
+	if p.token != "" && time.Since(p.tokenIssued) < APNS_TOKEN_LIFETIME {
+		return p.token, nil
+	}
+
+	now := time.Now()
+
+	header := map[string]interface{}{"alg": "ES256", "kid": p.keyID}
+	claims := map[string]interface{}{"iss": p.teamID, "iat": now.Unix()}
+
+	token, err := signJWT(header, claims, es256Signer(p.key))
+	if err != nil {
+		return "", err
+	}
+
+	p.token = token
+	p.tokenIssued = now
+	return token, nil
+}
+
+// Notifications are alert pushes sent with high priority. Data only messages
+// are background pushes, that Apple only accepts with normal priority.
+func apnsHeaders(message *PushMessage) map[string]string {
+
+	headers := map[string]string{
+		"apns-expiration": strconv.FormatInt(time.Now().Add(message.ttl()).Unix(), 10),
+	}
+
+	if message.Notification != nil {
+		headers["apns-push-type"] = "alert"
+		headers["apns-priority"] = "10"
+	} else {
+		headers["apns-push-type"] = "background"
+		headers["apns-priority"] = "5"
+	}
+
+	if message.CollapseKey != "" {
+		headers["apns-collapse-id"] = message.CollapseKey
+	}
+
+	return headers
+}
+
+// Builds APNs JSON payload of message. Data is sent as custom keys next to
+// the aps dictionary.
+func apnsPayload(message *PushMessage) map[string]interface{} {
+
+	aps := make(map[string]interface{})
+
+	if n := message.Notification; n != nil {
+		alert := make(map[string]interface{})
+		if n.Title != "" {
+			alert["title"] = n.Title
+		}
+		if n.Body != "" {
+			alert["body"] = n.Body
+		}
+		if n.TitleLocKey != "" {
+			alert["title-loc-key"] = n.TitleLocKey
+		}
+		if len(n.TitleLocArgs) > 0 {
+			alert["title-loc-args"] = n.TitleLocArgs
+		}
+		if n.BodyLocKey != "" {
+			alert["loc-key"] = n.BodyLocKey
+		}
+		if len(n.BodyLocArgs) > 0 {
+			alert["loc-args"] = n.BodyLocArgs
+		}
+		aps["alert"] = alert
+		if n.Sound != "" {
+			aps["sound"] = n.Sound
+		}
+	}
+
+	if message.ContentAvailable {
+		aps["content-available"] = 1
+	}
+
+	payload := map[string]interface{}{"aps": aps}
+	for key, value := range message.Data {
+		if key != "aps" {
+			payload[key] = value
+		}
+	}
+
+	return payload
+}
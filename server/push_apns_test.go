@@ -0,0 +1,171 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func verifyES256(token string, key *ecdsa.PublicKey) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	return ecdsa.Verify(key, digest[:], r, s)
+}
+
+func newTestAPNSProvider(t *testing.T, dir string, key *ecdsa.PrivateKey) *APNSProvider {
+
+	keyBytes, _ := x509.MarshalPKCS8PrivateKey(key)
+	keyFile := filepath.Join(dir, "AuthKey_KEYID.p8")
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}), 0600)
+
+	provider, err := NewAPNSProvider(keyFile, "KEYID", "TEAMID", "com.example.areyouin", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if provider.endpoint != APNS_SANDBOX_URL {
+		t.Fatalf("unexpected endpoint %v", provider.endpoint)
+	}
+
+	return provider
+}
+
+func TestAPNSProvider(t *testing.T) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type request struct {
+		path    string
+		header  http.Header
+		payload map[string]interface{}
+	}
+
+	requests := make(chan request, 1)
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := strings.TrimPrefix(r.Header.Get("Authorization"), "bearer ")
+		if !verifyES256(auth, &key.PublicKey) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"reason": "InvalidProviderToken"}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/unregistered") {
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"reason": "Unregistered", "timestamp": 1500000000000}`))
+			return
+		}
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		requests <- request{r.URL.Path, r.Header, payload}
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "apns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	provider := newTestAPNSProvider(t, dir, key)
+	provider.endpoint = ts.URL
+	provider.client = ts.Client()
+
+	// Notification
+	err = provider.Send(&PushMessage{
+		Token:    "device-token",
+		Platform: PLATFORM_IOS,
+		TTL:      time.Hour,
+		Notification: &PushNotification{
+			TitleLocKey:  "notification.event.cancelled.title",
+			TitleLocArgs: []string{"Party"},
+			BodyLocKey:   "notification.event.cancelled.body",
+			Sound:        "default",
+		},
+		ContentAvailable: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-requests
+
+	if req.path != "/3/device/device-token" || req.header.Get("apns-topic") != "com.example.areyouin" ||
+		req.header.Get("apns-push-type") != "alert" || req.header.Get("apns-priority") != "10" {
+		t.Fatalf("unexpected request %v %v", req.path, req.header)
+	}
+
+	aps := req.payload["aps"].(map[string]interface{})
+	alert := aps["alert"].(map[string]interface{})
+	if alert["title-loc-key"] != "notification.event.cancelled.title" || aps["content-available"] != float64(1) ||
+		aps["sound"] != "default" {
+		t.Fatalf("unexpected payload %v", req.payload)
+	}
+
+	// Data only messages are background pushes
+	err = provider.Send(&PushMessage{
+		Token:            "device-token",
+		CollapseKey:      "send-to-sync",
+		ContentAvailable: true,
+		Data:             map[string]interface{}{"notify_type": 4},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req = <-requests
+
+	if req.header.Get("apns-push-type") != "background" || req.header.Get("apns-priority") != "5" ||
+		req.header.Get("apns-collapse-id") != "send-to-sync" || req.payload["notify_type"] != float64(4) {
+		t.Fatalf("unexpected request %v %v", req.header, req.payload)
+	}
+
+	// Errors of APNs are reported with their reason
+	err = provider.Send(&PushMessage{Token: "unregistered"})
+	if pushErr, ok := err.(*PushError); !ok || pushErr.Reason != "Unregistered" || pushErr.Status != 410 {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestAPNSProviderRequiresECKey(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "apns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "key.p8")
+	ioutil.WriteFile(keyFile, []byte("not a key"), 0600)
+
+	if _, err := NewAPNSProvider(keyFile, "KEYID", "TEAMID", "com.example.areyouin", false); err != ErrInvalidPrivateKey {
+		t.Fatalf("expected invalid key, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+const (
+	PUSH_WORKERS         = 8
+	PUSH_QUEUE_SIZE      = 1000
+	PUSH_MAX_RETRIES     = 5
+	PUSH_INITIAL_BACKOFF = 1 * time.Second
+	PUSH_MAX_BACKOFF     = 1 * time.Minute
+)
+
+// A message to be sent to a device of a user
+type pushDelivery struct {
+	userID   int64
+	deviceID string
+	message  *PushMessage
+	attempts int
+}
+
+// PushDispatcher sends push messages in the background through a bounded pool
+// of workers. Messages that fail with a retryable error are sent again with
+// exponential backoff, and tokens rejected by the push service are reported
+// to onInvalidToken so that they can be removed.
+type PushDispatcher struct {
+	provider       PushProvider
+	queue          chan *pushDelivery
+	quit           chan bool
+	wg             sync.WaitGroup
+	workers        int
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	onInvalidToken func(userID int64, deviceID string, token string)
+
+	statsMutex sync.Mutex
+	stats      map[int64]*api.PushStats
+}
+
+func NewPushDispatcher(provider PushProvider, workers int, queueSize int) *PushDispatcher {
+	return &PushDispatcher{
+		provider:       provider,
+		queue:          make(chan *pushDelivery, queueSize),
+		quit:           make(chan bool),
+		workers:        workers,
+		maxRetries:     PUSH_MAX_RETRIES,
+		initialBackoff: PUSH_INITIAL_BACKOFF,
+		maxBackoff:     PUSH_MAX_BACKOFF,
+		stats:          make(map[int64]*api.PushStats),
+	}
+}
+
+// Start starts workers of the dispatcher
+func (d *PushDispatcher) Start() {
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+}
+
+// Stop stops workers once they finish the message being sent. Queued messages
+// and pending retries are discarded.
+func (d *PushDispatcher) Stop() {
+	close(d.quit)
+	d.wg.Wait()
+}
+
+// Dispatch queues message to be sent to deviceID of userID. Returns false if
+// the queue is full and message was dropped.
+func (d *PushDispatcher) Dispatch(userID int64, deviceID string, message *PushMessage) bool {
+	return d.enqueue(&pushDelivery{userID: userID, deviceID: deviceID, message: message})
+}
+
+func (d *PushDispatcher) enqueue(delivery *pushDelivery) bool {
+	select {
+	case d.queue <- delivery:
+		return true
+	case <-d.quit:
+		return false
+	default:
+		log.Printf("* (%v) Push queue full. Message dropped\n", delivery.userID)
+		d.updateStats(delivery.userID, func(stats *api.PushStats) {
+			stats.Dropped++
+		})
+		return false
+	}
+}
+
+func (d *PushDispatcher) worker() {
+	defer d.wg.Done()
+	for {
+		select {
+		case delivery := <-d.queue:
+			d.deliver(delivery)
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+func (d *PushDispatcher) deliver(delivery *pushDelivery) {
+
+	err := d.provider.Send(delivery.message)
+	delivery.attempts++
+
+	switch {
+	case err == nil:
+		d.updateStats(delivery.userID, func(stats *api.PushStats) {
+			stats.Sent++
+			stats.LastSent = time.Now()
+		})
+
+	case isInvalidPushToken(err):
+		log.Printf("* (%v) Push token of device %v is invalid: %v\n", delivery.userID, delivery.deviceID, err)
+		d.updateStats(delivery.userID, func(stats *api.PushStats) {
+			stats.InvalidTokens++
+			setLastPushError(stats, err)
+		})
+		if d.onInvalidToken != nil {
+			d.onInvalidToken(delivery.userID, delivery.deviceID, delivery.message.Token)
+		}
+
+	case isRetryablePushError(err) && delivery.attempts <= d.maxRetries:
+		delay := d.backoff(delivery.attempts)
+		log.Printf("* (%v) Push Error: %v (retry in %v)\n", delivery.userID, err, delay)
+		d.updateStats(delivery.userID, func(stats *api.PushStats) {
+			stats.Retries++
+			setLastPushError(stats, err)
+		})
+		time.AfterFunc(delay, func() {
+			d.enqueue(delivery)
+		})
+
+	default:
+		log.Printf("* (%v) Push Error: %v\n", delivery.userID, err)
+		d.updateStats(delivery.userID, func(stats *api.PushStats) {
+			stats.Failed++
+			setLastPushError(stats, err)
+		})
+	}
+}
+
+// Returns how long to wait before sending a message again after attempts
+// failed attempts
+func (d *PushDispatcher) backoff(attempts int) time.Duration {
+	delay := d.initialBackoff
+	for i := 1; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.maxBackoff {
+		delay = d.maxBackoff
+	}
+	return delay
+}
+
+func (d *PushDispatcher) updateStats(userID int64, update func(stats *api.PushStats)) {
+
+	defer d.statsMutex.Unlock()
+	d.statsMutex.Lock()
+
+	stats, ok := d.stats[userID]
+	if !ok {
+		stats = &api.PushStats{}
+		d.stats[userID] = stats
+	}
+
+	update(stats)
+}
+
+func setLastPushError(stats *api.PushStats, err error) {
+	stats.LastError = err.Error()
+	stats.LastErrorTime = time.Now()
+}
+
+// Stats returns delivery stats of users that have been sent any message
+func (d *PushDispatcher) Stats() map[int64]api.PushStats {
+
+	defer d.statsMutex.Unlock()
+	d.statsMutex.Lock()
+
+	stats := make(map[int64]api.PushStats, len(d.stats))
+	for userID, userStats := range d.stats {
+		stats[userID] = *userStats
+	}
+
+	return stats
+}
+
+func (s *Server) PushStats() map[int64]api.PushStats {
+	return s.pushDispatcher.Stats()
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Push provider that fails with errors of a token in order and then succeeds
+type scriptedPushProvider struct {
+	mutex  sync.Mutex
+	errors map[string][]error
+	sent   chan *PushMessage
+}
+
+func (p *scriptedPushProvider) Name() string {
+	return "scripted"
+}
+
+func (p *scriptedPushProvider) Send(message *PushMessage) error {
+	p.mutex.Lock()
+	errs := p.errors[message.Token]
+	if len(errs) > 0 {
+		p.errors[message.Token] = errs[1:]
+		p.mutex.Unlock()
+		return errs[0]
+	}
+	p.mutex.Unlock()
+	p.sent <- message
+	return nil
+}
+
+func newTestPushDispatcher(errs map[string][]error) (*PushDispatcher, *scriptedPushProvider) {
+	provider := &scriptedPushProvider{errors: errs, sent: make(chan *PushMessage, 10)}
+	d := NewPushDispatcher(provider, 2, 10)
+	d.initialBackoff = time.Millisecond
+	d.maxBackoff = 4 * time.Millisecond
+	d.maxRetries = 2
+	return d, provider
+}
+
+func waitPushStats(t *testing.T, d *PushDispatcher, userID int64, done func(sent, failed, retries, invalid uint64) bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		s := d.Stats()[userID]
+		if done(s.Sent, s.Failed, s.Retries, s.InvalidTokens) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("unexpected stats %+v", d.Stats()[userID])
+}
+
+func TestPushDispatcherRetries(t *testing.T) {
+
+	unavailable := &PushError{Provider: "scripted", Status: 503, Reason: "UNAVAILABLE"}
+
+	d, provider := newTestPushDispatcher(map[string][]error{
+		"flaky": {unavailable, errors.New("connection reset")},
+		"down":  {unavailable, unavailable, unavailable},
+	})
+	d.Start()
+	defer d.Stop()
+
+	// Sent after two retries
+	d.Dispatch(1, "phone", &PushMessage{Token: "flaky"})
+
+	select {
+	case message := <-provider.sent:
+		if message.Token != "flaky" {
+			t.Fatalf("unexpected message %v", message)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not sent")
+	}
+
+	waitPushStats(t, d, 1, func(sent, failed, retries, invalid uint64) bool {
+		return sent == 1 && retries == 2 && failed == 0
+	})
+
+	// Discarded after max retries
+	d.Dispatch(2, "phone", &PushMessage{Token: "down"})
+
+	waitPushStats(t, d, 2, func(sent, failed, retries, invalid uint64) bool {
+		return sent == 0 && retries == 2 && failed == 1
+	})
+
+	if stats := d.Stats()[2]; stats.LastError == "" {
+		t.Fatal("last error not recorded")
+	}
+}
+
+func TestPushDispatcherInvalidToken(t *testing.T) {
+
+	d, _ := newTestPushDispatcher(map[string][]error{
+		"uninstalled": {&PushError{Provider: "scripted", Status: 200, Reason: "NotRegistered"}},
+		"invalid":     {&PushError{Provider: "scripted", Status: 400, Reason: "INVALID_ARGUMENT"}},
+	})
+
+	removed := make(chan string, 1)
+	d.onInvalidToken = func(userID int64, deviceID string, token string) {
+		removed <- deviceID + ":" + token
+	}
+
+	d.Start()
+	defer d.Stop()
+
+	d.Dispatch(1, "phone", &PushMessage{Token: "uninstalled"})
+
+	select {
+	case r := <-removed:
+		if r != "phone:uninstalled" {
+			t.Fatalf("unexpected token removed %v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("token not removed")
+	}
+
+	waitPushStats(t, d, 1, func(sent, failed, retries, invalid uint64) bool {
+		return invalid == 1 && retries == 0
+	})
+
+	// Not retryable, but token isn't removed
+	d.Dispatch(2, "phone", &PushMessage{Token: "invalid"})
+
+	waitPushStats(t, d, 2, func(sent, failed, retries, invalid uint64) bool {
+		return failed == 1 && retries == 0 && invalid == 0
+	})
+
+	select {
+	case r := <-removed:
+		t.Fatalf("unexpected token removed %v", r)
+	default:
+	}
+}
+
+func TestPushDispatcherQueueFull(t *testing.T) {
+
+	provider := &scriptedPushProvider{sent: make(chan *PushMessage, 10)}
+	d := NewPushDispatcher(provider, 1, 1)
+
+	// Workers aren't started, so queue isn't consumed
+	if !d.Dispatch(1, "phone", &PushMessage{Token: "token"}) {
+		t.Fatal("message not queued")
+	}
+
+	if d.Dispatch(1, "phone", &PushMessage{Token: "token"}) {
+		t.Fatal("message queued in full queue")
+	}
+
+	if stats := d.Stats()[1]; stats.Dropped != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestPushDispatcherBackoff(t *testing.T) {
+
+	d := NewPushDispatcher(nil, 1, 1)
+	d.initialBackoff = time.Second
+	d.maxBackoff = 10 * time.Second
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+
+	for i, delay := range expected {
+		if backoff := d.backoff(i + 1); backoff != delay {
+			t.Fatalf("attempt %v: expected %v, got %v", i+1, delay, backoff)
+		}
+	}
+}
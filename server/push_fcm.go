@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	FCM_URL   = "https://fcm.googleapis.com"
+	FCM_SCOPE = "https://www.googleapis.com/auth/firebase.messaging"
+)
+
+// Service account credentials file downloaded from Firebase console
+type fcmCredentials struct {
+	Type        string `json:"type"`
+	ProjectID   string `json:"project_id"`
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMProvider sends messages through the Firebase Cloud Messaging HTTP v1 API
+// authenticated with a service account. The same API delivers to Android and
+// iOS devices.
+type FCMProvider struct {
+	endpoint    string
+	projectID   string
+	clientEmail string
+	tokenURI    string
+	key         *rsa.PrivateKey
+	client      *http.Client
+
+	mutex       sync.Mutex
+	accessToken string
+	expiry      time.Time
+}
+
+func NewFCMProvider(credentialsFile string) (*FCMProvider, error) {
+
+	data, err := ioutil.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials fcmCredentials
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return nil, err
+	}
+
+	if credentials.Type != "service_account" || credentials.ProjectID == "" ||
+		credentials.ClientEmail == "" || credentials.TokenURI == "" {
+		return nil, errors.New("invalid fcm service account credentials")
+	}
+
+	key, err := parsePrivateKey([]byte(credentials.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return &FCMProvider{
+		endpoint:    FCM_URL,
+		projectID:   credentials.ProjectID,
+		clientEmail: credentials.ClientEmail,
+		tokenURI:    credentials.TokenURI,
+		key:         rsaKey,
+		client:      &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (p *FCMProvider) Name() string {
+	return PUSH_PROVIDER_FCM
+}
+
+func (p *FCMProvider) Send(message *PushMessage) error {
+
+	accessToken, err := p.getAccessToken()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"message": fcmMessage(message)})
+	if err != nil {
+		return err
+	}
+
+	sendURL := fmt.Sprintf("%v/v1/projects/%v/messages:send", p.endpoint, p.projectID)
+	req, err := http.NewRequest("POST", sendURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	// Error details carry a more specific FCM error code (i.e. UNREGISTERED)
+	// than the status of the error
+	var fcmError struct {
+		Error struct {
+			Status  string `json:"status"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+
+	data, _ := ioutil.ReadAll(resp.Body)
+	json.Unmarshal(data, &fcmError)
+
+	reason := fcmError.Error.Status
+	for _, detail := range fcmError.Error.Details {
+		if detail.ErrorCode != "" {
+			reason = detail.ErrorCode
+		}
+	}
+	if reason == "" {
+		reason = http.StatusText(resp.StatusCode)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		p.invalidateAccessToken()
+	}
+
+	return &PushError{Provider: p.Name(), Status: resp.StatusCode, Reason: reason}
+}
+
+// Returns an OAuth2 access token for the service account. Tokens are cached
+// and requested again a minute before they expire.
+func (p *FCMProvider) getAccessToken() (string, error) {
+
+	defer p.mutex.Unlock()
+	p.mutex.Lock()
+
+	if p.accessToken != "" && time.Now().Add(time.Minute).Before(p.expiry) {
+		return p.accessToken, nil
+	}
+
+	now := time.Now()
+
+	header := map[string]interface{}{"alg": "RS256", "typ": "JWT"}
+	claims := map[string]interface{}{
+		"iss":   p.clientEmail,
+		"scope": FCM_SCOPE,
+		"aud":   p.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+
+	assertion, err := signJWT(header, claims, rs256Signer(p.key))
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := p.client.PostForm(p.tokenURI, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &PushError{Provider: p.Name(), Status: resp.StatusCode, Reason: "access token request failed"}
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+
+	p.accessToken = token.AccessToken
+	p.expiry = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return p.accessToken, nil
+}
+
+func (p *FCMProvider) invalidateAccessToken() {
+	defer p.mutex.Unlock()
+	p.mutex.Lock()
+	p.accessToken = ""
+}
+
+// Builds FCM v1 message. Android and iOS settings are given in their own
+// blocks, so notification is sent localised for each platform. Data values
+// must be strings in this API, and FCM also delivers them to iOS as custom
+// keys of the APNs payload.
+func fcmMessage(message *PushMessage) map[string]interface{} {
+
+	android := map[string]interface{}{
+		"priority": "HIGH",
+		"ttl":      strconv.FormatInt(int64(message.ttl().Seconds()), 10) + "s",
+	}
+
+	if message.CollapseKey != "" {
+		android["collapse_key"] = message.CollapseKey
+	}
+
+	if n := message.Notification; n != nil {
+		notification := make(map[string]interface{})
+		if n.Title != "" {
+			notification["title"] = n.Title
+		}
+		if n.Body != "" {
+			notification["body"] = n.Body
+		}
+		if n.TitleLocKey != "" {
+			notification["title_loc_key"] = n.TitleLocKey
+		}
+		if n.BodyLocKey != "" {
+			notification["body_loc_key"] = n.BodyLocKey
+		}
+		if len(n.TitleLocArgs) > 0 {
+			notification["title_loc_args"] = n.TitleLocArgs
+		}
+		if len(n.BodyLocArgs) > 0 {
+			notification["body_loc_args"] = n.BodyLocArgs
+		}
+		if n.Icon != "" {
+			notification["icon"] = n.Icon
+		}
+		if n.Sound != "" {
+			notification["sound"] = n.Sound
+		}
+		if n.Color != "" {
+			notification["color"] = n.Color
+		}
+		if n.Tag != "" {
+			notification["tag"] = n.Tag
+		}
+		android["notification"] = notification
+	}
+
+	fcm := map[string]interface{}{
+		"token":   message.Token,
+		"android": android,
+		"apns": map[string]interface{}{
+			"headers": apnsHeaders(message),
+			"payload": map[string]interface{}{"aps": apnsPayload(message)["aps"]},
+		},
+	}
+
+	if len(message.Data) > 0 {
+		data := make(map[string]string, len(message.Data))
+		for key, value := range message.Data {
+			data[key] = fmt.Sprint(value)
+		}
+		fcm["data"] = data
+	}
+
+	return fcm
+}
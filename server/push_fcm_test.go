@@ -0,0 +1,166 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Fake Google OAuth2 and FCM endpoints
+func newFCMTestServer(key *rsa.PrivateKey, sent chan<- map[string]interface{}) *httptest.Server {
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assertion := r.FormValue("assertion")
+		if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" ||
+			!verifyRS256(assertion, &key.PublicKey) {
+			http.Error(w, "invalid grant", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"access_token": "access-token", "expires_in": 3600, "token_type": "Bearer"}`))
+	})
+
+	mux.HandleFunc("/v1/projects/test-project/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			http.Error(w, "", http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Message map[string]interface{} `json:"message"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Message["token"] == "unregistered" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": 404, "status": "NOT_FOUND",
+				"details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "UNREGISTERED"}]}}`))
+			return
+		}
+		sent <- body.Message
+		w.Write([]byte(`{"name": "projects/test-project/messages/1"}`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func verifyRS256(token string, key *rsa.PublicKey) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+}
+
+func TestFCMProvider(t *testing.T) {
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := make(chan map[string]interface{}, 1)
+	ts := newFCMTestServer(key, sent)
+	defer ts.Close()
+
+	// Write service account credentials
+	dir, err := ioutil.TempDir("", "fcm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyBytes, _ := x509.MarshalPKCS8PrivateKey(key)
+	credentials, _ := json.Marshal(fcmCredentials{
+		Type:        "service_account",
+		ProjectID:   "test-project",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})),
+		ClientEmail: "push@test-project.iam.gserviceaccount.com",
+		TokenURI:    ts.URL + "/token",
+	})
+
+	credentialsFile := filepath.Join(dir, "credentials.json")
+	ioutil.WriteFile(credentialsFile, credentials, 0600)
+
+	provider, err := NewFCMProvider(credentialsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.endpoint = ts.URL
+
+	err = provider.Send(&PushMessage{
+		Token:    "device-token",
+		Platform: PLATFORM_ANDROID,
+		TTL:      time.Hour,
+		Notification: &PushNotification{
+			TitleLocKey: "notification.event.new.title",
+			BodyLocKey:  "notification.event.new.body",
+			BodyLocArgs: []string{"Alice"},
+			Sound:       "default",
+		},
+		Data: map[string]interface{}{"notify_type": 4},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := <-sent
+
+	android := message["android"].(map[string]interface{})
+	notification := android["notification"].(map[string]interface{})
+
+	if message["token"] != "device-token" || android["ttl"] != "3600s" || android["priority"] != "HIGH" ||
+		notification["body_loc_key"] != "notification.event.new.body" {
+		t.Fatalf("unexpected message %v", message)
+	}
+
+	if data := message["data"].(map[string]interface{}); data["notify_type"] != "4" {
+		t.Fatalf("unexpected data %v", data)
+	}
+
+	apns := message["apns"].(map[string]interface{})
+	aps := apns["payload"].(map[string]interface{})["aps"].(map[string]interface{})
+	if aps["alert"].(map[string]interface{})["loc-key"] != "notification.event.new.body" {
+		t.Fatalf("unexpected apns %v", apns)
+	}
+
+	// Errors of FCM are reported with their error code
+	err = provider.Send(&PushMessage{Token: "unregistered"})
+	if pushErr, ok := err.(*PushError); !ok || pushErr.Reason != "UNREGISTERED" || pushErr.Status != 404 {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestFCMProviderInvalidCredentials(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "fcm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	credentialsFile := filepath.Join(dir, "credentials.json")
+	ioutil.WriteFile(credentialsFile, []byte(`{"type": "authorized_user"}`), 0600)
+
+	if _, err := NewFCMProvider(credentialsFile); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+
+	gcm "github.com/google/go-gcm"
+)
+
+// GCMProvider sends messages through the legacy FCM HTTP API authenticated
+// with a server key.
+type GCMProvider struct {
+	apiKey string
+}
+
+func NewGCMProvider(apiKey string) *GCMProvider {
+	return &GCMProvider{apiKey: apiKey}
+}
+
+func (p *GCMProvider) Name() string {
+	return PUSH_PROVIDER_GCM
+}
+
+func (p *GCMProvider) Send(message *PushMessage) error {
+
+	response, err := gcm.SendHttp(p.apiKey, newGCMMessage(message))
+	if err != nil {
+		return err
+	}
+
+	for _, result := range response.Results {
+		if result.Error != "" {
+			return &PushError{Provider: p.Name(), Status: 200, Reason: result.Error}
+		}
+	}
+
+	return nil
+}
+
+func newGCMMessage(message *PushMessage) gcm.HttpMessage {
+
+	ttl := uint(message.ttl().Seconds())
+
+	gcmMessage := gcm.HttpMessage{
+		To:               message.Token,
+		TimeToLive:       &ttl,
+		Priority:         "high",
+		CollapseKey:      message.CollapseKey,
+		ContentAvailable: message.ContentAvailable,
+		Data:             gcm.Data(message.Data),
+	}
+
+	if n := message.Notification; n != nil {
+		gcmMessage.Notification = &gcm.Notification{
+			Title:        n.Title,
+			Body:         n.Body,
+			TitleLocKey:  n.TitleLocKey,
+			TitleLocArgs: encodeLocArgs(n.TitleLocArgs),
+			BodyLocKey:   n.BodyLocKey,
+			BodyLocArgs:  encodeLocArgs(n.BodyLocArgs),
+			Icon:         n.Icon,
+			Sound:        n.Sound,
+			Color:        n.Color,
+			Tag:          n.Tag,
+		}
+	}
+
+	return gcmMessage
+}
+
+// Legacy API expects loc args as a JSON encoded array
+func encodeLocArgs(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	encoded, _ := json.Marshal(args)
+	return string(encoded)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+)
+
+// Push services authenticate requests with JSON Web Tokens signed with RS256
+// (FCM service accounts) or ES256 (APNs auth keys).
+
+var ErrInvalidPrivateKey = errors.New("invalid private key")
+
+// Signs header and claims with signer and returns the compact serialisation
+// of the token
+func signJWT(header map[string]interface{}, claims map[string]interface{}, signer func(digest []byte) ([]byte, error)) (string, error) {
+
+	encodedHeader, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+
+	encodedClaims, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(encodedHeader) + "." +
+		base64.RawURLEncoding.EncodeToString(encodedClaims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := signer(digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func rs256Signer(key *rsa.PrivateKey) func([]byte) ([]byte, error) {
+	return func(digest []byte) ([]byte, error) {
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest)
+	}
+}
+
+// ES256 signature is r and s concatenated, each one padded to 32 bytes
+func es256Signer(key *ecdsa.PrivateKey) func([]byte) ([]byte, error) {
+	return func(digest []byte) ([]byte, error) {
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+		if err != nil {
+			return nil, err
+		}
+		signature := make([]byte, 64)
+		copyPadded(signature[:32], r)
+		copyPadded(signature[32:], s)
+		return signature, nil
+	}
+}
+
+func copyPadded(dst []byte, n *big.Int) {
+	b := n.Bytes()
+	copy(dst[len(dst)-len(b):], b)
+}
+
+// Parses a PEM encoded PKCS#8 (or PKCS#1 for RSA) private key
+func parsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, ErrInvalidPrivateKey
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"sync"
+)
+
+// Max number of messages kept by a MemoryPushProvider
+const MEMORY_PUSH_MAX_MESSAGES = 1000
+
+// MemoryPushProvider records messages instead of sending them. It's used in
+// tests and when no push service is configured. Only the latest
+// MEMORY_PUSH_MAX_MESSAGES messages are kept.
+type MemoryPushProvider struct {
+	mutex    sync.Mutex
+	messages []*PushMessage
+}
+
+func NewMemoryPushProvider() *MemoryPushProvider {
+	return &MemoryPushProvider{}
+}
+
+func (p *MemoryPushProvider) Name() string {
+	return PUSH_PROVIDER_MEMORY
+}
+
+func (p *MemoryPushProvider) Send(message *PushMessage) error {
+
+	defer p.mutex.Unlock()
+	p.mutex.Lock()
+
+	if len(p.messages) >= MEMORY_PUSH_MAX_MESSAGES {
+		p.messages = p.messages[1:]
+	}
+
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+// Messages returns recorded messages in the order they were sent
+func (p *MemoryPushProvider) Messages() []*PushMessage {
+
+	defer p.mutex.Unlock()
+	p.mutex.Lock()
+
+	messages := make([]*PushMessage, len(p.messages))
+	copy(messages, p.messages)
+	return messages
+}
+
+// Reset removes recorded messages
+func (p *MemoryPushProvider) Reset() {
+	defer p.mutex.Unlock()
+	p.mutex.Lock()
+	p.messages = nil
+}
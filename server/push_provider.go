@@ -0,0 +1,232 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+// Push providers that can be selected in config
+const (
+	PUSH_PROVIDER_FCM    = "fcm"    // Firebase Cloud Messaging HTTP v1 API
+	PUSH_PROVIDER_APNS   = "apns"   // Apple Push Notification service
+	PUSH_PROVIDER_GCM    = "gcm"    // Legacy FCM HTTP API (server key)
+	PUSH_PROVIDER_MEMORY = "memory" // Keeps messages in memory instead of sending them
+)
+
+// Max time to live allowed by push services. Messages are stored at most this
+// time while devices are offline.
+const PUSH_MAX_TTL = 28 * 24 * time.Hour
+
+var ErrUnknownPushProvider = errors.New("unknown push provider")
+
+// PushNotification is a user visible notification. Title and body are
+// rendered in the language of the recipient. Clients that support loc keys
+// localise the texts themselves from the keys and args instead.
+type PushNotification struct {
+	Title        string
+	Body         string
+	TitleLocKey  string
+	TitleLocArgs []string
+	BodyLocKey   string
+	BodyLocArgs  []string
+	Icon         string // Android only (drawable name)
+	Sound        string
+	Color        string // Android only
+	Tag          string // Android only. Replaces shown notification with same tag
+}
+
+// PushMessage is a push sent to a single device token. Messages are always
+// sent with high priority, except data only messages to iOS devices, that
+// Apple only allows with normal priority.
+type PushMessage struct {
+	Token            string
+	Platform         string
+	TTL              time.Duration // Zero or negative is sent as zero
+	CollapseKey      string
+	ContentAvailable bool // Wake up iOS app in background
+	Notification     *PushNotification
+	Data             map[string]interface{}
+}
+
+// Returns a copy of notification without loc keys, so that clients show title
+// and body as they are
+func (n *PushNotification) withoutLocKeys() *PushNotification {
+	stripped := *n
+	stripped.TitleLocKey, stripped.TitleLocArgs = "", nil
+	stripped.BodyLocKey, stripped.BodyLocArgs = "", nil
+	return &stripped
+}
+
+// Returns TTL of message bounded to what push services accept
+func (m *PushMessage) ttl() time.Duration {
+	if m.TTL <= 0 {
+		return 0
+	}
+	if m.TTL > PUSH_MAX_TTL {
+		return PUSH_MAX_TTL
+	}
+	return m.TTL
+}
+
+// PushError is returned by providers when a push service rejects a message
+type PushError struct {
+	Provider string
+	Status   int    // HTTP status code
+	Reason   string // Error reported by push service
+}
+
+func (e *PushError) Error() string {
+	return fmt.Sprintf("%v: %v (status %v)", e.Provider, e.Reason, e.Status)
+}
+
+// Errors reported by push services when a token isn't valid anymore, i.e. app
+// was uninstalled
+var invalidTokenReasons = map[string]bool{
+	"NotRegistered":          true, // gcm
+	"InvalidRegistration":    true, // gcm
+	"UNREGISTERED":           true, // fcm
+	"Unregistered":           true, // apns
+	"BadDeviceToken":         true, // apns
+	"DeviceTokenNotForTopic": true, // apns
+}
+
+// Errors reported by push services when a message may be sent later
+var retryableReasons = map[string]bool{
+	"Unavailable":         true, // gcm
+	"InternalServerError": true, // gcm, apns
+	"UNAVAILABLE":         true, // fcm
+	"INTERNAL":            true, // fcm
+	"QUOTA_EXCEEDED":      true, // fcm
+	"TooManyRequests":     true, // apns
+	"ServiceUnavailable":  true, // apns
+}
+
+// InvalidToken returns true if message token must be removed
+func (e *PushError) InvalidToken() bool {
+	return invalidTokenReasons[e.Reason]
+}
+
+// Retryable returns true if sending the message again may succeed
+func (e *PushError) Retryable() bool {
+	return retryableReasons[e.Reason] || e.Status == 429 || e.Status >= 500
+}
+
+func isInvalidPushToken(err error) bool {
+	pushErr, ok := err.(*PushError)
+	return ok && pushErr.InvalidToken()
+}
+
+// Errors other than PushError are network errors, so they are retryable too
+func isRetryablePushError(err error) bool {
+	if pushErr, ok := err.(*PushError); ok {
+		return pushErr.Retryable()
+	}
+	return err != nil
+}
+
+// PushProvider sends push messages through a push service
+type PushProvider interface {
+	Name() string
+	Send(message *PushMessage) error
+}
+
+// pushRouter sends each message through the provider of its platform, or the
+// default one if the platform has no provider of its own.
+type pushRouter struct {
+	def       PushProvider
+	platforms map[string]PushProvider
+}
+
+func (r *pushRouter) Name() string {
+	platforms := make([]string, 0, len(r.platforms))
+	for platform := range r.platforms {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+
+	name := r.def.Name()
+	for _, platform := range platforms {
+		name += fmt.Sprintf(", %v: %v", platform, r.platforms[platform].Name())
+	}
+	return name
+}
+
+func (r *pushRouter) Send(message *PushMessage) error {
+	return r.provider(message.Platform).Send(message)
+}
+
+func (r *pushRouter) provider(platform string) PushProvider {
+	if provider, ok := r.platforms[platform]; ok {
+		return provider
+	}
+	return r.def
+}
+
+// Creates the push provider selected in config. If none is selected, the legacy
+// GCM provider is used when a Firebase API key is configured. Otherwise
+// messages are only kept in memory.
+func newPushProvider(cfg api.Config) (PushProvider, error) {
+
+	name := cfg.PushProvider()
+	if name == "" {
+		if cfg.FirebaseAPIKey() != "" {
+			name = PUSH_PROVIDER_GCM
+		} else {
+			log.Println("* WARNING: No push provider configured. Push notifications won't be sent")
+			name = PUSH_PROVIDER_MEMORY
+		}
+	}
+
+	router := &pushRouter{
+		platforms: make(map[string]PushProvider),
+	}
+
+	// Providers are shared between platforms that use the same one
+	providers := make(map[string]PushProvider)
+
+	getProvider := func(name string) (PushProvider, error) {
+		if provider, ok := providers[name]; ok {
+			return provider, nil
+		}
+		provider, err := newPushProviderByName(name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("push provider %v: %v", name, err)
+		}
+		providers[name] = provider
+		return provider, nil
+	}
+
+	var err error
+
+	if router.def, err = getProvider(name); err != nil {
+		return nil, err
+	}
+
+	for platform, name := range cfg.PushPlatformProviders() {
+		if router.platforms[platform], err = getProvider(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return router, nil
+}
+
+func newPushProviderByName(name string, cfg api.Config) (PushProvider, error) {
+	switch name {
+	case PUSH_PROVIDER_FCM:
+		return NewFCMProvider(cfg.FCMCredentialsFile())
+	case PUSH_PROVIDER_APNS:
+		return NewAPNSProvider(cfg.APNSKeyFile(), cfg.APNSKeyID(), cfg.APNSTeamID(), cfg.APNSTopic(), cfg.APNSSandbox())
+	case PUSH_PROVIDER_GCM:
+		return NewGCMProvider(cfg.FirebaseAPIKey()), nil
+	case PUSH_PROVIDER_MEMORY:
+		return NewMemoryPushProvider(), nil
+	default:
+		return nil, ErrUnknownPushProvider
+	}
+}
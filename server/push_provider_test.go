@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPushProviderFromConfig(t *testing.T) {
+
+	cfg := &Config{data: ConfigDTO{
+		PushProvider:          PUSH_PROVIDER_MEMORY,
+		PushPlatformProviders: map[string]string{PLATFORM_IOS: PUSH_PROVIDER_GCM},
+	}}
+
+	provider, err := newPushProvider(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := provider.(*pushRouter)
+
+	if name := router.provider(PLATFORM_ANDROID).Name(); name != PUSH_PROVIDER_MEMORY {
+		t.Fatalf("android uses %v", name)
+	}
+
+	if name := router.provider(PLATFORM_IOS).Name(); name != PUSH_PROVIDER_GCM {
+		t.Fatalf("iOS uses %v", name)
+	}
+
+	if name := provider.Name(); name != "memory, iOS: gcm" {
+		t.Fatalf("unexpected name %v", name)
+	}
+}
+
+func TestPushProviderDefaults(t *testing.T) {
+
+	provider, err := newPushProvider(&Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider.Name() != PUSH_PROVIDER_MEMORY {
+		t.Fatalf("expected memory provider, got %v", provider.Name())
+	}
+
+	provider, err = newPushProvider(&Config{data: ConfigDTO{FirebaseAPIKey: "key"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider.Name() != PUSH_PROVIDER_GCM {
+		t.Fatalf("expected gcm provider, got %v", provider.Name())
+	}
+
+	if _, err := newPushProvider(&Config{data: ConfigDTO{PushProvider: "pigeon"}}); err == nil {
+		t.Fatal("expected error with unknown provider")
+	}
+
+	if _, err := newPushProvider(&Config{data: ConfigDTO{PushProvider: PUSH_PROVIDER_FCM}}); err == nil {
+		t.Fatal("expected error without fcm credentials")
+	}
+}
+
+func TestMemoryPushProvider(t *testing.T) {
+
+	provider := NewMemoryPushProvider()
+
+	for i := 0; i < MEMORY_PUSH_MAX_MESSAGES+5; i++ {
+		provider.Send(&PushMessage{Token: "token", TTL: time.Duration(i)})
+	}
+
+	messages := provider.Messages()
+	if len(messages) != MEMORY_PUSH_MAX_MESSAGES {
+		t.Fatalf("expected %v messages, got %v", MEMORY_PUSH_MAX_MESSAGES, len(messages))
+	}
+
+	if messages[0].TTL != 5 {
+		t.Fatalf("oldest messages weren't discarded (first is %v)", messages[0].TTL)
+	}
+
+	provider.Reset()
+	if len(provider.Messages()) != 0 {
+		t.Fatal("messages not removed")
+	}
+}
+
+func TestPushMessageTTL(t *testing.T) {
+
+	tests := []struct {
+		ttl      time.Duration
+		expected time.Duration
+	}{
+		{0, 0},
+		{-time.Hour, 0},
+		{time.Hour, time.Hour},
+		{PUSH_MAX_TTL + time.Hour, PUSH_MAX_TTL},
+	}
+
+	for _, test := range tests {
+		message := &PushMessage{TTL: test.ttl}
+		if ttl := message.ttl(); ttl != test.expected {
+			t.Fatalf("TTL %v: expected %v, got %v", test.ttl, test.expected, ttl)
+		}
+	}
+}
+
+func TestGCMMessage(t *testing.T) {
+
+	message := newGCMMessage(&PushMessage{
+		Token: "token",
+		TTL:   time.Hour,
+		Notification: &PushNotification{
+			TitleLocKey: "title",
+			BodyLocKey:  "body",
+			BodyLocArgs: []string{"Alice"},
+		},
+	})
+
+	if message.To != "token" || *message.TimeToLive != 3600 || message.Priority != "high" {
+		t.Fatalf("unexpected message %+v", message)
+	}
+
+	if message.Notification.BodyLocArgs != `["Alice"]` || message.Notification.TitleLocArgs != "" {
+		t.Fatalf("unexpected notification %+v", message.Notification)
+	}
+}
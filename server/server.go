@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,6 +30,11 @@ var (
 type Server struct {
 	TLSConfig     *tls.Config
 	sessions      *SessionsMap
+	captures      *captureSet
+	capabilities  *CapabilityRegistry
+	outboxes      *OutboxMap
+	ackMetrics    *ackMetrics
+	pushDispatcher *PushDispatcher
 	callbacks     map[proto.PacketType]Callback
 	Model         *model.AyiModel
 	modelObserver *ModelObserver
@@ -76,7 +82,23 @@ func (s *Server) init() {
 
 	// Init sessions holder
 	s.sessions = NewSessionsMap()
+	s.captures = newCaptureSet()
+	s.capabilities = newDefaultCapabilityRegistry()
+	s.outboxes = NewOutboxMap()
+	s.ackMetrics = &ackMetrics{}
 	s.callbacks = make(map[proto.PacketType]Callback)
+
+	// Init push notifications
+	pushProvider, err := newPushProvider(s.Config)
+	if err != nil {
+		panic(err)
+	}
+
+	log.Printf("Push provider: %v\n", pushProvider.Name())
+
+	s.pushDispatcher = NewPushDispatcher(pushProvider, PUSH_WORKERS, PUSH_QUEUE_SIZE)
+	s.pushDispatcher.onInvalidToken = s.removeInvalidPushToken
+	s.pushDispatcher.Start()
 }
 
 func (s *Server) bootstrapServer() {
@@ -110,6 +132,7 @@ func (s *Server) run(bootstrap bool) {
 
 		// Start model background tasks
 
+		s.Model.Events.SetReminderOffsets(s.Config.EventReminders(), s.Config.EventNudges())
 		s.Model.StartBackgroundTasks()
 
 		// Start up model observer
@@ -117,6 +140,10 @@ func (s *Server) run(bootstrap bool) {
 		s.modelObserver = newModelObserver(s)
 		go s.modelObserver.run()
 
+		// Start up outbox sweeper
+
+		go s.sweepOutboxes()
+
 	} else {
 
 		log.Println("Server running in MAINTENANCE MODE")
@@ -126,6 +153,12 @@ func (s *Server) run(bootstrap bool) {
 		}
 	}
 
+	// Start up WebSocket listener
+
+	if s.Config.WebSocketEnabled() {
+		go s.runWebSocket()
+	}
+
 	// Start up server listener
 
 	listener, err := net.Listen("tcp", fmt.Sprintf("%v:%v", s.Config.ListenAddress(), s.Config.ListenPort()))
@@ -158,28 +191,34 @@ func (s *Server) registerCallback(command proto.PacketType, f Callback) {
 
 func (s *Server) registerSession(session *AyiSession) {
 
-	if oldSession, ok := s.sessions.Get(session.UserId); ok {
+	// Only the session of the same device is replaced. Sessions of other
+	// devices of the user are kept.
+	if oldSession := s.sessions.Put(session); oldSession != nil && oldSession != session {
 		oldSession.WriteSync(oldSession.NewMessage().Error(proto.M_USER_AUTH, proto.E_INVALID_USER_OR_PASSWORD))
 		log.Printf("< (%v) SEND INVALID USER OR PASSWORD\n", oldSession)
 		oldSession.Exit()
 		log.Printf("* (%v) Closing old session for endpoint %v\n", oldSession, oldSession.Conn.RemoteAddr())
 	}
 
-	s.sessions.Put(session.UserId, session)
-	log.Printf("* (%v) Register session for endpoint %v\n", session, session.Conn.RemoteAddr())
+	log.Printf("* (%v) Register session for endpoint %v (device: %v)\n", session, session.Conn.RemoteAddr(), session.DeviceID)
+
+	if s.captures.Contains(session.UserId) {
+		if _, err := session.startCapture(s.Config.CaptureDir()); err != nil {
+			log.Printf("* (%v) Start capture error: %v\n", session, err)
+		}
+	}
 }
 
 func (s *Server) unregisterSession(session *AyiSession) {
 
-	user_id := session.UserId
 	if !session.IsClosed() {
 		session.Exit()
 	}
 
-	oldSession, ok := s.sessions.Get(user_id)
-	if ok && oldSession == session {
-		s.sessions.Remove(user_id)
-		log.Printf("* (%v) Unregister session for endpoint %v\n", session, session.Conn.RemoteAddr())
+	session.stopCapture()
+
+	if s.sessions.Remove(session) {
+		log.Printf("* (%v) Unregister session for endpoint %v (device: %v)\n", session, session.Conn.RemoteAddr(), session.DeviceID)
 	}
 
 }
@@ -387,11 +426,29 @@ func (s *Server) onFacebookUpdate(updateInfo *wh.FacebookUpdate) {
 	} // End outter loop
 }
 
-func (s *Server) getSession(userID int64) *AyiSession {
-	if session, ok := s.sessions.Get(userID); ok {
-		return session
+// Returns sessions of userID in all of its devices
+func (s *Server) getSessions(userID int64) []*AyiSession {
+	return s.sessions.GetAll(userID)
+}
+
+// UserSessions returns connected devices of userID
+func (s *Server) UserSessions(userID int64) []api.SessionInfo {
+
+	sessions := s.getSessions(userID)
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].DeviceID < sessions[j].DeviceID })
+
+	info := make([]api.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		info = append(info, api.SessionInfo{
+			UserID:        session.UserId,
+			DeviceID:      session.DeviceID,
+			Platform:      session.Platform,
+			ClientVersion: session.ClientVersion,
+			RemoteAddr:    session.Conn.RemoteAddr().String(),
+		})
 	}
-	return nil
+
+	return info
 }
 
 func checkAuthenticated(session *AyiSession) {
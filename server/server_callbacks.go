@@ -154,15 +154,36 @@ func onUserAuthentication(request *proto.AyiPacket, message proto.Message, sessi
 		return
 	}
 
-	iidToken, err := server.Model.Accounts.GetPushToken(msg.UserId)
-	checkNoErrorOrPanic(err)
+	iidToken, err := server.Model.Accounts.TouchPushToken(msg.UserId, session.DeviceID)
+	if err != model.ErrNotFound {
+		checkNoErrorOrPanic(err)
+	}
+
+	// Notifications are rendered in the language of the last client used
+	if session.Language != "" {
+		if err := server.Model.Accounts.SetUserLanguage(msg.UserId, session.Language); err != nil {
+			log.Printf("* (%v) Couldn't save user language %v: %v\n", session, session.Language, err)
+		}
+	}
+
+	// Packets missed while offline are kept only if the previous session
+	// is resumed. Otherwise, client has to do a full sync.
+	resumeToken, missed, resumed := server.outboxes.Reset(msg.UserId, session.DeviceID, msg.ResumeToken)
+	resumeInfo := &proto.ResumeInfo{
+		ResumeToken:   resumeToken,
+		Resumed:       resumed,
+		MissedPackets: uint32(missed),
+	}
 
 	session.IsAuth = isAuthenticated
 	session.UserId = msg.UserId
 	session.IIDToken = iidToken
-	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
-	log.Printf("< (%v) AUTH OK\n", session)
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().OkWithPayload(request.Type(), resumeInfo))
+	log.Printf("< (%v) AUTH OK (resumed: %v, missed: %v)\n", session, resumed, missed)
+	// Packets sent to the user once registered wait in the outbox until
+	// missed ones are replayed
 	server.registerSession(session)
+	server.replayOutbox(session)
 
 	server.refreshSessionActivity(session)
 }
@@ -194,7 +215,7 @@ func onIIDTokenReceived(request *proto.AyiPacket, message proto.Message, session
 
 	checkAuthenticated(session)
 
-	iidToken := model.NewIIDToken(msg.Token, int(session.ProtocolVersion), session.Platform)
+	iidToken := model.NewIIDToken(session.DeviceID, msg.Token, int(session.ProtocolVersion), session.Platform)
 	if err := server.Model.Accounts.SetPushToken(session.UserId, iidToken); err != nil {
 		reply := session.NewMessage().Error(request.Type(), proto.E_OPERATION_FAILED)
 		log.Printf("< (%v) IID TOKEN ERROR: %v\n", session, err)
@@ -207,6 +228,200 @@ func onIIDTokenReceived(request *proto.AyiPacket, message proto.Message, session
 	log.Printf("< (%v) IID TOKEN OK\n", session)
 }
 
+// Returns devices of the user that receive push notifications
+func onListDevices(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+
+	log.Printf("> (%v) LIST DEVICES\n", session) // Message does not has payload
+	checkAuthenticated(session)
+
+	tokens, err := server.Model.Accounts.GetPushTokens(session.UserId)
+	checkNoErrorOrPanic(err)
+
+	devices := make([]*proto.Device, 0, len(tokens))
+	for _, token := range tokens {
+		devices = append(devices, convPushToken2Net(token, session.DeviceID))
+	}
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().DevicesList(devices))
+	log.Printf("< (%v) SEND DEVICES LIST (num.devices: %v)\n", session, len(devices))
+}
+
+// Revokes push token of a device of the user. Sessions of that device are
+// kept, but it doesn't receive push notifications until it sends a new token.
+func onRevokeDevice(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.RevokeDevice)
+
+	log.Printf("> (%v) REVOKE DEVICE %v\n", session, msg.DeviceId)
+	checkAuthenticated(session)
+
+	err := server.Model.Accounts.RevokePushToken(session.UserId, msg.DeviceId)
+	if err == model.ErrNotFound {
+		err = ErrDeviceNotFound
+	}
+	checkNoErrorOrPanic(err)
+
+	if msg.DeviceId == session.DeviceID {
+		session.IIDToken = nil
+	}
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) REVOKE DEVICE OK\n", session)
+}
+
+func onGetNotificationSettings(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+
+	log.Printf("> (%v) GET NOTIFICATION SETTINGS\n", session) // Message does not has payload
+	checkAuthenticated(session)
+
+	settings, err := server.Model.Accounts.GetNotificationSettings(session.UserId)
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(),
+		session.NewMessage().NotificationSettings(convNotificationSettings2Net(settings)))
+	log.Printf("< (%v) SEND NOTIFICATION SETTINGS\n", session)
+}
+
+// Replaces notification settings of the user. Muted events are ignored, they
+// are changed with MUTE_EVENT.
+func onSetNotificationSettings(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.NotificationSettings)
+
+	log.Printf("> (%v) SET NOTIFICATION SETTINGS\n", session)
+	checkAuthenticated(session)
+
+	err := server.Model.Accounts.SetNotificationSettings(session.UserId, convNetNotificationSettings(msg))
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) SET NOTIFICATION SETTINGS OK\n", session)
+}
+
+func onMuteEvent(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.MuteEvent)
+
+	log.Printf("> (%v) MUTE EVENT %v (mute: %v)\n", session, msg.EventId, msg.Mute)
+	checkAuthenticated(session)
+
+	event, err := server.Model.Events.GetEventForUser(session.UserId, msg.EventId)
+	checkNoErrorOrPanic(err)
+
+	err = server.Model.Accounts.MuteEvent(session.UserId, event, msg.Mute)
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) MUTE EVENT OK\n", session)
+}
+
+// Comment is sent back to the author, as other participants, with its id and
+// created date
+func onPostComment(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.EventComment)
+
+	log.Printf("> (%v) POST COMMENT (eventId: %v)\n", session, msg.EventId)
+	checkAuthenticated(session)
+
+	comment, err := server.Model.Events.PostComment(msg.EventId, session.UserId, msg.Message)
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().CommentPosted(convComment2Net(comment)))
+	log.Printf("< (%v) POST COMMENT OK (eventId: %v, commentId: %v)\n", session, msg.EventId, comment.Id())
+}
+
+func onEditComment(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.EventComment)
+
+	log.Printf("> (%v) EDIT COMMENT (eventId: %v, commentId: %v)\n", session, msg.EventId, msg.CommentId)
+	checkAuthenticated(session)
+
+	comment, err := server.Model.Events.EditComment(msg.EventId, msg.CommentId, session.UserId, msg.Message)
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().CommentEdited(convComment2Net(comment)))
+	log.Printf("< (%v) EDIT COMMENT OK\n", session)
+}
+
+func onDeleteComment(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.EventComment)
+
+	log.Printf("> (%v) DELETE COMMENT (eventId: %v, commentId: %v)\n", session, msg.EventId, msg.CommentId)
+	checkAuthenticated(session)
+
+	err := server.Model.Events.DeleteComment(msg.EventId, msg.CommentId, session.UserId)
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) DELETE COMMENT OK\n", session)
+}
+
+// Comments are sent newest first. Older pages are requested with the id of
+// the oldest comment received.
+func onGetComments(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.CommentListRequest)
+
+	log.Printf("> (%v) GET COMMENTS (eventId: %v, before: %v)\n", session, msg.EventId, msg.BeforeId)
+	checkAuthenticated(session)
+
+	comments, hasMore, err := server.Model.Events.GetComments(msg.EventId, session.UserId, msg.BeforeId, int(msg.Limit))
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(),
+		session.NewMessage().CommentsList(msg.EventId, convCommentList2Net(comments), hasMore))
+	log.Printf("< (%v) SEND COMMENTS (%v comments)\n", session, len(comments))
+}
+
+func onUserPosition(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.UserPosition)
+	log.Printf("> (%v) USER POSITION (error: %v meters)\n", session, msg.EstimationError)
+
+	checkAuthenticated(session)
+
+	if msg.GlobalCoordinates == nil {
+		panic(model.ErrInvalidLocation)
+	}
+
+	err := server.Model.Accounts.SetUserPosition(session.UserId,
+		convNetLocation(msg.GlobalCoordinates), msg.EstimationError)
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) USER POSITION OK\n", session)
+}
+
+func onUserPositionRange(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.UserPositionRange)
+	log.Printf("> (%v) USER POSITION RANGE (%v meters)\n", session, msg.RangeInMeters)
+
+	checkAuthenticated(session)
+
+	err := server.Model.Accounts.SetUserPositionRange(session.UserId, msg.RangeInMeters)
+	checkNoErrorOrPanic(err)
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) USER POSITION RANGE OK\n", session)
+}
+
 func onGetUserAccount(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
 
 	server := session.Server
@@ -309,8 +524,8 @@ func onCreateEvent(request *proto.AyiPacket, message proto.Message, session *Ayi
 	startDate := utils.MillisToTimeUTC(msg.StartDate)
 	endDate := utils.MillisToTimeUTC(msg.EndDate)
 
-	log.Printf("> (%v) CREATE EVENT (start: %v, end: %v, invitations: %v, picture: %v bytes)\n",
-		session, startDate, endDate, len(msg.Participants), len(msg.Picture))
+	log.Printf("> (%v) CREATE EVENT (start: %v, end: %v, invitations: %v, picture: %v bytes, public: %v)\n",
+		session, startDate, endDate, len(msg.Participants), len(msg.Picture), msg.IsPublic)
 
 	checkAuthenticated(session)
 
@@ -319,7 +534,32 @@ func onCreateEvent(request *proto.AyiPacket, message proto.Message, session *Ayi
 	checkNoErrorOrPanic(err)
 
 	// New event
-	event, err := server.Model.Events.NewEvent(author, createdDate, startDate, endDate, msg.Message, msg.Participants)
+	b := server.Model.Events.NewEventBuilder().
+		SetAuthor(author).
+		SetCreatedDate(createdDate).
+		SetStartDate(startDate).
+		SetEndDate(endDate).
+		SetDescription(msg.Message).
+		SetPublic(msg.IsPublic).
+		SetCapacity(int(msg.Capacity))
+
+	if msg.Geolocation != nil {
+		b.SetLocation(convNetLocation(msg.Geolocation))
+	}
+
+	if msg.Quorum != 0 || msg.QuorumDeadline != 0 {
+		b.SetQuorum(int(msg.Quorum), utils.MillisToTimeUTC(msg.QuorumDeadline))
+	}
+
+	if msg.Recurrence != nil && msg.Recurrence.Frequency != proto.RecurrenceFrequency_R_NONE {
+		b.SetRecurrence(convNetRecurrence(msg.Recurrence))
+	}
+
+	for _, pID := range msg.Participants {
+		b.ParticipantAdder().AddUserID(pID)
+	}
+
+	event, err := b.Build()
 	checkNoErrorOrPanic(err)
 
 	// Publish event
@@ -338,16 +578,15 @@ func onCreateEvent(request *proto.AyiPacket, message proto.Message, session *Ayi
 	// Send event with InvitationStatus_CLIENT_DELIVERED
 	coreEvent := convEvent2Net(event)
 	coreEvent.Participants[session.UserId].Delivered = core.InvitationStatus_CLIENT_DELIVERED
-	session.WriteResponse(request.Header.GetToken(), session.NewMessage().EventCreated(coreEvent))
+	session.writeDeliveryResponse(request.Header.GetToken(), session.NewMessage().EventCreated(coreEvent), func() {
+		// Change invitation status
+		_, err := server.Model.Events.ChangeDeliveryState(session.UserId, api.InvitationStatus_CLIENT_DELIVERED, event)
+		if err != nil {
+			log.Printf("* (%v) CREATE EVENT WARNING Changing delivery state: %v", session, err)
+		}
+	})
 	log.Printf("< (%v) CREATE EVENT OK (eventId: %v, Num.Participants: %v, Remaining.Participants: %v)\n",
 		session, event.Id(), event.NumGuests(), 1+len(msg.Participants)-event.NumGuests())
-
-	// Change invitation status
-	_, err = server.Model.Events.ChangeDeliveryState(session.UserId, api.InvitationStatus_CLIENT_DELIVERED, event)
-	if err != nil {
-		log.Printf("* (%v) CREATE EVENT WARNING Changing delivery state: %err", err)
-	}
-
 }
 
 // Modify existing event. If a field isn't set that means it isn't modified.
@@ -359,9 +598,9 @@ func onModifyEvent(request *proto.AyiPacket, message proto.Message, session *Ayi
 	modificationDate := utils.MillisToTimeUTC(msg.ModifyDate)
 	startDate := utils.MillisToTimeUTC(msg.StartDate)
 	endDate := utils.MillisToTimeUTC(msg.EndDate)
-	log.Printf("> (%v) MODIFY EVENT %v (message: %v, start: %v, end: %v, modify: %v, invitations: %v, picture: %v, remove: %v)\n",
+	log.Printf("> (%v) MODIFY EVENT %v (message: %v, start: %v, end: %v, modify: %v, invitations: %v, picture: %v, remove: %v, series: %v)\n",
 		session, msg.EventId, msg.Message != "", startDate, endDate, modificationDate,
-		len(msg.Participants), len(msg.Picture) > 0, msg.RemovePicture)
+		len(msg.Participants), len(msg.Picture) > 0, msg.RemovePicture, msg.ApplyToSeries)
 
 	checkAuthenticated(session)
 
@@ -372,6 +611,7 @@ func onModifyEvent(request *proto.AyiPacket, message proto.Message, session *Ayi
 	// Modify event
 	b := server.Model.Events.NewEventModifier(event, session.UserId)
 	b.SetModifiedDate(modificationDate)
+	b.SetApplyToSeries(msg.ApplyToSeries)
 	eventInfoChanged := false
 
 	if msg.Message != "" && msg.Message != event.Description() {
@@ -389,6 +629,57 @@ func onModifyEvent(request *proto.AyiPacket, message proto.Message, session *Ayi
 		eventInfoChanged = true
 	}
 
+	if msg.Visibility != proto.EventVisibility_V_UNCHANGED {
+		isPublic := msg.Visibility == proto.EventVisibility_V_PUBLIC
+		if isPublic != event.IsPublic() {
+			b.SetPublic(isPublic)
+			eventInfoChanged = true
+		}
+	}
+
+	if msg.RemoveGeolocation {
+		if event.Location() != nil {
+			b.SetLocation(nil)
+			eventInfoChanged = true
+		}
+	} else if msg.Geolocation != nil {
+		location := convNetLocation(msg.Geolocation)
+		if !location.Equal(event.Location()) {
+			b.SetLocation(location)
+			eventInfoChanged = true
+		}
+	}
+
+	if msg.RemoveCapacity {
+		if event.Capacity() != 0 {
+			b.SetCapacity(0)
+			eventInfoChanged = true
+		}
+	} else if msg.Capacity != 0 && int(msg.Capacity) != event.Capacity() {
+		b.SetCapacity(int(msg.Capacity))
+		eventInfoChanged = true
+	}
+
+	if msg.RemoveQuorum {
+		if event.Quorum() != 0 {
+			b.SetQuorum(0, time.Time{})
+			eventInfoChanged = true
+		}
+	} else if msg.Quorum != 0 || msg.QuorumDeadline != 0 {
+		quorum := event.Quorum()
+		if msg.Quorum != 0 {
+			quorum = int(msg.Quorum)
+		}
+		deadline := event.QuorumDeadline()
+		if msg.QuorumDeadline != 0 {
+			deadline = utils.MillisToTimeUTC(msg.QuorumDeadline)
+		}
+		if quorum != event.Quorum() || !deadline.Equal(event.QuorumDeadline()) {
+			b.SetQuorum(quorum, deadline)
+			eventInfoChanged = true
+		}
+	}
+
 	for _, pID := range msg.Participants {
 		b.ParticipantAdder().AddUserID(pID)
 	}
@@ -418,14 +709,11 @@ func onModifyEvent(request *proto.AyiPacket, message proto.Message, session *Ayi
 	// Get newParticipants
 	newParticipants := server.Model.Events.ExtractNewParticipants(modifiedEvent, event)
 
-	/* DISABLED because send full event causes a crash in iOS version<=1.0.10
-	if eventInfoChanged && len(newParticipants) > 0 {
+	if eventInfoChanged && len(newParticipants) > 0 && session.Supports(CAP_FULL_EVENT_MODIFIED) {
 		netEvent := convEvent2Net(modifiedEvent)
 		session.Write(session.NewMessage().EventModified(netEvent))
 		log.Printf("< (%v) EVENT %v CHANGED\n", session.UserId, modifiedEvent.Id())
-	} else*/
-
-	if eventInfoChanged {
+	} else if eventInfoChanged {
 		// FIXME: If two users modify an event at the same time, each one will
 		// receive a different view of the event
 		netEvent := convEvent2Net(modifiedEvent.CloneWithEmptyParticipants())
@@ -486,7 +774,8 @@ func onCancelEvent(request *proto.AyiPacket, message proto.Message, session *Ayi
 	// Cancel event
 	cancelledEvent, err :=
 		server.Model.Events.NewEventModifier(event, session.UserId).
-			SetCancelled(true).Build()
+			SetCancelled(true).
+			SetApplyToSeries(msg.CancelSeries).Build()
 	checkNoErrorOrPanic(err)
 
 	// Persist event
@@ -549,6 +838,48 @@ func onInviteUsers(request *proto.AyiPacket, message proto.Message, session *Ayi
 	log.Printf("< (%v) EVENT %v ATTENDANCE STATUS CHANGED (%v participants changed)\n", session.UserId, modifiedEvent.Id(), len(netParticipants))
 }
 
+func onCancelUsersInvitation(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.CancelUsersInvitation)
+	log.Printf("> (%v) CANCEL USERS INVITATION %v\n", session, msg)
+
+	checkAuthenticated(session)
+
+	// Fail early
+	if len(msg.Participants) == 0 {
+		session.WriteResponse(request.Header.GetToken(), session.NewMessage().Error(request.Type(), proto.E_EVENT_PARTICIPANTS_REQUIRED))
+		log.Printf("< (%v) CANCEL USERS INVITATION ERROR (event_id=%v) PARTICIPANTS REQUIRED\n", session, msg.EventId)
+		return
+	}
+
+	// Read event
+	event, err := server.Model.Events.LoadEvent(msg.EventId)
+	checkNoErrorOrPanic(err)
+
+	// Build event
+	b := server.Model.Events.NewEventModifier(event, session.UserId)
+	for _, pID := range msg.Participants {
+		b.RemoveParticipant(pID)
+	}
+	modifiedEvent, err := b.Build()
+	checkNoErrorOrPanic(err)
+
+	// Save it
+	err = server.Model.Events.SaveEvent(modifiedEvent)
+	checkNoErrorOrPanic(err)
+
+	// Write response back
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) CANCEL USERS INVITATION OK (eventID: %v, removed: %v, total: %v)\n",
+		session, event.Id(), len(msg.Participants), modifiedEvent.NumGuests())
+
+	// Send removed participants
+	packet := session.NewMessage().ParticipantsRemoved(event.Id(), msg.Participants, modifiedEvent.NumGuests())
+	session.Write(packet)
+	log.Printf("< (%v) EVENT %v ATTENDANCE STATUS CHANGED (%v participants removed)\n", session.UserId, modifiedEvent.Id(), len(msg.Participants))
+}
+
 // When a ConfirmAttendance message is received, the attendance response of the participant
 // in the participant event list is changed and notified to the other participants.
 func onConfirmAttendance(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
@@ -566,6 +897,10 @@ func onConfirmAttendance(request *proto.AyiPacket, message proto.Message, sessio
 		session.UserId, api.AttendanceResponse(msg.ActionCode))
 	checkNoErrorOrPanic(err)
 
+	// Read waitlist after the change
+	event, err := server.Model.Events.LoadEvent(eventID)
+	checkNoErrorOrPanic(err)
+
 	// Send OK Response
 	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
 	log.Printf("< (%v) CONFIRM ATTENDANCE %v OK\n", session, eventID)
@@ -574,10 +909,63 @@ func onConfirmAttendance(request *proto.AyiPacket, message proto.Message, sessio
 	participantList := make(map[int64]*model.Participant)
 	participantList[participant.Id()] = participant
 	netParticipants := convParticipantList2Net(participantList)
-	session.Write(session.NewMessage().AttendanceStatus(eventID, netParticipants))
+	session.Write(session.NewMessage().AttendanceStatusWithWaitlist(eventID, netParticipants,
+		event.Capacity(), event.Participants.Waitlist()))
 	log.Printf("< (%v) EVENT %v ATTENDANCE STATUS CHANGED (%v participants changed)\n", session.UserId, eventID, len(netParticipants))
 }
 
+func onProposeEventChange(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	msg := message.(*proto.EventChangeProposed)
+	startDate := utils.MillisToTimeUTC(msg.StartDate)
+	endDate := utils.MillisToTimeUTC(msg.EndDate)
+	log.Printf("> (%v) PROPOSE EVENT CHANGE %v (message: %v, start: %v, end: %v)\n",
+		session, msg.EventId, msg.Message != "", startDate, endDate)
+
+	checkAuthenticated(session)
+
+	server := session.Server
+
+	// Start voting
+	proposal, err := server.Model.Events.ProposeChange(msg.EventId, session.UserId,
+		startDate, endDate, msg.Message)
+	checkNoErrorOrPanic(err)
+
+	// Send OK Response
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) PROPOSE EVENT CHANGE %v OK (changeId: %v)\n", session, msg.EventId, proposal.Id())
+
+	// Send proposal so that author knows the change id
+	session.Write(session.NewMessage().EventChangeProposed(convChangeProposal2Net(proposal)))
+	log.Printf("< (%v) EVENT %v CHANGE PROPOSED (changeId: %v)\n", session.UserId, msg.EventId, proposal.Id())
+}
+
+func onVoteChange(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	msg := message.(*proto.VoteChange)
+	log.Printf("> (%v) VOTE CHANGE %v\n", session, msg)
+
+	checkAuthenticated(session)
+
+	server := session.Server
+
+	// Vote
+	proposal, err := server.Model.Events.VoteChange(msg.EventId, msg.ChangeId,
+		session.UserId, msg.AcceptChange)
+	checkNoErrorOrPanic(err)
+
+	// Send OK Response
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Ok(request.Type()))
+	log.Printf("< (%v) VOTE CHANGE %v OK\n", session, msg.EventId)
+
+	// Send voting status
+	if !proposal.IsFinished() {
+		session.Write(session.NewMessage().VotingStatus(convVotingStatus2Net(proposal)))
+		log.Printf("< (%v) EVENT %v VOTING STATUS (changeId: %v, votes: %v/%v)\n", session.UserId,
+			msg.EventId, proposal.Id(), proposal.VotesReceived(), proposal.VotesTotal())
+	}
+}
+
 func onReadEvent(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
 
 	msg := message.(*proto.ReadEvent)
@@ -601,26 +989,25 @@ func onReadEvent(request *proto.AyiPacket, message proto.Message, session *AyiSe
 		}
 	}
 
-	session.WriteResponse(request.Header.GetToken(), session.NewMessage().Event(netEvent))
+	session.writeDeliveryResponse(request.Header.GetToken(), session.NewMessage().Event(netEvent), func() {
+		if event.Status() == api.EventState_NOT_STARTED {
+			// Update delivery status
+			if participant, _ := event.Participants.Get(session.UserId); participant != nil {
+				if participant.InvitationStatus() != api.InvitationStatus_CLIENT_DELIVERED {
+					_, err := server.Model.Events.ChangeDeliveryState(session.UserId, api.InvitationStatus_CLIENT_DELIVERED, event)
+					if err != nil {
+						log.Printf("* (%v) READ EVENT UPDATE DELIVERY STATUS ERROR (eventID: %v): %v)", session, event.Id(), err)
+					}
+				}
+			}
+		}
+	})
 	log.Printf("< (%v) SEND EVENT %v\n", session.UserId, event.Id())
 
 	// WORKAROUND: Read EventManager
 	if event.IsCancelled() {
 		server.Model.Events.RemoveFromInbox(session.UserId, event.Id())
 	}
-
-	if event.Status() == api.EventState_NOT_STARTED {
-		// Update delivery status
-		// TODO: I should receive an ACK before try to change state.
-		if participant, _ := event.Participants.Get(session.UserId); participant != nil {
-			if participant.InvitationStatus() != api.InvitationStatus_CLIENT_DELIVERED {
-				_, err := server.Model.Events.ChangeDeliveryState(session.UserId, api.InvitationStatus_CLIENT_DELIVERED, event)
-				if err != nil {
-					log.Printf("* (%v) READ EVENT UPDATE DELIVERY STATUS ERROR (eventID: %v): %v)", session, event.Id(), err)
-				}
-			}
-		}
-	}
 }
 
 func onListPrivateEvents(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
@@ -650,26 +1037,89 @@ func onListPrivateEvents(request *proto.AyiPacket, message proto.Message, sessio
 		}
 	}
 
-	session.WriteResponse(request.Header.GetToken(), session.NewMessage().EventsList(eventList))
+	session.writeDeliveryResponse(request.Header.GetToken(), session.NewMessage().EventsList(eventList), func() {
+		// Update delivery status
+		for _, event := range events {
+			if participant, _ := event.Participants.Get(session.UserId); participant != nil {
+				if participant.InvitationStatus() != api.InvitationStatus_CLIENT_DELIVERED {
+					_, err := server.Model.Events.ChangeDeliveryState(session.UserId, api.InvitationStatus_CLIENT_DELIVERED, event)
+					if err != nil {
+						log.Printf("* (%v) SEND PRIVATE EVENTS UPDATE DELIVERY STATUS ERROR (eventID: %v): %v)", session, event.Id(), err)
+					}
+				}
+			}
+		}
+	})
 
-	// Update delivery status
+	// WORKAROUND: Read EventManager
 	for _, event := range events {
-
-		// WORKAROUND: Read EventManager
 		if event.IsCancelled() {
 			server.Model.Events.RemoveFromInbox(session.UserId, event.Id())
 		}
+	}
+}
 
-		// TODO: I should receive an ACK before try to change state.
-		if participant, _ := event.Participants.Get(session.UserId); participant != nil {
-			if participant.InvitationStatus() != api.InvitationStatus_CLIENT_DELIVERED {
-				_, err := server.Model.Events.ChangeDeliveryState(session.UserId, api.InvitationStatus_CLIENT_DELIVERED, event)
-				if err != nil {
-					log.Printf("* (%v) SEND PRIVATE EVENTS UPDATE DELIVERY STATUS ERROR (eventID: %v): %v)", session, event.Id(), err)
-				}
-			}
+// Returns public events around a location. If request has no coordinates, last
+// known position of the user is used instead.
+func onListPublicEvents(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.EventListRequest)
+
+	log.Printf("> (%v) REQUEST PUBLIC EVENTS (coordinates: %v, range: %v)\n",
+		session, msg.UserCoordinates != nil, msg.RangeInMeters)
+
+	checkAuthenticated(session)
+
+	var location *model.Location
+	rangeInMeters := float64(msg.RangeInMeters)
+
+	if msg.UserCoordinates != nil {
+		location = convNetLocation(msg.UserCoordinates)
+	} else {
+		position, err := server.Model.Accounts.GetUserPosition(session.UserId)
+		checkNoErrorOrPanic(err)
+		if position.LastUpdate.IsZero() {
+			panic(ErrUnknownPosition)
+		}
+		location = &position.Location
+		if rangeInMeters == 0 {
+			rangeInMeters = float64(position.RangeInMeters)
 		}
 	}
+
+	events, err := server.Model.Events.GetPublicEvents(location, rangeInMeters)
+
+	if err == model.ErrEmptyInbox {
+		log.Printf("< (%v) SEND PUBLIC EVENTS (num.events: %v)", session, 0)
+		session.WriteResponse(request.Header.GetToken(), session.NewMessage().EventsList(nil))
+		return
+	}
+
+	checkNoErrorOrPanic(err)
+
+	log.Printf("< (%v) SEND PUBLIC EVENTS (num.events: %v)", session, len(events))
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().EventsList(convEventList2Net(events)))
+}
+
+func onListAuthoredEvents(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	log.Printf("> (%v) REQUEST AUTHORED EVENTS\n", session) // Message does not has payload
+	checkAuthenticated(session)
+
+	server := session.Server
+	events, err := server.Model.Events.GetRecentAuthoredEvents(session.UserId)
+
+	if err == model.ErrEmptyInbox {
+		log.Printf("< (%v) SEND AUTHORED EVENTS (num.events: %v)", session, 0)
+		session.WriteResponse(request.Header.GetToken(), session.NewMessage().EventsList(nil))
+		return
+	}
+
+	checkNoErrorOrPanic(err)
+
+	log.Printf("< (%v) SEND AUTHORED EVENTS (num.events: %v)", session, len(events))
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().EventsList(convEventList2Net(events)))
 }
 
 func onListEventsHistory(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
@@ -687,10 +1137,49 @@ func onListEventsHistory(request *proto.AyiPacket, message proto.Message, sessio
 	events, err := server.Model.Events.GetEventsHistory(session.UserId, reqStartWindow, reqEndWindow)
 	checkNoErrorOrPanic(err)
 
+	startWindow, endWindow := getHistoryWindow(events, msg.StartWindow < msg.EndWindow)
+
+	// Send event list to user
+	log.Printf("< (%v) SEND EVENTS HISTORY (num.events: %v, startWindow: %v, endWindow: %v)",
+		session, len(events), startWindow, endWindow)
+
+	session.WriteResponse(request.Header.GetToken(),
+		session.NewMessage().EventsHistoryList(convEventList2Net(events),
+			utils.TimeToMillis(startWindow), utils.TimeToMillis(endWindow)))
+}
+
+func onListAuthoredEventsHistory(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.EventListRequest)
+
+	log.Printf("> (%v) REQUEST AUTHORED EVENTS HISTORY (start: %v, end: %v)\n",
+		session, utils.MillisToTimeUTC(msg.StartWindow), utils.MillisToTimeUTC(msg.EndWindow))
+
+	checkAuthenticated(session)
+
+	reqStartWindow := utils.MillisToTimeUTC(msg.StartWindow)
+	reqEndWindow := utils.MillisToTimeUTC(msg.EndWindow)
+	events, err := server.Model.Events.GetAuthoredEventsHistory(session.UserId, reqStartWindow, reqEndWindow)
+	checkNoErrorOrPanic(err)
+
+	startWindow, endWindow := getHistoryWindow(events, msg.StartWindow < msg.EndWindow)
+
+	log.Printf("< (%v) SEND AUTHORED EVENTS HISTORY (num.events: %v, startWindow: %v, endWindow: %v)",
+		session, len(events), startWindow, endWindow)
+
+	session.WriteResponse(request.Header.GetToken(),
+		session.NewMessage().EventsHistoryList(convEventList2Net(events),
+			utils.TimeToMillis(startWindow), utils.TimeToMillis(endWindow)))
+}
+
+// Returns the window covered by a list of events of the history. Events are
+// sorted ascending if forward is true, and descending otherwise.
+func getHistoryWindow(events []*model.Event, forward bool) (startWindow time.Time, endWindow time.Time) {
+
 	var firstEvent, lastEvent *model.Event
-	var startWindow, endWindow time.Time
 
-	if msg.StartWindow < msg.EndWindow {
+	if forward {
 		firstEvent, lastEvent = events[0], events[len(events)-1]
 	} else {
 		firstEvent, lastEvent = events[len(events)-1], events[0]
@@ -708,13 +1197,35 @@ func onListEventsHistory(request *proto.AyiPacket, message proto.Message, sessio
 		endWindow = lastEvent.EndDate()
 	}
 
-	// Send event list to user
-	log.Printf("< (%v) SEND EVENTS HISTORY (num.events: %v, startWindow: %v, endWindow: %v)",
-		session, len(events), startWindow, endWindow)
+	return startWindow, endWindow
+}
 
-	session.WriteResponse(request.Header.GetToken(),
-		session.NewMessage().EventsHistoryList(convEventList2Net(events),
-			utils.TimeToMillis(startWindow), utils.TimeToMillis(endWindow)))
+// Returns events of the user that changed after the cursor sent by client. If
+// cursor is too old, client must request its whole inbox again.
+func onSyncEvents(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
+
+	server := session.Server
+	msg := message.(*proto.SyncEvents)
+
+	log.Printf("> (%v) SYNC EVENTS (cursor: %v, event: %v)\n", session, msg.Cursor, msg.CursorEventId)
+
+	checkAuthenticated(session)
+
+	changes, err := server.Model.Events.GetEventChangesSince(session.UserId, msg.Cursor, msg.CursorEventId)
+	checkNoErrorOrPanic(err)
+
+	netChanges := &proto.EventChanges{
+		Events:        convEventList2Net(changes.Events),
+		RemovedEvents: changes.RemovedEvents,
+		Cursor:        changes.Cursor,
+		CursorEventId: changes.CursorEventID,
+		HasMore:       changes.HasMore,
+	}
+
+	log.Printf("< (%v) SEND EVENT CHANGES (num.events: %v, removed: %v, cursor: %v, more: %v)\n",
+		session, len(changes.Events), len(changes.RemovedEvents), changes.Cursor, changes.HasMore)
+
+	session.WriteResponse(request.Header.GetToken(), session.NewMessage().EventChanges(netChanges))
 }
 
 func onGetUserFriends(request *proto.AyiPacket, message proto.Message, session *AyiSession) {
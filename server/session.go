@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/d3ce1t/areyouin-server/model"
 	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/d3ce1t/areyouin-server/protocol/capture"
 )
 
 const (
@@ -22,11 +25,16 @@ const (
 	// Platforms
 	PLATFORM_IOS     = "iOS"
 	PLATFORM_ANDROID = "Android"
+
+	// Writes with ACK
+	ACK_TIMEOUT     = 10 * time.Second
+	ACK_MAX_RETRIES = 2
 )
 
 type WriteMsg struct {
-	Packet *proto.AyiPacket
-	Future *Future
+	Packet   *proto.AyiPacket
+	Future   *Future
+	attempts int // Times a packet that requires ACK has been written
 }
 
 type Future struct {
@@ -77,6 +85,21 @@ type AyiSession struct {
 	// Platform Version
 	PlatformVersion string
 
+	// Device where client runs. A user has at most one session per device.
+	DeviceID string
+
+	// Language of client (language tag sent in HELLO)
+	Language string
+
+	// Client supports compressed payloads
+	CompressionEnabled bool
+
+	// Capabilities declared by client in HELLO
+	Capabilities []string
+
+	// Guards client info set in HELLO, which is read by the writer
+	helloMutex sync.RWMutex
+
 	IsAuth        bool
 	readReadyChan chan *proto.AyiPacket
 	errorChan     chan error
@@ -84,15 +107,21 @@ type AyiSession struct {
 	writeChan     chan *WriteMsg
 	Server        *Server
 	IIDToken      *model.IIDToken
-	closed        bool
+	closed        int32 // Accessed atomically, 1 if closed
 	lastRecvMsg   time.Time
 	pendingResp   map[uint16]chan bool
+	pendingMutex  sync.Mutex
 	nextToken     uint16 // most significant bit reserved (1 -> Response, 0 -> Normal). Reset each 32768 messages (0 - 32767)
 	OnRead        func(s *AyiSession, packet *proto.AyiPacket)
 	OnError       func(s *AyiSession, err error)
 	OnClosed      func(s *AyiSession, peer bool)
 	OnIdle        func(s *AyiSession)
 	pingTime      time.Time
+
+	// Packet capture (see capture.go)
+	captureMutex sync.Mutex
+	capture      *capture.Writer
+	captureFile  string
 }
 
 func (s *AyiSession) String() string {
@@ -104,7 +133,12 @@ func (s *AyiSession) String() string {
 }
 
 func (s *AyiSession) IsClosed() bool {
-	return s.closed
+	return atomic.LoadInt32(&s.closed) == 1
+}
+
+// setClosed marks the session as closed. Returns false if it already was.
+func (s *AyiSession) setClosed() bool {
+	return atomic.CompareAndSwapInt32(&s.closed, 0, 1)
 }
 
 func (s *AyiSession) NewMessage() proto.MessageBuilder {
@@ -174,6 +208,54 @@ func (s *AyiSession) WriteResponse(token uint16, packet *proto.AyiPacket) (ok bo
 	return true
 }
 
+// Writes a response that client must acknowledge. Returned future is
+// signalled with true when the ACK is received, or with false if the packet
+// couldn't be written or wasn't acknowledged after ACK_MAX_RETRIES.
+func (s *AyiSession) WriteResponseWithAck(token uint16, packet *proto.AyiPacket) (future *Future) {
+
+	future = newFuture(true)
+
+	defer func() {
+		if r := recover(); r != nil {
+			future.C <- false
+			log.Printf("Session %v Write Error: %v\n", s, r)
+		}
+	}()
+
+	packet.Header.SetToken(token | (1 << 15))
+
+	// may panic if writeChan is closed
+	s.writeChan <- &WriteMsg{
+		Packet: packet,
+		Future: future,
+	}
+
+	return future
+}
+
+// Writes a response that delivers content to the user and calls onDelivered
+// once it has been received. If client doesn't acknowledge responses, it's
+// assumed to be received as soon as it's queued.
+func (s *AyiSession) writeDeliveryResponse(token uint16, packet *proto.AyiPacket, onDelivered func()) {
+
+	if !s.Supports(CAP_ACK_RESPONSES) {
+		if s.WriteResponse(token, packet) {
+			onDelivered()
+		}
+		return
+	}
+
+	future := s.WriteResponseWithAck(token, packet)
+
+	go func() {
+		if <-future.C {
+			onDelivered()
+		} else {
+			log.Printf("* (%v) %v response %v not delivered\n", s, packet.Type(), token)
+		}
+	}()
+}
+
 func (s *AyiSession) WriteAsync(future *Future, packet *proto.AyiPacket) (ok bool) {
 
 	defer func() {
@@ -229,7 +311,7 @@ func (s *AyiSession) eventLoop() (exit bool) {
 
 	defer func() {
 		if r := recover(); r != nil {
-			exit = s.closed
+			exit = s.IsClosed()
 			log.Printf("Session %v EventLoop Panic: %v\n", s, r)
 		}
 	}()
@@ -253,8 +335,7 @@ func (s *AyiSession) eventLoop() (exit bool) {
 }
 
 func (s *AyiSession) Exit() {
-	if !s.closed {
-		s.closed = true
+	if s.setClosed() {
 		go func() {
 			s.exitChan <- false
 		}()
@@ -273,7 +354,7 @@ func (s *AyiSession) closeSocket() {
 func (s *AyiSession) startRecv() {
 	if s.Conn != nil {
 		go func() {
-			for !s.closed {
+			for !s.IsClosed() {
 				s.doRead()
 			}
 		}()
@@ -285,7 +366,7 @@ func (s *AyiSession) startRecv() {
 func (s *AyiSession) startWrite() {
 	if s.Conn != nil {
 		go func() {
-			for !s.closed {
+			for !s.IsClosed() {
 				for writeMsg := range s.writeChan {
 					s.manageWrite(writeMsg)
 				}
@@ -311,6 +392,8 @@ func (s *AyiSession) doRead() {
 
 		// Read
 
+		s.capturePacket(capture.Inbound, packet)
+
 		if !s.manageSessionMsg(packet) {
 			s.readReadyChan <- packet
 		}
@@ -319,13 +402,12 @@ func (s *AyiSession) doRead() {
 
 		// Manage Error
 
-		if err == proto.ErrConnectionClosed || s.closed {
+		if err == proto.ErrConnectionClosed || s.IsClosed() {
 
 			// If socket was closed by remote peer, then write to exitChan. Otherwise,
 			// ignore it because Exit() already writes to that channel.
 
-			if !s.closed {
-				s.closed = true
+			if s.setClosed() {
 				s.exitChan <- true
 			}
 		} else {
@@ -336,7 +418,9 @@ func (s *AyiSession) doRead() {
 
 func (s *AyiSession) doWrite(msg *WriteMsg) {
 
-	_, err := proto.WriteBytes(msg.Packet.Marshal(), s.Conn)
+	s.capturePacket(capture.Outbound, msg.Packet)
+
+	_, err := proto.WriteBytes(s.marshalPacket(msg.Packet), s.Conn)
 	if err != nil {
 		s.errorChan <- err
 	}
@@ -345,42 +429,124 @@ func (s *AyiSession) doWrite(msg *WriteMsg) {
 	}
 }
 
+// Writes a packet and waits for its ACK in background. If it isn't received
+// in ACK_TIMEOUT, packet is written again up to ACK_MAX_RETRIES times before
+// giving up and keeping it in the outbox of the device. An ACK for any attempt
+// acknowledges the packet.
 func (s *AyiSession) doWriteWithAck(msg *WriteMsg) {
 
+	packetID := msg.Packet.Id()
+	userID, deviceID := s.UserId, s.DeviceID
 	waitResponse := make(chan bool, 1)
-	s.pendingResp[msg.Packet.Id()] = waitResponse
-	log.Printf("* (%v) Register write with ACK for packet %v\n", s, msg.Packet.Id())
+	s.setPendingAck(packetID, waitResponse)
+
+	if msg.attempts == 0 {
+		log.Printf("* (%v) Register write with ACK for packet %v\n", s, packetID)
+	}
+	msg.attempts++
 
-	_, err := proto.WriteBytes(msg.Packet.Marshal(), s.Conn)
+	s.capturePacket(capture.Outbound, msg.Packet)
+
+	sentTime := time.Now()
+	_, err := proto.WriteBytes(s.marshalPacket(msg.Packet), s.Conn)
 	if err != nil {
-		delete(s.pendingResp, msg.Packet.Id())
-		close(waitResponse)
+		s.removePendingAck(packetID)
 		s.errorChan <- err
 		msg.Future.C <- false
 		return
 	}
 
 	go func() {
-		var err error
-
-		defer func() {
-			delete(s.pendingResp, msg.Packet.Id())
-			close(waitResponse)
-
-			msg.Future.C <- (err == nil)
-			if err != nil {
-				s.errorChan <- err
-			}
-		}()
 
 		select {
 		case <-waitResponse:
-		case <-time.After(10 * time.Second):
-			err = proto.ErrTimeout
+			s.removePendingAck(packetID)
+			s.Server.ackMetrics.Acked(time.Since(sentTime))
+			msg.Future.C <- true
+
+		case <-time.After(ACK_TIMEOUT):
+			s.removePendingAck(packetID)
+
+			if msg.attempts <= ACK_MAX_RETRIES && !s.IsClosed() {
+				log.Printf("* (%v) ACK timeout for packet %v. Retry %v of %v\n", s, packetID, msg.attempts, ACK_MAX_RETRIES)
+				s.Server.ackMetrics.Retried()
+				s.retryWrite(msg)
+				return
+			}
+
+			log.Printf("* (%v) Packet %v not acknowledged\n", s, packetID)
+			s.Server.ackMetrics.TimedOut()
+			s.keepUnacknowledged(userID, deviceID, msg.Packet)
+			msg.Future.C <- false
 		}
 	}()
 }
 
+// keepUnacknowledged keeps a packet the device didn't acknowledge in its
+// outbox, so that it is replayed when the session is resumed instead of
+// closing the session
+func (s *AyiSession) keepUnacknowledged(userID int64, deviceID string, packet *proto.AyiPacket) bool {
+	if s.Server.outboxes.Add(userID, deviceID, packet.Copy()) {
+		log.Printf("* (%v) %v kept in outbox (device: %v)\n", userID, packet.Type(), deviceID)
+		return true
+	}
+	return false
+}
+
+// Queues again a packet that wasn't acknowledged
+func (s *AyiSession) retryWrite(msg *WriteMsg) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			msg.Future.C <- false
+			log.Printf("Session %v Retry Write Error: %v\n", s, r)
+		}
+	}()
+
+	// may panic if writeChan is closed
+	s.writeChan <- msg
+}
+
+func (s *AyiSession) setPendingAck(packetID uint16, c chan bool) {
+	s.pendingMutex.Lock()
+	s.pendingResp[packetID] = c
+	s.pendingMutex.Unlock()
+}
+
+func (s *AyiSession) removePendingAck(packetID uint16) {
+	s.pendingMutex.Lock()
+	delete(s.pendingResp, packetID)
+	s.pendingMutex.Unlock()
+}
+
+// Signals the write waiting for the ACK of packetID, if any
+func (s *AyiSession) ackReceived(packetID uint16) bool {
+
+	s.pendingMutex.Lock()
+	c, ok := s.pendingResp[packetID]
+	s.pendingMutex.Unlock()
+
+	if ok {
+		select {
+		case c <- true:
+		default: // Duplicated ACK
+		}
+	}
+
+	return ok
+}
+
+// Marshals packet compressing its payload if client supports it
+func (s *AyiSession) marshalPacket(packet *proto.AyiPacket) []byte {
+	s.helloMutex.RLock()
+	compressionEnabled := s.CompressionEnabled
+	s.helloMutex.RUnlock()
+	if compressionEnabled {
+		return packet.MarshalCompressed()
+	}
+	return packet.Marshal()
+}
+
 func (s *AyiSession) manageWrite(writeMsg *WriteMsg) {
 
 	defer func() {
@@ -396,6 +562,23 @@ func (s *AyiSession) manageWrite(writeMsg *WriteMsg) {
 	}
 }
 
+// Sets client info sent in HELLO. Info read from the writer goroutine is
+// guarded by helloMutex.
+func (s *AyiSession) setClientInfo(hello *proto.Hello) {
+
+	defer s.helloMutex.Unlock()
+	s.helloMutex.Lock()
+
+	s.ProtocolVersion = uint8(hello.ProtocolVersion)
+	s.ClientVersion = hello.ClientVersion
+	s.Platform = hello.Platform
+	s.PlatformVersion = hello.PlatformVersion
+	s.CompressionEnabled = proto.SupportsCompression(hello, proto.COMPRESSION_SNAPPY)
+	s.Capabilities = hello.Capabilities
+	s.DeviceID = hello.DeviceId
+	s.Language = hello.Language
+}
+
 // Manage session messages. Returns true if message has been
 // managed or false otherwise
 func (s *AyiSession) manageSessionMsg(packet *proto.AyiPacket) bool {
@@ -424,7 +607,10 @@ func (s *AyiSession) manageSessionMsg(packet *proto.AyiPacket) bool {
 	// USE TLS
 	if packet.Header.GetType() == proto.M_USE_TLS {
 		log.Printf("> (%v) USE TLS\n", s)
-		if tlsconn, ok := s.Conn.(*tls.Conn); !ok {
+		if _, ok := s.Conn.(*wsConn); ok {
+			// WebSocket connections are secured by the listener itself
+			log.Printf("* (%v) Ignore USE TLS on WebSocket connection\n", s)
+		} else if tlsconn, ok := s.Conn.(*tls.Conn); !ok {
 			log.Printf("* (%v) Changing to use TLS\n", s)
 			if tlsconn = tls.Server(s.Conn, s.Server.TLSConfig); tlsconn != nil {
 				s.Conn = tlsconn
@@ -440,20 +626,22 @@ func (s *AyiSession) manageSessionMsg(packet *proto.AyiPacket) bool {
 		generic_message, _ := packet.DecodeMessage()
 		if generic_message != nil {
 			hello_info := generic_message.(*proto.Hello)
-			s.ProtocolVersion = uint8(hello_info.ProtocolVersion)
-			s.ClientVersion = hello_info.ClientVersion
-			s.Platform = hello_info.Platform
-			s.PlatformVersion = hello_info.PlatformVersion
+			s.setClientInfo(hello_info)
 			log.Printf("> (%v) HELLO %v\n", s, hello_info)
+
+			if !s.isClientVersionSupported() {
+				s.WriteResponseSync(packet.Id(), s.NewMessage().Error(proto.M_HELLO, proto.E_UPGRADE_REQUIRED))
+				log.Printf("< (%v) UPGRADE REQUIRED (%v %v)\n", s, s.Platform, s.ClientVersion)
+				s.Exit()
+			}
 		}
 		return true
 	}
 
 	// REQUIRE ACK
 	if packet.Header.GetType() == proto.M_OK && !packet.HasPayload() {
-		if c, ok := s.pendingResp[packet.Id()]; ok {
+		if s.ackReceived(packet.Id()) {
 			log.Printf("> (%v) ACK for packet with id %v\n", s.UserId, packet.Id())
-			c <- true
 		}
 		return true
 	}
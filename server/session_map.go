@@ -6,41 +6,77 @@ import (
 
 func NewSessionsMap() *SessionsMap {
 	object := &SessionsMap{
-		m: make(map[int64]*AyiSession),
+		m: make(map[int64]map[string]*AyiSession),
 	}
 	return object
 }
 
+// SessionsMap holds sessions of authenticated users. A user may have one
+// session per device, identified by the device id sent in HELLO.
 type SessionsMap struct {
 	mutex sync.RWMutex
-	m     map[int64]*AyiSession
+	m     map[int64]map[string]*AyiSession
 }
 
-func (sm *SessionsMap) Get(key int64) (v *AyiSession, ok bool) {
+// Get returns the session of userID in deviceID
+func (sm *SessionsMap) Get(userID int64, deviceID string) (v *AyiSession, ok bool) {
 	defer sm.mutex.RUnlock()
 	sm.mutex.RLock()
-	v, ok = sm.m[key]
+	v, ok = sm.m[userID][deviceID]
 	return
 }
 
-func (sm *SessionsMap) Put(key int64, session *AyiSession) {
+// GetAll returns sessions of userID in every device
+func (sm *SessionsMap) GetAll(userID int64) []*AyiSession {
+	defer sm.mutex.RUnlock()
+	sm.mutex.RLock()
+	devices := sm.m[userID]
+	sessions := make([]*AyiSession, 0, len(devices))
+	for _, session := range devices {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+// Put adds session to its user and device. If there was another session for
+// the same device, it is replaced and returned.
+func (sm *SessionsMap) Put(session *AyiSession) (old *AyiSession) {
 	defer sm.mutex.Unlock()
 	sm.mutex.Lock()
-	sm.m[key] = session
+	devices, ok := sm.m[session.UserId]
+	if !ok {
+		devices = make(map[string]*AyiSession)
+		sm.m[session.UserId] = devices
+	}
+	old = devices[session.DeviceID]
+	devices[session.DeviceID] = session
+	return old
 }
 
-func (sm *SessionsMap) Remove(key int64) {
+// Remove removes session only if it's the current session of its device.
+// Returns true if it was removed.
+func (sm *SessionsMap) Remove(session *AyiSession) bool {
 	defer sm.mutex.Unlock()
 	sm.mutex.Lock()
-	delete(sm.m, key)
+	devices := sm.m[session.UserId]
+	if devices[session.DeviceID] != session {
+		return false
+	}
+	delete(devices, session.DeviceID)
+	if len(devices) == 0 {
+		delete(sm.m, session.UserId)
+	}
+	return true
 }
 
+// Len returns the number of users with at least one session
 func (sm *SessionsMap) Len() int {
 	defer sm.mutex.RUnlock()
 	sm.mutex.RLock()
 	return len(sm.m)
 }
 
+// Keys returns IDs of users with at least one session
 func (sm *SessionsMap) Keys() (keys []int64) {
 	defer sm.mutex.RUnlock()
 	sm.mutex.RLock()
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestSessionsMapDevices(t *testing.T) {
+
+	sm := NewSessionsMap()
+
+	phone := &AyiSession{UserId: 100, DeviceID: "phone"}
+	tablet := &AyiSession{UserId: 100, DeviceID: "tablet"}
+
+	if old := sm.Put(phone); old != nil {
+		t.Fatal("unexpected old session")
+	}
+	if old := sm.Put(tablet); old != nil {
+		t.Fatal("session of another device replaced")
+	}
+
+	if sessions := sm.GetAll(100); len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %v", len(sessions))
+	}
+
+	// Same device replaces its previous session
+	newPhone := &AyiSession{UserId: 100, DeviceID: "phone"}
+	if old := sm.Put(newPhone); old != phone {
+		t.Fatal("old session of device not replaced")
+	}
+
+	if sm.Remove(phone) {
+		t.Fatal("replaced session removed")
+	}
+
+	if s, ok := sm.Get(100, "phone"); !ok || s != newPhone {
+		t.Fatal("current session of device not found")
+	}
+
+	sm.Remove(newPhone)
+	sm.Remove(tablet)
+
+	if sm.Len() != 0 || len(sm.GetAll(100)) != 0 {
+		t.Fatal("sessions not removed")
+	}
+}
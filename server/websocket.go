@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WEBSOCKET_PATH = "/ws"
+)
+
+// wsConn adapts a WebSocket connection to net.Conn so that AyiSession and
+// protocol.ReadPacket can use it as a TCP stream. Each packet written is sent
+// as a single binary message. Text messages received are ignored.
+type wsConn struct {
+	*websocket.Conn
+	reader io.Reader // Reader of the message being read
+}
+
+func newWsConn(conn *websocket.Conn) *wsConn {
+	return &wsConn{Conn: conn}
+}
+
+func (c *wsConn) Read(b []byte) (int, error) {
+
+	for {
+
+		if c.reader == nil {
+
+			msgType, reader, err := c.Conn.NextReader()
+			if err != nil {
+				if _, ok := err.(*websocket.CloseError); ok {
+					return 0, io.EOF
+				}
+				return 0, err
+			}
+
+			if msgType != websocket.BinaryMessage {
+				continue
+			}
+
+			c.reader = reader
+		}
+
+		n, err := c.reader.Read(b)
+
+		if err == io.EOF {
+			// Packets may span several messages, so keep reading from the next one
+			c.reader = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+
+		return n, err
+	}
+}
+
+func (c *wsConn) Write(b []byte) (int, error) {
+	if err := c.Conn.WriteMessage(websocket.BinaryMessage, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (c *wsConn) SetDeadline(t time.Time) error {
+	if err := c.Conn.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.Conn.SetWriteDeadline(t)
+}
+
+// Start up WebSocket listener. Connections are served exactly the same as TCP
+// connections.
+func (s *Server) runWebSocket() {
+
+	allowedOrigins := make(map[string]bool)
+	for _, origin := range s.Config.WebSocketAllowedOrigins() {
+		allowedOrigins[origin] = true
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  4096,
+		WriteBufferSize: 4096,
+	}
+
+	// If no origin is configured, only same origin requests are allowed
+	if len(allowedOrigins) > 0 {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			return allowedOrigins[r.Header.Get("Origin")]
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(WEBSOCKET_PATH, func(w http.ResponseWriter, r *http.Request) {
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Println("WebSocket upgrade failed:", err)
+			return
+		}
+
+		session := NewSession(newWsConn(conn), s)
+		go s.handleSession(session)
+	})
+
+	addr := fmt.Sprintf("%v:%v", s.Config.ListenAddress(), s.Config.WebSocketListenPort())
+	log.Printf("Listening WebSocket connections at %v%v\n", addr, WEBSOCKET_PATH)
+
+	var err error
+
+	if s.Config.WebSocketEnableTLS() {
+		err = http.ListenAndServeTLS(addr, s.Config.CertFile(), s.Config.CertKey(), mux)
+	} else {
+		err = http.ListenAndServe(addr, mux)
+	}
+
+	if err != nil {
+		log.Println("WebSocket ListenAndServe:", err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	proto "github.com/d3ce1t/areyouin-server/protocol"
+	"github.com/gorilla/websocket"
+)
+
+func TestWsConnReadPacket(t *testing.T) {
+
+	upgrader := websocket.Upgrader{}
+	received := make(chan *proto.AyiPacket, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		packet, err := proto.ReadPacket(newWsConn(conn))
+		if err != nil {
+			t.Error(err)
+		}
+		received <- packet
+	}))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	packet := proto.NewPacket(2).EventExpired(1234)
+	data := packet.Marshal()
+
+	// Text messages are ignored and a packet may be split in several messages
+	client := newWsConn(conn)
+	conn.WriteMessage(websocket.TextMessage, []byte("ignored"))
+	conn.WriteMessage(websocket.BinaryMessage, data[:3])
+	if _, err := client.Write(data[3:]); err != nil {
+		t.Fatal(err)
+	}
+
+	recvPacket := <-received
+	if recvPacket == nil {
+		t.FailNow()
+	}
+
+	if recvPacket.Type() != proto.M_EVENT_EXPIRED || string(recvPacket.Marshal()) != string(data) {
+		t.Fatalf("Received packet %v doesn't match sent packet %v", recvPacket, packet)
+	}
+}
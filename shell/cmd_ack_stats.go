@@ -0,0 +1,20 @@
+package shell
+
+import (
+	"fmt"
+)
+
+// ack_stats
+type ackStatsCmd struct {
+}
+
+func (c *ackStatsCmd) Exec(shell *Shell, args []string) {
+
+	stats := shell.server.AckStats()
+
+	fmt.Fprintf(shell, "Acknowledged: %v\n", stats.Acked)
+	fmt.Fprintf(shell, "Retries: %v\n", stats.Retries)
+	fmt.Fprintf(shell, "Timeouts: %v\n", stats.Timeouts)
+	fmt.Fprintf(shell, "Avg. latency: %v\n", stats.AvgLatency)
+	fmt.Fprintf(shell, "Max. latency: %v\n", stats.MaxLatency)
+}
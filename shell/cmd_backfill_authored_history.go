@@ -0,0 +1,19 @@
+package shell
+
+import (
+	"fmt"
+)
+
+// backfill_authored_history
+type backfillAuthoredHistoryCmd struct {
+}
+
+// Events archived before authors had their own history are missing from it.
+// This command adds them.
+func (c *backfillAuthoredHistoryCmd) Exec(shell *Shell, args []string) {
+
+	count, err := shell.model.Events.BackfillAuthoredHistory()
+	manageShellError(err)
+
+	fmt.Fprintf(shell, "%v events added to authored history\n", count)
+}
@@ -0,0 +1,66 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+)
+
+// capture -user-id 1234 [-stop]
+type captureCmd struct {
+}
+
+func (c *captureCmd) Exec(shell *Shell, args []string) {
+
+	var userID int64
+	var stop bool
+
+	cmd := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	cmd.SetOutput(shell)
+	cmd.Usage = func() {
+		fmt.Fprintf(shell, "Usage of %s:\n", args[0])
+		fmt.Fprintf(shell, "  Without arguments, list users whose packets are being captured\n")
+		cmd.PrintDefaults()
+	}
+
+	cmd.Int64Var(&userID, "user-id", 0, "ID of the user whose packets are captured")
+	cmd.BoolVar(&stop, "stop", false, "Stop capturing packets of the user")
+
+	err := cmd.Parse(args[1:])
+	if err == flag.ErrHelp {
+		return
+	}
+
+	manageShellError(err)
+
+	if userID == 0 {
+		if stop {
+			cmd.Usage()
+			return
+		}
+		users := shell.server.CapturedUsers()
+		if len(users) == 0 {
+			fmt.Fprintln(shell, "No captures in progress")
+		}
+		for _, id := range users {
+			fmt.Fprintf(shell, "- %v\n", id)
+		}
+		return
+	}
+
+	if stop {
+		manageShellError(shell.server.StopCapture(userID))
+		fmt.Fprintf(shell, "Capture of user %v stopped\n", userID)
+		return
+	}
+
+	files, err := shell.server.StartCapture(userID)
+	manageShellError(err)
+
+	for _, file := range files {
+		fmt.Fprintf(shell, "Capturing packets of user %v to %v\n", userID, file)
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintf(shell, "User %v is not connected. Capture will start on next login\n", userID)
+	}
+}
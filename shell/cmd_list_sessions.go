@@ -18,5 +18,17 @@ func (c *listSessionsCmd) Exec(shell *Shell, args []string) {
 
 	for _, activeSession := range activeSessions {
 		fmt.Fprintf(shell, "- %v %v\n", activeSession.UserID, utils.MillisToTimeUTC(activeSession.LastTime))
+		for _, device := range shell.server.UserSessions(activeSession.UserID) {
+			fmt.Fprintf(shell, "    * %v %v %v (%v)\n", deviceName(device.DeviceID), device.Platform,
+				device.ClientVersion, device.RemoteAddr)
+		}
 	}
 }
+
+// Clients that don't send a device id in HELLO share the same device
+func deviceName(deviceID string) string {
+	if deviceID == "" {
+		return "(unknown device)"
+	}
+	return deviceID
+}
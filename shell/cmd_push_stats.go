@@ -0,0 +1,79 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/d3ce1t/areyouin-server/api"
+)
+
+// push_stats [-user-id 1234]
+type pushStatsCmd struct {
+}
+
+func (c *pushStatsCmd) Exec(shell *Shell, args []string) {
+
+	var userID int64
+
+	cmd := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	cmd.SetOutput(shell)
+	cmd.Usage = func() {
+		fmt.Fprintf(shell, "Usage of %s:\n", args[0])
+		fmt.Fprintf(shell, "  Without arguments, show stats of every user sent a push notification\n")
+		cmd.PrintDefaults()
+	}
+
+	cmd.Int64Var(&userID, "user-id", 0, "ID of the user whose stats are shown")
+
+	err := cmd.Parse(args[1:])
+	if err == flag.ErrHelp {
+		return
+	}
+
+	manageShellError(err)
+
+	allStats := shell.server.PushStats()
+
+	if userID != 0 {
+		stats, ok := allStats[userID]
+		if !ok {
+			fmt.Fprintf(shell, "No push notifications sent to %v\n", userID)
+			return
+		}
+		fmt.Fprintf(shell, "Sent: %v\n", stats.Sent)
+		fmt.Fprintf(shell, "Failed: %v\n", stats.Failed)
+		fmt.Fprintf(shell, "Retries: %v\n", stats.Retries)
+		fmt.Fprintf(shell, "Dropped: %v\n", stats.Dropped)
+		fmt.Fprintf(shell, "Invalid tokens: %v\n", stats.InvalidTokens)
+		if !stats.LastSent.IsZero() {
+			fmt.Fprintf(shell, "Last sent: %v\n", stats.LastSent)
+		}
+		if stats.LastError != "" {
+			fmt.Fprintf(shell, "Last error: %v (%v)\n", stats.LastError, stats.LastErrorTime)
+		}
+		return
+	}
+
+	users := make([]int64, 0, len(allStats))
+	for id := range allStats {
+		users = append(users, id)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+
+	var total api.PushStats
+
+	for _, id := range users {
+		stats := allStats[id]
+		fmt.Fprintf(shell, "- %v sent: %v failed: %v retries: %v dropped: %v invalid tokens: %v\n",
+			id, stats.Sent, stats.Failed, stats.Retries, stats.Dropped, stats.InvalidTokens)
+		total.Sent += stats.Sent
+		total.Failed += stats.Failed
+		total.Retries += stats.Retries
+		total.Dropped += stats.Dropped
+		total.InvalidTokens += stats.InvalidTokens
+	}
+
+	fmt.Fprintf(shell, "Total: sent: %v failed: %v retries: %v dropped: %v invalid tokens: %v\n",
+		total.Sent, total.Failed, total.Retries, total.Dropped, total.InvalidTokens)
+}
@@ -72,8 +72,12 @@ func (s *Shell) init() {
 		"create_fake_user": new(createFakeUserCmd),
 		//"make_friends":     makeFriends,
 		//"fix_database":         fixDatabase,
-		"change_user_password": new(changeUserPasswordCmd),
-		"version":              new(versionCmd),
+		"change_user_password":      new(changeUserPasswordCmd),
+		"version":                   new(versionCmd),
+		"capture":                   new(captureCmd),
+		"ack_stats":                 new(ackStatsCmd),
+		"push_stats":                new(pushStatsCmd),
+		"backfill_authored_history": new(backfillAuthoredHistoryCmd),
 	}
 }
 
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	geohashBase32   = "0123456789bcdefghjkmnpqrstuvwxyz"
+	earthRadiusMtrs = 6371000.0
+)
+
+// GeohashEncode encodes a latitude and longitude into a geohash string of the
+// given precision (number of characters).
+func GeohashEncode(latitude float64, longitude float64, precision int) string {
+
+	var hash strings.Builder
+
+	latRange := [2]float64{-90.0, 90.0}
+	lonRange := [2]float64{-180.0, 180.0}
+
+	bit, ch := 0, 0
+	even := true
+
+	for hash.Len() < precision {
+
+		if even {
+			mid := (lonRange[0] + lonRange[1]) / 2
+			if longitude >= mid {
+				ch |= 1 << uint(4-bit)
+				lonRange[0] = mid
+			} else {
+				lonRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if latitude >= mid {
+				ch |= 1 << uint(4-bit)
+				latRange[0] = mid
+			} else {
+				latRange[1] = mid
+			}
+		}
+
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			hash.WriteByte(geohashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return hash.String()
+}
+
+// GeohashCellSize returns the size in degrees of a geohash cell of the given precision
+func GeohashCellSize(precision int) (latDegrees float64, lonDegrees float64) {
+	bits := uint(precision * 5)
+	lonBits := (bits + 1) / 2
+	latBits := bits / 2
+	return 180.0 / float64(uint64(1)<<latBits), 360.0 / float64(uint64(1)<<lonBits)
+}
+
+// GeohashesInRange returns geohashes of the given precision that cover a circle
+// centered at latitude and longitude with a radius of rangeMeters.
+func GeohashesInRange(latitude float64, longitude float64, rangeMeters float64, precision int) []string {
+
+	// Bounding box of the circle
+	latDelta := rangeMeters / earthRadiusMtrs * 180.0 / math.Pi
+	minLat := math.Max(latitude-latDelta, -90.0)
+	maxLat := math.Min(latitude+latDelta, 90.0)
+
+	lonDelta := 180.0
+	if cosLat := math.Cos(latitude * math.Pi / 180.0); cosLat > 0 {
+		lonDelta = math.Min(latDelta/cosLat, 180.0)
+	}
+
+	minLon := longitude - lonDelta
+	maxLon := longitude + lonDelta
+
+	cellLat, cellLon := GeohashCellSize(precision)
+	hashes := make(map[string]bool)
+
+	for lat := minLat; ; lat += cellLat {
+		lat = math.Min(lat, maxLat)
+		for lon := minLon; ; lon += cellLon {
+			lon = math.Min(lon, maxLon)
+			hashes[GeohashEncode(lat, normalizeLongitude(lon), precision)] = true
+			if lon >= maxLon {
+				break
+			}
+		}
+		if lat >= maxLat {
+			break
+		}
+	}
+
+	result := make([]string, 0, len(hashes))
+	for hash := range hashes {
+		result = append(result, hash)
+	}
+
+	return result
+}
+
+// DistanceInMeters computes the great-circle distance between two points
+// using the haversine formula
+func DistanceInMeters(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64 {
+	toRad := math.Pi / 180.0
+	dLat := (lat2 - lat1) * toRad
+	dLon := (lon2 - lon1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMtrs * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func normalizeLongitude(lon float64) float64 {
+	for lon < -180.0 {
+		lon += 360.0
+	}
+	for lon >= 180.0 {
+		lon -= 360.0
+	}
+	return lon
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeohashEncode(t *testing.T) {
+
+	var tests = []struct {
+		latitude  float64
+		longitude float64
+		precision int
+		expected  string
+	}{
+		{57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{42.6, -5.6, 5, "ezs42"},
+		{40.416775, -3.703790, 4, "ezjm"},
+		{-33.8688, 151.2093, 6, "r3gx2f"},
+	}
+
+	for i, test := range tests {
+		hash := GeohashEncode(test.latitude, test.longitude, test.precision)
+		if hash != test.expected {
+			t.Fatalf("test %v: Expected '%v' but got '%v'", i, test.expected, hash)
+		}
+	}
+}
+
+func TestGeohashesInRange(t *testing.T) {
+
+	latitude, longitude := 40.416775, -3.703790
+	center := GeohashEncode(latitude, longitude, 4)
+	hashes := GeohashesInRange(latitude, longitude, 50000, 4)
+
+	found := false
+	for _, hash := range hashes {
+		if hash == center {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatalf("Expected '%v' to be in %v", center, hashes)
+	}
+
+	// A point 40 km to the north must be covered too
+	north := GeohashEncode(latitude+0.36, longitude, 4)
+	found = false
+	for _, hash := range hashes {
+		if hash == north {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatalf("Expected '%v' to be in %v", north, hashes)
+	}
+}
+
+func TestDistanceInMeters(t *testing.T) {
+	// Madrid - Barcelona
+	distance := DistanceInMeters(40.416775, -3.703790, 41.385064, 2.173403)
+	if math.Abs(distance-505000) > 5000 {
+		t.Fatalf("Expected about 505 km but got %v", distance)
+	}
+}
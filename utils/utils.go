@@ -8,6 +8,8 @@ import (
 	"image/jpeg"
 	"math/big"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/disintegration/imaging"
@@ -98,6 +100,11 @@ func GetCurrentTimeMillis() int64 {
 	return TimeToMillis(time.Now())
 }
 
+// Timestamps used to version rows in database are in microseconds
+func GetCurrentTimeMicros() int64 {
+	return time.Now().UnixNano() / int64(time.Microsecond)
+}
+
 // Return time as millis
 func TimeToMillis(t time.Time) int64 {
 	return t.UnixNano() / int64(time.Millisecond)
@@ -138,6 +145,46 @@ func HashPasswordWithSalt(password string, salt [32]byte) [32]byte {
 	return sha256.Sum256(data)
 }
 
+// CompareVersions compares two dotted versions, i.e. 1.0.10, number by number.
+// Returns -1 if a < b, 0 if a == b and 1 if a > b. Missing or non numeric parts
+// count as zero, so that 1.0 == 1.0.0 and an empty version is the lowest one.
+func CompareVersions(a, b string) int {
+
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+
+	for i := 0; i < MaxInt(len(partsA), len(partsB)); i++ {
+
+		var numA, numB int
+
+		if i < len(partsA) {
+			numA = versionNumber(partsA[i])
+		}
+
+		if i < len(partsB) {
+			numB = versionNumber(partsB[i])
+		}
+
+		if numA < numB {
+			return -1
+		} else if numA > numB {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func versionNumber(part string) int {
+	part = strings.TrimSpace(part)
+	end := 0
+	for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+		end++
+	}
+	num, _ := strconv.Atoi(part[:end])
+	return num
+}
+
 func MinInt(a, b int) int {
 	if a < b {
 		return a
@@ -12,3 +12,26 @@ func TestUnixMillisToTime(t *testing.T) {
 	time := MillisToTimeUTC(TimeToMillis(currentTime))
 	log.Println("Retrieve", time)
 }
+
+func TestCompareVersions(t *testing.T) {
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.10", "1.0.10", 0},
+		{"1.0.9", "1.0.10", -1},
+		{"1.0.11", "1.0.10", 1},
+		{"1.0", "1.0.0", 0},
+		{"1.1", "1.0.25", 1},
+		{"", "0.0.1", -1},
+		{"1.0.11-beta", "1.0.11", 0},
+		{"2", "10", -1},
+	}
+
+	for _, test := range tests {
+		if got := CompareVersions(test.a, test.b); got != test.want {
+			t.Errorf("CompareVersions(%q, %q) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}